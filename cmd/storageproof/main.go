@@ -0,0 +1,232 @@
+// Copyright 2021 The utg Authors
+// This file is part of utg.
+//
+// utg is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// utg is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with utg. If not, see <http://www.gnu.org/licenses/>.
+
+// storageproof plots capacity files and builds the proof of capacity strings of
+// storage pledges and storage proofs.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/storageproof"
+	"github.com/UltronGlow/UltronGlow-Origin/internal/flags"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Git SHA1 commit hash of the release (set via linker flags)
+var gitCommit = ""
+var gitDate = ""
+
+var app *cli.App
+
+var (
+	deviceFlag = cli.StringFlag{
+		Name:  "device",
+		Usage: "Address of the storage device the capacity is pledged for",
+	}
+	plotNumberFlag = cli.Uint64Flag{
+		Name:  "plotnumber",
+		Usage: "Number of the block the pledge is declared with",
+	}
+	plotNonceFlag = cli.Uint64Flag{
+		Name:  "plotnonce",
+		Usage: "Nonce of the block the pledge is declared with",
+	}
+	plotHashFlag = cli.StringFlag{
+		Name:  "plothash",
+		Usage: "Hash of the block the pledge is declared with",
+	}
+	blocksFlag = cli.Uint64Flag{
+		Name:  "blocks",
+		Usage: "Number of 20 byte blocks of the capacity file",
+	}
+	fileFlag = cli.StringFlag{
+		Name:  "file",
+		Usage: "Capacity file plotted on disk (default = plot the blocks in memory)",
+	}
+	numberFlag = cli.Uint64Flag{
+		Name:  "number",
+		Usage: "Number of the block to answer the challenge of (default = plot block)",
+	}
+	nonceFlag = cli.Uint64Flag{
+		Name:  "nonce",
+		Usage: "Nonce of the block to answer the challenge of",
+	}
+	blockHashFlag = cli.StringFlag{
+		Name:  "blockhash",
+		Usage: "Hash of the block to answer the challenge of",
+	}
+	rangeFlag = cli.StringFlag{
+		Name:  "range",
+		Usage: "Range <start>-<end> of blocks proven by the v1 proof (default = whole file)",
+	}
+
+	plotFlags = []cli.Flag{deviceFlag, plotNumberFlag, plotNonceFlag, plotHashFlag}
+
+	commandPlot = cli.Command{
+		Name:      "plot",
+		Usage:     "Plot a capacity file",
+		ArgsUsage: "<file>",
+		Flags:     append([]cli.Flag{blocksFlag}, plotFlags...),
+		Action:    plot,
+		Description: `
+storageproof plot --blocks <n> --device <address> --plotnumber <number> --plotnonce <nonce> --plothash <hash> <file>
+writes the capacity file of a storage pledge declared with the given block.`,
+	}
+	commandProve = cli.Command{
+		Name:   "prove",
+		Usage:  "Build the proof of capacity strings of a challenge",
+		Flags:  append([]cli.Flag{fileFlag, blocksFlag, numberFlag, nonceFlag, blockHashFlag, rangeFlag}, plotFlags...),
+		Action: prove,
+		Description: `
+storageproof prove --file <file> --device <address> --plotnumber <number> --plotnonce <nonce> --plothash <hash>
+prints the root hash of the capacity file with the version 0 and version 1 proofs
+answering the challenge of the block the pledge is declared with, to be sent in
+the storage pledge declaration.
+
+Adding --number <number> --nonce <nonce> --blockhash <hash> answers the challenge
+of that block instead, for storage proofs. Without --file the capacity file of
+--blocks blocks is plotted again in memory.`,
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "an utg storage proof of capacity builder")
+	app.Commands = []cli.Command{
+		commandPlot,
+		commandProve,
+	}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// plotChallenge returns the challenge of the block the pledge is declared with.
+func plotChallenge(ctx *cli.Context) (storageproof.Challenge, error) {
+	for _, flag := range []string{deviceFlag.Name, plotNumberFlag.Name, plotNonceFlag.Name, plotHashFlag.Name} {
+		if !ctx.IsSet(flag) {
+			return storageproof.Challenge{}, fmt.Errorf("missing flag --%s", flag)
+		}
+	}
+	return storageproof.Challenge{
+		Number: ctx.Uint64(plotNumberFlag.Name),
+		Nonce:  ctx.Uint64(plotNonceFlag.Name),
+		Hash:   common.HexToHash(ctx.String(plotHashFlag.Name)),
+	}, nil
+}
+
+func seed(ctx *cli.Context) (storageproof.Block, storageproof.Challenge, error) {
+	plot, err := plotChallenge(ctx)
+	if err != nil {
+		return storageproof.Block{}, plot, err
+	}
+	device := ctx.String(deviceFlag.Name)
+	if !common.IsHexAddress(device) {
+		return storageproof.Block{}, plot, fmt.Errorf("invalid device address %q", device)
+	}
+	return storageproof.Seed(plot, common.HexToAddress(device)), plot, nil
+}
+
+func plot(ctx *cli.Context) error {
+	if ctx.NArg() != 1 || !ctx.IsSet(blocksFlag.Name) {
+		return errors.New("usage: storageproof plot --blocks <n> [plot flags] <file>")
+	}
+	seed, _, err := seed(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(ctx.Args().First(), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := storageproof.Plot(out, seed, ctx.Uint64(blocksFlag.Name)); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func prove(ctx *cli.Context) error {
+	seed, challenge, err := seed(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet(numberFlag.Name) {
+		challenge = storageproof.Challenge{
+			Number: ctx.Uint64(numberFlag.Name),
+			Nonce:  ctx.Uint64(nonceFlag.Name),
+			Hash:   common.HexToHash(ctx.String(blockHashFlag.Name)),
+		}
+	}
+	var src storageproof.Source
+	switch {
+	case ctx.IsSet(fileFlag.Name):
+		file, err := storageproof.OpenFile(ctx.String(fileFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		src = file
+	case ctx.IsSet(blocksFlag.Name):
+		src = storageproof.NewSparse(seed, ctx.Uint64(blocksFlag.Name))
+	default:
+		return fmt.Errorf("missing flag --%s or --%s", fileFlag.Name, blocksFlag.Name)
+	}
+	proof, err := storageproof.Prove(src, seed, challenge)
+	if err != nil {
+		return err
+	}
+	result := struct {
+		Root   string `json:"root"`
+		V0     string `json:"v0"`
+		V1     string `json:"v1,omitempty"`
+		V1Root string `json:"v1root,omitempty"`
+	}{Root: proof.Root.String(), V0: proof.V0, V1: proof.V1}
+
+	if ctx.IsSet(rangeFlag.Name) {
+		bounds := strings.Split(ctx.String(rangeFlag.Name), "-")
+		if len(bounds) != 2 {
+			return fmt.Errorf("invalid range %q", ctx.String(rangeFlag.Name))
+		}
+		start, err1 := strconv.ParseUint(bounds[0], 10, 64)
+		end, err2 := strconv.ParseUint(bounds[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("invalid range %q", ctx.String(rangeFlag.Name))
+		}
+		v1, root, err := storageproof.ProveV1(src, seed, challenge, start, end)
+		if err != nil {
+			return err
+		}
+		result.V1, result.V1Root = v1, root.String()
+	}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
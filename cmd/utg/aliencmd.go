@@ -0,0 +1,466 @@
+// Copyright 2021 The utg Authors
+// This file is part of utg.
+//
+// utg is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// utg is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with utg. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/UltronGlow/UltronGlow-Origin/cmd/utils"
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	alienNumberFlag = cli.Uint64Flag{
+		Name:  "number",
+		Usage: "Block number to inspect, the last stored checkpoint snapshot at or below it is used (default = head)",
+	}
+	alienFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format (json|csv)",
+		Value: "json",
+	}
+	alienTrustedFlag = cli.Uint64Flag{
+		Name:  "trusted",
+		Usage: "Number of a trusted checkpoint to replay the headers from",
+	}
+	alienPledgeKindFlag = cli.StringFlag{
+		Name:  "kind",
+		Usage: "Kind of pledges to list (storage|pos)",
+		Value: "storage",
+	}
+	alienLockTypeFlag = cli.UintFlag{
+		Name:  "type",
+		Usage: "Lock type of the records, as used by the SSC lock configuration",
+	}
+	alienLockRootFlag = cli.StringFlag{
+		Name:  "lockroot",
+		Usage: "Root hash of the lock account trie",
+	}
+	alienReleaseRootFlag = cli.StringFlag{
+		Name:  "releaseroot",
+		Usage: "Root hash of the lock release trie",
+	}
+
+	alienFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.AncientFlag,
+		utils.MainnetFlag,
+		utils.TestnetFlag,
+		alienNumberFlag,
+		alienFormatFlag,
+	}
+
+	alienCommand = cli.Command{
+		Name:      "alien",
+		Usage:     "Inspect the alien consensus snapshots of a stopped node",
+		ArgsUsage: "",
+		Category:  "DATABASE COMMANDS",
+		Description: `
+The alien commands open the chain database read-only and print the state kept
+in the checkpoint snapshots of the alien consensus engine, as JSON or CSV.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "dump-snapshot",
+				Usage:  "Dump a checkpoint snapshot",
+				Action: utils.MigrateFlags(alienDumpSnapshot),
+				Flags:  alienFlags,
+				Description: `
+utg alien dump-snapshot --number <number>
+dumps the last checkpoint snapshot stored at or below the given block.`,
+			},
+			{
+				Name:   "list-pledges",
+				Usage:  "List the storage or PoS pledges of a checkpoint snapshot",
+				Action: utils.MigrateFlags(alienListPledges),
+				Flags:  append([]cli.Flag{alienPledgeKindFlag}, alienFlags...),
+			},
+			{
+				Name:   "srt-balances",
+				Usage:  "List the SRT balances of a checkpoint snapshot",
+				Action: utils.MigrateFlags(alienSRTBalances),
+				Flags:  alienFlags,
+			},
+			{
+				Name:   "lock-records",
+				Usage:  "List the lock records of a lock trie",
+				Action: utils.MigrateFlags(alienLockRecords),
+				Flags:  append([]cli.Flag{alienLockTypeFlag, alienLockRootFlag, alienReleaseRootFlag}, alienFlags...),
+				Description: `
+utg alien lock-records --type <type> --lockroot <root> --releaseroot <root>
+lists the lock records of the given lock type held in the lock trie.`,
+			},
+			{
+				Name:      "decode-extra",
+				Usage:     "Decode the alien data of a header extra",
+				ArgsUsage: "[<extra>]",
+				Action:    utils.MigrateFlags(alienDecodeExtra),
+				Flags:     []cli.Flag{utils.DataDirFlag, utils.AncientFlag, utils.MainnetFlag, utils.TestnetFlag, alienNumberFlag},
+				Description: `
+utg alien decode-extra <extra>
+decodes the given hex encoded header extra, of a header sealed at any height.
+The layout is recognised from the extra itself, so no chain database is needed.
+
+utg alien decode-extra --number <number>
+decodes the extra of the canonical header at the given block.`,
+			},
+			{
+				Name:   "verify-snapshot",
+				Usage:  "Check a checkpoint snapshot or replay the headers leading to it",
+				Action: utils.MigrateFlags(alienVerifySnapshot),
+				Flags:  append([]cli.Flag{alienTrustedFlag}, alienFlags...),
+				Description: `
+utg alien verify-snapshot --number <number>
+recomputes the accumulated storage and SP hashes of the last checkpoint snapshot
+stored at or below the given block, compares them with the stored ones and walks
+its SRT trie. It fails if any check does not pass.
+
+utg alien verify-snapshot --trusted <number> --number <number>
+rebuilds the snapshots from the last checkpoint snapshot stored at or below the
+trusted block by applying the canonical headers up to the given block, compares
+every stored checkpoint snapshot on the way with the rebuilt one and reports the
+differing fields of the first one that does not match.`,
+			},
+		},
+	}
+)
+
+// openAlienInspector opens the chain database read-only and returns an
+// inspector for the alien snapshots stored in it.
+func openAlienInspector(ctx *cli.Context) (*alien.Inspector, ethdb.Database, func()) {
+	stack, _ := makeConfigNode(ctx)
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	closer := func() {
+		db.Close()
+		stack.Close()
+	}
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	config := rawdb.ReadChainConfig(db, genesis)
+	if config == nil || config.Alien == nil {
+		closer()
+		utils.Fatalf("Database does not hold an alien chain")
+	}
+	inspector, err := alien.NewInspector(db, config.Alien)
+	if err != nil {
+		closer()
+		utils.Fatalf("Failed to create alien inspector: %v", err)
+	}
+	return inspector, db, closer
+}
+
+// alienNumber returns the block selected by the number flag, the head by default.
+func alienNumber(ctx *cli.Context, db ethdb.Database) (uint64, error) {
+	if ctx.IsSet(alienNumberFlag.Name) {
+		return ctx.Uint64(alienNumberFlag.Name), nil
+	}
+	number := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadHeaderHash(db))
+	if number == nil {
+		return 0, errors.New("head header not found")
+	}
+	return *number, nil
+}
+
+// alienSnapshot loads the snapshot selected by the number flag.
+func alienSnapshot(ctx *cli.Context, inspector *alien.Inspector, db ethdb.Database) (*alien.Snapshot, error) {
+	number, err := alienNumber(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return inspector.Snapshot(number)
+}
+
+// writeAlienOutput prints v as JSON, or the rows under the given header as CSV.
+func writeAlienOutput(ctx *cli.Context, v interface{}, header []string, rows [][]string) error {
+	switch format := ctx.String(alienFormatFlag.Name); format {
+	case "json":
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		return w.Error()
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func alienDumpSnapshot(ctx *cli.Context) error {
+	inspector, db, closer := openAlienInspector(ctx)
+	defer closer()
+
+	snap, err := alienSnapshot(ctx, inspector, db)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	if ctx.String(alienFormatFlag.Name) == "csv" {
+		fields, err := alien.SnapshotFields(snap)
+		if err != nil {
+			return err
+		}
+		paths := make([]string, 0, len(fields))
+		for path := range fields {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			rows = append(rows, []string{path, fields[path]})
+		}
+	}
+	return writeAlienOutput(ctx, snap, []string{"path", "value"}, rows)
+}
+
+func alienListPledges(ctx *cli.Context) error {
+	inspector, db, closer := openAlienInspector(ctx)
+	defer closer()
+
+	snap, err := alienSnapshot(ctx, inspector, db)
+	if err != nil {
+		return err
+	}
+	var (
+		header []string
+		rows   [][]string
+		result interface{}
+	)
+	switch kind := ctx.String(alienPledgeKindFlag.Name); kind {
+	case "storage":
+		header = []string{"address", "number", "totalcapacity", "storagesize", "price", "bandwidth", "spacedeposit", "pledgestatus", "leases"}
+		pledges := make(map[common.Address]*alien.SPledge)
+		if snap.StorageData != nil {
+			pledges = snap.StorageData.StoragePledge
+		}
+		for _, addr := range sortedAddresses(pledges) {
+			p := pledges[addr]
+			rows = append(rows, []string{addr.Hex(), p.Number.String(), p.TotalCapacity.String(), p.StorageSize.String(), p.Price.String(),
+				p.Bandwidth.String(), p.SpaceDeposit.String(), p.PledgeStatus.String(), strconv.Itoa(len(p.Lease))})
+		}
+		result = pledges
+	case "pos":
+		header = []string{"address", "manager", "active", "totalamount", "distributerate", "lastpunish", "entrusts"}
+		for _, addr := range sortedAddresses(snap.PosPledge) {
+			p := snap.PosPledge[addr]
+			rows = append(rows, []string{addr.Hex(), p.Manager.Hex(), strconv.FormatUint(p.Active, 10), p.TotalAmount.String(),
+				p.DisRate.String(), strconv.FormatUint(p.LastPunish, 10), strconv.Itoa(len(p.Detail))})
+		}
+		result = snap.PosPledge
+	default:
+		return fmt.Errorf("unknown pledge kind %q", kind)
+	}
+	return writeAlienOutput(ctx, result, header, rows)
+}
+
+func alienSRTBalances(ctx *cli.Context) error {
+	inspector, db, closer := openAlienInspector(ctx)
+	defer closer()
+
+	snap, err := alienSnapshot(ctx, inspector, db)
+	if err != nil {
+		return err
+	}
+	if snap.SRT == nil {
+		return fmt.Errorf("snapshot %d has no SRT state", snap.Number)
+	}
+	balances := snap.SRT.GetAll()
+	var rows [][]string
+	for _, addr := range sortedAddresses(balances) {
+		rows = append(rows, []string{addr.Hex(), balances[addr].String()})
+	}
+	return writeAlienOutput(ctx, balances, []string{"address", "balance"}, rows)
+}
+
+func alienLockRecords(ctx *cli.Context) error {
+	if !ctx.IsSet(alienLockTypeFlag.Name) || !ctx.IsSet(alienLockRootFlag.Name) {
+		return fmt.Errorf("required flags: --%s, --%s", alienLockTypeFlag.Name, alienLockRootFlag.Name)
+	}
+	inspector, _, closer := openAlienInspector(ctx)
+	defer closer()
+
+	root := common.HexToHash(ctx.String(alienLockRootFlag.Name))
+	releaseRoot := common.HexToHash(ctx.String(alienReleaseRootFlag.Name))
+	records, err := inspector.LockRecords(root, releaseRoot, uint8(ctx.Uint(alienLockTypeFlag.Name)))
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, addr := range sortedAddresses(records) {
+		for _, r := range records[addr] {
+			rows = append(rows, []string{addr.Hex(), strconv.FormatUint(r.Number, 10), r.Address.Hex(), r.TotalBalance.String(),
+				r.Released.String(), r.Pledgeed.String(), r.Destroyed.String(), strconv.Itoa(int(r.PledgeRatio)), strconv.Itoa(int(r.DestroyRatio)),
+				strconv.Itoa(int(r.LockDays)), strconv.Itoa(int(r.ReleaseDays)), strconv.Itoa(int(r.ReleaseIdx)), strconv.FormatUint(r.ReleaseNumber, 10)})
+		}
+	}
+	header := []string{"pledgeaddress", "number", "address", "totalbalance", "released", "pledged", "destroyed", "pledgeratio", "destroyratio",
+		"lockdays", "releasedays", "releaseidx", "releasenumber"}
+	return writeAlienOutput(ctx, records, header, rows)
+}
+
+func alienVerifySnapshot(ctx *cli.Context) error {
+	inspector, db, closer := openAlienInspector(ctx)
+	defer closer()
+
+	if ctx.IsSet(alienTrustedFlag.Name) {
+		return alienReplaySnapshots(ctx, inspector, db)
+	}
+	snap, err := alienSnapshot(ctx, inspector, db)
+	if err != nil {
+		return err
+	}
+	checks, err := inspector.VerifySnapshot(snap.Hash)
+	if err != nil {
+		return err
+	}
+	var (
+		rows   [][]string
+		failed int
+	)
+	for _, c := range checks {
+		rows = append(rows, []string{c.Name, strconv.FormatBool(c.OK), c.Have, c.Want})
+		if !c.OK {
+			failed++
+		}
+	}
+	result := struct {
+		Number uint64                `json:"number"`
+		Hash   common.Hash           `json:"hash"`
+		Checks []alien.SnapshotCheck `json:"checks"`
+	}{snap.Number, snap.Hash, checks}
+	if err := writeAlienOutput(ctx, result, []string{"check", "ok", "have", "want"}, rows); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed for snapshot %d (%x)", failed, len(checks), snap.Number, snap.Hash)
+	}
+	return nil
+}
+
+func alienDecodeExtra(ctx *cli.Context) error {
+	var (
+		extra  []byte
+		number *uint64
+	)
+	if ctx.NArg() > 0 {
+		var err error
+		if extra, err = hexutil.Decode(ctx.Args().First()); err != nil {
+			return fmt.Errorf("invalid header extra: %v", err)
+		}
+	} else {
+		stack, _ := makeConfigNode(ctx)
+		defer stack.Close()
+		db := utils.MakeChainDatabase(ctx, stack, true)
+		defer db.Close()
+
+		n, err := alienNumber(ctx, db)
+		if err != nil {
+			return err
+		}
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, n), n)
+		if header == nil {
+			return fmt.Errorf("canonical header %d not found", n)
+		}
+		extra, number = header.Extra, &n
+	}
+	headerExtra, layout, err := alien.DecodeHeaderExtra(extra)
+	if err != nil {
+		return err
+	}
+	result := struct {
+		Number *uint64                 `json:"number,omitempty"`
+		Layout alien.HeaderExtraLayout `json:"layout"`
+		Extra  *alien.HeaderExtra      `json:"extra"`
+	}{number, layout, headerExtra}
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// alienReplaySnapshots replays the headers from the trusted checkpoint and
+// reports the first stored checkpoint snapshot differing from the rebuilt one.
+func alienReplaySnapshots(ctx *cli.Context, inspector *alien.Inspector, db ethdb.Database) error {
+	number, err := alienNumber(ctx, db)
+	if err != nil {
+		return err
+	}
+	trusted, err := inspector.Snapshot(ctx.Uint64(alienTrustedFlag.Name))
+	if err != nil {
+		return err
+	}
+	replay, err := inspector.ReplaySnapshots(trusted.Hash, number)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, d := range replay.Diff {
+		rows = append(rows, []string{d.Path, d.Replay, d.Stored})
+	}
+	if err := writeAlienOutput(ctx, replay, []string{"path", "replay", "stored"}, rows); err != nil {
+		return err
+	}
+	if len(replay.Diff) > 0 {
+		return fmt.Errorf("snapshot %d (%x) differs from the replay of the headers from %d in %d fields", replay.Number, replay.Hash, replay.From, len(replay.Diff))
+	}
+	return nil
+}
+
+// sortedAddresses returns the address keys of a map in ascending order.
+func sortedAddresses(m interface{}) []common.Address {
+	var addrs []common.Address
+	switch m := m.(type) {
+	case map[common.Address]*alien.SPledge:
+		for addr := range m {
+			addrs = append(addrs, addr)
+		}
+	case map[common.Address]*alien.PosPledgeItem:
+		for addr := range m {
+			addrs = append(addrs, addr)
+		}
+	case map[common.Address]*big.Int:
+		for addr := range m {
+			addrs = append(addrs, addr)
+		}
+	case map[common.Address][]alien.LockRecord:
+		for addr := range m {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	return addrs
+}
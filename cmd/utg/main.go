@@ -31,6 +31,7 @@ import (
 	"github.com/UltronGlow/UltronGlow-Origin/accounts/keystore"
 	"github.com/UltronGlow/UltronGlow-Origin/cmd/utils"
 	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
 	"github.com/UltronGlow/UltronGlow-Origin/console/prompt"
 	"github.com/UltronGlow/UltronGlow-Origin/eth"
 	"github.com/UltronGlow/UltronGlow-Origin/eth/downloader"
@@ -90,6 +91,7 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolCustomTxCheckFlag,
 		utils.SyncModeFlag,
 		utils.ExitWhenSyncedFlag,
 		utils.GCModeFlag,
@@ -139,6 +141,7 @@ var (
 		utils.MainnetFlag,
 		utils.DeveloperFlag,
 		utils.DeveloperPeriodFlag,
+		utils.DeveloperAlienFlag,
 		utils.TestnetFlag,
 		utils.VMEnableDebugFlag,
 		utils.NetworkIdFlag,
@@ -208,6 +211,9 @@ var (
 		utils.SCAEnableFlag,
 		utils.SCAMainRPCAddrFlag,
 		utils.SCAMainRPCPortFlag,
+		utils.SCAMainRPCFlag,
+		utils.SCAMainRPCTimeoutFlag,
+		utils.SCAMainCheckpointFlag,
 		utils.SCAPeriod,
 	}
 )
@@ -248,6 +254,8 @@ func init() {
 		utils.ShowDeprecated,
 		// See snapshot.go
 		snapshotCommand,
+		// See aliencmd.go
+		alienCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -411,24 +419,59 @@ func startNode(ctx *cli.Context, stack *node.Node, backend ethapi.Backend) {
 
 	// Set Side chain config
 	if ctx.GlobalBool(utils.SCAEnableFlag.Name) {
+		var urls []string
 		mcRPCAddress := ctx.GlobalString(utils.SCAMainRPCAddrFlag.Name)
-		// got random rpc
-		mainRPCnode := params.MainnetRPCnodes[rand.Intn(len(params.MainnetRPCnodes))]
-		if mcRPCAddress == "" {
-			mcRPCAddress = strings.Split(mainRPCnode, ":")[0]
-		}
 		mcRPCPort := ctx.GlobalInt(utils.SCAMainRPCPortFlag.Name)
-		if mcRPCPort == 0 {
-			mcRPCPort, _ = strconv.Atoi(strings.Split(mainRPCnode, ":")[1])
+		switch {
+		case ctx.GlobalString(utils.SCAMainRPCFlag.Name) != "":
+			urls = strings.Split(ctx.GlobalString(utils.SCAMainRPCFlag.Name), ",")
+		case mcRPCAddress != "" || mcRPCPort != 0:
+			// got random rpc
+			mainRPCnode := params.MainnetRPCnodes[rand.Intn(len(params.MainnetRPCnodes))]
+			if mcRPCAddress == "" {
+				mcRPCAddress = strings.Split(mainRPCnode, ":")[0]
+			}
+			if mcRPCPort == 0 {
+				mcRPCPort, _ = strconv.Atoi(strings.Split(mainRPCnode, ":")[1])
+			}
+			urls = []string{"http://" + mcRPCAddress + ":" + strconv.Itoa(mcRPCPort)}
+		default:
+			for _, node := range params.MainnetRPCnodes {
+				urls = append(urls, "http://"+node)
+			}
 		}
-		mcPeriod := ctx.GlobalInt(utils.SCAPeriod.Name)
-		client, err := rpc.Dial("http://" + mcRPCAddress + ":" + strconv.Itoa(mcRPCPort))
-		if err != nil {
-			utils.Fatalf("Main net rpc connect fail: %v", err)
+		var backends []alien.MainChainBackend
+		for _, url := range urls {
+			client, err := rpc.Dial(strings.TrimSpace(url))
+			if err != nil {
+				utils.Fatalf("Main net rpc connect fail: %v", err)
+			}
+			if backend.ChainConfig().Alien.MCRPCClient == nil {
+				backend.ChainConfig().Alien.MCRPCClient = client
+			}
+			backends = append(backends, client)
 		}
+		mcPeriod := ctx.GlobalInt(utils.SCAPeriod.Name)
 		backend.ChainConfig().Alien.SideChain = true
 		backend.ChainConfig().Alien.Period = uint64(mcPeriod)
-		backend.ChainConfig().Alien.MCRPCClient = client
+
+		if engine, ok := backend.Engine().(*alien.Alien); ok {
+			config := alien.DefaultMainChainConfig
+			config.Timeout = ctx.GlobalDuration(utils.SCAMainRPCTimeoutFlag.Name)
+			if cp := ctx.GlobalString(utils.SCAMainCheckpointFlag.Name); cp != "" {
+				parts := strings.Split(cp, ":")
+				number, err := strconv.ParseUint(parts[0], 10, 64)
+				if len(parts) != 2 || err != nil || len(common.FromHex(parts[1])) != common.HashLength {
+					utils.Fatalf("Invalid main chain checkpoint %q, want <number>:<hash>", cp)
+				}
+				config.Checkpoint = &alien.MainChainCheckpoint{Number: number, Hash: common.HexToHash(parts[1])}
+			}
+			client, err := alien.NewMainChainClient(backends, config)
+			if err != nil {
+				utils.Fatalf("Main net rpc connect fail: %v", err)
+			}
+			engine.SetMainChainClient(client)
+		}
 	}
 
 	// Start auxiliary services if enabled
@@ -160,6 +160,10 @@ var (
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
 	}
+	DeveloperAlienFlag = cli.BoolFlag{
+		Name:  "dev.alien",
+		Usage: "Run the developer chain on alien rules with storage and PoS active from genesis and short days",
+	}
 	IdentityFlag = cli.StringFlag{
 		Name:  "identity",
 		Usage: "Custom node name",
@@ -371,6 +375,10 @@ var (
 		Usage: "Maximum amount of time non-executable transaction are queued",
 		Value: ethconfig.Defaults.TxPool.Lifetime,
 	}
+	TxPoolCustomTxCheckFlag = cli.BoolFlag{
+		Name:  "txpool.customtxcheck",
+		Usage: "Refuse custom transactions the alien engine would reject on execution",
+	}
 	// Performance tuning settings
 	CacheFlag = cli.IntFlag{
 		Name:  "cache",
@@ -786,6 +794,21 @@ var (
 		Usage: "Port of main chain rpc port",
 		Value: 0,
 	}
+	SCAMainRPCFlag = cli.StringFlag{
+		Name:  "sca.mainrpc",
+		Usage: "Comma separated URLs of main chain rpc endpoints, failed over in turn (overrides sca.mainrpcaddr and sca.mainrpcport)",
+		Value: "",
+	}
+	SCAMainRPCTimeoutFlag = cli.DurationFlag{
+		Name:  "sca.mainrpctimeout",
+		Usage: "Timeout of a single main chain rpc call",
+		Value: alien.DefaultMainChainConfig.Timeout,
+	}
+	SCAMainCheckpointFlag = cli.StringFlag{
+		Name:  "sca.maincheckpoint",
+		Usage: "Main chain header trusted without a majority of the main chain rpc endpoints (<number>:<hash>)",
+		Value: "",
+	}
 	SCAPeriod = cli.IntFlag{
 		Name:  "sca.period",
 		Usage: "Period of each side chain block",
@@ -1361,6 +1384,9 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolCustomTxCheckFlag.Name) {
+		cfg.CustomTxCheck = ctx.GlobalBool(TxPoolCustomTxCheckFlag.Name)
+	}
 }
 
 func setEthash(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1668,7 +1694,11 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Info("Using developer account", "address", developer.Address)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+		if ctx.GlobalBool(DeveloperAlienFlag.Name) {
+			cfg.Genesis = core.DeveloperAlienGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address, alien.ManagerAddresses())
+		} else {
+			cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+		}
 		if ctx.GlobalIsSet(DataDirFlag.Name) {
 			// Check if we have an already initialized chain and fall back to
 			// that if so. Otherwise we need to generate a new genesis spec.
@@ -47,10 +47,12 @@ import (
 )
 
 const (
-	inMemorySnapshots  = 128             // Number of recent vote snapshots to keep in memory
-	inMemorySignatures = 4096            // Number of recent block signatures to keep in memory
-	secondsPerYear     = 365 * 24 * 3600 // Number of seconds for one year
-	scUnconfirmLoop    = 3               // First count of Loop not send confirm tx to main chain
+	inMemorySnapshots    = 128             // Number of recent vote snapshots to keep in memory
+	inMemorySignatures   = 4096            // Number of recent block signatures to keep in memory
+	snapshotBaseInterval = 16              // Number of checkpoints between two snapshots stored in full, the others are stored as deltas
+	snapshotRetainBases  = 32              // Number of full snapshot intervals whose delta snapshots are kept on disk
+	secondsPerYear       = 365 * 24 * 3600 // Number of seconds for one year
+	scUnconfirmLoop      = 3               // First count of Loop not send confirm tx to main chain
 )
 
 // Alien delegated-proof-of-stake protocol constants.
@@ -85,6 +87,12 @@ var (
 	managerAddressManager            = common.HexToAddress("ux02C37850bDa531EcBb7edADF30DBadd89Ad5A824") ////TODO seaskycheng
 )
 
+// ManagerAddresses returns the default addresses of the manager slots of the
+// system config, along with the manager allowed to reassign them.
+func ManagerAddresses() []common.Address {
+	return []common.Address{managerAddressExchRate, managerAddressSystem, managerAddressWdthPnsh, managerAddressFlowReport, managerAddressManager}
+}
+
 // Various error messages to mark blocks invalid. These should be private to
 // prevent engine specific errors from being referenced in the remainder of the
 // codebase, inherently breaking if the engine is swapped out. Please put common
@@ -163,6 +171,16 @@ type Alien struct {
 	signTxFn   SignTxFn            // Sign transaction function to sign tx
 	lock       sync.RWMutex        // Protects the signer fields
 	lcsc       uint64              // Last confirmed side chain
+
+	layer     *snapshotLayer // Layer of the last stored checkpoint snapshot, parent of the next delta
+	layerLock sync.Mutex     // Protects the layer field
+
+	evidence *evidencePool // Double sign evidences waiting to be punished
+
+	mainChain     *MainChainClient // Client of the main chain, when running as a side chain
+	mainChainLock sync.Mutex       // Protects the mainChain field
+
+	now func() time.Time // Wall clock the blocks are timed by, time.Now unless simulated
 }
 
 // SignerFn hashes and signs the data to be signed by a backing account.
@@ -250,6 +268,9 @@ func New(config *params.AlienConfig, db ethdb.Database) *Alien {
 	if conf.MinVoterBalance.Uint64() > 0 {
 		minVoterBalance = conf.MinVoterBalance
 	}
+	if err := applySchedule(&conf); err != nil {
+		log.Crit("Failed to apply alien schedule", "err", err)
+	}
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inMemorySnapshots)
 	signatures, _ := lru.NewARC(inMemorySignatures)
@@ -259,6 +280,8 @@ func New(config *params.AlienConfig, db ethdb.Database) *Alien {
 		db:         db,
 		recents:    recents,
 		signatures: signatures,
+		evidence:   newEvidencePool(conf.Period, signatures),
+		now:        time.Now,
 	}
 }
 
@@ -304,7 +327,7 @@ func (a *Alien) verifyHeader(chain consensus.ChainHeaderReader, state *state.Sta
 	}
 
 	// Don't waste time checking blocks from the future
-	if header.Time > uint64(time.Now().Unix()) {
+	if header.Time > uint64(a.now().Unix()) {
 		return consensus.ErrFutureBlock
 	}
 
@@ -398,7 +421,10 @@ func (a *Alien) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 			}
 			a.config.Period = chain.Config().Alien.Period
 			snap = newSnapshot(a.config, a.signatures, genesis.Hash(), genesisVotes, lcrs)
-			if err := snap.store(a.db); err != nil {
+			if err := snap.activateGenesisForks(genesis, a.db); err != nil {
+				return nil, err
+			}
+			if err := a.storeSnapshot(snap, nil); err != nil {
 				return nil, err
 			}
 			log.Trace("Stored genesis voting snapshot to disk")
@@ -428,6 +454,7 @@ func (a *Alien) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 		headers[i], headers[len(headers)-1-i] = headers[len(headers)-1-i], headers[i]
 	}
 
+	parent := snap
 	snap, err := snap.apply(headers, a.db, chain)
 	if err != nil {
 		return nil, err
@@ -437,7 +464,7 @@ func (a *Alien) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 
 	// If we've generated a new checkpoint snapshot, save to disk
 	if snap.Number%checkpointInterval == 0 && len(headers) > 0 {
-		if err = snap.store(a.db); err != nil {
+		if err = a.storeSnapshot(snap, parent); err != nil {
 			return nil, err
 		}
 		log.Trace("Stored voting snapshot to disk", "number", snap.Number, "hash", snap.Hash)
@@ -446,6 +473,36 @@ func (a *Alien) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 	return snap, err
 }
 
+// storeSnapshot saves a checkpoint snapshot to disk. It is stored as a delta if
+// parent is the snapshot of the previous checkpoint, and checkpoint snapshots
+// falling out of the retention window are pruned whenever one is stored in full.
+func (a *Alien) storeSnapshot(snap *Snapshot, parent *Snapshot) error {
+	a.layerLock.Lock()
+	defer a.layerLock.Unlock()
+
+	var layer *snapshotLayer
+	if parent != nil && parent.Number%checkpointInterval == 0 && parent.Number+checkpointInterval == snap.Number {
+		if a.layer != nil && a.layer.hash == parent.Hash {
+			layer = a.layer
+		} else if l, err := flattenSnapshot(parent); err == nil {
+			layer = l
+		} else {
+			log.Warn("Failed to flatten parent snapshot", "number", parent.Number, "err", err)
+		}
+	}
+	layer, err := snap.store(a.db, layer)
+	if err != nil {
+		return err
+	}
+	a.layer = layer
+	if isSnapshotBase(snap.Number) {
+		if err := pruneSnapshots(a.db, snap.Number); err != nil {
+			log.Warn("Failed to prune snapshots", "number", snap.Number, "err", err)
+		}
+	}
+	return nil
+}
+
 // VerifyUncles implements consensus.Engine, always returning an error for any
 // uncles as this consensus mechanism doesn't permit uncles.
 func (a *Alien) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
@@ -591,6 +648,12 @@ func (a *Alien) verifySeal(chain consensus.ChainHeaderReader, state *state.State
 		if !snap.inturn(signer, header.Time) {
 			return errUnauthorized
 		}
+		a.evidence.observe(header, signer)
+		if isGEDoubleSignPunishNumber(number) {
+			if err := a.verifyDoubleSignPunish(snap, header); err != nil {
+				return err
+			}
+		}
 	} else {
 		if notice, loopStartTime, period, signerLength, _, err := a.mcSnapshot(chain, signer, header.Time); err != nil {
 			return err
@@ -641,25 +704,25 @@ func (a *Alien) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 			return consensus.ErrUnknownAncestor
 		}
 		header.Time = parent.Time + uint64(a.config.Period)
-		if header.Time < uint64(time.Now().Unix()) {
-			header.Time = uint64(time.Now().Unix())
+		if header.Time < uint64(a.now().Unix()) {
+			header.Time = uint64(a.now().Unix())
 		}
 	}
 	// If now is later than genesis timestamp, skip prepare
-	if a.config.GenesisTimestamp < uint64(time.Now().Unix()) {
+	if a.config.GenesisTimestamp < uint64(a.now().Unix()) {
 		return nil
 	}
 	// Count down for start
 	if header.Number.Uint64() == 1 {
 		for {
-			delay := time.Unix(int64(a.config.GenesisTimestamp-2), 0).Sub(time.Now())
+			delay := time.Unix(int64(a.config.GenesisTimestamp-2), 0).Sub(a.now())
 			if delay <= time.Duration(0) {
-				log.Info("Ready for seal block", "time", time.Now())
+				log.Info("Ready for seal block", "time", a.now())
 				break
 			} else if delay > time.Duration(a.config.Period)*time.Second {
 				delay = time.Duration(a.config.Period) * time.Second
 			}
-			log.Info("Waiting for seal block", "delay", common.PrettyDuration(time.Unix(int64(a.config.GenesisTimestamp-2), 0).Sub(time.Now())))
+			log.Info("Waiting for seal block", "delay", common.PrettyDuration(time.Unix(int64(a.config.GenesisTimestamp-2), 0).Sub(a.now())))
 			select {
 			case <-time.After(delay):
 				continue
@@ -889,11 +952,16 @@ func (a *Alien) Finalize(chain consensus.ChainHeaderReader, header *types.Header
 		return consensus.ErrPrunedAncestor
 	}
 	header.Time = parent.Time + a.config.Period
-	if int64(header.Time) < time.Now().Unix() {
-		header.Time = uint64(time.Now().Unix())
+	if int64(header.Time) < a.now().Unix() {
+		header.Time = uint64(a.now().Unix())
 	}
 
-	// Ensure the extra data has all it's components
+	// Ensure the extra data has all it's components, keeping the sealed extra of
+	// a block being imported for the double sign punishments it carries
+	var sealedExtra []byte
+	if len(header.Extra) >= extraVanity+extraSeal {
+		sealedExtra = common.CopyBytes(header.Extra[extraVanity : len(header.Extra)-extraSeal])
+	}
 	if len(header.Extra) < extraVanity {
 		header.Extra = append(header.Extra, bytes.Repeat([]byte{0x00}, extraVanity-len(header.Extra))...)
 	}
@@ -963,7 +1031,7 @@ func (a *Alien) Finalize(chain consensus.ChainHeaderReader, header *types.Header
 	if !chain.Config().Alien.SideChain {
 		var es LockState
 		if number >= StorageEffectBlockNumber {
-			es, err = NewLockState(parentHeaderExtra.ExtraStateRoot, parentHeaderExtra.LockAccountsRoot, number)
+			es, err = NewLockState(parentHeaderExtra.ExtraStateRoot, parentHeaderExtra.LockAccountsRoot, number, a.db, a.config.Period)
 			if err != nil {
 				//log.Error("extrastate open failed", "root", parent.MixDigest, "err", err)
 				return err
@@ -976,6 +1044,18 @@ func (a *Alien) Finalize(chain consensus.ChainHeaderReader, header *types.Header
 		}
 		currentHeaderExtra = mcCurrentHeaderExtra
 		currentHeaderExtra.ConfirmedBlockNumber = snap.getLastConfirmedBlockNumber(currentHeaderExtra.CurrentBlockConfirmations).Uint64()
+		// seal the double sign evidences collected if sealing the block locally,
+		// or carry over the valid punishments of a block being imported
+		a.lock.RLock()
+		signer := a.signer
+		a.lock.RUnlock()
+		if isGEDoubleSignPunishNumber(number) {
+			if sealedExtra != nil {
+				currentHeaderExtra.DoubleSignPunish = a.sealedDoubleSignPunish(snap, header.Number, sealedExtra)
+			} else if header.Coinbase == signer {
+				currentHeaderExtra.DoubleSignPunish = a.evidence.punish(snap, number)
+			}
+		}
 		// write signerQueue in first header, from self vote signers in genesis block
 		if number == 1 {
 			currentHeaderExtra.LoopStartTime = a.config.GenesisTimestamp
@@ -1055,7 +1135,7 @@ func (a *Alien) Finalize(chain consensus.ChainHeaderReader, header *types.Header
 			if err != nil {
 				return err
 			}
-			err = es.PayLockReward(parentHeaderExtra.LockAccountsRoot, number, state)
+			err = es.PayLockReward(number, state)
 			if err != nil {
 				log.Error("extrastate addlockreward", "error", err)
 				return err
@@ -1241,7 +1321,7 @@ func (a *Alien) Seal(chain consensus.ChainHeaderReader, block *types.Block, resu
 	}
 
 	// correct the time
-	delay := time.Unix(int64(header.Time), 0).Sub(time.Now())
+	delay := time.Unix(int64(header.Time), 0).Sub(a.now())
 
 	sighash, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeAlien, AlienRLP(header))
 	if err != nil {
@@ -1666,6 +1746,26 @@ func (a *Alien) VerifyHeaderExtra(chain consensus.ChainHeaderReader, header *typ
 	return err
 }
 
+// FinalizedNumber implements consensus.Finality, returning the last block
+// confirmed by more than 2/3 of the signers as recorded in the extra of head.
+func (a *Alien) FinalizedNumber(head *types.Header) (uint64, error) {
+	number := head.Number.Uint64()
+	if number == 0 {
+		return 0, nil
+	}
+	if len(head.Extra) < extraVanity+extraSeal {
+		return 0, errMissingSignature
+	}
+	headerExtra := HeaderExtra{}
+	if err := decodeHeaderExtra(a.config, head.Number, head.Extra[extraVanity:len(head.Extra)-extraSeal], &headerExtra); err != nil {
+		return 0, err
+	}
+	if headerExtra.ConfirmedBlockNumber > number {
+		return number, nil
+	}
+	return headerExtra.ConfirmedBlockNumber, nil
+}
+
 func doVerifyHeaderExtra(header *types.Header, verifyExtra []byte, a *Alien) error {
 	currentHExtra := HeaderExtra{}
 	err := decodeHeaderExtra(a.config, header.Number, header.Extra[extraVanity:len(header.Extra)-extraSeal], &currentHExtra)
@@ -1,81 +1,213 @@
 package alien
 
-import "math/big"
+import (
+	"errors"
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
 
 const (
 	checkpointInterval = 360 //360        // About N hours if config.period is N
 
-	secondsPerDay                    = 24 * 60 * 60 // Number of seconds for one day
-	accumulateFlowRewardInterval     = 2 * 60 * 60  // accumulate flow reward interval every day
-	accumulateBandwithRewardInterval = 1 * 60 * 60  // accumulate flow reward interval every day
-
-	paySignerRewardInterval    = 0               // pay singer reward  interval every day
-	payFlowRewardInterval      = 2*60*60 + 30*60 //  pay flow reward  interval every day
-	payBandwidthRewardInterval = 1*60*60 + 30*60 //  pay bandwidth reward  interval every day
+	accumulateBandwithRewardInterval = 1 * 60 * 60 // accumulate flow reward interval every day
 
-	signerPledgeLockParamPeriod    = 180 * 24 * 60 * 60
 	signerPledgeLockParamRlsPeriod = 0
 	signerPledgeLockParamInterval  = 0
 
-	flowPledgeLockParamPeriod    = 180 * 24 * 60 * 60
 	flowPledgeLockParamRlsPeriod = 0
 	flowPledgeLockParamInterval  = 0
 
-	rewardLockParamPeriod         = 30 * 24 * 60 * 60
-	rewardLockParamRlsPeriod      = 180 * 24 * 60 * 60
-	rewardLockParamInterval       = 24 * 60 * 60
-	maxCandidateMiner             = 500 //	The maximum number of candidate nodes participating in each election is 500
-	electionPartitionThreshold    = 36  //Election partition threshold
-	signFixBlockNumber            = 21
-	grantProfitOneTimeBlockNumber = 30
-	lockSimplifyEffectBlocknumber = 38
-
-	lockMergeNumber                      = 44
-	tallyRevenueEffectBlockNumber        = 48
-	SigerQueueFixBlockNumber             = 53
-	SigerElectNewEffectBlockNumber       = 66
-	MinerUpdateStateFixBlockNumber       = 75
-	TallyPunishdProcessEffectBlockNumber = 84
-	TallyPunishdFixBlockNumber           = 92
-	StorageEffectBlockNumber             = 101
+	maxCandidateMiner          = 500 //	The maximum number of candidate nodes participating in each election is 500
+	electionPartitionThreshold = 36  //Election partition threshold
+
 	//storage
-	storageVerificationCheck = 1 * 60 * 60 //return funds where contract expiration
 	rentRenewalExpires       = 50
 	rentFailToRescind        = 10
 	maxStgVerContinueDayFail = 3 //storage Verification failed and failed for 7 consecutive days
 
-	SPledgeRevertFixBlockNumber          = 122
-	AdjustSPRBlockNumber                 = 132 //Adjust calc StoragePledgeReward
-	CompareGrantProfitHash               = false
-	storageVerifyNewEffectNumber         = 147
-	storagePledgeTmpVerifyEffectNumber   = 154
-	StorageChBwEffectNumber              = 184
-	storagePledgeTmpVerifyEffectNumberV2 = 214
-	PledgeRevertLockEffectNumber         = 254
-	payPOSPGRedeemInterval               = 1*60*60 + 40*60 //  pay bandwidth reward  interval every day
-	StoragePledgeOptEffectNumber         = 289
-	FixLeaseCapacityNumber               = 300
-	PosrIncentiveEffectNumber            = 321
-	PosrExitNewRuleEffectNumber          = 332
-	PosrNewCalEffectNumber               = 342
-	PosNewEffectNumber                   = 352
-	payPOSExitInterval                   = 1*60*60 + 50*60 //  pay bandwidth reward  interval every day
-	checkPOSAutoExit                     = 1*60*60 + 60*60
-	PosLastPunishFixNumber               = 362
-	PosAutoExitPunishChangeNumber        = 372
-	GrantEffectNumber                    = 1501830
-	PoCrsAccCalNumber                    = 1502010
-	initStorageManagerNumber             = 1502190
-	utgLockRewardInterval                = 30 //Lock and release every 30 days
-	accumulateRewardLockInterval         = 2*60*60 + 10*10
-	paySpReWardInterval                  = 2*60*60 + 10*50
-	paySpEntrustRewardInterval           = 2*60*60 + 10*60
-	paySTPEntrustExitInterval            = 2*60*60 + 10*70
-	paySpExitInterval                    = 2*60*60 + 10*80
-	paySpEntrustExitInterval             = 2*60*60 + 10*90
-	paySTPEntrustInterval                = 2*60*60 + 10*100
+	CompareGrantProfitHash = false
+)
+
+// Lengths of the reward schedules in seconds, taken from the time table of the
+// chain config by setTimeSchedule.
+var (
+	secondsPerDay                uint64 // Number of seconds for one day
+	accumulateFlowRewardInterval uint64 // accumulate flow reward interval every day
+
+	paySignerRewardInterval    uint64 // pay singer reward  interval every day
+	payFlowRewardInterval      uint64 //  pay flow reward  interval every day
+	payBandwidthRewardInterval uint64 //  pay bandwidth reward  interval every day
+
+	signerPledgeLockParamPeriod uint64
+	flowPledgeLockParamPeriod   uint64
+
+	rewardLockParamPeriod    uint64
+	rewardLockParamRlsPeriod uint64
+	rewardLockParamInterval  uint64
+
+	storageVerificationCheck uint64 //return funds where contract expiration
+
+	payPOSPGRedeemInterval       uint64 //  pay bandwidth reward  interval every day
+	payPOSExitInterval           uint64
+	checkPOSAutoExit             uint64
+	utgLockRewardInterval        uint64 //Lock and release every 30 days on mainnet
+	accumulateRewardLockInterval uint64
+	paySpReWardInterval          uint64
+	paySpEntrustRewardInterval   uint64
+	paySTPEntrustExitInterval    uint64
+	paySpExitInterval            uint64
+	paySpEntrustExitInterval     uint64
+	paySTPEntrustInterval        uint64
+)
+
+// Activation heights of the rule changes, taken from the fork table of the
+// chain config by setForkSchedule.
+var (
+	signFixBlockNumber                   uint64
+	grantProfitOneTimeBlockNumber        uint64
+	lockSimplifyEffectBlocknumber        uint64
+	lockMergeNumber                      uint64
+	tallyRevenueEffectBlockNumber        uint64
+	SigerQueueFixBlockNumber             uint64
+	SigerElectNewEffectBlockNumber       uint64
+	MinerUpdateStateFixBlockNumber       uint64
+	TallyPunishdProcessEffectBlockNumber uint64
+	TallyPunishdFixBlockNumber           uint64
+	StorageEffectBlockNumber             uint64
+	SPledgeRevertFixBlockNumber          uint64
+	AdjustSPRBlockNumber                 uint64
+	storageVerifyNewEffectNumber         uint64
+	storagePledgeTmpVerifyEffectNumber   uint64
+	StorageChBwEffectNumber              uint64
+	storagePledgeTmpVerifyEffectNumberV2 uint64
+	PledgeRevertLockEffectNumber         uint64
+	StoragePledgeOptEffectNumber         uint64
+	FixLeaseCapacityNumber               uint64
+	PosrIncentiveEffectNumber            uint64
+	PosrExitNewRuleEffectNumber          uint64
+	PosrNewCalEffectNumber               uint64
+	PosNewEffectNumber                   uint64
+	PosLastPunishFixNumber               uint64
+	PosAutoExitPunishChangeNumber        uint64
+	StorageMinCapacityEffectNumber       uint64
+	GrantEffectNumber                    uint64
+	PoCrsAccCalNumber                    uint64
+	initStorageManagerNumber             uint64
+	CustomTxResultEffectNumber           uint64
+	headerExtraVersionNumber             uint64
+	doubleSignPunishNumber               uint64
+	lockTrieNumber                       uint64
+)
+
+func init() {
+	setForkSchedule(nil)
+	setTimeSchedule(nil)
+}
+
+// setForkSchedule activates the rule changes at the heights of the given fork
+// table, with the rule changes it leaves unset at their mainnet height.
+func setForkSchedule(forks *params.AlienForks) {
+	schedule := forks.WithDefaults()
+	signFixBlockNumber = schedule.SignFixBlock.Uint64()
+	grantProfitOneTimeBlockNumber = schedule.GrantProfitOneTimeBlock.Uint64()
+	lockSimplifyEffectBlocknumber = schedule.LockSimplifyBlock.Uint64()
+	lockMergeNumber = schedule.LockMergeBlock.Uint64()
+	tallyRevenueEffectBlockNumber = schedule.TallyRevenueBlock.Uint64()
+	SigerQueueFixBlockNumber = schedule.SignerQueueFixBlock.Uint64()
+	SigerElectNewEffectBlockNumber = schedule.SignerElectNewBlock.Uint64()
+	MinerUpdateStateFixBlockNumber = schedule.MinerUpdateStateFixBlock.Uint64()
+	TallyPunishdProcessEffectBlockNumber = schedule.TallyPunishdProcessBlock.Uint64()
+	TallyPunishdFixBlockNumber = schedule.TallyPunishdFixBlock.Uint64()
+	StorageEffectBlockNumber = schedule.StorageBlock.Uint64()
+	SPledgeRevertFixBlockNumber = schedule.SPledgeRevertFixBlock.Uint64()
+	AdjustSPRBlockNumber = schedule.AdjustSPRBlock.Uint64()
+	storageVerifyNewEffectNumber = schedule.StorageVerifyNewBlock.Uint64()
+	storagePledgeTmpVerifyEffectNumber = schedule.StoragePledgeTmpVerifyBlock.Uint64()
+	StorageChBwEffectNumber = schedule.StorageChBwBlock.Uint64()
+	storagePledgeTmpVerifyEffectNumberV2 = schedule.StoragePledgeTmpVerifyV2Block.Uint64()
+	PledgeRevertLockEffectNumber = schedule.PledgeRevertLockBlock.Uint64()
+	StoragePledgeOptEffectNumber = schedule.StoragePledgeOptBlock.Uint64()
+	FixLeaseCapacityNumber = schedule.FixLeaseCapacityBlock.Uint64()
+	PosrIncentiveEffectNumber = schedule.PosrIncentiveBlock.Uint64()
+	PosrExitNewRuleEffectNumber = schedule.PosrExitNewRuleBlock.Uint64()
+	PosrNewCalEffectNumber = schedule.PosrNewCalBlock.Uint64()
+	PosNewEffectNumber = schedule.PosNewBlock.Uint64()
+	PosLastPunishFixNumber = schedule.PosLastPunishFixBlock.Uint64()
+	PosAutoExitPunishChangeNumber = schedule.PosAutoExitPunishChangeBlock.Uint64()
+	StorageMinCapacityEffectNumber = schedule.StorageMinCapacityBlock.Uint64()
+	GrantEffectNumber = schedule.GrantBlock.Uint64()
+	PoCrsAccCalNumber = schedule.PoCrsAccCalBlock.Uint64()
+	initStorageManagerNumber = schedule.StorageManagerBlock.Uint64()
+	CustomTxResultEffectNumber = forkNumber(schedule.CustomTxResultBlock)
+	headerExtraVersionNumber = forkNumber(schedule.HeaderExtraVersionBlock)
+	doubleSignPunishNumber = forkNumber(schedule.DoubleSignPunishBlock)
+	lockTrieNumber = forkNumber(schedule.LockTrieBlock)
+}
+
+// setTimeSchedule runs the reward schedules on the spans of the given time
+// table, with the spans it leaves unset at their mainnet length.
+func setTimeSchedule(times *params.AlienTimes) {
+	schedule := times.WithDefaults()
+	secondsPerDay = schedule.Day
+	accumulateFlowRewardInterval = schedule.AccumulateFlowReward
+	paySignerRewardInterval = schedule.PaySignerReward
+	payFlowRewardInterval = schedule.PayFlowReward
+	payBandwidthRewardInterval = schedule.PayBandwidthReward
+	signerPledgeLockParamPeriod = schedule.SignerPledgeLock
+	flowPledgeLockParamPeriod = schedule.FlowPledgeLock
+	rewardLockParamPeriod = schedule.RewardLock
+	rewardLockParamRlsPeriod = schedule.RewardLockRelease
+	rewardLockParamInterval = schedule.RewardLockReleaseInterval
+	storageVerificationCheck = schedule.StorageVerification
+	payPOSPGRedeemInterval = schedule.PayPosPledgeRedeem
+	payPOSExitInterval = schedule.PayPosExit
+	checkPOSAutoExit = schedule.CheckPosAutoExit
+	utgLockRewardInterval = schedule.LockRewardDays
+	accumulateRewardLockInterval = schedule.AccumulateRewardLock
+	paySpReWardInterval = schedule.PaySpReward
+	paySpEntrustRewardInterval = schedule.PaySpEntrustReward
+	paySTPEntrustExitInterval = schedule.PaySTPEntrustExit
+	paySpExitInterval = schedule.PaySpExit
+	paySpEntrustExitInterval = schedule.PaySpEntrustExit
+	paySTPEntrustInterval = schedule.PaySTPEntrust
+}
+
+// errScheduleConflict is returned when an engine or inspector is created for
+// a fork or time table other than the one already applied to the process.
+var errScheduleConflict = errors.New("conflicting alien fork or time table")
+
+// The rule changes and reward spans are kept in package globals, so all the
+// engines and inspectors of a process run on the tables applied by the first.
+var (
+	scheduleLock  sync.Mutex
+	scheduleForks *params.AlienForks // Fork table applied to the process, nil until one is
+	scheduleTimes *params.AlienTimes // Time table applied to the process
 )
 
+// applySchedule applies the fork and time tables of an alien config to the
+// process. Once tables are applied, it only checks that the config runs on the
+// same ones.
+func applySchedule(config *params.AlienConfig) error {
+	forks, times := config.Forks.WithDefaults(), config.Times.WithDefaults()
+
+	scheduleLock.Lock()
+	defer scheduleLock.Unlock()
+
+	if scheduleForks != nil {
+		if !scheduleForks.Equal(forks) || *scheduleTimes != *times {
+			return errScheduleConflict
+		}
+		return nil
+	}
+	setForkSchedule(forks)
+	setTimeSchedule(times)
+	scheduleForks, scheduleTimes = forks, times
+	return nil
+}
+
 var (
 	minCndPledgeBalance        = new(big.Int).Mul(big.NewInt(1e+18), big.NewInt(20)) // candidate pledge balance
 	minSignerLockBalance       = new(big.Int).Mul(big.NewInt(1e+18), big.NewInt(0))  // signer reward lock balance
@@ -98,7 +230,7 @@ var (
 	maxPosContinueDayFail      = uint64(30)
 	posDistributionDefaultRate = big.NewInt(10000)
 	//How many days to keep snapshots
-	retainedLastSnapshot = uint64(100) * (secondsPerDay / defaultBlockPeriod)
+	retainedLastSnapshot = uint64(100) * (params.MainnetAlienTimes.Day / defaultBlockPeriod)
 )
 
 func (a *Alien) blockPerDay() uint64 {
@@ -226,6 +358,10 @@ func isGEPosAutoExitPunishChange(number uint64) bool {
 	return number >= PosAutoExitPunishChangeNumber
 }
 
+func isGEStorageMinCapacityEffect(number uint64) bool {
+	return number >= StorageMinCapacityEffectNumber
+}
+
 func isLtGrantEffectNumber(number uint64) bool {
 	return number < GrantEffectNumber
 }
@@ -240,6 +376,15 @@ func isGEPoCrsAccCalNumber(number uint64) bool {
 func isGEInitStorageManagerNumber(number uint64) bool {
 	return number >= initStorageManagerNumber
 }
+func isGECustomTxResultEffect(number uint64) bool {
+	return number >= CustomTxResultEffectNumber
+}
+func isGEDoubleSignPunishNumber(number uint64) bool {
+	return number >= doubleSignPunishNumber
+}
+func isGELockTrieNumber(number uint64) bool {
+	return number >= lockTrieNumber && number >= initStorageManagerNumber
+}
 func isPaySTPEntrustExit(number uint64, period uint64) bool {
 	if number < initStorageManagerNumber {
 		return false
@@ -306,3 +451,12 @@ func isSpDelExit(number uint64, period uint64) bool {
 	blockPerDay := secondsPerDay / period
 	return block+1 == number%blockPerDay && block != number
 }
+
+// forkNumber returns the activation height of a rule change, which is never
+// reached if the rule change is not scheduled.
+func forkNumber(block *big.Int) uint64 {
+	if block == nil {
+		return math.MaxUint64
+	}
+	return block.Uint64()
+}
@@ -19,24 +19,70 @@
 package alien
 
 import (
+	"bytes"
 	"container/list"
+	"context"
 	"errors"
+	"fmt"
 	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
 	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/customtx"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
 	"github.com/UltronGlow/UltronGlow-Origin/core/types"
 	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/event"
 	"github.com/UltronGlow/UltronGlow-Origin/log"
 	"github.com/UltronGlow/UltronGlow-Origin/rlp"
 	"github.com/UltronGlow/UltronGlow-Origin/rpc"
 	"github.com/shopspring/decimal"
 	"math/big"
+	"sort"
 	"sync"
 )
 
 var (
 	errNumberTooSmall = errors.New("block number too small")
+
+	// errUnknownTransaction is returned if the requested transaction is not in the chain.
+	errUnknownTransaction = errors.New("unknown transaction")
+
+	// errNoCustomTxResult is returned if no outcome is recorded for the requested transaction.
+	errNoCustomTxResult = errors.New("no custom transaction result")
+
+	// errNoSRT is returned if the SRT balances are not kept yet at the requested block.
+	errNoSRT = errors.New("no SRT trie")
+
+	// errUnknownLease is returned if the requested lease is neither held by a
+	// storage pledge nor journaled.
+	errUnknownLease = errors.New("unknown lease")
+
+	// errInvalidBlockRange is returned if the requested block range is empty or
+	// spans more than maxReleaseHistoryRange blocks.
+	errInvalidBlockRange = errors.New("invalid block range")
+
+	// errUnknownProposal is returned if the requested proposal is neither
+	// waiting for declares nor for its result.
+	errUnknownProposal = errors.New("unknown proposal")
+
+	// errInvalidProposal is returned if the parameters of a proposal would get
+	// it dropped by processEventProposal.
+	errInvalidProposal = errors.New("invalid proposal")
+
+	// errProposalClosed is returned if a declare would reach a proposal after
+	// its last declare block.
+	errProposalClosed = errors.New("proposal closed to declares")
 )
 
+// maxReleaseHistoryRange is the most blocks the release history is retrieved
+// for at once, as the releases of the lock trie are replayed block by block.
+const maxReleaseHistoryRange = 10000
+
+// maxSignerScheduleLoops is the most loops the signer schedule is predicted
+// for, as the signers of later loops depend on blocks not sealed yet.
+const maxSignerScheduleLoops = 2
+
 // API is a user facing RPC API to allow controlling the signer and voting
 // mechanisms of the delegated-proof-of-stake scheme.
 type API struct {
@@ -90,6 +136,71 @@ func (api *API) GetSnapshotAtNumber(number uint64) (*Snapshot, error) {
 	return api.getSnapshotCache(header)
 }
 
+// GetSnapshotByHeaderTime retrieves the loop of the snapshot sealed at the given
+// header time as seen by side chain scHash: the signers replaced by the coinbase
+// they set on the side chain, along with the notices sent to it.
+func (api *API) GetSnapshotByHeaderTime(targetTime uint64, scHash common.Hash) (*Snapshot, error) {
+	header := api.chain.CurrentHeader()
+	if header == nil || targetTime >= header.Time+api.alien.config.Period {
+		return nil, errUnknownBlock
+	}
+	// Search the last header sealed at or before the target time
+	low, high := uint64(0), header.Number.Uint64()
+	for low < high {
+		mid := (low + high + 1) / 2
+		h := api.chain.GetHeaderByNumber(mid)
+		if h == nil {
+			return nil, errUnknownBlock
+		}
+		if h.Time <= targetTime {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	header = api.chain.GetHeaderByNumber(low)
+	if header == nil || header.Time > targetTime {
+		return nil, errUnknownBlock
+	}
+	snap, err := api.getSnapshotCache(header)
+	if err != nil {
+		return nil, err
+	}
+	mcs := &Snapshot{
+		Period:        snap.Period,
+		Number:        snap.Number,
+		Hash:          snap.Hash,
+		HeaderTime:    snap.HeaderTime,
+		LoopStartTime: snap.LoopStartTime,
+	}
+	mcs.Signers = snap.scSigners(scHash)
+	if notice, ok := snap.SCNoticeMap[scHash]; ok {
+		mcs.SCNoticeMap = map[common.Hash]*CCNotice{scHash: notice}
+	}
+	return mcs, nil
+}
+
+// scSigners returns the signers of the snapshot mapped to their coinbases on the
+// side chain scHash. The coinbases are recorded by coinbase, so a signer having
+// set several of them is mapped to the lowest one, the same on every node.
+func (s *Snapshot) scSigners(scHash common.Hash) []*common.Address {
+	coinbases := make(map[common.Address]common.Address)
+	for coinbase, signer := range s.SCCoinbase[scHash] {
+		if last, ok := coinbases[signer]; !ok || bytes.Compare(coinbase[:], last[:]) < 0 {
+			coinbases[signer] = coinbase
+		}
+	}
+	var signers []*common.Address
+	for _, signer := range s.Signers {
+		if coinbase, ok := coinbases[*signer]; ok {
+			signers = append(signers, &coinbase)
+		} else {
+			signers = append(signers, signer)
+		}
+	}
+	return signers
+}
+
 func (api *API) GetSnapshotSignerAtNumber(number uint64) (*SnapshotSign, error) {
 	log.Info("api GetSnapshotSignerAtNumber", "number", number)
 	header := api.chain.GetHeaderByNumber(number)
@@ -733,6 +844,99 @@ func (api *API) GetSRTBalance(address common.Address) (*SnapshotAddrSRT, error)
 	return api.GetSRTBalanceAtNumber(address, header.Number.Uint64())
 }
 
+// SRTProof is the Merkle proof of the SRT balance of an account in the SRT trie
+// a block commits to in the SRTDataRoot of its header extra, checked by
+// srtproof.VerifyHeader against the header. The trie holds the balances the
+// block is sealed on, before the SRT exchanges of the block itself.
+type SRTProof struct {
+	Address     common.Address  `json:"address"`
+	Balance     *hexutil.Big    `json:"balance"`
+	BlockNumber uint64          `json:"blockNumber"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	SRTHash     common.Hash     `json:"srtHash"`
+	Proof       []hexutil.Bytes `json:"proof"` // Trie nodes from the root down
+}
+
+// srtProofList collects the trie nodes of a proof in the order they are written.
+type srtProofList []hexutil.Bytes
+
+func (n *srtProofList) Put(key []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *srtProofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// GetSRTProof retrieves the SRT balance of an account the header of a block
+// commits to, along with the Merkle proof of it.
+func (api *API) GetSRTProof(address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*SRTProof, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header.Number.Uint64() < PledgeRevertLockEffectNumber {
+		return nil, errNoSRT
+	}
+	headerExtra := HeaderExtra{}
+	if err := decodeHeaderExtra(api.alien.config, header.Number, header.Extra[extraVanity:len(header.Extra)-extraSeal], &headerExtra); err != nil {
+		return nil, err
+	}
+	srt, err := NewSrtTrie(headerExtra.SRTDataRoot, api.alien.db)
+	if err != nil {
+		return nil, err
+	}
+	proof := srtProofList{}
+	if err := srt.Prove(address, &proof); err != nil {
+		return nil, err
+	}
+	return &SRTProof{
+		Address:     address,
+		Balance:     (*hexutil.Big)(srt.Get(address)),
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash(),
+		SRTHash:     headerExtra.SRTDataRoot,
+		Proof:       proof,
+	}, nil
+}
+
+// headerByNumberOrHash retrieves the header of a block given by number, tag or
+// hash. The finalized and safe tags both stand for the last block confirmed by
+// the signers.
+func (api *API) headerByNumberOrHash(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header := api.chain.GetHeaderByHash(hash)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+		if blockNrOrHash.RequireCanonical && !api.isCanonical(header.Number.Uint64(), hash) {
+			return nil, errUnknownBlock
+		}
+		return header, nil
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, errUnknownBlock
+	}
+	head := api.chain.CurrentHeader()
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return head, nil
+	case rpc.FinalizedBlockNumber, rpc.SafeBlockNumber:
+		finalized, err := api.alien.FinalizedNumber(head)
+		if err != nil {
+			return nil, err
+		}
+		number = rpc.BlockNumber(finalized)
+	}
+	header := api.chain.GetHeaderByNumber(uint64(number))
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return header, nil
+}
+
 func (api *API) GetSPledgeInfoByAddr(address common.Address) (*SnapshotSPledgeInfo, error) {
 	log.Info("api GetSPledgeInfoByAddr", "address", address)
 	header := api.chain.CurrentHeader()
@@ -1465,3 +1669,1015 @@ func (sr *SnapshotRewardBalanceV1) appendRBd(FlowRevenue map[common.Address]*Loc
 	}
 	return nil
 }
+
+type CustomTxResult struct {
+	TxHash      common.Hash `json:"txHash"`
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Category    string      `json:"category"`
+	Status      string      `json:"status"`
+	ReasonCode  uint64      `json:"reasonCode"`
+	Reason      string      `json:"reason"`
+}
+
+// GetCustomTxResult retrieves the outcome recorded in the receipt of a custom transaction.
+func (api *API) GetCustomTxResult(txHash common.Hash) (*CustomTxResult, error) {
+	tx, blockHash, number, index := rawdb.ReadTransaction(api.alien.db, txHash)
+	if tx == nil {
+		return nil, errUnknownTransaction
+	}
+	receipts := rawdb.ReadReceipts(api.alien.db, blockHash, number, api.chain.Config())
+	if uint64(len(receipts)) <= index {
+		return nil, errUnknownTransaction
+	}
+	status, reason, ok := findCustomTxResult(receipts[index])
+	if !ok {
+		return nil, errNoCustomTxResult
+	}
+	result := &CustomTxResult{
+		TxHash:      txHash,
+		BlockHash:   blockHash,
+		BlockNumber: number,
+		Status:      "rejected",
+		ReasonCode:  uint64(reason),
+		Reason:      reason.String(),
+	}
+	if status == CustomTxAccepted {
+		result.Status = "accepted"
+	}
+	if fields := customtx.Split(tx.Data()); len(fields) > posCategory {
+		result.Category = fields[posCategory]
+	}
+	return result, nil
+}
+
+// GetDoubleSignEvidence retrieves the double sign evidences waiting in the local
+// evidence pool to be punished.
+func (api *API) GetDoubleSignEvidence() []*DoubleSignEvidence {
+	return api.alien.PendingDoubleSignEvidence()
+}
+
+// LeaseInfo is a storage lease as it stands at the head of the chain, along
+// with the lifecycle events journaled for it.
+type LeaseInfo struct {
+	Hash          common.Hash    `json:"hash"`
+	Pledge        common.Address `json:"pledge"`
+	Tenant        common.Address `json:"tenant"`
+	Status        int            `json:"status"`
+	Removed       bool           `json:"removed"` // Whether the storage pledge dropped the lease
+	RequestNumber uint64         `json:"requestNumber"`
+	Capacity      *big.Int       `json:"capacity,omitempty"`
+	RootHash      common.Hash    `json:"roothash"`
+	Deposit       *big.Int       `json:"deposit,omitempty"`
+	UnitPrice     *big.Int       `json:"unitprice,omitempty"`
+	Cost          *big.Int       `json:"cost,omitempty"`
+	Duration      *big.Int       `json:"duration,omitempty"`
+	Details       []*LeaseDetail `json:"details"` // Requests of the lease and its renewals, oldest first
+	History       []*LeaseEvent  `json:"history"`
+}
+
+// GetLease retrieves a storage lease and its status history.
+func (api *API) GetLease(leaseHash common.Hash) (*LeaseInfo, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	infos, err := api.leaseInfos(snap, []common.Hash{leaseHash})
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, errUnknownLease
+	}
+	return infos[0], nil
+}
+
+// GetLeasesByUser retrieves the storage leases rented by a tenant and their
+// status histories, oldest request first.
+func (api *API) GetLeasesByUser(address common.Address) ([]*LeaseInfo, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := readOwnerLeases(api.alien.db, leaseTenantPrefix, address)
+	if err != nil {
+		return nil, err
+	}
+	if snap.StorageData != nil {
+		for _, pledge := range snap.StorageData.StoragePledge {
+			for hash, lease := range pledge.Lease {
+				if lease.Address == address {
+					hashes = append(hashes, hash)
+				}
+			}
+		}
+	}
+	return api.leaseInfos(snap, hashes)
+}
+
+// GetLeasesByPledge retrieves the storage leases served by a storage pledge and
+// their status histories, oldest request first.
+func (api *API) GetLeasesByPledge(address common.Address) ([]*LeaseInfo, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := readOwnerLeases(api.alien.db, leasePledgePrefix, address)
+	if err != nil {
+		return nil, err
+	}
+	if snap.StorageData != nil {
+		if pledge, ok := snap.StorageData.StoragePledge[address]; ok {
+			for hash := range pledge.Lease {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	return api.leaseInfos(snap, hashes)
+}
+
+// leaseInfos assembles the given leases from the snapshot and the journaled
+// events, leaving out those found in neither.
+func (api *API) leaseInfos(snap *Snapshot, hashes []common.Hash) ([]*LeaseInfo, error) {
+	held := make(map[common.Hash]common.Address)
+	if snap.StorageData != nil {
+		for pledgeAddr, pledge := range snap.StorageData.StoragePledge {
+			for hash := range pledge.Lease {
+				held[hash] = pledgeAddr
+			}
+		}
+	}
+	var (
+		infos []*LeaseInfo
+		seen  = make(map[common.Hash]bool)
+	)
+	for _, hash := range hashes {
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		history, err := readLeaseHistory(api.alien.db, hash, api.isCanonical)
+		if err != nil {
+			return nil, err
+		}
+		info := &LeaseInfo{Hash: hash, History: history}
+		if pledgeAddr, ok := held[hash]; ok {
+			lease := snap.StorageData.StoragePledge[pledgeAddr].Lease[hash]
+			info.Pledge, info.Tenant, info.Status = pledgeAddr, lease.Address, lease.Status
+			info.Capacity, info.RootHash, info.Deposit = lease.Capacity, lease.RootHash, lease.Deposit
+			info.UnitPrice, info.Cost, info.Duration = lease.UnitPrice, lease.Cost, lease.Duration
+			for _, detail := range lease.LeaseList {
+				info.Details = append(info.Details, detail)
+			}
+			sort.Slice(info.Details, func(i, j int) bool {
+				return info.Details[i].RequestTime.Cmp(info.Details[j].RequestTime) < 0
+			})
+			if len(info.Details) > 0 {
+				info.RequestNumber = info.Details[0].RequestTime.Uint64()
+			}
+		} else if len(history) > 0 {
+			last := history[len(history)-1]
+			info.Pledge, info.Tenant, info.Status = last.Pledge, last.Tenant, last.Status
+			info.Removed = last.Kind == LeaseEventRemoved
+			info.RequestNumber = history[0].BlockNumber
+		} else {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].RequestNumber < infos[j].RequestNumber
+	})
+	return infos, nil
+}
+
+// isCanonical reports whether the block of the given number and hash is part of
+// the canonical chain.
+func (api *API) isCanonical(number uint64, hash common.Hash) bool {
+	header := api.chain.GetHeaderByNumber(number)
+	return header != nil && header.Hash() == hash
+}
+
+// chainHeadSubscriber is implemented by the chains announcing their new heads,
+// as the block chain the engine runs on does.
+type chainHeadSubscriber interface {
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// leaseHeadChanSize is the size of the channel receiving the new chain heads
+// of a lease event subscription.
+const leaseHeadChanSize = 10
+
+// LeaseEvents creates a subscription to the lease events of the blocks joining
+// the canonical chain. Events of blocks which are later reorganised away are not
+// retracted.
+func (api *API) LeaseEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	chain, ok := api.chain.(chainHeadSubscriber)
+	if !ok {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		heads := make(chan core.ChainHeadEvent, leaseHeadChanSize)
+		headSub := chain.SubscribeChainHeadEvent(heads)
+		defer headSub.Unsubscribe()
+
+		last := api.chain.CurrentHeader()
+		for {
+			select {
+			case headEv := <-heads:
+				head := headEv.Block.Header()
+				if last == nil {
+					last = head
+					continue
+				}
+				for _, ev := range api.headLeaseEvents(last, head) {
+					notifier.Notify(rpcSub.ID, ev)
+				}
+				last = head
+			case <-headSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// headLeaseEvents returns the lease events of the blocks leading to head from
+// its common ancestor with last, oldest first.
+func (api *API) headLeaseEvents(last, head *types.Header) []*LeaseEvent {
+	if head.Hash() == last.Hash() {
+		return nil
+	}
+	// The head snapshot was applied when the block was imported, which journaled
+	// the events of the blocks up to it, so it is taken from the cache
+	if _, err := api.alien.snapshot(api.chain, head.Number.Uint64(), head.Hash(), nil, nil, defaultLoopCntRecalculateSigners); err != nil {
+		return nil
+	}
+	var events []*LeaseEvent
+	for _, header := range api.joinedHeaders(last, head) {
+		blockEvents, err := readBlockLeaseEvents(api.alien.db, header.Number.Uint64(), header.Hash())
+		if err != nil {
+			log.Warn("Failed to read lease events", "number", header.Number, "err", err)
+		}
+		events = append(events, blockEvents...)
+	}
+	return events
+}
+
+// joinedHeaders returns the headers leading to head from its common ancestor
+// with last, oldest first.
+func (api *API) joinedHeaders(last, head *types.Header) []*types.Header {
+	var headers []*types.Header
+	for head != nil && head.Number.Cmp(last.Number) > 0 {
+		headers = append(headers, head)
+		head = api.chain.GetHeader(head.ParentHash, head.Number.Uint64()-1)
+	}
+	for head != nil && last != nil && head.Hash() != last.Hash() {
+		if head.Number.Cmp(last.Number) == 0 {
+			headers = append(headers, head)
+			head = api.chain.GetHeader(head.ParentHash, head.Number.Uint64()-1)
+		}
+		if head != nil && last.Number.Cmp(head.Number) > 0 {
+			last = api.chain.GetHeader(last.ParentHash, last.Number.Uint64()-1)
+		}
+	}
+	for i := 0; i < len(headers)/2; i++ {
+		headers[i], headers[len(headers)-1-i] = headers[len(headers)-1-i], headers[i]
+	}
+	return headers
+}
+
+// SPoolSummary is a storage pool as listed to its operators.
+type SPoolSummary struct {
+	SpAddr         common.Address `json:"spAddr"`
+	Hash           common.Hash    `json:"hash"`
+	Address        common.Address `json:"address"`
+	Manager        common.Address `json:"manager"`
+	RevenueAddress common.Address `json:"revenueAddress"`
+	Status         uint64         `json:"status"`
+	TotalCapacity  *big.Int       `json:"totalcapacity"`
+	UsedCapacity   *big.Int       `json:"usedcapacity"`
+	FreeCapacity   *big.Int       `json:"freecapacity"`
+	TotalAmount    *big.Int       `json:"totalAmount"`
+	ManagerAmount  *big.Int       `json:"managerAmount"`
+	Fee            uint64         `json:"fee"`
+	EntrustRate    uint64         `json:"entrustRate"`
+	Entrusts       int            `json:"entrusts"` // Number of entrust positions
+}
+
+// SPEntrustPosition is an amount entrusted to a storage pool, with the part of
+// the rewards of the pool it accrued. The accrued rewards are split between
+// the positions an address holds in a pool by their amounts, the way they are
+// distributed to the address.
+type SPEntrustPosition struct {
+	SpAddr     common.Address `json:"spAddr"`     // Zero for the rewards locked in the lock trie
+	SpHash     common.Hash    `json:"spHash"`     // Zero once the pool is gone
+	PledgeHash common.Hash    `json:"pledgeHash"` // Zero for the rewards left by positions which exited
+	Address    common.Address `json:"address"`
+	Height     *big.Int       `json:"height,omitempty"`
+	Amount     *big.Int       `json:"amount,omitempty"`
+	Pending    *big.Int       `json:"pending,omitempty"`  // Rewards distributed since the last lock round
+	Locked     *big.Int       `json:"locked,omitempty"`   // Rewards locked and not released yet
+	Released   *big.Int       `json:"released,omitempty"` // Rewards released
+}
+
+// SPEntrustExit is an amount taken out of a storage pool, locked until it is
+// paid back to its entruster.
+type SPEntrustExit struct {
+	SpAddr     common.Address `json:"spAddr"`
+	Address    common.Address `json:"address"`
+	Amount     *big.Int       `json:"amount"`
+	Released   *big.Int       `json:"released"`
+	Remaining  *big.Int       `json:"remaining"`
+	LockNumber uint64         `json:"lockNumber"`
+	RlsPeriod  uint32         `json:"releaseperiod"`
+	Interval   uint32         `json:"releaseinterval"`
+}
+
+// GetSPools lists the storage pools at the head of the chain.
+func (api *API) GetSPools() ([]*SPoolSummary, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	var pools []*SPoolSummary
+	if snap.SpData == nil {
+		return pools, nil
+	}
+	for hash, sp := range snap.SpData.PoolPledge {
+		pools = append(pools, &SPoolSummary{
+			SpAddr:         common.BigToAddress(hash.Big()),
+			Hash:           hash,
+			Address:        sp.Address,
+			Manager:        sp.Manager,
+			RevenueAddress: sp.RevenueAddress,
+			Status:         sp.Status,
+			TotalCapacity:  new(big.Int).Set(sp.TotalCapacity),
+			UsedCapacity:   new(big.Int).Set(sp.UsedCapacity),
+			FreeCapacity:   new(big.Int).Sub(sp.TotalCapacity, sp.UsedCapacity),
+			TotalAmount:    new(big.Int).Set(sp.TotalAmount),
+			ManagerAmount:  new(big.Int).Set(sp.ManagerAmount),
+			Fee:            sp.Fee,
+			EntrustRate:    sp.EntrustRate,
+			Entrusts:       len(sp.EtDetail),
+		})
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return bytes.Compare(pools[i].SpAddr[:], pools[j].SpAddr[:]) < 0
+	})
+	return pools, nil
+}
+
+// GetSPEntrustPositions retrieves the amounts an address entrusted to the
+// storage pools at the head of the chain.
+func (api *API) GetSPEntrustPositions(address common.Address) ([]*SPEntrustPosition, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	return snap.spEntrustPositions(address), nil
+}
+
+// GetSPEntrustRewards retrieves the amounts an address entrusted to the storage
+// pools at the head of the chain, along with the rewards each of them accrued.
+func (api *API) GetSPEntrustRewards(address common.Address) ([]*SPEntrustPosition, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	trie, err := api.lockTrie(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	return snap.spEntrustRewards(api.alien.db, address, trie)
+}
+
+// GetSPEntrustExits retrieves the amounts taken out of the storage pools which
+// are not paid back in full yet, those of address alone if given.
+func (api *API) GetSPEntrustExits(address *common.Address) ([]*SPEntrustExit, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	var exits []*SPEntrustExit
+	if snap.FlowRevenue == nil || snap.FlowRevenue.SpEntrustExitLock == nil {
+		return exits, nil
+	}
+	rlsLockBalance, err := snap.FlowRevenue.SpEntrustExitLock.loadRlsLockBalanceV1(api.alien.db)
+	if err != nil {
+		return nil, err
+	}
+	for target, rls := range rlsLockBalance {
+		if address != nil && target != *address {
+			continue
+		}
+		for _, byType := range rls.LockBalanceV1 {
+			for spAddr, item := range byType[sscSpEntrustExitLockReward] {
+				remaining := new(big.Int).Sub(item.Amount, item.Playment)
+				if remaining.Sign() <= 0 {
+					continue
+				}
+				exits = append(exits, &SPEntrustExit{
+					SpAddr:     spAddr,
+					Address:    target,
+					Amount:     new(big.Int).Set(item.Amount),
+					Released:   new(big.Int).Set(item.Playment),
+					Remaining:  remaining,
+					LockNumber: item.StartHigh,
+					RlsPeriod:  item.RlsPeriod,
+					Interval:   item.Interval,
+				})
+			}
+		}
+	}
+	sort.Slice(exits, func(i, j int) bool {
+		if exits[i].LockNumber != exits[j].LockNumber {
+			return exits[i].LockNumber < exits[j].LockNumber
+		}
+		if exits[i].Address != exits[j].Address {
+			return bytes.Compare(exits[i].Address[:], exits[j].Address[:]) < 0
+		}
+		return bytes.Compare(exits[i].SpAddr[:], exits[j].SpAddr[:]) < 0
+	})
+	return exits, nil
+}
+
+// spEntrustRewards returns the amounts address entrusted to the storage pools
+// of the snapshot, along with the rewards each of them accrued. From
+// lockTrieNumber on the rewards are locked in trie, which keeps them by
+// entruster alone, so they make up one position of the zero pool.
+func (s *Snapshot) spEntrustRewards(db ethdb.Database, address common.Address, trie *LockTrie) ([]*SPEntrustPosition, error) {
+	positions := s.spEntrustPositions(address)
+	rewards := make(map[common.Address]*SPEntrustPosition)
+	reward := func(spAddr common.Address) *SPEntrustPosition {
+		if _, ok := rewards[spAddr]; !ok {
+			rewards[spAddr] = &SPEntrustPosition{Pending: new(big.Int), Locked: new(big.Int), Released: new(big.Int)}
+		}
+		return rewards[spAddr]
+	}
+	if trie != nil {
+		if account := trie.GetAccount(address, uint8(sscSpEntrustLockReward)); account != nil {
+			r := reward(common.Address{})
+			for _, record := range account.LockRecords {
+				locked := new(big.Int).Sub(record.TotalBalance, record.Released)
+				locked.Sub(locked, record.Pledgeed).Sub(locked, record.Destroyed)
+				r.Locked.Add(r.Locked, locked)
+				r.Released.Add(r.Released, record.Released)
+			}
+		}
+	}
+	if s.FlowRevenue == nil || s.FlowRevenue.SpEntrustLock == nil {
+		return s.spEntrustRewardPositions(address, positions, rewards), nil
+	}
+	lock := s.FlowRevenue.SpEntrustLock
+	if revenue, ok := lock.FlowRevenue[address]; ok {
+		for spAddr, item := range revenue.RewardBalanceV1[sscSpEntrustLockReward] {
+			reward(spAddr).Pending.Add(reward(spAddr).Pending, item.Amount)
+		}
+	}
+	rlsLockBalance, err := lock.loadRlsLockBalanceV1(db)
+	if err != nil {
+		return nil, err
+	}
+	if rls, ok := rlsLockBalance[address]; ok {
+		for _, byType := range rls.LockBalanceV1 {
+			for spAddr, item := range byType[sscSpEntrustLockReward] {
+				r := reward(spAddr)
+				r.Locked.Add(r.Locked, new(big.Int).Sub(item.Amount, item.Playment))
+				r.Released.Add(r.Released, item.Playment)
+			}
+		}
+	}
+	return s.spEntrustRewardPositions(address, positions, rewards), nil
+}
+
+// spEntrustRewardPositions splits the rewards of each pool between the
+// positions address holds in it, and appends those of the pools it left.
+func (s *Snapshot) spEntrustRewardPositions(address common.Address, positions []*SPEntrustPosition, rewards map[common.Address]*SPEntrustPosition) []*SPEntrustPosition {
+	held := make(map[common.Address]*big.Int)
+	for _, pos := range positions {
+		if _, ok := held[pos.SpAddr]; !ok {
+			held[pos.SpAddr] = new(big.Int)
+		}
+		held[pos.SpAddr].Add(held[pos.SpAddr], pos.Amount)
+	}
+	share := func(total *big.Int, pos *SPEntrustPosition) *big.Int {
+		if held[pos.SpAddr].Sign() == 0 {
+			return new(big.Int)
+		}
+		return new(big.Int).Div(new(big.Int).Mul(total, pos.Amount), held[pos.SpAddr])
+	}
+	for _, pos := range positions {
+		r, ok := rewards[pos.SpAddr]
+		if !ok {
+			pos.Pending, pos.Locked, pos.Released = new(big.Int), new(big.Int), new(big.Int)
+			continue
+		}
+		pos.Pending, pos.Locked, pos.Released = share(r.Pending, pos), share(r.Locked, pos), share(r.Released, pos)
+	}
+	spHashes := make(map[common.Address]common.Hash)
+	if s.SpData != nil {
+		for spHash := range s.SpData.PoolPledge {
+			spHashes[common.BigToAddress(spHash.Big())] = spHash
+		}
+	}
+	for spAddr, r := range rewards {
+		if _, ok := held[spAddr]; ok {
+			continue
+		}
+		r.SpAddr, r.SpHash, r.Address = spAddr, spHashes[spAddr], address
+		positions = append(positions, r)
+	}
+	sortSPEntrustPositions(positions)
+	return positions
+}
+
+// spEntrustPositions returns the amounts address entrusted to the storage pools
+// of the snapshot.
+func (s *Snapshot) spEntrustPositions(address common.Address) []*SPEntrustPosition {
+	var positions []*SPEntrustPosition
+	if s.SpData == nil {
+		return positions
+	}
+	for spHash, sp := range s.SpData.PoolPledge {
+		for pledgeHash, detail := range sp.EtDetail {
+			if detail.Address != address {
+				continue
+			}
+			positions = append(positions, &SPEntrustPosition{
+				SpAddr:     common.BigToAddress(spHash.Big()),
+				SpHash:     spHash,
+				PledgeHash: pledgeHash,
+				Address:    address,
+				Height:     new(big.Int).Set(detail.Height),
+				Amount:     new(big.Int).Set(detail.Amount),
+			})
+		}
+	}
+	sortSPEntrustPositions(positions)
+	return positions
+}
+
+func sortSPEntrustPositions(positions []*SPEntrustPosition) {
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].SpAddr != positions[j].SpAddr {
+			return bytes.Compare(positions[i].SpAddr[:], positions[j].SpAddr[:]) < 0
+		}
+		if positions[i].Height == nil || positions[j].Height == nil {
+			return positions[j].Height == nil && positions[i].Height != nil
+		}
+		if c := positions[i].Height.Cmp(positions[j].Height); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(positions[i].PledgeHash[:], positions[j].PledgeHash[:]) < 0
+	})
+}
+
+// GetLockSchedule retrieves the locks held by an address at the head of the
+// chain, by lock type, along with the projected release of each of them.
+func (api *API) GetLockSchedule(address common.Address) (*LockSchedule, error) {
+	head := api.chain.CurrentHeader()
+	snap, err := api.getSnapshotCache(head)
+	if err != nil {
+		return nil, err
+	}
+	number := head.Number.Uint64()
+	buckets, calendar, err := snap.lockBuckets(api.alien.db, address, number)
+	if err != nil {
+		return nil, err
+	}
+	trie, err := api.lockTrie(head)
+	if err != nil {
+		return nil, err
+	}
+	if trie != nil {
+		trieBuckets, trieCalendar := lockTrieBuckets(trie, address, number)
+		buckets = append(buckets, trieBuckets...)
+		calendar = append(calendar, trieCalendar...)
+	}
+	return newLockSchedule(address, number, buckets, calendar), nil
+}
+
+// lockTrie opens the lock trie on the roots sealed in the header, nil before
+// lockTrieNumber.
+func (api *API) lockTrie(head *types.Header) (*LockTrie, error) {
+	if !isGELockTrieNumber(head.Number.Uint64()) {
+		return nil, nil
+	}
+	headerExtra := HeaderExtra{}
+	if err := decodeHeaderExtra(api.alien.config, head.Number, head.Extra[extraVanity:len(head.Extra)-extraSeal], &headerExtra); err != nil {
+		return nil, err
+	}
+	return NewLockTrie(headerExtra.ExtraStateRoot, headerExtra.LockAccountsRoot, api.alien.db, api.alien.config.Period)
+}
+
+// GetReleaseHistory retrieves the payments out of the locks held by or paid to
+// an address in the canonical blocks from fromBlock to toBlock. The payments of
+// the LockProfitSnap are those journaled as the blocks were applied.
+func (api *API) GetReleaseHistory(address common.Address, fromBlock uint64, toBlock uint64) ([]*LockReleaseEvent, error) {
+	if head := api.chain.CurrentHeader().Number.Uint64(); toBlock > head {
+		toBlock = head
+	}
+	if fromBlock > toBlock || toBlock-fromBlock >= maxReleaseHistoryRange {
+		return nil, errInvalidBlockRange
+	}
+	history, err := readLockReleases(api.alien.db, address, fromBlock, toBlock, api.isCanonical)
+	if err != nil {
+		return nil, err
+	}
+	from := fromBlock
+	for _, fork := range []uint64{lockTrieNumber, initStorageManagerNumber, 1} {
+		if from < fork {
+			from = fork
+		}
+	}
+	for number := from; number <= toBlock; number++ {
+		header := api.chain.GetHeaderByNumber(number)
+		parent := api.chain.GetHeaderByNumber(number - 1)
+		if header == nil || parent == nil {
+			return nil, errUnknownBlock
+		}
+		parentExtra := HeaderExtra{}
+		if number > 1 {
+			if err := decodeHeaderExtra(api.alien.config, parent.Number, parent.Extra[extraVanity:len(parent.Extra)-extraSeal], &parentExtra); err != nil {
+				return nil, err
+			}
+		}
+		events, err := lockTrieReleases(api.alien.db, api.alien.config.Period, parentExtra.ExtraStateRoot, parentExtra.LockAccountsRoot, header)
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range events {
+			if ev.Target == address || ev.RevenueAddress == address {
+				history = append(history, ev)
+			}
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].BlockNumber < history[j].BlockNumber
+	})
+	return history, nil
+}
+
+// ProposalDeclare is a declare on a proposal, with the stake of its declarer.
+type ProposalDeclare struct {
+	Declarer common.Address `json:"declarer"`
+	Decision bool           `json:"decision"`
+	Stake    *big.Int       `json:"stake"` // Zero if the declarer has no tally
+}
+
+// ProposalInfo is a governance proposal waiting for declares or for its result,
+// with its declares tallied the way calculateProposalResult does.
+type ProposalInfo struct {
+	*Proposal
+	Declares       []*ProposalDeclare `json:"declares"`
+	ExpiryNumber   uint64             `json:"expiryNumber"` // Last block declares are accepted in
+	ResultNumber   uint64             `json:"resultNumber"` // Block the result is calculated in
+	YesCount       int                `json:"yesCount"`
+	NoCount        int                `json:"noCount"`
+	YesStake       *big.Int           `json:"yesStake"`
+	NoStake        *big.Int           `json:"noStake"`
+	JudgementStake *big.Int           `json:"judgementStake"` // Stake the yes declares need to exceed
+	Passing        bool               `json:"passing"`        // Whether the proposal passes on the current tally
+}
+
+// ListProposals lists the proposals at the head of the chain, oldest first.
+// Proposals are dropped from the snapshot once their result is calculated.
+func (api *API) ListProposals() ([]*ProposalInfo, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*ProposalInfo, 0, len(snap.Proposals))
+	for _, proposal := range snap.Proposals {
+		infos = append(infos, snap.proposalInfo(proposal))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if c := infos[i].ReceivedNumber.Cmp(infos[j].ReceivedNumber); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(infos[i].Hash[:], infos[j].Hash[:]) < 0
+	})
+	return infos, nil
+}
+
+// GetProposal retrieves a proposal at the head of the chain by the hash of the
+// transaction which made it.
+func (api *API) GetProposal(hash common.Hash) (*ProposalInfo, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	proposal, ok := snap.Proposals[hash]
+	if !ok {
+		return nil, errUnknownProposal
+	}
+	return snap.proposalInfo(proposal), nil
+}
+
+// proposalInfo tallies the declares on a proposal with the current stakes.
+func (s *Snapshot) proposalInfo(proposal *Proposal) *ProposalInfo {
+	info := &ProposalInfo{
+		Proposal:       proposal.copy(),
+		Declares:       make([]*ProposalDeclare, 0, len(proposal.Declares)),
+		ExpiryNumber:   s.proposalDeadline(proposal),
+		ResultNumber:   s.proposalDeadline(proposal) + 1,
+		YesStake:       s.proposalDeclareStake(proposal, true),
+		NoStake:        s.proposalDeclareStake(proposal, false),
+		JudgementStake: s.proposalJudgementStake(),
+	}
+	for _, declare := range proposal.Declares {
+		stake := new(big.Int)
+		if tally, ok := s.Tally[declare.Declarer]; ok {
+			stake.Set(tally)
+		}
+		info.Declares = append(info.Declares, &ProposalDeclare{Declarer: declare.Declarer, Decision: declare.Decision, Stake: stake})
+		if declare.Decision {
+			info.YesCount++
+		} else {
+			info.NoCount++
+		}
+	}
+	info.Passing = info.YesStake.Cmp(info.JudgementStake) > 0
+	return info
+}
+
+// ProposalArgs are the parameters of a proposal transaction. Zero parameters
+// are left out of the transaction, so the defaults of the engine apply.
+type ProposalArgs struct {
+	ProposalType           uint64         `json:"proposalType"`
+	ValidationLoopCnt      uint64         `json:"validationLoopCnt"`
+	Candidate              common.Address `json:"candidate"`
+	SCHash                 common.Hash    `json:"scHash"`
+	SCBlockCountPerPeriod  uint64         `json:"scBlockCountPerPeriod"`
+	SCBlockRewardPerPeriod uint64         `json:"scBlockRewardPerPeriod"`
+	MinerRewardPerThousand uint64         `json:"minerRewardPerThousand"`
+	MinVoterBalance        uint64         `json:"minVoterBalance"`
+	ProposalDeposit        uint64         `json:"proposalDeposit"`
+	SCRentTarget           common.Address `json:"scRentTarget"`
+	SCRentFee              uint64         `json:"scRentFee"`
+	SCRentRate             uint64         `json:"scRentRate"`
+	SCRentLength           uint64         `json:"scRentLength"`
+}
+
+// GovernanceTx is the data of a proposal or declare transaction, which the
+// proposer or declarer sends to itself with no value.
+type GovernanceTx struct {
+	Data    hexutil.Bytes `json:"data"`
+	Deposit *big.Int      `json:"deposit,omitempty"` // Taken from the balance of the proposer when the proposal is received
+}
+
+// BuildProposalTx encodes a proposal transaction, after checking its parameters
+// against the limits processEventProposal accepts it with.
+func (api *API) BuildProposalTx(args ProposalArgs) (*GovernanceTx, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	proposal := customtx.Proposal(args)
+	if err := snap.checkProposal(&proposal); err != nil {
+		return nil, err
+	}
+	deposit := new(big.Int).Set(proposalDeposit)
+	if proposal.ProposalType == proposalTypeRentSideChain {
+		deposit.Add(deposit, new(big.Int).Mul(new(big.Int).SetUint64(proposal.SCRentFee), big.NewInt(1e+18)))
+	}
+	return &GovernanceTx{Data: proposal.Encode(), Deposit: deposit}, nil
+}
+
+// BuildDeclareTx encodes a declare transaction on a proposal which still takes
+// declares. Only the declares of candidates are counted.
+func (api *API) BuildDeclareTx(proposalHash common.Hash, decision bool) (*GovernanceTx, error) {
+	header := api.chain.CurrentHeader()
+	snap, err := api.getSnapshotCache(header)
+	if err != nil {
+		return nil, err
+	}
+	proposal, ok := snap.Proposals[proposalHash]
+	if !ok {
+		return nil, errUnknownProposal
+	}
+	if snap.proposalDeadline(proposal) <= header.Number.Uint64() {
+		return nil, errProposalClosed
+	}
+	declare := customtx.Declare{ProposalHash: proposalHash, Decision: decision}
+	return &GovernanceTx{Data: declare.Encode()}, nil
+}
+
+// checkProposal checks a proposal the way processEventProposal does, and that
+// it carries the parameters its type acts on.
+func (s *Snapshot) checkProposal(p *customtx.Proposal) error {
+	const maxInt = uint64(^uint(0) >> 1)
+	for _, param := range []struct {
+		key   string
+		value uint64
+	}{
+		{"vlcnt", p.ValidationLoopCnt},
+		{"sccount", p.SCBlockCountPerPeriod},
+		{"screward", p.SCBlockRewardPerPeriod},
+		{"mrpt", p.MinerRewardPerThousand},
+		{"mvb", p.MinVoterBalance},
+		{"mpd", p.ProposalDeposit},
+		{"scrf", p.SCRentFee},
+		{"scrr", p.SCRentRate},
+		{"scrl", p.SCRentLength},
+	} {
+		if param.value != 0 && (param.value > maxInt || !proposalParamInRange(param.key, int(param.value))) {
+			return fmt.Errorf("%w: %s %d out of range", errInvalidProposal, param.key, param.value)
+		}
+	}
+	var missing string
+	switch p.ProposalType {
+	case proposalTypeCandidateAdd, proposalTypeCandidateRemove:
+		if p.Candidate == (common.Address{}) {
+			missing = "candidate"
+		}
+	case proposalTypeMinerRewardDistributionModify:
+		if p.MinerRewardPerThousand == 0 {
+			missing = "minerRewardPerThousand"
+		}
+	case proposalTypeSideChainAdd, proposalTypeSideChainRemove:
+		if p.SCHash == (common.Hash{}) {
+			missing = "scHash"
+		}
+	case proposalTypeMinVoterBalanceModify:
+		if p.MinVoterBalance == 0 {
+			missing = "minVoterBalance"
+		}
+	case proposalTypeProposalDepositModify:
+		if p.ProposalDeposit == 0 {
+			missing = "proposalDeposit"
+		}
+	case proposalTypeRentSideChain:
+		switch {
+		case p.SCRentTarget == (common.Address{}):
+			missing = "scRentTarget"
+		case p.SCRentFee == 0:
+			missing = "scRentFee"
+		case p.Candidate != (common.Address{}):
+			// Both are read into the target address of the proposal
+			return fmt.Errorf("%w: candidate set on side chain rent", errInvalidProposal)
+		case !s.isSideChainExist(p.SCHash):
+			return fmt.Errorf("%w: unknown side chain %s", errInvalidProposal, p.SCHash.Hex())
+		}
+	default:
+		return fmt.Errorf("%w: unknown proposal type %d", errInvalidProposal, p.ProposalType)
+	}
+	if missing != "" {
+		return fmt.Errorf("%w: proposal type %d requires %s", errInvalidProposal, p.ProposalType, missing)
+	}
+	return nil
+}
+
+// SignerSlot is the turn of a signer in a loop. The turns of a loop start over
+// if the loop is not sealed by the time its last turn is over.
+type SignerSlot struct {
+	Index  int            `json:"index"`
+	Time   uint64         `json:"time"` // Header time the turn starts at
+	Signer common.Address `json:"signer"`
+	Main   bool           `json:"main"` // Whether the signer was elected as main miner, otherwise as second miner
+}
+
+// LoopSigner is a signer of a loop.
+type LoopSigner struct {
+	Address common.Address `json:"address"`
+	Main    bool           `json:"main"`
+}
+
+// SignerLoop is the signer queue of a loop.
+type SignerLoop struct {
+	FirstBlock   uint64        `json:"firstBlock"`
+	LastBlock    uint64        `json:"lastBlock"`
+	StartTime    uint64        `json:"startTime"`
+	Recalculated bool          `json:"recalculated"` // Whether the signers were elected anew rather than reordered
+	Ordered      bool          `json:"ordered"`      // Whether the turns are known, they depend on the hashes of the loop before
+	Signers      []*LoopSigner `json:"signers"`
+	Slots        []*SignerSlot `json:"slots,omitempty"`
+}
+
+// SignerScore is the score a candidate runs in the next election with.
+type SignerScore struct {
+	Address common.Address `json:"address"`
+	Main    bool           `json:"main"` // Whether the candidate runs as main miner, otherwise as second miner
+	Score   *big.Int       `json:"score"`
+}
+
+// SignerSchedule is the signer queue of the current loop and the one predicted
+// for the next loop, from the snapshot at the given block.
+type SignerSchedule struct {
+	Number     uint64         `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	Period     uint64         `json:"period"`
+	Loops      []*SignerLoop  `json:"loops"`
+	Candidates []*SignerScore `json:"candidates"` // Ranked by score, main miners first
+}
+
+// GetSignerSchedule retrieves the turns of the signers in the current loop and,
+// if loops is 2, in the next loop as elected from the head of the chain. The
+// turns of the next loop are known once the head is the block before the last
+// block of the current loop, until then only its signers are.
+func (api *API) GetSignerSchedule(loops uint64) (*SignerSchedule, error) {
+	snap, err := api.getSnapshotCache(api.chain.CurrentHeader())
+	if err != nil {
+		return nil, err
+	}
+	return snap.signerSchedule(loops)
+}
+
+// signerSchedule lists the signers of the current loop and runs the election
+// of the next loop on the snapshot. Until the last block of the current loop
+// the hashes of the blocks left are not known, so the election is run with
+// empty hashes, which picks the signers but not their turns.
+func (s *Snapshot) signerSchedule(loops uint64) (*SignerSchedule, error) {
+	if loops == 0 {
+		loops = 1
+	} else if loops > maxSignerScheduleLoops {
+		loops = maxSignerScheduleLoops
+	}
+	maxSignerCount := s.config.MaxSignerCount
+	// The signer queue of the snapshot seals the blocks up to the next block
+	// which is a multiple of the signer count, that block sets the next queue.
+	lastBlock := s.Number + maxSignerCount - s.Number%maxSignerCount
+	current := make([]common.Address, len(s.Signers))
+	for i, signer := range s.Signers {
+		current[i] = *signer
+	}
+	schedule := &SignerSchedule{
+		Number: s.Number,
+		Hash:   s.Hash,
+		Period: s.config.Period,
+		Loops:  []*SignerLoop{s.signerLoop(current, lastBlock-maxSignerCount, s.LoopStartTime, true)},
+	}
+	elect := *s
+	elect.Number = lastBlock - 1
+	if elect.Number != s.Number {
+		elect.HistoryHash = make([]common.Hash, len(s.HistoryHash), len(s.HistoryHash)+int(elect.Number-s.Number))
+		copy(elect.HistoryHash, s.HistoryHash)
+		for number := s.Number; number < elect.Number; number++ {
+			elect.HistoryHash = append(elect.HistoryHash, common.Hash{})
+		}
+		elect.Hash = common.Hash{}
+	}
+	if loops > 1 {
+		next, err := elect.createSignerQueue()
+		if err != nil {
+			return nil, err
+		}
+		startTime := s.LoopStartTime + s.config.Period*maxSignerCount
+		schedule.Loops = append(schedule.Loops, elect.signerLoop(next, lastBlock, startTime, elect.Number == s.Number))
+	}
+	mainMiners, secondMiners := elect.electionScores()
+	for _, miners := range []TallySlice{mainMiners, secondMiners} {
+		for _, item := range miners {
+			schedule.Candidates = append(schedule.Candidates, &SignerScore{
+				Address: item.addr,
+				Main:    len(schedule.Candidates) < len(mainMiners),
+				Score:   new(big.Int).Set(item.stake),
+			})
+		}
+	}
+	return schedule, nil
+}
+
+// signerLoop lays out a signer queue on the turns of the loop following the
+// given block. Signers in the tally of the candidates were elected as main
+// miners.
+func (s *Snapshot) signerLoop(queue []common.Address, after uint64, startTime uint64, ordered bool) *SignerLoop {
+	maxSignerCount := s.config.MaxSignerCount
+	loop := &SignerLoop{
+		FirstBlock:   after + 1,
+		LastBlock:    after + maxSignerCount,
+		StartTime:    startTime,
+		Recalculated: after%(maxSignerCount*s.LCRS) == 0,
+		Ordered:      ordered,
+		Signers:      []*LoopSigner{},
+	}
+	listed := make(map[common.Address]bool)
+	for i, signer := range queue {
+		_, main := s.Tally[signer]
+		if !listed[signer] {
+			listed[signer] = true
+			loop.Signers = append(loop.Signers, &LoopSigner{Address: signer, Main: main})
+		}
+		if ordered {
+			loop.Slots = append(loop.Slots, &SignerSlot{
+				Index:  i,
+				Time:   startTime + uint64(i)*s.config.Period,
+				Signer: signer,
+				Main:   main,
+			})
+		}
+	}
+	return loop
+}
@@ -0,0 +1,442 @@
+package alien
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/customtx"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/state"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb/memorydb"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+	"github.com/UltronGlow/UltronGlow-Origin/rpc"
+	"github.com/UltronGlow/UltronGlow-Origin/trie"
+)
+
+// Tests that the rewards a storage pool distributes to an entruster are split
+// between the positions of the entruster by their amounts, through the lock
+// and release of the rewards.
+func TestSPEntrustRewards(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		spHash  = common.HexToHash("0xa1a2a3a4a5a6a7a8a9aaabacadaeafb0b1b2b3b4b5b6b7b8b9babbbcbdbebfc0")
+		spAddr  = common.BigToAddress(spHash.Big())
+		goneSp  = common.HexToAddress("0x0b")
+		manager = common.HexToAddress("0x01")
+		user    = common.HexToAddress("0x02")
+		other   = common.HexToAddress("0x03")
+		first   = common.HexToHash("0x11")
+		second  = common.HexToHash("0x12")
+	)
+	snap := &Snapshot{
+		SpData:      NewSPSnap(),
+		FlowRevenue: NewLockProfitSnap(),
+		SystemConfig: SystemParameter{LockParameters: map[uint32]*LockParameter{
+			sscEnumRwdLock: {RlsPeriod: 10, Interval: 1},
+		}},
+	}
+	snap.SpData.PoolPledge[spHash] = &PoolPledge{
+		Manager:     manager,
+		TotalAmount: big.NewInt(1000),
+		EntrustRate: 50,
+		EtDetail: map[common.Hash]*EntrustDetail{
+			first:                    {Address: user, Height: big.NewInt(1), Amount: big.NewInt(100)},
+			second:                   {Address: user, Height: big.NewInt(2), Amount: big.NewInt(300)},
+			common.HexToHash("0x13"): {Address: other, Height: big.NewInt(3), Amount: big.NewInt(600)},
+		},
+	}
+	lock := snap.FlowRevenue.SpEntrustLock
+	// Half of the reward goes to the entrusters, 400 of which to the user
+	lock.distributeSpReward(snap, map[common.Hash]*big.Int{spHash: big.NewInt(2000)}, big.NewInt(10))
+	lock.updateAllLockDataV1(snap, sscSpEntrustLockReward, big.NewInt(20))
+	lock.distributeSpReward(snap, map[common.Hash]*big.Int{spHash: big.NewInt(4000)}, big.NewInt(30))
+	lock.FlowRevenue[user].LockBalanceV1[20][sscSpEntrustLockReward][spAddr].Playment = big.NewInt(40)
+
+	// Rewards left by a pool the user exited
+	lock.addLockDataV1(LockRewardNewRecord{Target: user, Amount: big.NewInt(7), IsReward: sscSpEntrustLockReward, SourceAddress: goneSp}, big.NewInt(30))
+
+	positions, err := snap.spEntrustRewards(db, user, nil)
+	if err != nil {
+		t.Fatalf("failed to compute rewards: %v", err)
+	}
+	want := []struct {
+		spAddr                    common.Address
+		pledge                    common.Hash
+		pending, locked, released int64
+	}{
+		{goneSp, common.Hash{}, 7, 0, 0},
+		{spAddr, first, 200, 90, 10},
+		{spAddr, second, 600, 270, 30},
+	}
+	if len(positions) != len(want) {
+		t.Fatalf("have %d positions, want %d", len(positions), len(want))
+	}
+	for i, w := range want {
+		pos := positions[i]
+		if pos.SpAddr != w.spAddr || pos.PledgeHash != w.pledge || pos.Address != user {
+			t.Errorf("position %d: have pool %x pledge %x, want pool %x pledge %x", i, pos.SpAddr, pos.PledgeHash, w.spAddr, w.pledge)
+			continue
+		}
+		if pos.Pending.Int64() != w.pending || pos.Locked.Int64() != w.locked || pos.Released.Int64() != w.released {
+			t.Errorf("position %d: have pending %v locked %v released %v, want %d %d %d", i, pos.Pending, pos.Locked, pos.Released, w.pending, w.locked, w.released)
+		}
+	}
+	if positions := snap.spEntrustPositions(other); len(positions) != 1 || positions[0].Amount.Int64() != 600 || positions[0].SpHash != spHash {
+		t.Errorf("unexpected positions of other entruster: %v", positions)
+	}
+}
+
+// Tests that the rewards a storage pool distributes to an entruster are kept by
+// the pool they came from before the lock trie fork, and locked in the lock
+// trie by entruster alone after it.
+func TestSPEntrustRewardsLockTrie(t *testing.T) {
+	defer func(number uint64) { lockTrieNumber = number }(lockTrieNumber)
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		spHash = common.HexToHash("0xa1a2a3a4a5a6a7a8a9aaabacadaeafb0b1b2b3b4b5b6b7b8b9babbbcbdbebfc0")
+		spAddr = common.BigToAddress(spHash.Big())
+		miner  = common.HexToAddress("0x01")
+		user   = common.HexToAddress("0x02")
+		other  = common.HexToAddress("0x03")
+		pledge = common.HexToHash("0x11")
+		number = initStorageManagerNumber + 1
+		total  = new(big.Int).Mul(spSpacePgPrice, big.NewInt(10))
+	)
+	snap := &Snapshot{
+		config:      &params.AlienConfig{Period: 10},
+		FlowRevenue: NewLockProfitSnap(),
+		StorageData: &StorageData{StorageEntrust: map[common.Address]*SEntrust{miner: {Manager: miner, Sphash: spHash}}},
+		SpData:      NewSPSnap(),
+		SystemConfig: SystemParameter{LockParameters: map[uint32]*LockParameter{
+			sscEnumRwdLock: {RlsPeriod: 2 * blockperday, Interval: 1},
+		}},
+	}
+	snap.SpData.PoolPledge[spHash] = &PoolPledge{
+		Manager:       miner,
+		TotalAmount:   total,
+		TotalCapacity: getCapacity(total),
+		SnRatio:       big.NewInt(1),
+		Fee:           10,
+		EntrustRate:   50,
+		Status:        spStatusActive,
+		EtDetail: map[common.Hash]*EntrustDetail{
+			pledge:                   {Address: user, Height: big.NewInt(1), Amount: new(big.Int).Div(total, big.NewInt(5))},
+			common.HexToHash("0x12"): {Address: other, Height: big.NewInt(2), Amount: new(big.Int).Div(total, big.NewInt(2))},
+		},
+	}
+	// A fee of 1000 goes to the pool, half of it to its entrusters, 100 of
+	// which to the user
+	rewards := []LockRewardRecord{{Target: miner, Amount: big.NewInt(10000), IsReward: sscEnumFlwReward}}
+
+	lockTrieNumber = number + 1
+	snap.FlowRevenue.updateLockData(snap, rewards, new(big.Int).SetUint64(number))
+	positions, err := snap.spEntrustRewards(db, user, nil)
+	if err != nil {
+		t.Fatalf("failed to compute rewards before the fork: %v", err)
+	}
+	if len(positions) != 1 || positions[0].SpAddr != spAddr || positions[0].PledgeHash != pledge || positions[0].Pending.Int64() != 100 || positions[0].Locked.Sign() != 0 {
+		t.Fatalf("unexpected rewards before the fork: %+v", positions)
+	}
+
+	es, err := NewLockState(common.Hash{}, common.Hash{}, number+1, db, blockperoid)
+	if err != nil {
+		t.Fatalf("failed to open lock state: %v", err)
+	}
+	if _, err := es.AddLockReward(rewards, snap, db, number+1); err != nil {
+		t.Fatalf("failed to lock rewards: %v", err)
+	}
+	root, releaseRoot, err := es.CommitData()
+	if err != nil {
+		t.Fatalf("failed to commit lock state: %v", err)
+	}
+	trie, err := NewLockTrie(root, releaseRoot, db, blockperoid)
+	if err != nil {
+		t.Fatalf("failed to open lock trie: %v", err)
+	}
+	positions, err = snap.spEntrustRewards(db, user, trie)
+	if err != nil {
+		t.Fatalf("failed to compute rewards after the fork: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("have %d positions after the fork, want 2", len(positions))
+	}
+	if pos := positions[0]; pos.SpAddr != (common.Address{}) || pos.Pending.Sign() != 0 || pos.Locked.Int64() != 100 || pos.Released.Sign() != 0 {
+		t.Errorf("unexpected trie rewards: %+v", pos)
+	}
+	if pos := positions[1]; pos.SpAddr != spAddr || pos.Pending.Int64() != 100 || pos.Locked.Sign() != 0 {
+		t.Errorf("unexpected rewards of the pool: %+v", pos)
+	}
+}
+
+// Tests that the declares on a proposal are tallied with the stakes of their
+// declarers, against the stake calculateProposalResult judges them with.
+func TestProposalInfo(t *testing.T) {
+	var (
+		yes   = common.HexToAddress("0x01")
+		no    = common.HexToAddress("0x02")
+		other = common.HexToAddress("0x03")
+		hash  = common.HexToHash("0x11")
+	)
+	snap := &Snapshot{
+		config: &params.AlienConfig{MaxSignerCount: 3},
+		Tally:  map[common.Address]*big.Int{yes: big.NewInt(500), no: big.NewInt(200), other: big.NewInt(200)},
+	}
+	proposal := &Proposal{
+		Hash:              hash,
+		ReceivedNumber:    big.NewInt(10),
+		CurrentDeposit:    big.NewInt(1),
+		ValidationLoopCnt: 4,
+		Declares:          []*Declare{{hash, yes, true}, {hash, no, false}},
+	}
+	info := snap.proposalInfo(proposal)
+	if info.ExpiryNumber != 22 || info.ResultNumber != 23 {
+		t.Errorf("have expiry %d result %d, want 22 23", info.ExpiryNumber, info.ResultNumber)
+	}
+	if info.YesCount != 1 || info.NoCount != 1 || info.YesStake.Int64() != 500 || info.NoStake.Int64() != 200 {
+		t.Errorf("unexpected tally: %d yes (%v) %d no (%v)", info.YesCount, info.YesStake, info.NoCount, info.NoStake)
+	}
+	if info.JudgementStake.Int64() != 600 || info.Passing {
+		t.Errorf("have judgement stake %v passing %v, want 600 false", info.JudgementStake, info.Passing)
+	}
+	proposal.Declares = append(proposal.Declares, &Declare{hash, other, true})
+	if info := snap.proposalInfo(proposal); !info.Passing || len(info.Declares) != 3 || info.Declares[2].Stake.Int64() != 200 {
+		t.Errorf("proposal not passing with 700 of 900 stake")
+	}
+}
+
+// Tests that the proposals built by the API are accepted by the engine with the
+// requested parameters, and that those the engine drops are refused.
+func TestCheckProposal(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		proposer = common.HexToAddress("0x01")
+		target   = common.HexToAddress("0x02")
+		scHash   = common.HexToHash("0x21")
+	)
+	snap := &Snapshot{SCRecordMap: map[common.Hash]*SCRecord{scHash: {}}}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db), nil)
+	statedb.SetBalance(proposer, new(big.Int).Mul(big.NewInt(1e+18), big.NewInt(1e+6)))
+
+	valid := []customtx.Proposal{
+		{ProposalType: proposalTypeCandidateAdd, Candidate: target, ValidationLoopCnt: minValidationLoopCnt},
+		{ProposalType: proposalTypeMinerRewardDistributionModify, MinerRewardPerThousand: 1000},
+		{ProposalType: proposalTypeProposalDepositModify, ProposalDeposit: maxProposalDeposit},
+		{ProposalType: proposalTypeRentSideChain, SCHash: scHash, SCRentTarget: target, SCRentFee: minSCRentFee, SCRentLength: maxSCRentLength},
+	}
+	for i, p := range valid {
+		if err := snap.checkProposal(&p); err != nil {
+			t.Errorf("proposal %d: refused: %v", i, err)
+			continue
+		}
+		tx := types.NewTransaction(uint64(i), proposer, new(big.Int), 0, new(big.Int), p.Encode())
+		accepted := new(Alien).processEventProposal(nil, customtx.Split(tx.Data()), statedb, tx, nil, proposer, snap)
+		if len(accepted) != 1 {
+			t.Errorf("proposal %d: dropped by the engine", i)
+			continue
+		}
+		if have := accepted[0]; have.ProposalType != p.ProposalType || have.SCHash != p.SCHash ||
+			(p.Candidate != common.Address{} && have.TargetAddress != p.Candidate) || (p.SCRentTarget != common.Address{} && have.TargetAddress != p.SCRentTarget) {
+			t.Errorf("proposal %d: engine read %+v", i, have)
+		}
+	}
+	invalid := []customtx.Proposal{
+		{ProposalType: proposalTypeCandidateAdd, Candidate: target, ValidationLoopCnt: maxValidationLoopCnt + 1},
+		{ProposalType: proposalTypeMinerRewardDistributionModify, MinerRewardPerThousand: 1001},
+		{ProposalType: proposalTypeProposalDepositModify, ProposalDeposit: maxProposalDeposit + 1},
+		{ProposalType: proposalTypeRentSideChain, SCHash: scHash, SCRentTarget: target, SCRentFee: minSCRentFee - 1},
+		{ProposalType: proposalTypeRentSideChain, SCHash: scHash, SCRentTarget: target, SCRentFee: minSCRentFee, SCRentLength: minSCRentLength - 1},
+	}
+	for i, p := range invalid {
+		if err := snap.checkProposal(&p); !errors.Is(err, errInvalidProposal) {
+			t.Errorf("invalid proposal %d: have error %v, want %v", i, err, errInvalidProposal)
+		}
+		tx := types.NewTransaction(uint64(i), proposer, new(big.Int), 0, new(big.Int), p.Encode())
+		if accepted := new(Alien).processEventProposal(nil, customtx.Split(tx.Data()), statedb, tx, nil, proposer, snap); len(accepted) != 0 {
+			t.Errorf("invalid proposal %d: accepted by the engine", i)
+		}
+	}
+	for i, p := range []customtx.Proposal{
+		{ProposalType: proposalTypeCandidateAdd},
+		{ProposalType: proposalTypeRentSideChain, SCHash: common.HexToHash("0x22"), SCRentTarget: target, SCRentFee: minSCRentFee},
+		{ProposalType: proposalTypeRentSideChain + 1},
+	} {
+		if err := snap.checkProposal(&p); !errors.Is(err, errInvalidProposal) {
+			t.Errorf("incomplete proposal %d: have error %v, want %v", i, err, errInvalidProposal)
+		}
+	}
+}
+
+// Tests that the signer schedule lays out the current queue on its turns, and
+// elects the next queue the way the last block of the loop does once the hashes
+// of the loop are known.
+func TestSignerSchedule(t *testing.T) {
+	var (
+		a = common.HexToAddress("0x01")
+		b = common.HexToAddress("0x02")
+		c = common.HexToAddress("0x03")
+		d = common.HexToAddress("0x04")
+	)
+	snap := &Snapshot{
+		config:        &params.AlienConfig{Period: 10, MaxSignerCount: 3},
+		LCRS:          1,
+		Number:        4,
+		LoopStartTime: 1000,
+		Signers:       []*common.Address{&a, &b, &c},
+		Tally:         map[common.Address]*big.Int{a: big.NewInt(10), b: big.NewInt(30), c: big.NewInt(20), d: big.NewInt(40)},
+		Candidates:    map[common.Address]uint64{a: candidateStateNormal, b: candidateStateNormal, c: candidateStateNormal, d: candidateStateNormal},
+	}
+	for i := 1; i <= 5; i++ {
+		snap.HistoryHash = append(snap.HistoryHash, common.BigToHash(big.NewInt(int64(i*7919))))
+	}
+	snap.Hash = snap.HistoryHash[len(snap.HistoryHash)-1]
+
+	schedule, err := snap.signerSchedule(2)
+	if err != nil {
+		t.Fatalf("failed to predict schedule: %v", err)
+	}
+	if len(schedule.Loops) != 2 {
+		t.Fatalf("have %d loops, want 2", len(schedule.Loops))
+	}
+	current, next := schedule.Loops[0], schedule.Loops[1]
+	if current.FirstBlock != 4 || current.LastBlock != 6 || !current.Ordered || len(current.Slots) != 3 {
+		t.Fatalf("unexpected current loop: %+v", current)
+	}
+	for i, slot := range current.Slots {
+		if slot.Signer != *snap.Signers[i] || slot.Time != 1000+uint64(i)*10 || !slot.Main {
+			t.Errorf("slot %d: unexpected %+v", i, slot)
+		}
+	}
+	if next.FirstBlock != 7 || next.StartTime != 1030 || !next.Recalculated || next.Ordered || len(next.Slots) != 0 {
+		t.Fatalf("unexpected next loop: %+v", next)
+	}
+	elected := make(map[common.Address]bool)
+	for _, signer := range next.Signers {
+		elected[signer.Address] = true
+	}
+	if len(elected) != 3 || !elected[b] || !elected[c] || !elected[d] {
+		t.Errorf("unexpected next signers: %v", elected)
+	}
+	want := []common.Address{d, b, c, a}
+	if len(schedule.Candidates) != len(want) {
+		t.Fatalf("have %d candidates, want %d", len(schedule.Candidates), len(want))
+	}
+	for i, candidate := range schedule.Candidates {
+		if candidate.Address != want[i] || !candidate.Main || candidate.Score.Sign() <= 0 {
+			t.Errorf("candidate %d: have %+v, want %x", i, candidate, want[i])
+		}
+	}
+
+	// On the block before the last of the loop the turns are known
+	snap.Number = 5
+	snap.HistoryHash = append(snap.HistoryHash, common.HexToHash("0x1234"))
+	snap.Hash = snap.HistoryHash[len(snap.HistoryHash)-1]
+	schedule, err = snap.signerSchedule(2)
+	if err != nil {
+		t.Fatalf("failed to predict schedule: %v", err)
+	}
+	queue, err := snap.createSignerQueue()
+	if err != nil {
+		t.Fatalf("failed to create signer queue: %v", err)
+	}
+	next = schedule.Loops[1]
+	if !next.Ordered || len(next.Slots) != len(queue) {
+		t.Fatalf("unexpected next loop: %+v", next)
+	}
+	for i, slot := range next.Slots {
+		if slot.Signer != queue[i] || slot.Time != 1030+uint64(i)*10 {
+			t.Errorf("next slot %d: have %+v, want %x", i, slot, queue[i])
+		}
+	}
+	if schedule, _ := snap.signerSchedule(0); len(schedule.Loops) != 1 {
+		t.Errorf("have %d loops, want 1", len(schedule.Loops))
+	}
+}
+
+// Tests that the signers of a snapshot are mapped to their side chain coinbases
+// the same way whatever the map order, a signer with two coinbases included.
+func TestSCSigners(t *testing.T) {
+	var (
+		scHash = common.HexToHash("0x5c")
+		a      = common.HexToAddress("0x0a")
+		b      = common.HexToAddress("0x0b")
+		c      = common.HexToAddress("0x0c")
+	)
+	snap := &Snapshot{
+		Signers: []*common.Address{&a, &b, &c},
+		SCCoinbase: map[common.Hash]map[common.Address]common.Address{
+			scHash: {
+				common.HexToAddress("0xa2"): a,
+				common.HexToAddress("0xa1"): a,
+				common.HexToAddress("0xa3"): a,
+				common.HexToAddress("0xb1"): b,
+			},
+		},
+	}
+	want := []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xb1"), c}
+	for i := 0; i < 32; i++ {
+		signers := snap.scSigners(scHash)
+		if len(signers) != len(want) {
+			t.Fatalf("have %d signers, want %d", len(signers), len(want))
+		}
+		for j, signer := range signers {
+			if *signer != want[j] {
+				t.Fatalf("run %d: signer %d mismatch: have %x, want %x", i, j, *signer, want[j])
+			}
+		}
+	}
+	if signers := snap.scSigners(common.HexToHash("0x5d")); *signers[0] != a {
+		t.Errorf("unknown side chain signer mismatch: have %x, want %x", *signers[0], a)
+	}
+}
+
+// Tests that the SRT proofs are served against the SRT root the header of the
+// block commits to, which holds the exchanges of the blocks before it.
+func TestGetSRTProof(t *testing.T) {
+	chain, key := newTxValidatorTestChain(t)
+	api := &API{chain: chain.chain, alien: chain.engine}
+	target := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+
+	chain.send(key, target, big.NewInt(0), fmt.Sprintf("UTG:1:Exch:%s:0x2710", target.Hex()))
+	exchange := chain.mine()
+	chain.clock.advance(exchange.Time())
+	chain.clock.advance(chain.mine().Time())
+
+	// The 0x2710 exchanged are credited at the exchange rate per 10000
+	for i, want := range []int64{0, int64(chain.snapshot().SystemConfig.ExchRate)} {
+		number := rpc.BlockNumber(exchange.NumberU64() + uint64(i))
+		proof, err := api.GetSRTProof(target, rpc.BlockNumberOrHashWithNumber(number))
+		if err != nil {
+			t.Fatalf("block %d: failed to prove: %v", number, err)
+		}
+		header := chain.chain.GetHeaderByNumber(uint64(number))
+		extra, _, err := DecodeHeaderExtra(header.Extra)
+		if err != nil {
+			t.Fatalf("block %d: failed to decode header extra: %v", number, err)
+		}
+		if proof.SRTHash != extra.SRTDataRoot || proof.BlockHash != header.Hash() {
+			t.Errorf("block %d: proven against %x of %x, want %x of %x", number, proof.SRTHash, proof.BlockHash, extra.SRTDataRoot, header.Hash())
+		}
+		if extra.SRTDataRoot == types.EmptyRootHash {
+			if len(proof.Proof) != 0 {
+				t.Errorf("block %d: have %d proof nodes for the empty trie", number, len(proof.Proof))
+			}
+		} else {
+			nodes := memorydb.New()
+			for _, node := range proof.Proof {
+				nodes.Put(crypto.Keccak256(node), node)
+			}
+			if _, err := trie.VerifyProof(extra.SRTDataRoot, crypto.Keccak256(target.Bytes()), nodes); err != nil {
+				t.Errorf("block %d: proof does not verify against the header: %v", number, err)
+			}
+		}
+		if proof.Balance.ToInt().Int64() != want {
+			t.Errorf("block %d: have balance %v, want %d", number, proof.Balance, want)
+		}
+	}
+}
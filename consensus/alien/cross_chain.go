@@ -20,18 +20,10 @@ package alien
 import (
 	"context"
 	"errors"
-	"math/big"
-	"time"
 
 	"github.com/UltronGlow/UltronGlow-Origin/common"
-	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
 	"github.com/UltronGlow/UltronGlow-Origin/consensus"
 	"github.com/UltronGlow/UltronGlow-Origin/core/types"
-	"github.com/UltronGlow/UltronGlow-Origin/rlp"
-)
-
-const (
-	mainchainRPCTimeout = 300 // Number of millisecond mainchain rpc connect timeout
 )
 
 var (
@@ -48,90 +40,73 @@ var (
 	errMCGasChargingInvalid = errors.New("gas charging info is invalid")
 )
 
-// getMainChainSnapshotByTime return snapshot by header time of side chain
-// the rpc api will return the snapshot with the same header time (not loopStartTime)
-func (a *Alien) getMainChainSnapshotByTime(chain consensus.ChainHeaderReader, headerTime uint64, scHash common.Hash) (*Snapshot, error) {
+// SetMainChainClient sets the client the side chain talks to the main chain
+// through, in place of the rpc client of the chain config.
+func (a *Alien) SetMainChainClient(client *MainChainClient) {
+	a.mainChainLock.Lock()
+	defer a.mainChainLock.Unlock()
+
+	a.mainChain = client
+}
+
+// mainChainClient returns the client of the main chain, wrapping the rpc client
+// of the chain config if none was set.
+func (a *Alien) mainChainClient(chain consensus.ChainHeaderReader) (*MainChainClient, error) {
 	if !chain.Config().Alien.SideChain {
 		return nil, errNotSideChain
 	}
-	if chain.Config().Alien.MCRPCClient == nil {
-		return nil, errMCRPCClientEmpty
+	a.mainChainLock.Lock()
+	defer a.mainChainLock.Unlock()
+
+	if a.mainChain == nil {
+		if chain.Config().Alien.MCRPCClient == nil {
+			return nil, errMCRPCClientEmpty
+		}
+		client, err := NewMainChainClient([]MainChainBackend{chain.Config().Alien.MCRPCClient}, DefaultMainChainConfig)
+		if err != nil {
+			return nil, err
+		}
+		a.mainChain = client
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), mainchainRPCTimeout*time.Millisecond)
-	defer cancel()
+	return a.mainChain, nil
+}
 
-	var ms *Snapshot
-	if err := chain.Config().Alien.MCRPCClient.CallContext(ctx, &ms, "alien_getSnapshotByHeaderTime", headerTime, scHash); err != nil {
+// getMainChainSnapshotByTime return snapshot by header time of side chain
+// the rpc api will return the snapshot with the same header time (not loopStartTime)
+func (a *Alien) getMainChainSnapshotByTime(chain consensus.ChainHeaderReader, headerTime uint64, scHash common.Hash) (*Snapshot, error) {
+	client, err := a.mainChainClient(chain)
+	if err != nil {
 		return nil, err
-	} else if ms.Period == 0 {
-		return nil, errMCPeriodMissing
 	}
-	return ms, nil
+	return client.SnapshotByTime(context.Background(), headerTime, scHash)
 }
 
 // sendTransactionToMainChain
 // transaction send to main chain by rpc api, usually is the transaction for notify or confirm seal new block.
 func (a *Alien) sendTransactionToMainChain(chain consensus.ChainHeaderReader, tx *types.Transaction) (common.Hash, error) {
-	if !chain.Config().Alien.SideChain {
-		return common.Hash{}, errNotSideChain
-	}
-	if chain.Config().Alien.MCRPCClient == nil {
-		return common.Hash{}, errMCRPCClientEmpty
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), mainchainRPCTimeout*time.Millisecond)
-	defer cancel()
-
-	data, err := rlp.EncodeToBytes(tx)
+	client, err := a.mainChainClient(chain)
 	if err != nil {
 		return common.Hash{}, err
 	}
-	var hash common.Hash
-	if err := chain.Config().Alien.MCRPCClient.CallContext(ctx, &hash, "eth_sendRawTransaction", common.ToHex(data)); err != nil {
-		return common.Hash{}, err
-	}
-	return hash, nil
+	return client.SendTransaction(context.Background(), tx)
 }
 
 // getTransactionCountFromMainChain
 // get nonce from main chain for sendTransactionToMainChain
 func (a *Alien) getTransactionCountFromMainChain(chain consensus.ChainHeaderReader, account common.Address) (uint64, error) {
-	if !chain.Config().Alien.SideChain {
-		return 0, errNotSideChain
-	}
-	if chain.Config().Alien.MCRPCClient == nil {
-		return 0, errMCRPCClientEmpty
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), mainchainRPCTimeout*time.Millisecond)
-	defer cancel()
-
-	var result hexutil.Uint64
-	if err := chain.Config().Alien.MCRPCClient.CallContext(ctx, &result, "eth_getTransactionCount", account.Hex(), "latest"); err != nil {
+	client, err := a.mainChainClient(chain)
+	if err != nil {
 		return 0, err
 	}
-	return uint64(result), nil
+	return client.TransactionCount(context.Background(), account)
 }
 
 // getNetVersionFromMainChain
 // get network id
 func (a *Alien) getNetVersionFromMainChain(chain consensus.ChainHeaderReader) (uint64, error) {
-	if !chain.Config().Alien.SideChain {
-		return 0, errNotSideChain
-	}
-	if chain.Config().Alien.MCRPCClient == nil {
-		return 0, errMCRPCClientEmpty
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), mainchainRPCTimeout*time.Millisecond)
-	defer cancel()
-
-	var result string
-	if err := chain.Config().Alien.MCRPCClient.CallContext(ctx, &result, "net_version", "latest"); err != nil {
-		return 0, err
-	}
-
-	netVersion := new(big.Int)
-	err := netVersion.UnmarshalText([]byte(result))
+	client, err := a.mainChainClient(chain)
 	if err != nil {
 		return 0, err
 	}
-	return netVersion.Uint64(), nil
+	return client.NetVersion(context.Background())
 }
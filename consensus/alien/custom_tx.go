@@ -478,6 +478,89 @@ type HeaderExtra struct {
 	SpBind                 [] SpBindRecord
 	SpDataRoot        common.Hash
 	SPEPool                []common.Address
+
+	DoubleSignPunish []DoubleSignPunishRecord `rlp:"optional"`
+}
+type HeaderExtraV8 struct {
+	CurrentBlockConfirmations []Confirmation
+	CurrentBlockVotes         []Vote
+	CurrentBlockProposals     []Proposal
+	CurrentBlockDeclares      []Declare
+	ModifyPredecessorVotes    []Vote
+	LoopStartTime             uint64
+	SignerQueue               []common.Address
+	SignerMissing             []common.Address
+	ConfirmedBlockNumber      uint64
+	SideChainConfirmations    []SCConfirmation
+	SideChainSetCoinbases     []SCSetCoinbase
+	SideChainNoticeConfirmed  []SCConfirmation
+	SideChainCharging         []GasCharging //This only exist in side chain's header.Extra
+
+	ExchangeNFC      []ExchangeNFCRecord
+	DeviceBind       []DeviceBindRecord
+	CandidatePledge  []CandidatePledgeRecord
+	CandidatePunish  []CandidatePunishRecord
+	MinerStake       []MinerStakeRecord
+	CandidateExit    []common.Address
+	ClaimedBandwidth []ClaimedBandwidthRecord
+	FlowMinerExit    []common.Address
+	BandwidthPunish  []BandwidthPunishRecord
+	ConfigExchRate   uint32
+	ConfigOffLine    uint32
+	ConfigDeposit    []ConfigDepositRecord
+	ConfigISPQOS     []ISPQOSRecord
+	LockParameters   []LockParameterRecord
+	ManagerAddress   []ManagerAddressRecord
+	FlowHarvest      *big.Int
+	LockReward       []LockRewardRecord
+	GrantProfit      []consensus.GrantProfitRecord
+	FlowReport       []MinerFlowReportRecord
+
+	StoragePledge       []SPledgeRecord
+	StoragePledgeExit   []SPledgeExitRecord
+	LeaseRequest        []LeaseRequestRecord
+	ExchangeSRT         []ExchangeSRTRecord
+	LeasePledge         []LeasePledgeRecord
+	LeaseRenewal        []LeaseRenewalRecord
+	LeaseRenewalPledge  []LeaseRenewalPledgeRecord
+	LeaseRescind        []LeaseRescindRecord
+	StorageRecoveryData []SPledgeRecoveryRecord
+	StorageProofRecord  []StorageProofRecord
+
+	StorageExchangePrice   []StorageExchangePriceRecord
+	ExtraStateRoot         common.Hash
+	LockAccountsRoot       common.Hash
+	StorageDataRoot        common.Hash
+	StorageExchangeBw      []StorageExchangeBwRecord
+	SRTDataRoot            common.Hash
+	StorageBwPay           []StorageBwPayRecord
+	GrantProfitHash        common.Hash
+	CandidatePledgeNew     []CandidatePledgeNewRecord
+	CandidatePledgeEntrust []CandidatePledgeEntrustRecord
+	CandidatePEntrustExit  []CandidatePEntrustExitRecord
+	CandidateAutoExit      []common.Address
+	CandidateChangeRate    []CandidateChangeRateRecord
+	CurLeaseSpace          *big.Int
+	SpCreateParamter       []SpApplyRecord
+	ModifySManager         []ModifySManagerRecord
+	SpAdjustPgParamter     []SpAdjustPledgeRecord
+	SpRemoveSnParamter     []SpRemoveSnRecord
+	SpEttPledgeParamter    []SpEntrustPledgeRecord
+	SpExitParameter        []common.Hash
+	SpFeeParameter         []SpFeeRecord
+	SpEntrustParameter     []SpEntrustRateRecord
+	CompleteSPledge        []CompleteSPledgeRecord
+	SPRewardRatio          []SPRewardRatioRecord
+	SPPool                 []SPPoolRecord
+	SPMigration            []SPMigrationRecord
+	StoragePledge2         []SPledge2Record
+	SPEntrust              []SPEntrustRecord
+	SETransfer             []SETransferRecord
+	SEExit                 []SEExitRecord
+	POSTransfer            []POSTransferRecord
+	SpBind                 [] SpBindRecord
+	SpDataRoot        common.Hash
+	SPEPool                []common.Address
 }
 type HeaderExtraV7 struct {
 	CurrentBlockConfirmations []Confirmation
@@ -944,7 +1027,9 @@ func (s *SCConfirmation) copy() *SCConfirmation {
 
 // Encode HeaderExtra
 func encodeHeaderExtra(config *params.AlienConfig, number *big.Int, val HeaderExtra) ([]byte, error) {
-
+	if number.Uint64() >= headerExtraVersionNumber {
+		return encodeVersionedHeaderExtra(number.Uint64(), val)
+	}
 	var headerExtra interface{}
 	switch {
 	//case config.IsTrantor(number):
@@ -1375,6 +1460,16 @@ func encodeHeaderExtra(config *params.AlienConfig, number *big.Int, val HeaderEx
 
 // Decode HeaderExtra
 func decodeHeaderExtra(config *params.AlienConfig, number *big.Int, b []byte, val *HeaderExtra) error {
+	if number.Uint64() >= headerExtraVersionNumber {
+		if !isTaggedHeaderExtra(b) {
+			return errHeaderExtraVersionTag
+		}
+		_, err := decodeVersionedHeaderExtra(b, val)
+		return err
+	}
+	if isTaggedHeaderExtra(b) {
+		return errHeaderExtraVersionTag
+	}
 	var err error
 	switch {
 	//case config.IsTrantor(number):
@@ -1432,13 +1527,18 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 							if len(txDataInfo) > ufoMinSplitLen {
 								// check is vote or not
 								if txDataInfo[posEventVote] == ufoEventVote && (!candidateNeedPD || snap.isCandidate(*tx.To())) && state.GetBalance(txSender).Cmp(snap.MinVB) > 0 {
-									headerExtra.CurrentBlockVotes = a.processEventVote(headerExtra.CurrentBlockVotes, state, tx, txSender)
+									headerExtra.CurrentBlockVotes = a.processEventVote(headerExtra.CurrentBlockVotes, state, tx, receipts, txSender)
 								} else if txDataInfo[posEventConfirm] == ufoEventConfirm && snap.isCandidate(txSender) {
-									headerExtra.CurrentBlockConfirmations, refundHash = a.processEventConfirm(headerExtra.CurrentBlockConfirmations, chain, txDataInfo, number, tx, txSender, refundHash)
+									headerExtra.CurrentBlockConfirmations, refundHash = a.processEventConfirm(headerExtra.CurrentBlockConfirmations, chain, txDataInfo, number, tx, receipts, txSender, refundHash)
 								} else if txDataInfo[posEventProposal] == ufoEventPorposal {
-									headerExtra.CurrentBlockProposals = a.processEventProposal(headerExtra.CurrentBlockProposals, txDataInfo, state, tx, txSender, snap)
+									headerExtra.CurrentBlockProposals = a.processEventProposal(headerExtra.CurrentBlockProposals, txDataInfo, state, tx, receipts, txSender, snap)
 								} else if txDataInfo[posEventDeclare] == ufoEventDeclare && snap.isCandidate(txSender) {
-									headerExtra.CurrentBlockDeclares = a.processEventDeclare(headerExtra.CurrentBlockDeclares, txDataInfo, tx, txSender)
+									headerExtra.CurrentBlockDeclares = a.processEventDeclare(headerExtra.CurrentBlockDeclares, txDataInfo, tx, receipts, txSender)
+								} else if txDataInfo[posEventVote] == ufoEventVote {
+									// the target is not a candidate or the voter balance is below the minimum
+									a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
+								} else if txDataInfo[posEventConfirm] == ufoEventConfirm || txDataInfo[posEventDeclare] == ufoEventDeclare {
+									a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								}
 							} else {
 								// todo : something wrong, leave this transaction to process as normal transaction
@@ -1452,16 +1552,18 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 										number := new(big.Int)
 										if err := number.UnmarshalText([]byte(txDataInfo[ufoMinSplitLen+2])); err != nil {
 											log.Trace("Side chain confirm info fail", "number", txDataInfo[ufoMinSplitLen+2])
+											a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 											continue
 										}
 										if err := new(big.Int).UnmarshalText([]byte(txDataInfo[ufoMinSplitLen+3])); err != nil {
 											log.Trace("Side chain confirm info fail", "time", txDataInfo[ufoMinSplitLen+3])
+											a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 											continue
 										}
 										loopInfo := txDataInfo[ufoMinSplitLen+4]
 										scHash := common.HexToHash(txDataInfo[ufoMinSplitLen+1])
 										headerExtra.SideChainConfirmations, refundHash = a.processSCEventConfirm(headerExtra.SideChainConfirmations,
-											scHash, number.Uint64(), loopInfo, tx, txSender, refundHash)
+											scHash, number.Uint64(), loopInfo, tx, receipts, txSender, refundHash)
 
 										chargingInfo := txDataInfo[ufoMinSplitLen+5]
 										headerExtra.SideChainNoticeConfirmed = a.processSCEventNoticeConfirm(headerExtra.SideChainNoticeConfirmed,
@@ -1473,24 +1575,28 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 										// the signer of main chain must send some value to coinbase of side chain for confirm tx of side chain
 										if tx.Value().Cmp(minSCSetCoinbaseValue) >= 0 {
 											headerExtra.SideChainSetCoinbases = a.processSCEventSetCoinbase(headerExtra.SideChainSetCoinbases,
-												common.HexToHash(txDataInfo[ufoMinSplitLen+1]), txSender, *tx.To(), true)
+												common.HexToHash(txDataInfo[ufoMinSplitLen+1]), tx, receipts, txSender, *tx.To(), true)
+										} else {
+											a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 										}
 									}
 								} else if txDataInfo[posEventSetCoinbase] == ufoEventDelCoinbase && snap.isCandidate(txSender) {
 									if len(txDataInfo) > ufoMinSplitLen+1 {
 										headerExtra.SideChainSetCoinbases = a.processSCEventSetCoinbase(headerExtra.SideChainSetCoinbases,
-											common.HexToHash(txDataInfo[ufoMinSplitLen+1]), txSender, *tx.To(), false)
+											common.HexToHash(txDataInfo[ufoMinSplitLen+1]), tx, receipts, txSender, *tx.To(), false)
 									}
 								} else if ufoEventFlowReport1 == txDataInfo[posEventFlowReport] {
 									ok := false
-									headerExtra.FlowReport, ok = a.processFlowReport1(headerExtra.FlowReport, txDataInfo, txSender, snap)
+									headerExtra.FlowReport, ok = a.processFlowReport1(headerExtra.FlowReport, txDataInfo, txSender, tx, receipts, snap)
 									if ok {
 										refundHash[tx.Hash()] = RefundPair{txSender, tx.GasPrice()}
 									}
 								} else if ufoEventFlowReport2 == txDataInfo[posEventFlowReport] {
 									if txSender.String() == snap.SystemConfig.ManagerAddress[sscEnumFlowReport].String() {
-										headerExtra.FlowReport = a.processFlowReport2(headerExtra.FlowReport, txDataInfo)
+										headerExtra.FlowReport = a.processFlowReport2(headerExtra.FlowReport, txDataInfo, tx, receipts)
 										refundHash[tx.Hash()] = RefundPair{txSender, tx.GasPrice()}
+									} else {
+										a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 									}
 								}
 							}
@@ -1501,6 +1607,8 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 						if txDataInfo[posCategory] == nfcCategoryExch {
 							if number < StorageEffectBlockNumber {
 								headerExtra.ExchangeNFC = a.processExchangeNFC(headerExtra.ExchangeNFC, txDataInfo, txSender, tx, receipts, state, snap)
+							} else {
+								a.addCustomTxResult(tx, receipts, CustomTxReasonDisabled)
 							}
 						} else if txDataInfo[posCategory] == nfcCategoryMultiSign {
 							a.processCreateMultiSignature(txDataInfo, txSender, tx, receipts, state)
@@ -1527,6 +1635,8 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 						} else if txDataInfo[posCategory] == nfcCategoryFlwReq {
 							if number < PledgeRevertLockEffectNumber {
 								headerExtra.ClaimedBandwidth = a.processMinerPledge(headerExtra.ClaimedBandwidth, txDataInfo, txSender, tx, receipts, state, snapCache)
+							} else {
+								a.addCustomTxResult(tx, receipts, CustomTxReasonDisabled)
 							}
 						} else if txDataInfo[posCategory] == nfcCategoryFlwExit {
 							headerExtra.FlowMinerExit = a.processMinerExit(headerExtra.FlowMinerExit, txDataInfo, txSender, tx, receipts, state, snapCache)
@@ -1558,23 +1668,23 @@ func (a *Alien) processCustomTx(headerExtra HeaderExtra, chain consensus.ChainHe
 				} else if txDataInfo[posPrefix] == sscPrefix {
 					if txDataInfo[posVersion] == ufoVersion {
 						if txDataInfo[posCategory] == sscCategoryExchRate {
-							headerExtra.ConfigExchRate = a.processExchRate(txDataInfo, txSender, snapCache)
+							headerExtra.ConfigExchRate = a.processExchRate(txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryDeposit {
-							headerExtra.ConfigDeposit = a.processCandidateDeposit(headerExtra.ConfigDeposit, txDataInfo, txSender, snapCache)
+							headerExtra.ConfigDeposit = a.processCandidateDeposit(headerExtra.ConfigDeposit, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryCndLock {
-							headerExtra.LockParameters = a.processCndLockConfig(headerExtra.LockParameters, txDataInfo, txSender, snapCache)
+							headerExtra.LockParameters = a.processCndLockConfig(headerExtra.LockParameters, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryFlwLock {
-							headerExtra.LockParameters = a.processFlwLockConfig(headerExtra.LockParameters, txDataInfo, txSender, snapCache)
+							headerExtra.LockParameters = a.processFlwLockConfig(headerExtra.LockParameters, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryRwdLock {
-							headerExtra.LockParameters = a.processRwdLockConfig(headerExtra.LockParameters, txDataInfo, txSender, snapCache)
+							headerExtra.LockParameters = a.processRwdLockConfig(headerExtra.LockParameters, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryOffLine {
-							headerExtra.ConfigOffLine = a.processOffLine(txDataInfo, txSender, snapCache)
+							headerExtra.ConfigOffLine = a.processOffLine(txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryQOS {
-							headerExtra.ConfigISPQOS = a.processISPQos(headerExtra.ConfigISPQOS, txDataInfo, txSender, snapCache)
+							headerExtra.ConfigISPQOS = a.processISPQos(headerExtra.ConfigISPQOS, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryWdthPnsh {
 							headerExtra.BandwidthPunish = a.processBandwidthPunish(headerExtra.BandwidthPunish, txDataInfo, txSender, tx, receipts, snapCache)
 						} else if txDataInfo[posCategory] == sscCategoryManager {
-							headerExtra.ManagerAddress = a.processManagerAddress(headerExtra.ManagerAddress, txDataInfo, txSender, snapCache)
+							headerExtra.ManagerAddress = a.processManagerAddress(headerExtra.ManagerAddress, txDataInfo, txSender, tx, receipts, snapCache)
 						}
 					}
 				}
@@ -1617,7 +1727,7 @@ func (a *Alien) processSCEventNoticeConfirm(scEventNoticeConfirm []SCConfirmatio
 	return scEventNoticeConfirm
 }
 
-func (a *Alien) processSCEventConfirm(scEventConfirmaions []SCConfirmation, hash common.Hash, number uint64, loopInfo string, tx *types.Transaction, txSender common.Address, refundHash RefundHash) ([]SCConfirmation, RefundHash) {
+func (a *Alien) processSCEventConfirm(scEventConfirmaions []SCConfirmation, hash common.Hash, number uint64, loopInfo string, tx *types.Transaction, receipts []*types.Receipt, txSender common.Address, refundHash RefundHash) ([]SCConfirmation, RefundHash) {
 	scEventConfirmaions = append(scEventConfirmaions, SCConfirmation{
 		Hash:     hash,
 		Coinbase: txSender,
@@ -1625,25 +1735,28 @@ func (a *Alien) processSCEventConfirm(scEventConfirmaions []SCConfirmation, hash
 		LoopInfo: strings.Split(loopInfo, "#"),
 	})
 	refundHash[tx.Hash()] = RefundPair{txSender, tx.GasPrice()}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return scEventConfirmaions, refundHash
 }
 
-func (a *Alien) processSCEventSetCoinbase(scEventSetCoinbases []SCSetCoinbase, hash common.Hash, signer common.Address, coinbase common.Address, optype bool) []SCSetCoinbase {
+func (a *Alien) processSCEventSetCoinbase(scEventSetCoinbases []SCSetCoinbase, hash common.Hash, tx *types.Transaction, receipts []*types.Receipt, signer common.Address, coinbase common.Address, optype bool) []SCSetCoinbase {
 	scEventSetCoinbases = append(scEventSetCoinbases, SCSetCoinbase{
 		Hash:     hash,
 		Signer:   signer,
 		Coinbase: coinbase,
 		Type:     optype,
 	})
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return scEventSetCoinbases
 }
 
-func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInfo []string, state *state.StateDB, tx *types.Transaction, proposer common.Address, snap *Snapshot) []Proposal {
+func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInfo []string, state *state.StateDB, tx *types.Transaction, receipts []*types.Receipt, proposer common.Address, snap *Snapshot) []Proposal {
 	// sample for add side chain proposal
 	// eth.sendTransaction({from:eth.accounts[0],to:eth.accounts[0],value:0,data:web3.toHex("ufo:1:event:proposal:proposal_type:4:sccount:2:screward:50:schash:0x3210000000000000000000000000000000000000000000000000000000000000:vlcnt:4")})
 	// sample for declare
 	// eth.sendTransaction({from:eth.accounts[0],to:eth.accounts[0],value:0,data:web3.toHex("ufo:1:event:declare:hash:0x853e10706e6b9d39c5f4719018aa2417e8b852dec8ad18f9c592d526db64c725:decision:yes")})
 	if len(txDataInfo) <= posEventProposal+2 {
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentBlockProposals
 	}
 
@@ -1672,7 +1785,8 @@ func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInf
 		switch k {
 		case "vlcnt":
 			// If vlcnt is missing then user default value, but if the vlcnt is beyond the min/max value then ignore this proposal
-			if validationLoopCnt, err := strconv.Atoi(v); err != nil || validationLoopCnt < minValidationLoopCnt || validationLoopCnt > maxValidationLoopCnt {
+			if validationLoopCnt, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, validationLoopCnt) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.ValidationLoopCnt = uint64(validationLoopCnt)
@@ -1681,18 +1795,21 @@ func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInf
 			proposal.SCHash.UnmarshalText([]byte(v))
 		case "sccount":
 			if scBlockCountPerPeriod, err := strconv.Atoi(v); err != nil {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.SCBlockCountPerPeriod = uint64(scBlockCountPerPeriod)
 			}
 		case "screward":
 			if scBlockRewardPerPeriod, err := strconv.Atoi(v); err != nil {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.SCBlockRewardPerPeriod = uint64(scBlockRewardPerPeriod)
 			}
 		case "proposal_type":
 			if proposalType, err := strconv.Atoi(v); err != nil {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.ProposalType = uint64(proposalType)
@@ -1702,21 +1819,24 @@ func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInf
 			proposal.TargetAddress.UnmarshalText([]byte(v))
 		case "mrpt":
 			// miner reward per thousand
-			if mrpt, err := strconv.Atoi(v); err != nil || mrpt <= 0 || mrpt > 1000 {
+			if mrpt, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, mrpt) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.MinerRewardPerThousand = uint64(mrpt)
 			}
 		case "mvb":
 			// minVoterBalance
-			if mvb, err := strconv.Atoi(v); err != nil || mvb <= 0 {
+			if mvb, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, mvb) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.MinVoterBalance = uint64(mvb)
 			}
 		case "mpd":
 			// proposalDeposit
-			if mpd, err := strconv.Atoi(v); err != nil || mpd <= 0 || mpd > maxProposalDeposit {
+			if mpd, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, mpd) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.ProposalDeposit = uint64(mpd)
@@ -1726,21 +1846,24 @@ func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInf
 			proposal.TargetAddress.UnmarshalText([]byte(v))
 		case "scrf":
 			// side chain rent fee
-			if scrf, err := strconv.Atoi(v); err != nil || scrf < minSCRentFee {
+			if scrf, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, scrf) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.SCRentFee = uint64(scrf)
 			}
 		case "scrr":
 			// side chain rent rate
-			if scrr, err := strconv.Atoi(v); err != nil || scrr <= 0 {
+			if scrr, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, scrr) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.SCRentRate = uint64(scrr)
 			}
 		case "scrl":
 			// side chain rent length
-			if scrl, err := strconv.Atoi(v); err != nil || scrl < minSCRentLength || scrl > maxSCRentLength {
+			if scrl, err := strconv.Atoi(v); err != nil || !proposalParamInRange(k, scrl) {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockProposals
 			} else {
 				proposal.SCRentLength = uint64(scrl)
@@ -1752,25 +1875,50 @@ func (a *Alien) processEventProposal(currentBlockProposals []Proposal, txDataInf
 	if proposal.ProposalType == proposalTypeRentSideChain {
 		// check if the proposal target side chain exist
 		if !snap.isSideChainExist(proposal.SCHash) {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentBlockProposals
 		}
 		if (proposal.TargetAddress == common.Address{}) {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentBlockProposals
 		}
 		currentProposalPay.Add(currentProposalPay, new(big.Int).Mul(new(big.Int).SetUint64(proposal.SCRentFee), big.NewInt(1e+18)))
 	}
 	// check enough balance for deposit
 	if state.GetBalance(proposer).Cmp(currentProposalPay) < 0 {
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentBlockProposals
 	}
 	// collection the fee for this proposal (deposit and other fee , sc rent fee ...)
 	state.SetBalance(proposer, new(big.Int).Sub(state.GetBalance(proposer), currentProposalPay))
-
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return append(currentBlockProposals, proposal)
 }
 
-func (a *Alien) processEventDeclare(currentBlockDeclares []Declare, txDataInfo []string, tx *types.Transaction, declarer common.Address) []Declare {
+// proposalParamInRange reports whether the value of a numeric proposal key is
+// within the limits a proposal is accepted with. Keys without limits pass.
+func proposalParamInRange(key string, value int) bool {
+	switch key {
+	case "vlcnt":
+		return value >= minValidationLoopCnt && value <= maxValidationLoopCnt
+	case "mrpt":
+		// miner reward per thousand
+		return value > 0 && value <= 1000
+	case "mvb", "scrr":
+		return value > 0
+	case "mpd":
+		return value > 0 && value <= maxProposalDeposit
+	case "scrf":
+		return value >= minSCRentFee
+	case "scrl":
+		return value >= minSCRentLength && value <= maxSCRentLength
+	}
+	return true
+}
+
+func (a *Alien) processEventDeclare(currentBlockDeclares []Declare, txDataInfo []string, tx *types.Transaction, receipts []*types.Receipt, declarer common.Address) []Declare {
 	if len(txDataInfo) <= posEventDeclare+2 {
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentBlockDeclares
 	}
 	declare := Declare{
@@ -1789,15 +1937,16 @@ func (a *Alien) processEventDeclare(currentBlockDeclares []Declare, txDataInfo [
 			} else if v == "no" {
 				declare.Decision = false
 			} else {
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 				return currentBlockDeclares
 			}
 		}
 	}
-
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return append(currentBlockDeclares, declare)
 }
 
-func (a *Alien) processEventVote(currentBlockVotes []Vote, state *state.StateDB, tx *types.Transaction, voter common.Address) []Vote {
+func (a *Alien) processEventVote(currentBlockVotes []Vote, state *state.StateDB, tx *types.Transaction, receipts []*types.Receipt, voter common.Address) []Vote {
 
 	a.lock.RLock()
 	stake := state.GetBalance(voter)
@@ -1808,30 +1957,34 @@ func (a *Alien) processEventVote(currentBlockVotes []Vote, state *state.StateDB,
 		Candidate: *tx.To(),
 		Stake:     stake,
 	})
-
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentBlockVotes
 }
 
-func (a *Alien) processEventConfirm(currentBlockConfirmations []Confirmation, chain consensus.ChainHeaderReader, txDataInfo []string, number uint64, tx *types.Transaction, confirmer common.Address, refundHash RefundHash) ([]Confirmation, RefundHash) {
+func (a *Alien) processEventConfirm(currentBlockConfirmations []Confirmation, chain consensus.ChainHeaderReader, txDataInfo []string, number uint64, tx *types.Transaction, receipts []*types.Receipt, confirmer common.Address, refundHash RefundHash) ([]Confirmation, RefundHash) {
 	if len(txDataInfo) > posEventConfirmNumber {
 		confirmedBlockNumber := new(big.Int)
 		err := confirmedBlockNumber.UnmarshalText([]byte(txDataInfo[posEventConfirmNumber]))
 		if err != nil || number-confirmedBlockNumber.Uint64() > a.config.MaxSignerCount || number-confirmedBlockNumber.Uint64() < 0 {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentBlockConfirmations, refundHash
 		}
 		// check if the voter is in block
 		confirmedHeader := chain.GetHeaderByNumber(confirmedBlockNumber.Uint64())
 		if confirmedHeader == nil {
 			//log.Info("Fail to get confirmedHeader")
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentBlockConfirmations, refundHash
 		}
 		confirmedHeaderExtra := HeaderExtra{}
 		if extraVanity+extraSeal > len(confirmedHeader.Extra) {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentBlockConfirmations, refundHash
 		}
 		err = decodeHeaderExtra(a.config, confirmedBlockNumber, confirmedHeader.Extra[extraVanity:len(confirmedHeader.Extra)-extraSeal], &confirmedHeaderExtra)
 		if err != nil {
 			log.Info("Fail to decode parent header", "err", err)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentBlockConfirmations, refundHash
 		}
 		for _, s := range confirmedHeaderExtra.SignerQueue {
@@ -1841,9 +1994,14 @@ func (a *Alien) processEventConfirm(currentBlockConfirmations []Confirmation, ch
 					BlockNumber: new(big.Int).Set(confirmedBlockNumber),
 				})
 				refundHash[tx.Hash()] = RefundPair{confirmer, tx.GasPrice()}
-				break
+				a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
+				return currentBlockConfirmations, refundHash
 			}
 		}
+		// the confirmer did not sign in the loop of the confirmed block
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
+	} else {
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 	}
 
 	return currentBlockConfirmations, refundHash
@@ -1937,6 +2095,7 @@ func (a *Alien) verifyMultiSignatureAddress(state *state.StateDB, address common
 func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB) {
 	if len(txDataInfo) <= nfcPosThreshold+2 {
 		log.Warn("Create Multi-Signature fail", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return
 	}
 	parameter := consensus.MultiSignatureData{
@@ -1946,16 +2105,19 @@ func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common
 	if threshold, err := strconv.ParseUint(txDataInfo[nfcPosThreshold], 10, 32); err == nil {
 		if 2 > threshold || 10 < threshold {
 			log.Warn("Create Multi-Signature", "threshold", txDataInfo[nfcPosThreshold])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return
 		} else {
 			if len(txDataInfo) < nfcPosThreshold+2+int(threshold) || len(txDataInfo) > nfcPosThreshold+1000 {
 				log.Warn("Create Multi-Signature fail", "parameter number", len(txDataInfo))
+				a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 				return
 			}
 		}
 		parameter.Threshold = uint32(threshold)
 	} else {
 		log.Warn("Create Multi-Signature", "threshold", txDataInfo[nfcPosThreshold])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return
 	}
 	signers := make(map[common.Address]bool)
@@ -1964,6 +2126,7 @@ func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common
 		var address common.Address
 		if err := address.UnmarshalText1([]byte(txDataInfo[i])); err != nil {
 			log.Warn("Create Multi-Signature", "address", txDataInfo[i])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return
 		}
 		i++
@@ -1974,15 +2137,18 @@ func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common
 	}
 	if len(parameter.MultiSigners) <= int(parameter.Threshold) {
 		log.Warn("Create Multi-Signature fail", "Owner number", len(parameter.MultiSigners), "threshold", parameter.Threshold)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return
 	}
 	data, err := rlp.EncodeToBytes(parameter)
 	if nil != err {
 		log.Warn("Create Multi-Signature fail", "err", err)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return
 	}
 	if len(data) > params.MaxCodeSize {
 		log.Warn("Create Multi-Signature fail for max code size exceeded")
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return
 	}
 	snapshot := state.Snapshot()
@@ -1992,6 +2158,7 @@ func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common
 	if state.GetNonce(contractAddr) != 0 || (contractHash != (common.Hash{}) && contractHash != crypto.Keccak256Hash(nil)) {
 		state.RevertToSnapshot(snapshot)
 		log.Warn("Create Multi-Signature fail", "err", err)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return
 	}
 	state.CreateAccount(contractAddr)
@@ -2002,11 +2169,13 @@ func (a *Alien) processCreateMultiSignature(txDataInfo []string, txSender common
 	topics[1].SetBytes(txSender.Bytes())
 	topics[2].SetBytes(big.NewInt(int64(tx.Nonce())).Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, contractAddr.Hash().Bytes())
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 }
 
 func (a *Alien) processExchangeNFC(currentExchangeNFC []ExchangeNFCRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot) []ExchangeNFCRecord {
 	if len(txDataInfo) <= nfcPosExchValue {
 		log.Warn("Exchange NFC to FUL fail", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentExchangeNFC
 	}
 	exchangeNFC := ExchangeNFCRecord{
@@ -2015,16 +2184,19 @@ func (a *Alien) processExchangeNFC(currentExchangeNFC []ExchangeNFCRecord, txDat
 	}
 	if err := exchangeNFC.Target.UnmarshalText1([]byte(txDataInfo[nfcPosExchAddress])); err != nil {
 		log.Warn("Exchange NFC to FUL fail", "address", txDataInfo[nfcPosExchAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentExchangeNFC
 	}
 	amount := big.NewInt(0)
 	var err error
 	if amount, err = hexutil.UnmarshalText1([]byte(txDataInfo[nfcPosExchValue])); err != nil {
 		log.Warn("Exchange NFC to FUL fail", "number", txDataInfo[nfcPosExchValue])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentExchangeNFC
 	}
 	if state.GetBalance(txSender).Cmp(amount) < 0 {
 		log.Warn("Exchange NFC to FUL fail", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentExchangeNFC
 	}
 	exchangeNFC.Amount = new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(int64(snap.SystemConfig.ExchRate))), big.NewInt(10000))
@@ -2041,12 +2213,14 @@ func (a *Alien) processExchangeNFC(currentExchangeNFC []ExchangeNFCRecord, txDat
 	data = append(data, dataList[1].Bytes()...)
 	a.addCustomerTxLog(tx, receipts, topics, data)
 	currentExchangeNFC = append(currentExchangeNFC, exchangeNFC)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentExchangeNFC
 }
 
 func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot, number uint64) []DeviceBindRecord {
 	if len(txDataInfo) <= nfcPosMiltiSign {
 		log.Warn("Device bind revenue", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentDeviceBind
 	}
 	deviceBind := DeviceBindRecord{
@@ -2059,16 +2233,19 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 	}
 	if err := deviceBind.Device.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Device bind revenue", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeviceBind
 	}
 	if revenueType, err := strconv.ParseUint(txDataInfo[nfcPosRevenueType], 10, 32); err == nil {
 		if revenueType == 0 {
 			if _, ok := snap.RevenueNormal[deviceBind.Device]; ok {
 				log.Warn("Device bind revenue", "device already bond", txDataInfo[nfcPosMinerAddress])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentDeviceBind
 			}
 			if isGEPOSNewEffect(number) {
 				if !a.isPosManager(snap, deviceBind, txSender, txDataInfo) {
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return currentDeviceBind
 				}
 			}
@@ -2076,16 +2253,19 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 			if number >= StorageEffectBlockNumber {
 				if _, ok := snap.RevenueStorage[deviceBind.Device]; ok {
 					log.Warn("Device bind revenue", "device already bond", txDataInfo[nfcPosMinerAddress])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentDeviceBind
 				}
 				if isGEInitStorageManagerNumber(number) {
 					if !a.isStorageManager(snap, deviceBind, txSender, txDataInfo) {
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				}
 			} else {
 				if _, ok := snap.RevenueFlow[deviceBind.Device]; ok {
 					log.Warn("Device bind revenue", "device already bond", txDataInfo[nfcPosMinerAddress])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentDeviceBind
 				}
 			}
@@ -2093,18 +2273,21 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 		deviceBind.Type = uint32(revenueType)
 	} else {
 		log.Warn("Device bind revenue", "type", txDataInfo[nfcPosRevenueType])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentDeviceBind
 	}
 	if number < PledgeRevertLockEffectNumber {
 		if 0 < len(txDataInfo[nfcPosRevenueContract]) {
 			if err := deviceBind.Contract.UnmarshalText1([]byte(txDataInfo[nfcPosRevenueContract])); err != nil {
 				log.Warn("Device bind revenue", "contract address", txDataInfo[nfcPosRevenueContract])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 				return currentDeviceBind
 			}
 		}
 		if 0 < len(txDataInfo[nfcPosMiltiSign]) {
 			if err := deviceBind.MultiSign.UnmarshalText1([]byte(txDataInfo[nfcPosMiltiSign])); err != nil {
 				log.Warn("Device bind revenue", "milti-signature address", txDataInfo[nfcPosRevenueContract])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 				return currentDeviceBind
 			}
 		}
@@ -2114,6 +2297,7 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 			if 0 < len(txDataInfo[nfcPosRevenueAddress]) {
 				if err := deviceBind.Revenue.UnmarshalText1([]byte(txDataInfo[nfcPosRevenueAddress])); err != nil {
 					log.Warn("Device bind revenue", "Revenue address", txDataInfo[nfcPosRevenueAddress])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 					return currentDeviceBind
 				}
 			}
@@ -2121,10 +2305,12 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 	}
 	if err := a.checkRevenueNormalBind(deviceBind, snap); err != nil {
 		log.Warn("Device bind revenue", "checkRevenueNormalBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentDeviceBind
 	}
 	if err := a.checkBindMaxStorageSpace(currentDeviceBind, deviceBind, snap, number); err != nil {
 		log.Warn("Device bind revenue", "checkRevenueStorageBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentDeviceBind
 	}
 	topics := make([]common.Hash, 3)
@@ -2163,12 +2349,14 @@ func (a *Alien) processDeviceBind(currentDeviceBind []DeviceBindRecord, txDataIn
 		}
 
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentDeviceBind
 }
 
 func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []DeviceBindRecord {
 	if len(txDataInfo) <= nfcPosRevenueType {
 		log.Warn("Device unbind revenue", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentDeviceBind
 	}
 	nilHash := common.Address{}
@@ -2183,28 +2371,33 @@ func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txData
 	}
 	if err := deviceBind.Device.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Device unbind revenue", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeviceBind
 	}
 	if revenueType, err := strconv.ParseUint(txDataInfo[nfcPosRevenueType], 10, 32); err == nil {
 		if revenueType == 0 {
 			if oldBind, ok := snap.RevenueNormal[deviceBind.Device]; !ok {
 				log.Warn("Device unbind revenue", "device never bond", txDataInfo[nfcPosMinerAddress])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 				return currentDeviceBind
 			} else {
 				if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 					if isGEPOSNewEffect(number) {
 						if !a.isPosManager(snap, deviceBind, txSender, txDataInfo) {
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					} else {
 						if oldBind.RevenueAddress != txSender {
 							log.Warn("Device unbind revenue", "revenue address", oldBind.RevenueAddress)
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					}
 				} else {
 					if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 						log.Warn("Device unbind revenue failed to verify multi-signature")
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				}
@@ -2213,22 +2406,26 @@ func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txData
 			if number >= StorageEffectBlockNumber {
 				if oldBind, ok := snap.RevenueStorage[deviceBind.Device]; !ok {
 					log.Warn("Device unbind revenue", "device never bond", txDataInfo[nfcPosMinerAddress])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 					return currentDeviceBind
 				} else {
 					if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 						if isGEInitStorageManagerNumber(number) {
 							if !a.isStorageManager(snap, deviceBind, txSender, txDataInfo) {
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						} else {
 							if oldBind.RevenueAddress != txSender {
 								log.Warn("Device unbind revenue", "revenue address", oldBind.RevenueAddress)
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						}
 					} else {
 						if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 							log.Warn("Device unbind revenue failed to verify multi-signature")
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					}
@@ -2236,16 +2433,19 @@ func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txData
 			} else {
 				if oldBind, ok := snap.RevenueFlow[deviceBind.Device]; !ok {
 					log.Warn("Device unbind revenue", "device never bond", txDataInfo[nfcPosMinerAddress])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 					return currentDeviceBind
 				} else {
 					if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 						if oldBind.RevenueAddress != txSender {
 							log.Warn("Device unbind revenue", "revenue address", oldBind.RevenueAddress)
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					} else {
 						if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 							log.Warn("Device unbind revenue failed to verify multi-signature")
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					}
@@ -2256,6 +2456,7 @@ func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txData
 		deviceBind.Type = uint32(revenueType)
 	} else {
 		log.Warn("Device unbind revenue", "type", txDataInfo[nfcPosRevenueType])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentDeviceBind
 	}
 	topics := make([]common.Hash, 3)
@@ -2274,12 +2475,14 @@ func (a *Alien) processDeviceUnbind(currentDeviceBind []DeviceBindRecord, txData
 			delete(snap.RevenueFlow, deviceBind.Device)
 		}
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentDeviceBind
 }
 
 func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []DeviceBindRecord {
 	if len(txDataInfo) <= nfcPosRevenueAddress {
 		log.Warn("Device rebind revenue", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentDeviceBind
 	}
 	nilHash := common.Address{}
@@ -2294,10 +2497,12 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 	}
 	if err := deviceBind.Device.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Device rebind revenue", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeviceBind
 	}
 	if err := deviceBind.Revenue.UnmarshalText1([]byte(txDataInfo[nfcPosRevenueAddress])); err != nil {
 		log.Warn("Device rebind revenue", "revenue address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeviceBind
 	}
 	if revenueType, err := strconv.ParseUint(txDataInfo[nfcPosRevenueType], 10, 32); err == nil {
@@ -2305,10 +2510,12 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 			if isGEPOSNewEffect(number) {
 				if _, ok := snap.RevenueNormal[deviceBind.Device]; ok {
 					if !a.isPosManager(snap, deviceBind, txSender, txDataInfo) {
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				} else {
 					log.Warn("Device rebind revenue", "device cnnnot bind", deviceBind.Revenue)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return currentDeviceBind
 				}
 			} else {
@@ -2316,16 +2523,19 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 					if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 						if oldBind.RevenueAddress != txSender {
 							log.Warn("Device rebind revenue", "revenue address", oldBind.RevenueAddress)
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					} else {
 						if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 							log.Warn("Device rebind revenue failed to verify multi-signature")
+							a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 							return currentDeviceBind
 						}
 					}
 				} else if deviceBind.Revenue != txSender {
 					log.Warn("Device rebind revenue", "device cnnnot bind", deviceBind.Revenue)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return currentDeviceBind
 				}
 			}
@@ -2333,10 +2543,12 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 			if isGEInitStorageManagerNumber(number) {
 				if _, ok := snap.RevenueStorage[deviceBind.Device]; ok {
 					if !a.isStorageManager(snap, deviceBind, txSender, txDataInfo) {
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				} else {
 					log.Warn("Device rebind revenue", "device cnnnot bind", deviceBind.Revenue)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return currentDeviceBind
 				}
 			} else {
@@ -2345,16 +2557,19 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 						if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 							if oldBind.RevenueAddress != txSender {
 								log.Warn("Device rebind revenue", "revenue address", oldBind.RevenueAddress)
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						} else {
 							if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 								log.Warn("Device rebind revenue failed to verify multi-signature")
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						}
 					} else if deviceBind.Revenue != txSender {
 						log.Warn("Device rebind revenue", "device cnnnot bind", deviceBind.Revenue)
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				} else {
@@ -2362,16 +2577,19 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 						if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 							if oldBind.RevenueAddress != txSender {
 								log.Warn("Device rebind revenue", "revenue address", oldBind.RevenueAddress)
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						} else {
 							if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 								log.Warn("Device rebind revenue failed to verify multi-signature")
+								a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 								return currentDeviceBind
 							}
 						}
 					} else if deviceBind.Revenue != txSender {
 						log.Warn("Device rebind revenue", "device cnnnot bind", deviceBind.Revenue)
+						a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 						return currentDeviceBind
 					}
 				}
@@ -2380,18 +2598,21 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 		deviceBind.Type = uint32(revenueType)
 	} else {
 		log.Warn("Device rebind revenue", "type", txDataInfo[nfcPosRevenueType])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentDeviceBind
 	}
 	if number < PledgeRevertLockEffectNumber {
 		if 0 < len(txDataInfo[nfcPosRevenueContract]) {
 			if err := deviceBind.Contract.UnmarshalText1([]byte(txDataInfo[nfcPosRevenueContract])); err != nil {
 				log.Warn("Device rebind revenue", "contract address", txDataInfo[nfcPosRevenueContract])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 				return currentDeviceBind
 			}
 		}
 		if 0 < len(txDataInfo[nfcPosMiltiSign]) {
 			if err := deviceBind.MultiSign.UnmarshalText1([]byte(txDataInfo[nfcPosMiltiSign])); err != nil {
 				log.Warn("Device rebind revenue", "milti-signature address", txDataInfo[nfcPosRevenueContract])
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 				return currentDeviceBind
 			}
 		}
@@ -2399,10 +2620,12 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 
 	if err := a.checkRevenueNormalBind(deviceBind, snap); err != nil {
 		log.Warn("Device rebind revenue", "checkRevenueNormalBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentDeviceBind
 	}
 	if err := a.checkBindMaxStorageSpace(currentDeviceBind, deviceBind, snap, number); err != nil {
 		log.Warn("Device rebind revenue", "checkRevenueStorageBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentDeviceBind
 	}
 	topics := make([]common.Hash, 3)
@@ -2440,12 +2663,14 @@ func (a *Alien) processDeviceRebind(currentDeviceBind []DeviceBindRecord, txData
 			}
 		}
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentDeviceBind
 }
 
 func (a *Alien) processCandidatePledge(currentCandidatePledge []CandidatePledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot) []CandidatePledgeRecord {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Candidate pledge", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePledge
 	}
 	candidatePledge := CandidatePledgeRecord{
@@ -2457,15 +2682,18 @@ func (a *Alien) processCandidatePledge(currentCandidatePledge []CandidatePledgeR
 	}
 	if err := candidatePledge.Target.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Candidate pledge", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePledge
 	}
 	if state.GetBalance(txSender).Cmp(candidatePledge.Amount) < 0 {
 		log.Warn("Candidate pledge", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentCandidatePledge
 	}
 	if pledgeItem, ok := snap.CandidatePledge[candidatePledge.Target]; ok {
 		if pledgeItem.StartHigh > 0 {
 			log.Warn("Candidate pledge", "candidate already exit", pledgeItem.StartHigh)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentCandidatePledge
 		}
 		pledgeItem.Amount = new(big.Int).Add(pledgeItem.Amount, candidatePledge.Amount)
@@ -2483,12 +2711,14 @@ func (a *Alien) processCandidatePledge(currentCandidatePledge []CandidatePledgeR
 	data.SetBytes(candidatePledge.Amount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, data.Bytes())
 	currentCandidatePledge = append(currentCandidatePledge, candidatePledge)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePledge
 }
 
 func (a *Alien) processCandidatePledgeNew(currentCandidatePledge []CandidatePledgeNewRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []CandidatePledgeNewRecord {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Candidate pledgeNew", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePledge
 	}
 	candidatePledge := CandidatePledgeNewRecord{
@@ -2502,15 +2732,18 @@ func (a *Alien) processCandidatePledgeNew(currentCandidatePledge []CandidatePled
 	}
 	if err := candidatePledge.Target.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Candidate pledgeNew", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePledge
 	}
 	if candidatePledge.Target == txSender {
 		log.Warn("Candidate pledgeNew", "miner address is txSender", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 
 	if _, ok := snap.PosPledge[candidatePledge.Target]; ok {
 		log.Warn("Candidate pledgeNew", "candidate already exist", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 
@@ -2518,26 +2751,31 @@ func (a *Alien) processCandidatePledgeNew(currentCandidatePledge []CandidatePled
 	nilAddr := common.Address{}
 	if targetMiner != nilAddr {
 		log.Warn("Candidate pledgeNew", "one address can only pledge one miner ", targetMiner)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 	entrustMiner := snap.findPosTargetMiner(candidatePledge.Target)
 	if entrustMiner != nilAddr {
 		log.Warn("Candidate pledgeNew", "miner has pledge one miner ", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 
 	if snap.isPosMinerManager(candidatePledge.Manager) {
 		log.Warn("Candidate pledgeNew", "manager is pos manager", candidatePledge.Manager)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 
 	if snap.isPosMinerManager(candidatePledge.Target) {
 		log.Warn("Candidate pledgeNew", "miner is pos manager", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 
 	if state.GetBalance(txSender).Cmp(candidatePledge.Amount) < 0 {
 		log.Warn("Candidate pledgeNew", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentCandidatePledge
 	}
 	state.SubBalance(txSender, candidatePledge.Amount)
@@ -2549,12 +2787,14 @@ func (a *Alien) processCandidatePledgeNew(currentCandidatePledge []CandidatePled
 	data.SetBytes(candidatePledge.Amount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, data.Bytes())
 	currentCandidatePledge = append(currentCandidatePledge, candidatePledge)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePledge
 }
 
 func (a *Alien) processCandidateExit(currentCandidateExit []common.Address, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot) []common.Address {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Candidate exit", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidateExit
 	}
 	minerAddress := common.Address{}
@@ -2562,17 +2802,20 @@ func (a *Alien) processCandidateExit(currentCandidateExit []common.Address, txDa
 	zeroHash := common.BigToAddress(big.NewInt(0))
 	if err := minerAddress.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Candidate exit", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidateExit
 	}
 	if oldBind, ok := snap.RevenueNormal[minerAddress]; ok {
 		if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 			if oldBind.RevenueAddress != txSender {
 				log.Warn("Candidate exit", "revenue address", oldBind.RevenueAddress)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return currentCandidateExit
 			}
 		} else {
 			if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 				log.Warn("Candidate exit failed to verify multi-signature")
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return currentCandidateExit
 			}
 		}
@@ -2580,11 +2823,13 @@ func (a *Alien) processCandidateExit(currentCandidateExit []common.Address, txDa
 	if pledgeItem, ok := snap.CandidatePledge[minerAddress]; ok {
 		if pledgeItem.StartHigh > 0 {
 			log.Warn("Candidate exit", "candidate already exit", pledgeItem.StartHigh)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentCandidateExit
 		}
 		pledgeItem.StartHigh = snap.Number + 1
 	} else {
 		log.Warn("Candidate exit", "candidate isnot exist", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidateExit
 	}
 	topics := make([]common.Hash, 3)
@@ -2594,12 +2839,14 @@ func (a *Alien) processCandidateExit(currentCandidateExit []common.Address, txDa
 	topics[2].SetBytes(big.NewInt(sscEnumCndLock).Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentCandidateExit = append(currentCandidateExit, minerAddress)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidateExit
 }
 
 func (a *Alien) processCandidatePunish(currentCandidatePunish []CandidatePunishRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []CandidatePunishRecord {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Candidate punish", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePunish
 	}
 	candidatePunish := CandidatePunishRecord{
@@ -2609,10 +2856,12 @@ func (a *Alien) processCandidatePunish(currentCandidatePunish []CandidatePunishR
 	}
 	if err := candidatePunish.Target.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Candidate punish", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePunish
 	}
 	if candidateCredit, ok := snap.Punished[candidatePunish.Target]; !ok {
 		log.Warn("Candidate punish", "not punish", candidatePunish.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePunish
 	} else {
 		candidatePunish.Credit = uint32(candidateCredit)
@@ -2624,22 +2873,26 @@ func (a *Alien) processCandidatePunish(currentCandidatePunish []CandidatePunishR
 	}
 	if state.GetBalance(txSender).Cmp(candidatePunish.Amount) < 0 {
 		log.Warn("Candidate punish", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentCandidatePunish
 	}
 	if isGEPOSNewEffect(number) {
 		if _, ok := snap.PosPledge[candidatePunish.Target]; !ok {
 			log.Warn("Candidate punish", "PosPledge candidate is not exist", candidatePunish.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentCandidatePunish
 		}
 	} else {
 		if pledgeItem, ok := snap.CandidatePledge[candidatePunish.Target]; !ok {
 			if snap.Number < TallyPunishdProcessEffectBlockNumber {
 				log.Warn("Candidate punish", "candidate isnot exist", candidatePunish.Target)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 				return currentCandidatePunish
 			}
 		} else {
 			if pledgeItem.StartHigh > 0 {
 				log.Warn("Candidate punish", "candidate already exit", pledgeItem.StartHigh)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentCandidatePunish
 			}
 			pledgeItem.Amount = new(big.Int).Add(pledgeItem.Amount, candidatePunish.Amount)
@@ -2662,12 +2915,14 @@ func (a *Alien) processCandidatePunish(currentCandidatePunish []CandidatePunishR
 	data = append(data, dataList[1].Bytes()...)
 	a.addCustomerTxLog(tx, receipts, topics, data)
 	currentCandidatePunish = append(currentCandidatePunish, candidatePunish)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePunish
 }
 
 func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot) []ClaimedBandwidthRecord {
 	if len(txDataInfo) <= nfcPosBandwidth {
 		log.Warn("Claimed bandwidth", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentClaimedBandwidth
 	}
 	claimedBandwidth := ClaimedBandwidthRecord{
@@ -2678,20 +2933,24 @@ func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRec
 	}
 	if err := claimedBandwidth.Target.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Claimed bandwidth", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentClaimedBandwidth
 	}
 	if pledge, ok := snap.FlowPledge[claimedBandwidth.Target]; ok && 0 < pledge.StartHigh {
 		log.Warn("Claimed bandwidth", "miner exiting", claimedBandwidth.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentClaimedBandwidth
 	}
 	if ISPQosID, err := strconv.ParseUint(txDataInfo[nfcPosISPQosID], 16, 32); err != nil {
 		log.Warn("Claimed bandwidth", "ISP qos id", txDataInfo[nfcPosISPQosID])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentClaimedBandwidth
 	} else {
 		claimedBandwidth.ISPQosID = uint32(ISPQosID)
 	}
 	if bandwidth, err := strconv.ParseUint(txDataInfo[nfcPosBandwidth], 16, 32); err != nil {
 		log.Warn("Claimed bandwidth", "bandwidth", txDataInfo[nfcPosBandwidth])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentClaimedBandwidth
 	} else {
 		claimedBandwidth.Bandwidth = uint32(bandwidth)
@@ -2704,6 +2963,7 @@ func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRec
 	if oldBandwidth, ok := snap.Bandwidth[claimedBandwidth.Target]; ok {
 		if claimedBandwidth.Bandwidth < oldBandwidth.BandwidthClaimed {
 			log.Warn("Claimed bandwidth", "bandwidth reduce", oldBandwidth.BandwidthClaimed)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentClaimedBandwidth
 		}
 		bandwidth -= oldBandwidth.BandwidthClaimed
@@ -2712,6 +2972,7 @@ func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRec
 	claimedBandwidth.Amount = calBwPledgeAmount(bandwidth, snap, total)
 	if state.GetBalance(txSender).Cmp(claimedBandwidth.Amount) < 0 {
 		log.Warn("Claimed bandwidth", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentClaimedBandwidth
 	}
 	if pledgeItem, ok := snap.FlowPledge[claimedBandwidth.Target]; !ok {
@@ -2720,6 +2981,7 @@ func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRec
 	} else {
 		if pledgeItem.StartHigh > 0 {
 			log.Warn("Claimed bandwidth", "miner already exit", pledgeItem.StartHigh)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentClaimedBandwidth
 		}
 		pledgeItem.Amount = new(big.Int).Add(pledgeItem.Amount, claimedBandwidth.Amount)
@@ -2758,6 +3020,7 @@ func (a *Alien) processMinerPledge(currentClaimedBandwidth []ClaimedBandwidthRec
 	data = append(data, dataList[1].Bytes()...)
 	a.addCustomerTxLog(tx, receipts, topics, data)
 	currentClaimedBandwidth = append(currentClaimedBandwidth, claimedBandwidth)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentClaimedBandwidth
 }
 
@@ -2782,6 +3045,7 @@ func calBwPledgeAmount(bandwidth uint32, snap *Snapshot, total *big.Int) *big.In
 func (a *Alien) processMinerExit(currentFlowMinerExit []common.Address, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot) []common.Address {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Flow miner exit", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentFlowMinerExit
 	}
 	minerAddress := common.Address{}
@@ -2789,17 +3053,20 @@ func (a *Alien) processMinerExit(currentFlowMinerExit []common.Address, txDataIn
 	zeroHash := common.BigToAddress(big.NewInt(0))
 	if err := minerAddress.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Flow miner exit", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentFlowMinerExit
 	}
 	if oldBind, ok := snap.RevenueFlow[minerAddress]; ok {
 		if oldBind.MultiSignature == nilHash || oldBind.MultiSignature == zeroHash {
 			if oldBind.RevenueAddress != txSender {
 				log.Warn("Flow miner exit", "revenue address", oldBind.RevenueAddress)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return currentFlowMinerExit
 			}
 		} else {
 			if !a.verifyMultiSignatureAddress(state, oldBind.MultiSignature, tx.AllSigners()) {
 				log.Warn("Flow miner exit failed to verify multi-signature")
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return currentFlowMinerExit
 			}
 		}
@@ -2807,11 +3074,13 @@ func (a *Alien) processMinerExit(currentFlowMinerExit []common.Address, txDataIn
 	if pledgeItem, ok := snap.FlowPledge[minerAddress]; ok {
 		if pledgeItem.StartHigh > 0 {
 			log.Warn("Flow miner exit", "miner already exit", pledgeItem.StartHigh)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentFlowMinerExit
 		}
 		pledgeItem.StartHigh = snap.Number + 1
 	} else {
 		log.Warn("Flow miner exit", "miner isnot exist", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentFlowMinerExit
 	}
 	delete(snap.Bandwidth, minerAddress)
@@ -2822,16 +3091,19 @@ func (a *Alien) processMinerExit(currentFlowMinerExit []common.Address, txDataIn
 	topics[2].SetBytes(big.NewInt(sscEnumFlwLock).Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentFlowMinerExit = append(currentFlowMinerExit, minerAddress)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentFlowMinerExit
 }
 
 func (a *Alien) processBandwidthPunish(currentBandwidthPunish []BandwidthPunishRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []BandwidthPunishRecord {
 	if len(txDataInfo) <= sscPosWdthPnsh {
 		log.Warn("Bandwidth punish", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentBandwidthPunish
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumWdthPnsh].String() != txSender.String() {
 		log.Warn("Bandwidth punish", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentBandwidthPunish
 	}
 	bandwidthPunish := BandwidthPunishRecord{
@@ -2840,14 +3112,17 @@ func (a *Alien) processBandwidthPunish(currentBandwidthPunish []BandwidthPunishR
 	}
 	if err := bandwidthPunish.Target.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Bandwidth punish", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentBandwidthPunish
 	}
 	if _, ok := snap.Bandwidth[bandwidthPunish.Target]; !ok {
 		log.Warn("Bandwidth punish", "miner hasnot claimed bandwidth", bandwidthPunish.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentBandwidthPunish
 	}
 	if bandwidth, err := strconv.ParseUint(txDataInfo[sscPosWdthPnsh], 16, 32); err != nil {
 		log.Warn("Bandwidth punish", "bandwidth", txDataInfo[sscPosWdthPnsh])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentBandwidthPunish
 	} else {
 		bandwidthPunish.WdthPnsh = uint32(bandwidth)
@@ -2865,29 +3140,35 @@ func (a *Alien) processBandwidthPunish(currentBandwidthPunish []BandwidthPunishR
 	a.addCustomerTxLog(tx, receipts, topics, data)
 	snap.Bandwidth[bandwidthPunish.Target].BandwidthClaimed = bandwidthPunish.WdthPnsh
 	currentBandwidthPunish = append(currentBandwidthPunish, bandwidthPunish)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentBandwidthPunish
 }
 
-func (a *Alien) processExchRate(txDataInfo []string, txSender common.Address, snap *Snapshot) uint32 {
+func (a *Alien) processExchRate(txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) uint32 {
 	if len(txDataInfo) <= sscPosExchRate {
 		log.Warn("Config exchrate", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return 0
 	}
 	if exchRate, err := strconv.ParseUint(txDataInfo[sscPosExchRate], 10, 32); err != nil {
 		log.Warn("Config exchrate", "exchrate", txDataInfo[sscPosExchRate])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return 0
 	} else {
 		if snap.SystemConfig.ManagerAddress[sscEnumExchRate].String() != txSender.String() {
 			log.Warn("Config exchrate", "manager address", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return 0
 		}
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 		return uint32(exchRate)
 	}
 }
 
-func (a *Alien) processCandidateDeposit(currentDeposit []ConfigDepositRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []ConfigDepositRecord {
+func (a *Alien) processCandidateDeposit(currentDeposit []ConfigDepositRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []ConfigDepositRecord {
 	if len(txDataInfo) <= sscPosDepositWho {
 		log.Warn("Config candidate deposit", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentDeposit
 	}
 	deposit := ConfigDepositRecord{
@@ -2897,153 +3178,180 @@ func (a *Alien) processCandidateDeposit(currentDeposit []ConfigDepositRecord, tx
 	var err error
 	if deposit.Amount, err = hexutil.UnmarshalText1([]byte(txDataInfo[sscPosDeposit])); err != nil {
 		log.Warn("Config candidate deposit", "deposit", txDataInfo[sscPosDeposit])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeposit
 	}
 	if id, err := strconv.ParseUint(txDataInfo[sscPosDepositWho], 10, 32); err != nil {
 		log.Warn("Config manager", "id", txDataInfo[sscPosDepositWho])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentDeposit
 	} else {
 		deposit.Who = uint32(id)
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 		log.Warn("Config candidate deposit", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentDeposit
 	}
 	currentDeposit = append(currentDeposit, deposit)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentDeposit
 }
 
-func (a *Alien) processCndLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []LockParameterRecord {
+func (a *Alien) processCndLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []LockParameterRecord {
 	if len(txDataInfo) <= sscPosInterval {
 		log.Warn("Config candidate lock", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentLockParameters
 	}
 	lockParameter := LockParameterRecord{
 		Who:        sscEnumCndLock,
-		LockPeriod: uint32(180 * 24 * 60 * 60 / a.config.Period),
-		RlsPeriod:  0,
-		Interval:   0,
+		LockPeriod: uint32(signerPledgeLockParamPeriod / a.config.Period),
+		RlsPeriod:  signerPledgeLockParamRlsPeriod,
+		Interval:   signerPledgeLockParamInterval,
 	}
 	if lockPeriod, err := strconv.ParseUint(txDataInfo[sscPosLockPeriod], 16, 32); err != nil {
 		log.Warn("Config candidate lock", "lock period", txDataInfo[sscPosLockPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.LockPeriod = uint32(lockPeriod)
 	}
 	if releasePeriod, err := strconv.ParseUint(txDataInfo[sscPosRlsPeriod], 16, 32); err != nil {
 		log.Warn("Config candidate lock", "release period", txDataInfo[sscPosRlsPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.RlsPeriod = uint32(releasePeriod)
 	}
 	if interval, err := strconv.ParseUint(txDataInfo[sscPosInterval], 16, 32); err != nil {
 		log.Warn("Config candidate lock", "release interval", txDataInfo[sscPosInterval])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.Interval = uint32(interval)
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 		log.Warn("Config candidate lock", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentLockParameters
 	}
 	currentLockParameters = append(currentLockParameters, lockParameter)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentLockParameters
 }
 
-func (a *Alien) processFlwLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []LockParameterRecord {
+func (a *Alien) processFlwLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []LockParameterRecord {
 	if len(txDataInfo) <= sscPosInterval {
 		log.Warn("Config miner lock", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentLockParameters
 	}
 	lockParameter := LockParameterRecord{
 		Who:        sscEnumFlwLock,
-		LockPeriod: uint32(180 * 24 * 60 * 60 / a.config.Period),
-		RlsPeriod:  0,
-		Interval:   0,
+		LockPeriod: uint32(flowPledgeLockParamPeriod / a.config.Period),
+		RlsPeriod:  flowPledgeLockParamRlsPeriod,
+		Interval:   flowPledgeLockParamInterval,
 	}
 	if lockPeriod, err := strconv.ParseUint(txDataInfo[sscPosLockPeriod], 16, 32); err != nil {
 		log.Warn("Config miner lock", "lock period", txDataInfo[sscPosLockPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.LockPeriod = uint32(lockPeriod)
 	}
 	if releasePeriod, err := strconv.ParseUint(txDataInfo[sscPosRlsPeriod], 16, 32); err != nil {
 		log.Warn("Config miner lock", "release period", txDataInfo[sscPosRlsPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.RlsPeriod = uint32(releasePeriod)
 	}
 	if interval, err := strconv.ParseUint(txDataInfo[sscPosInterval], 16, 32); err != nil {
 		log.Warn("Config miner lock", "release interval", txDataInfo[sscPosInterval])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.Interval = uint32(interval)
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 		log.Warn("Config miner lock", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentLockParameters
 	}
 	currentLockParameters = append(currentLockParameters, lockParameter)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentLockParameters
 }
 
-func (a *Alien) processRwdLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []LockParameterRecord {
+func (a *Alien) processRwdLockConfig(currentLockParameters []LockParameterRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []LockParameterRecord {
 	if len(txDataInfo) <= sscPosInterval {
 		log.Warn("Config reward lock", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentLockParameters
 	}
 	lockParameter := LockParameterRecord{
 		Who:        sscEnumRwdLock,
-		LockPeriod: uint32(180 * 24 * 60 * 60 / a.config.Period),
-		RlsPeriod:  0,
-		Interval:   0,
+		LockPeriod: uint32(rewardLockParamPeriod / a.config.Period),
+		RlsPeriod:  uint32(rewardLockParamRlsPeriod / a.config.Period),
+		Interval:   uint32(rewardLockParamInterval / a.config.Period),
 	}
 	if lockPeriod, err := strconv.ParseUint(txDataInfo[sscPosLockPeriod], 16, 32); err != nil {
 		log.Warn("Config reward lock", "lock period", txDataInfo[sscPosLockPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.LockPeriod = uint32(lockPeriod)
 	}
 	if releasePeriod, err := strconv.ParseUint(txDataInfo[sscPosRlsPeriod], 16, 32); err != nil {
 		log.Warn("Config reward lock", "release period", txDataInfo[sscPosRlsPeriod])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.RlsPeriod = uint32(releasePeriod)
 	}
 	if interval, err := strconv.ParseUint(txDataInfo[sscPosInterval], 16, 32); err != nil {
 		log.Warn("Config reward lock", "release interval", txDataInfo[sscPosInterval])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentLockParameters
 	} else {
 		lockParameter.Interval = uint32(interval)
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 		log.Warn("Config reward lock", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentLockParameters
 	}
 	currentLockParameters = append(currentLockParameters, lockParameter)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentLockParameters
 }
 
-func (a *Alien) processOffLine(txDataInfo []string, txSender common.Address, snap *Snapshot) uint32 {
+func (a *Alien) processOffLine(txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) uint32 {
 	if len(txDataInfo) <= sscPosOffLine {
 		log.Warn("Config offLine", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return 0
 	}
 	if offline, err := strconv.ParseUint(txDataInfo[sscPosOffLine], 10, 32); err != nil {
 		log.Warn("Config offline", "offline", txDataInfo[sscPosOffLine])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return 0
 	} else {
 		if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 			log.Warn("Config offLine", "manager address", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return 0
 		}
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 		return uint32(offline)
 	}
 }
 
-func (a *Alien) processISPQos(currentISPQOS []ISPQOSRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []ISPQOSRecord {
+func (a *Alien) processISPQos(currentISPQOS []ISPQOSRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []ISPQOSRecord {
 	if len(txDataInfo) <= sscPosQosValue {
 		log.Warn("Config isp qos", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentISPQOS
 	}
 	ISPQOS := ISPQOSRecord{
@@ -3052,31 +3360,37 @@ func (a *Alien) processISPQos(currentISPQOS []ISPQOSRecord, txDataInfo []string,
 	}
 	if id, err := strconv.ParseUint(txDataInfo[sscPosQosID], 10, 32); err != nil {
 		log.Warn("Config isp qos", "isp id", txDataInfo[sscPosQosID])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentISPQOS
 	} else {
 		ISPQOS.ISPID = uint32(id)
 	}
 	if qos, err := strconv.ParseUint(txDataInfo[sscPosQosValue], 10, 32); err != nil {
 		log.Warn("Config isp qos", "qos", txDataInfo[sscPosQosValue])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentISPQOS
 	} else {
 		ISPQOS.QOS = uint32(qos)
 	}
 	if snap.SystemConfig.ManagerAddress[sscEnumSystem].String() != txSender.String() {
 		log.Warn("Config isp qos", "manager address", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentISPQOS
 	}
 	currentISPQOS = append(currentISPQOS, ISPQOS)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentISPQOS
 }
 
-func (a *Alien) processManagerAddress(currentManagerAddress []ManagerAddressRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) []ManagerAddressRecord {
+func (a *Alien) processManagerAddress(currentManagerAddress []ManagerAddressRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) []ManagerAddressRecord {
 	if len(txDataInfo) <= sscPosManagerAddress {
 		log.Warn("Config manager", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentManagerAddress
 	}
 	if txSender.String() != managerAddressManager.String() {
 		log.Warn("Config manager", "manager", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentManagerAddress
 	}
 	managerAddress := ManagerAddressRecord{
@@ -3085,22 +3399,26 @@ func (a *Alien) processManagerAddress(currentManagerAddress []ManagerAddressReco
 	}
 	if id, err := strconv.ParseUint(txDataInfo[sscPosManagerID], 10, 32); err != nil {
 		log.Warn("Config manager", "id", txDataInfo[sscPosManagerID])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentManagerAddress
 	} else {
 		managerAddress.Who = uint32(id)
 	}
 	if err := managerAddress.Target.UnmarshalText1([]byte(txDataInfo[sscPosManagerAddress])); err != nil {
 		log.Warn("Config manager", "address", txDataInfo[sscPosManagerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentManagerAddress
 	}
 	snap.SystemConfig.ManagerAddress[managerAddress.Who] = managerAddress.Target
 	currentManagerAddress = append(currentManagerAddress, managerAddress)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentManagerAddress
 }
 
-func (a *Alien) processFlowReport1(flowReport []MinerFlowReportRecord, txDataInfo []string, txSender common.Address, snap *Snapshot) ([]MinerFlowReportRecord, bool) {
+func (a *Alien) processFlowReport1(flowReport []MinerFlowReportRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot) ([]MinerFlowReportRecord, bool) {
 	if len(txDataInfo) <= posEventFlowValue {
 		log.Warn("Flow report", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return flowReport, false
 	}
 	ok := false
@@ -3109,16 +3427,21 @@ func (a *Alien) processFlowReport1(flowReport []MinerFlowReportRecord, txDataInf
 		if snap.isSideChainCoinbase(report.ChainHash, txSender, true) {
 			flowReport = append(flowReport, report)
 			ok = true
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
+		} else {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		}
 	} else {
 		log.Warn("processFlowReport1", "err", err)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 	}
 	return flowReport, ok
 }
 
-func (a *Alien) processFlowReport2(flowReport []MinerFlowReportRecord, txDataInfo []string) []MinerFlowReportRecord {
+func (a *Alien) processFlowReport2(flowReport []MinerFlowReportRecord, txDataInfo []string, tx *types.Transaction, receipts []*types.Receipt) []MinerFlowReportRecord {
 	if len(txDataInfo) <= posEventFlowValue {
 		log.Warn("Flow report", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return flowReport
 	}
 	buffer := common.Hex2Bytes(txDataInfo[posEventFlowValue])
@@ -3142,6 +3465,7 @@ func (a *Alien) processFlowReport2(flowReport []MinerFlowReportRecord, txDataInf
 		post += 20
 	}
 	flowReport = append(flowReport, census)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return flowReport
 }
 
@@ -3197,6 +3521,7 @@ func (a *Alien) processCandidatePledgeEntrust(currentCandidatePledge []Candidate
 
 	if len(txDataInfo) <= 4 {
 		log.Warn("Candidate Entrust", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePledge
 	}
 	candidatePledge := CandidatePledgeEntrustRecord{
@@ -3209,42 +3534,50 @@ func (a *Alien) processCandidatePledgeEntrust(currentCandidatePledge []Candidate
 		isTransfer :=isInCurrentPOSTransfer(currentPOSTransfer,txSender)
 		if isTransfer{
 			log.Warn("Candidate Entrust", "miner address  just pledge on this blockNumber",txSender,"number",number)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentCandidatePledge
 		}
 	}
 	postion := 3
 	if err := candidatePledge.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("Candidate Entrust", "miner address", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePledge
 	}
 
 	if _, ok := snap.PosPledge[candidatePledge.Target]; !ok {
 		log.Warn("Candidate Entrust", "candidate is not exist", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidatePledge
 	}
 
 	if _, ok := snap.PosPledge[candidatePledge.Address]; ok {
 		log.Warn("Candidate Entrust", "txSender is miner address", candidatePledge.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 	postion++
 	var err error
 	if candidatePledge.Amount, err = hexutil.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("Candidate Entrust", "number", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePledge
 	}
 	if candidatePledge.Amount.Cmp(minCndEntrustPledgeBalance) < 0 {
 		log.Warn("Candidate Entrust", "Amount less than 1 ", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentCandidatePledge
 	}
 	targetMiner := snap.findPosTargetMiner(txSender)
 	nilAddr := common.Address{}
 	if targetMiner != nilAddr && targetMiner != candidatePledge.Target {
 		log.Warn("Candidate Entrust", "one address can only pledge one miner ", targetMiner)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePledge
 	}
 	if state.GetBalance(txSender).Cmp(candidatePledge.Amount) < 0 {
 		log.Warn("Candidate Entrust", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentCandidatePledge
 	}
 	state.SubBalance(txSender, candidatePledge.Amount)
@@ -3256,12 +3589,14 @@ func (a *Alien) processCandidatePledgeEntrust(currentCandidatePledge []Candidate
 	data.SetBytes(candidatePledge.Amount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, data.Bytes())
 	currentCandidatePledge = append(currentCandidatePledge, candidatePledge)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePledge
 }
 func (a *Alien) processCandidatePEntrustExit(currentCandidatePEntrustExit []CandidatePEntrustExitRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []CandidatePEntrustExitRecord {
 
 	if len(txDataInfo) <= 4 {
 		log.Warn("Candidate PEntrustExit", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePEntrustExit
 	}
 	candidatePledge := CandidatePEntrustExitRecord{
@@ -3273,6 +3608,7 @@ func (a *Alien) processCandidatePEntrustExit(currentCandidatePEntrustExit []Cand
 	postion := 3
 	if err := candidatePledge.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("Candidate PEntrustExit", "miner address", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePEntrustExit
 	}
 	postion++
@@ -3280,16 +3616,19 @@ func (a *Alien) processCandidatePEntrustExit(currentCandidatePEntrustExit []Cand
 
 	if _, ok := snap.PosPledge[candidatePledge.Target]; !ok {
 		log.Warn("Candidate PEntrustExit", "candidate is not exist", candidatePledge.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidatePEntrustExit
 	}
 
 	if _, ok := snap.PosPledge[candidatePledge.Target].Detail[candidatePledge.Hash]; !ok {
 		log.Warn("Candidate PEntrustExit", "Hash is not exist", candidatePledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidatePEntrustExit
 	} else {
 		pledgeDetail := snap.PosPledge[candidatePledge.Target].Detail[candidatePledge.Hash]
 		if pledgeDetail.Address != txSender {
 			log.Warn("Candidate PEntrustExit", "txSender is not right", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentCandidatePEntrustExit
 		}
 		candidatePledge.Address = pledgeDetail.Address
@@ -3298,22 +3637,26 @@ func (a *Alien) processCandidatePEntrustExit(currentCandidatePEntrustExit []Cand
 
 	if isInCurrentCandidatePEntrustExit(currentCandidatePEntrustExit, candidatePledge.Hash) {
 		log.Warn("Candidate PEntrustExit", "Hash is in currentCandidatePEntrustExit", candidatePledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePEntrustExit
 	}
 
 	if snap.isInPosCommitPeriod(candidatePledge.Target, number) {
 		if txSender == snap.PosPledge[candidatePledge.Target].Manager {
 			log.Warn("Candidate exit New", "minerAddress is in commit period", candidatePledge.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentCandidatePEntrustExit
 		} else {
 			if snap.isInPosCommitPeriodPass(candidatePledge.Target, number, candidatePledge.Hash, snap.SystemConfig.Deposit[sscEnumPosWithinCommitPeriod].Uint64()) {
 				log.Warn("Candidate exit New", "hash is not BeyondCommitPeriod", candidatePledge.Hash)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentCandidatePEntrustExit
 			}
 		}
 	} else {
 		if snap.isInPosCommitPeriodPass(candidatePledge.Target, number, candidatePledge.Hash, snap.SystemConfig.Deposit[sscEnumPosBeyondCommitPeriod].Uint64()) {
 			log.Warn("Candidate exit New", "hash is not BeyondCommitPeriod", candidatePledge.Hash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentCandidatePEntrustExit
 		}
 	}
@@ -3355,36 +3698,43 @@ func (a *Alien) processCandidatePEntrustExit(currentCandidatePEntrustExit []Cand
 			}
 		}
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePEntrustExit
 }
 
 func (a *Alien) processCandidateExitNew(currentCandidatePEntrustExit []CandidatePEntrustExitRecord, currentCandidateExit []common.Address, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) ([]CandidatePEntrustExitRecord, []common.Address) {
 	if len(txDataInfo) <= nfcPosMinerAddress {
 		log.Warn("Candidate exit New", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidatePEntrustExit, currentCandidateExit
 	}
 	minerAddress := common.Address{}
 	if err := minerAddress.UnmarshalText1([]byte(txDataInfo[nfcPosMinerAddress])); err != nil {
 		log.Warn("Candidate exit New", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidatePEntrustExit, currentCandidateExit
 	}
 	if oldBind, ok := snap.PosPledge[minerAddress]; ok {
 		if oldBind.Manager != txSender && !(snap.isSystemManagerAndInTally(txSender, minerAddress)) {
 			log.Warn("Candidate exit New", "Manager address is not txSender", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentCandidatePEntrustExit, currentCandidateExit
 		}
 	} else {
 		log.Warn("Candidate exit New", "minerAddress is not exist", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidatePEntrustExit, currentCandidateExit
 	}
 
 	if snap.isInPosCommitPeriod(minerAddress, number) {
 		log.Warn("Candidate exit New", "minerAddress is in commit period", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePEntrustExit, currentCandidateExit
 	}
 
 	if snap.isInTally(minerAddress) && !snap.isSystemManager(txSender) {
 		log.Warn("Candidate exit New", "minerAddress is in tally", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentCandidatePEntrustExit, currentCandidateExit
 	}
 	topics := make([]common.Hash, 3)
@@ -3404,6 +3754,7 @@ func (a *Alien) processCandidateExitNew(currentCandidatePEntrustExit []Candidate
 	if snap.isSystemManagerAndInTally(txSender, minerAddress) {
 		currentCandidateExit = append(currentCandidateExit, minerAddress)
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidatePEntrustExit, currentCandidateExit
 }
 
@@ -3424,21 +3775,25 @@ func (a *Alien) isPosManager(snap *Snapshot, deviceBind DeviceBindRecord, txSend
 func (a *Alien) processCandidateChangeRate(currentCandidateRate []CandidateChangeRateRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []CandidateChangeRateRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("Candidate ChangeRate", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCandidateRate
 	}
 	postion := 3
 	minerAddress := common.Address{}
 	if err := minerAddress.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("Candidate ChangeRate", "miner address", txDataInfo[nfcPosMinerAddress])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidateRate
 	}
 	if oldBind, ok := snap.PosPledge[minerAddress]; ok {
 		if oldBind.Manager != txSender {
 			log.Warn("Candidate ChangeRate", "Manager address is not txSender", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentCandidateRate
 		}
 	} else {
 		log.Warn("Candidate ChangeRate", "minerAddress is not exist", minerAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCandidateRate
 	}
 	candidateChangeRate := CandidateChangeRateRecord{
@@ -3449,14 +3804,17 @@ func (a *Alien) processCandidateChangeRate(currentCandidateRate []CandidateChang
 	var err error
 	if candidateChangeRate.Rate, err = hexutil.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("Candidate ChangeRate", "number", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCandidateRate
 	}
 	if candidateChangeRate.Rate.Cmp(posDistributionDefaultRate) > 0 {
 		log.Warn("Candidate ChangeRate", "Rate greater than posDistributionDefaultRate ", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentCandidateRate
 	}
 	if candidateChangeRate.Rate.Cmp(common.Big0) <= 0 {
 		log.Warn("Candidate ChangeRate", "Rate Less than or equal to 0 ", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentCandidateRate
 	}
 	topics := make([]common.Hash, 3)
@@ -3465,6 +3823,7 @@ func (a *Alien) processCandidateChangeRate(currentCandidateRate []CandidateChang
 	topics[2].SetBytes(candidateChangeRate.Rate.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentCandidateRate = append(currentCandidateRate, candidateChangeRate)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCandidateRate
 }
 
@@ -3495,6 +3854,7 @@ func (a *Alien) isStorageManager(snap *Snapshot, deviceBind DeviceBindRecord, tx
 func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []POSTransferRecord {
 	if len(txDataInfo) <= 5 {
 		log.Warn("processCandidateWtfd", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentPOSTransfer
 	}
 	posTransfer := POSTransferRecord{
@@ -3507,16 +3867,19 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 	}
 	if isInCurrentPOSTransfer(currentPOSTransfer, posTransfer.Address) {
 		log.Warn("processCandidateWtfd", "Address is in currentPOSTransfer", posTransfer.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentPOSTransfer
 	}
 	postion := 3
 	if err := posTransfer.Original.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("processCandidateWtfd", "Target error", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentPOSTransfer
 	}
 	if se, ok := snap.PosPledge[posTransfer.Original]; ok {
 		if se.Manager == txSender {
 			log.Warn("processCandidateWtfd", "manager address no role", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentPOSTransfer
 		}
 		transAmount := big.NewInt(0)
@@ -3530,6 +3893,7 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 				pledgeBLock := new(big.Int).Sub(new(big.Int).SetUint64(snap.Number), new(big.Int).SetUint64(detail.Height))
 				if pledgeBLock.Cmp(pledgeMinBLock) < 0 {
 					log.Warn("processCandidateWtfd", "Entrust hash illegality", txSender)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 					return currentPOSTransfer
 				}
 				transAmount = new(big.Int).Add(transAmount, detail.Amount)
@@ -3537,12 +3901,14 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 		}
 		if transAmount.Cmp(big.NewInt(0)) <= 0 {
 			log.Warn("processCandidateWtfd", "TxSender does not have a transferable deposit ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentPOSTransfer
 		}
 		posTransfer.PledgeAmount = transAmount
 
 	} else {
 		log.Warn("processCandidateWtfd", "pos not exist ", posTransfer.Original)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentPOSTransfer
 	}
 
@@ -3552,23 +3918,28 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 	if TargetTypePos == posTransfer.TargetType {
 		if err := posTransfer.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("processCandidateWtfd", "PoS target Address error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentPOSTransfer
 		}
 		if _, ok := snap.PosPledge[posTransfer.Target]; !ok {
 			log.Warn("processCandidateWtfd", "PoS node not exit ", posTransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentPOSTransfer
 		}
 	} else if TargetTypeSp == posTransfer.TargetType {
 		if err := posTransfer.TargetHash.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("processCandidateWtfd", "Sp target Hash error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentPOSTransfer
 		}
 		if sp, ok := snap.SpData.PoolPledge[posTransfer.TargetHash]; !ok {
 			log.Warn("processCandidateWtfd", "Sp target not exit ", posTransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentPOSTransfer
 		}else{
 			if sp.Status != spStatusActive {
 				log.Warn("processCandidateWtfd", "SP Status  is need active ", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentPOSTransfer
 			}
 		}
@@ -3576,25 +3947,30 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 		nilAddr := common.Hash{}
 		if targetPool != nilAddr && targetPool != posTransfer.TargetHash {
 			log.Warn("processCandidateWtfd", "one address can only pledge one pool ", targetPool)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentPOSTransfer
 		}
 	} else if TargetTypeSn == posTransfer.TargetType {
 		if _, ok := snap.StorageData.StoragePledge[posTransfer.Address]; ok {
 			log.Warn("processCandidateWtfd", "txSender is Storage address", posTransfer.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentPOSTransfer
 		}
 		if err := posTransfer.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("processCandidateWtfd", "SN target Address error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentPOSTransfer
 		}
 		if _, ok := snap.StorageData.StoragePledge[posTransfer.Address]; ok {
 			log.Warn("processCandidateWtfd", "txSender is Storage address", posTransfer.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentPOSTransfer
 		}
 		targetMiner := snap.findStorageTargetMiner(txSender)
 		nilAddr := common.Address{}
 		if targetMiner != nilAddr && targetMiner != posTransfer.Target {
 			log.Warn("processCandidateWtfd", "one address can only pledge one miner ", targetMiner)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentPOSTransfer
 		}
 		currBlockTranAmount := big.NewInt(0)
@@ -3607,11 +3983,13 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 			if snItem, ok1 := snap.StorageData.StoragePledge[posTransfer.Target]; ok1 {
 				if snItem.PledgeStatus.Cmp(big.NewInt(SPledgeInactive))!=0{
 					log.Warn("processCandidateWtfd", "Sn is not inactive", posTransfer.Target)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentPOSTransfer
 				}
 				estimateAmountV1 := new(big.Int).Add(currBlockTranAmount, snEtPledge.PledgeAmount)
 				if estimateAmountV1.Cmp(snItem.SpaceDeposit) >= 0 {
 					log.Warn("processCandidateWtfd", "Sn entrusted pledge is full", txDataInfo[postion])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentPOSTransfer
 				}
 				estimateAmountV2 := new(big.Int).Add(estimateAmountV1, posTransfer.PledgeAmount)
@@ -3633,10 +4011,12 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 			}
 		} else {
 			log.Warn("processCandidateWtfd", "SN node not exit", posTransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentPOSTransfer
 		}
 	} else {
 		log.Warn("processCandidateWtfd", "TargetType is illegal", posTransfer.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentPOSTransfer
 	}
 
@@ -3647,6 +4027,7 @@ func (a *Alien) processCandidateWtfd(currentPOSTransfer []POSTransferRecord, txD
 	topics[1].SetBytes(posTransfer.LockAmount.Bytes())
 	topics[2].SetBytes(posTransfer.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentPOSTransfer
 }
 
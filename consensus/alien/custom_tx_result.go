@@ -0,0 +1,113 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+)
+
+// Status of a processed custom transaction, as recorded in its receipt.
+const (
+	CustomTxRejected uint64 = 0
+	CustomTxAccepted uint64 = 1
+)
+
+// CustomTxReason is the reason code recorded in the receipt of a UTG custom
+// transaction which did not take effect.
+type CustomTxReason uint64
+
+const (
+	CustomTxReasonNone                CustomTxReason = iota // the transaction took effect
+	CustomTxReasonFieldCount                                // wrong number of payload fields
+	CustomTxReasonInvalidField                              // a payload field could not be parsed
+	CustomTxReasonInvalidValue                              // a payload field is out of range
+	CustomTxReasonUnauthorized                              // the sender may not perform the operation
+	CustomTxReasonInsufficientBalance                       // the sender balance does not cover the pledge
+	CustomTxReasonInsufficientSRT                           // the SRT balance does not cover the lease
+	CustomTxReasonNotFound                                  // the referenced pledge, lease or pool does not exist
+	CustomTxReasonProofFailed                               // a storage proof could not be verified
+	CustomTxReasonStateCheck                                // the current pledge or lease state does not allow the operation
+	CustomTxReasonDisabled                                  // the category is not enabled at this block
+)
+
+var customTxReasonNames = map[CustomTxReason]string{
+	CustomTxReasonNone:                "none",
+	CustomTxReasonFieldCount:          "field count",
+	CustomTxReasonInvalidField:        "invalid field",
+	CustomTxReasonInvalidValue:        "invalid value",
+	CustomTxReasonUnauthorized:        "unauthorized",
+	CustomTxReasonInsufficientBalance: "insufficient balance",
+	CustomTxReasonInsufficientSRT:     "insufficient SRT",
+	CustomTxReasonNotFound:            "not found",
+	CustomTxReasonProofFailed:         "proof failed",
+	CustomTxReasonStateCheck:          "state check failed",
+	CustomTxReasonDisabled:            "category disabled",
+}
+
+func (r CustomTxReason) String() string {
+	if name, ok := customTxReasonNames[r]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// customTxResultTopic is the first topic of the log holding the outcome of a custom transaction.
+var customTxResultTopic = crypto.Keccak256Hash([]byte("CustomTxResult(uint256,uint256)"))
+
+// addCustomTxResult records the outcome of a custom transaction in its receipt,
+// with the status and reason code as two 32 byte words of data. Every process
+// function reports the outcome on each of its paths; only the first outcome of
+// a transaction is recorded. Transactions no handler processed carry none.
+func (a *Alien) addCustomTxResult(tx *types.Transaction, receipts []*types.Receipt, reason CustomTxReason) {
+	for _, receipt := range receipts {
+		if receipt.TxHash != tx.Hash() {
+			continue
+		}
+		if receipt.BlockNumber == nil || !isGECustomTxResultEffect(receipt.BlockNumber.Uint64()) {
+			return
+		}
+		if _, _, ok := findCustomTxResult(receipt); ok {
+			return
+		}
+		status := CustomTxRejected
+		if reason == CustomTxReasonNone {
+			status = CustomTxAccepted
+		}
+		topics := []common.Hash{customTxResultTopic}
+		data := common.BigToHash(new(big.Int).SetUint64(status)).Bytes()
+		data = append(data, common.BigToHash(new(big.Int).SetUint64(uint64(reason))).Bytes()...)
+		a.addCustomerTxLog(tx, receipts, topics, data)
+		return
+	}
+}
+
+// findCustomTxResult returns the outcome recorded in a receipt by addCustomTxResult.
+func findCustomTxResult(receipt *types.Receipt) (uint64, CustomTxReason, bool) {
+	for _, l := range receipt.Logs {
+		if len(l.Topics) == 0 || l.Topics[0] != customTxResultTopic || len(l.Data) != 2*common.HashLength {
+			continue
+		}
+		status := new(big.Int).SetBytes(l.Data[:common.HashLength]).Uint64()
+		reason := new(big.Int).SetBytes(l.Data[common.HashLength:]).Uint64()
+		return status, CustomTxReason(reason), true
+	}
+	return 0, CustomTxReasonNone, false
+}
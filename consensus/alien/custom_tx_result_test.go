@@ -0,0 +1,77 @@
+package alien
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestAddCustomTxResult(t *testing.T) {
+	defer setForkSchedule(nil)
+	setForkSchedule(&params.AlienForks{CustomTxResultBlock: big.NewInt(1502370)})
+
+	tests := []struct {
+		number  uint64
+		reasons []CustomTxReason
+		found   bool
+		status  uint64
+		reason  CustomTxReason
+	}{
+		{CustomTxResultEffectNumber - 1, []CustomTxReason{CustomTxReasonNone}, false, 0, CustomTxReasonNone},
+		{CustomTxResultEffectNumber, []CustomTxReason{CustomTxReasonNone}, true, CustomTxAccepted, CustomTxReasonNone},
+		{CustomTxResultEffectNumber, []CustomTxReason{CustomTxReasonInsufficientSRT, CustomTxReasonNone}, true, CustomTxRejected, CustomTxReasonInsufficientSRT},
+		{CustomTxResultEffectNumber + 1, []CustomTxReason{CustomTxReasonFieldCount, CustomTxReasonNotFound}, true, CustomTxRejected, CustomTxReasonFieldCount},
+	}
+	a := &Alien{}
+	for i, tt := range tests {
+		tx := types.NewTransaction(uint64(i), common.Address{}, big.NewInt(0), 100000, big.NewInt(1), []byte("UTG:1:Exch"))
+		receipt := &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful, BlockNumber: new(big.Int).SetUint64(tt.number)}
+		for _, reason := range tt.reasons {
+			a.addCustomTxResult(tx, []*types.Receipt{receipt}, reason)
+		}
+		status, reason, found := findCustomTxResult(receipt)
+		if found != tt.found {
+			t.Errorf("test %d: result found %v, want %v", i, found, tt.found)
+			continue
+		}
+		if found && len(receipt.Logs) != 1 {
+			t.Errorf("test %d: %d logs recorded, want 1", i, len(receipt.Logs))
+		}
+		if status != tt.status || reason != tt.reason {
+			t.Errorf("test %d: have (%d, %s), want (%d, %s)", i, status, reason, tt.status, tt.reason)
+		}
+	}
+}
+
+func TestSystemConfigTxResult(t *testing.T) {
+	defer setForkSchedule(nil)
+	setForkSchedule(&params.AlienForks{CustomTxResultBlock: big.NewInt(0)})
+
+	manager := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	snap := &Snapshot{SystemConfig: SystemParameter{ManagerAddress: map[uint32]common.Address{sscEnumExchRate: manager}}}
+	tests := []struct {
+		data   string
+		sender common.Address
+		status uint64
+		reason CustomTxReason
+	}{
+		{"SSC:1:ExchRate", manager, CustomTxRejected, CustomTxReasonFieldCount},
+		{"SSC:1:ExchRate:rate", manager, CustomTxRejected, CustomTxReasonInvalidField},
+		{"SSC:1:ExchRate:100", common.Address{}, CustomTxRejected, CustomTxReasonUnauthorized},
+		{"SSC:1:ExchRate:100", manager, CustomTxAccepted, CustomTxReasonNone},
+	}
+	a := &Alien{}
+	for i, tt := range tests {
+		tx := types.NewTransaction(uint64(i), common.Address{}, big.NewInt(0), 100000, big.NewInt(1), []byte(tt.data))
+		receipt := &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1)}
+		a.processExchRate(strings.Split(tt.data, ":"), tt.sender, tx, []*types.Receipt{receipt}, snap)
+		status, reason, found := findCustomTxResult(receipt)
+		if !found || status != tt.status || reason != tt.reason {
+			t.Errorf("test %d: have (%v, %d, %s), want (%d, %s)", i, found, status, reason, tt.status, tt.reason)
+		}
+	}
+}
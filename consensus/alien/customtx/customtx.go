@@ -0,0 +1,222 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package customtx implements typed parsing and encoding of the colon separated
+// payloads carried in the data field of alien custom transactions, such as
+// "UTG:1:Bind:<device>:<type>:<contract>:<multisign>" or "SSC:1:ExchRate:<rate>".
+//
+// Every category is a registered Go type implementing Payload. Parse decodes raw
+// transaction data into the matching type and Encode turns it back into bytes
+// the alien engine accepts.
+package customtx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// Separator is the field separator of custom transaction payloads.
+	Separator = ":"
+
+	PrefixUFO = "ufo"
+	PrefixUTG = "UTG"
+	PrefixSSC = "SSC"
+
+	Version1 = "1"
+
+	// minFields is the number of fields of the payload header (prefix:version:category).
+	minFields = 3
+
+	posPrefix   = 0
+	posVersion  = 1
+	posCategory = 2
+	posEvent    = 3
+)
+
+var (
+	// ErrNotCustomTx is returned if the data does not start with a known prefix.
+	ErrNotCustomTx = errors.New("not a custom transaction payload")
+
+	// ErrUnknownCategory is returned if the prefix and version are known but no
+	// decoder is registered for the category.
+	ErrUnknownCategory = errors.New("unknown custom transaction category")
+
+	// ErrMissingField is returned if the payload has fewer fields than the category requires.
+	ErrMissingField = errors.New("missing field")
+
+	// ErrInvalidValue is returned if a field is present but out of range or not an allowed value.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// Header identifies the category of a custom transaction payload. Event is only
+// used by the "ufo" prefix, whose categories ("event", "sc") are further split
+// by the fourth field.
+type Header struct {
+	Prefix   string
+	Version  string
+	Category string
+	Event    string
+}
+
+// String returns the header in its wire format.
+func (h Header) String() string {
+	s := h.Prefix + Separator + h.Version + Separator + h.Category
+	if h.Event != "" {
+		s += Separator + h.Event
+	}
+	return s
+}
+
+// fieldCount returns the number of payload fields the header occupies.
+func (h Header) fieldCount() int {
+	if h.Event != "" {
+		return minFields + 1
+	}
+	return minFields
+}
+
+// Payload is a decoded custom transaction payload.
+type Payload interface {
+	// Header returns the prefix, version and category of the payload.
+	Header() Header
+	// Encode returns the payload in the format accepted by the alien engine.
+	Encode() []byte
+}
+
+// Decoder decodes the fields of a payload (including the header fields) into
+// its typed representation.
+type Decoder func(fields []string) (Payload, error)
+
+// FieldError is returned when a single field of a payload cannot be decoded.
+type FieldError struct {
+	Header Header // category of the payload
+	Index  int    // position of the field in the colon separated payload
+	Field  string // name of the field
+	Value  string // raw value of the field, empty if it is missing
+	Err    error  // underlying error
+}
+
+func (e *FieldError) Error() string {
+	if errors.Is(e.Err, ErrMissingField) {
+		return fmt.Sprintf("customtx %s: field %d (%s): %v", e.Header, e.Index, e.Field, e.Err)
+	}
+	return fmt.Sprintf("customtx %s: field %d (%s) %q: %v", e.Header, e.Index, e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[Header]Decoder)
+)
+
+// Register makes a decoder available for the given header. Registering the
+// same header twice panics.
+func Register(h Header, dec Decoder) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registry[h]; ok {
+		panic("customtx: duplicate registration of " + h.String())
+	}
+	registry[h] = dec
+}
+
+// register wraps a field reader based decoder into a Decoder and registers it.
+func register(h Header, dec func(r *fieldReader) Payload) {
+	Register(h, func(fields []string) (Payload, error) {
+		r := newFieldReader(h, fields)
+		p := dec(r)
+		if r.err != nil {
+			return nil, r.err
+		}
+		return p, nil
+	})
+}
+
+// Registered returns the headers of all registered categories in a stable order.
+func Registered() []Header {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	headers := make([]Header, 0, len(registry))
+	for h := range registry {
+		headers = append(headers, h)
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].String() < headers[j].String()
+	})
+	return headers
+}
+
+// IsCustomTx reports whether data starts with one of the custom transaction prefixes.
+func IsCustomTx(data []byte) bool {
+	fields := strings.SplitN(string(data), Separator, 2)
+	if len(fields) < 2 {
+		return false
+	}
+	switch fields[posPrefix] {
+	case PrefixUFO, PrefixUTG, PrefixSSC:
+		return true
+	}
+	return false
+}
+
+// Split splits the payload into its fields the same way the alien engine does.
+func Split(data []byte) []string {
+	return strings.Split(string(data), Separator)
+}
+
+// Lookup returns the header and decoder that handle the given payload fields.
+func Lookup(fields []string) (Header, Decoder, error) {
+	if len(fields) < minFields {
+		return Header{}, nil, ErrNotCustomTx
+	}
+	h := Header{Prefix: fields[posPrefix], Version: fields[posVersion], Category: fields[posCategory]}
+	switch h.Prefix {
+	case PrefixUFO, PrefixUTG, PrefixSSC:
+	default:
+		return Header{}, nil, ErrNotCustomTx
+	}
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	if h.Prefix == PrefixUFO && len(fields) > posEvent {
+		withEvent := h
+		withEvent.Event = fields[posEvent]
+		if dec, ok := registry[withEvent]; ok {
+			return withEvent, dec, nil
+		}
+	}
+	if dec, ok := registry[h]; ok {
+		return h, dec, nil
+	}
+	return h, nil, fmt.Errorf("%w: %s", ErrUnknownCategory, h)
+}
+
+// Parse decodes a custom transaction payload into its registered type.
+func Parse(data []byte) (Payload, error) {
+	fields := Split(data)
+	_, dec, err := Lookup(fields)
+	if err != nil {
+		return nil, err
+	}
+	return dec(fields)
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+var (
+	testAddr1 = common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	testAddr2 = common.HexToAddress("0x3a1c7b6e9d2f4e5a8b0c1d2e3f405162738495a6")
+	testAddr3 = common.HexToAddress("0x5b38da6a701c568545dcfcb03fcb875f56beddc4")
+	testHash1 = common.HexToHash("0x3210000000000000000000000000000000000000000000000000000000000000")
+	testHash2 = common.HexToHash("0x9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+)
+
+func TestParseRawPayloads(t *testing.T) {
+	tests := []struct {
+		data string
+		want string // payload re-encoded after parsing, compared case insensitively
+	}{
+		{"ufo:1:event:vote", "ufo:1:event:vote"},
+		{"ufo:1:event:confirm:123", "ufo:1:event:confirm:123"},
+		{
+			"ufo:1:event:proposal:proposal_type:4:sccount:2:screward:50:schash:0x3210000000000000000000000000000000000000000000000000000000000000:vlcnt:4",
+			"ufo:1:event:proposal:proposal_type:4:vlcnt:4:sccount:2:screward:50:schash:0x3210000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			"UTG:1:Exch:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0x1bc16d674ec80000",
+			"UTG:1:Exch:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0x1bc16d674ec80000",
+		},
+		{
+			"UTG:1:Bind:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0:::",
+			"UTG:1:Bind:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0::",
+		},
+		{"SSC:1:CndLock:a:14:1", "SSC:1:CndLock:a:14:1"},
+		{"SSC:1:ExchRate:1000", "SSC:1:ExchRate:1000"},
+	}
+	for i, tt := range tests {
+		p, err := Parse([]byte(tt.data))
+		if err != nil {
+			t.Errorf("test %d: parse %q failed: %v", i, tt.data, err)
+			continue
+		}
+		if got := string(p.Encode()); !strings.EqualFold(got, tt.want) {
+			t.Errorf("test %d: re-encoded payload mismatch\n have %s\n want %s", i, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	payloads := []Payload{
+		&Vote{},
+		&Confirm{BlockNumber: big.NewInt(1024)},
+		&Proposal{ProposalType: 4, SCHash: testHash1, SCBlockCountPerPeriod: 2, SCBlockRewardPerPeriod: 50, ValidationLoopCnt: 4},
+		&Proposal{ProposalType: 5, Candidate: testAddr1, SCRentTarget: testAddr2, SCRentFee: 100, SCRentLength: 259200},
+		&Declare{ProposalHash: testHash2, Decision: false},
+		&SCConfirm{SCHash: testHash1, Number: big.NewInt(10), Time: big.NewInt(1600000000), LoopInfo: "a", ChargingInfo: "b"},
+		&SCSetCoinbase{SCHash: testHash1},
+		&SCDelCoinbase{SCHash: testHash1},
+		&FlowReport{Report: []byte{0xc0, 0x01}},
+		&Exch{Target: testAddr1, Amount: big.NewInt(1e18)},
+		&MultiSign{Threshold: 2, Signers: []common.Address{testAddr1, testAddr2, testAddr3}},
+		&Bind{Device: testAddr1, RevenueType: 1, Contract: testAddr2, Revenue: testAddr2},
+		&Unbind{Device: testAddr1, RevenueType: 1},
+		&Rebind{Device: testAddr1, RevenueType: 0, Revenue: testAddr2},
+		&FlwReq{Target: testAddr1, ISPQosID: 3, Bandwidth: 100},
+		&CandEntrustExit{Target: testAddr1, Hash: testHash2},
+		&CandPoSTransfer{Original: testAddr1, Transfer: TransferTarget{TargetType: TargetTypeSP, Hash: testHash1}},
+		&CandPoSTransfer{Original: testAddr1, Transfer: TransferTarget{TargetType: TargetTypeSN, Address: testAddr2}},
+		&StorageDeclare{
+			Pledge:    testAddr1,
+			Price:     big.NewInt(1000),
+			Capacity:  big.NewInt(1 << 40),
+			Proof:     PackageProof{StartPkNumber: "1", PkNonce: big.NewInt(7), PkBlockHash: testHash1.Hex(), VerifyData: "1,2,3"},
+			Bandwidth: big.NewInt(100),
+		},
+		&StorageProof{Pledge: testAddr1, Capacity: "1024", Proof: "x,y"},
+		&StorageProof{Pledge: testAddr1, LeaseHash: testHash2, Capacity: "1024", Proof: "x,y"},
+		&SPApply{PledgeAmount: big.NewInt(5000), Fee: 10, EntrustRate: 50, Revenue: testAddr2},
+		&SPEntrustTransfer{PoolHash: testHash1, Transfer: TransferTarget{TargetType: TargetTypePoS, Address: testAddr2}},
+		&SPRevenueBind{PoolHash: testHash1, Bind: true, Revenue: testAddr1},
+		&SPRevenueBind{PoolHash: testHash1},
+		&Deposit{Amount: big.NewInt(300), Who: 2},
+		&LockConfig{Kind: CategoryRwdLock, LockPeriod: 30, RlsPeriod: 180, Interval: 1},
+		&WdthPnsh{Target: testAddr1, Punish: 0x20},
+		&Manager{Who: 3, Address: testAddr2},
+	}
+	for i, p := range payloads {
+		enc := p.Encode()
+		dec, err := Parse(enc)
+		if err != nil {
+			t.Errorf("test %d (%s): parse %q failed: %v", i, p.Header(), enc, err)
+			continue
+		}
+		if dec.Header() != p.Header() {
+			t.Errorf("test %d: header mismatch: have %s, want %s", i, dec.Header(), p.Header())
+		}
+		if got := string(dec.Encode()); got != string(enc) {
+			t.Errorf("test %d: round trip mismatch\n have %s\n want %s", i, got, enc)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		data  string
+		err   error
+		index int // expected FieldError index, -1 if not a field error
+		field string
+	}{
+		{"hello", ErrNotCustomTx, -1, ""},
+		{"ETH:1:Exch:0x00", ErrNotCustomTx, -1, ""},
+		{"UTG:1:Unknown:0x00", ErrUnknownCategory, -1, ""},
+		{"UTG:1:Exch:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", ErrMissingField, 4, "amount"},
+		{"UTG:1:Exch:xyz:0x10", nil, 3, "target"},
+		{"UTG:1:Unbind:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:abc", nil, 4, "type"},
+		{"UTG:1:PoSwtfd:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:XX:0x00", ErrInvalidValue, 4, "targettype"},
+		{"UTG:1:sprvebind:0x3210000000000000000000000000000000000000000000000000000000000000:maybe", ErrInvalidValue, 4, "bindtype"},
+		{"ufo:1:event:declare:hash:0x3210000000000000000000000000000000000000000000000000000000000000:decision:perhaps", ErrInvalidValue, 7, "decision"},
+		{"SSC:1:ExchRate:99999999999", nil, 3, "exchrate"},
+		{"UTG:1:Multi:1:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0x3a1c7b6e9d2f4e5a8b0c1d2e3f405162738495a6", ErrInvalidValue, 3, "threshold"},
+		{"UTG:1:Multi:2:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9:0x3a1c7b6e9d2f4e5a8b0c1d2e3f405162738495a6:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", ErrInvalidValue, 3, "threshold"},
+	}
+	for i, tt := range tests {
+		_, err := Parse([]byte(tt.data))
+		if err == nil {
+			t.Errorf("test %d: parse %q succeeded, want error", i, tt.data)
+			continue
+		}
+		if tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		var ferr *FieldError
+		if tt.index < 0 {
+			if errors.As(err, &ferr) {
+				t.Errorf("test %d: unexpected field error %v", i, err)
+			}
+			continue
+		}
+		if !errors.As(err, &ferr) {
+			t.Errorf("test %d: have %v, want field error", i, err)
+			continue
+		}
+		if ferr.Index != tt.index || ferr.Field != tt.field {
+			t.Errorf("test %d: field error at %d (%s), want %d (%s)", i, ferr.Index, ferr.Field, tt.index, tt.field)
+		}
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	seen := make(map[Header]bool)
+	for _, h := range Registered() {
+		if seen[h] {
+			t.Errorf("duplicate header %s", h)
+		}
+		seen[h] = true
+	}
+	for _, h := range []Header{headerVote, headerSCConfirm, headerExch, headerStorageDeclare, headerSPApply, headerManager, sscHeader(CategoryFlwLock)} {
+		if !seen[h] {
+			t.Errorf("header %s not registered", h)
+		}
+	}
+}
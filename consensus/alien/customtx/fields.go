@@ -0,0 +1,269 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/shopspring/decimal"
+)
+
+// fieldReader walks the fields of a payload after its header. The first error
+// is kept and all later reads become no-ops, so decoders can read every field
+// and check the error once at the end.
+type fieldReader struct {
+	header Header
+	fields []string
+	pos    int
+	err    error
+}
+
+func newFieldReader(h Header, fields []string) *fieldReader {
+	return &fieldReader{header: h, fields: fields, pos: h.fieldCount()}
+}
+
+// more reports whether there are unread fields left.
+func (r *fieldReader) more() bool {
+	return r.err == nil && r.pos < len(r.fields)
+}
+
+func (r *fieldReader) fail(index int, name string, value string, err error) {
+	if r.err == nil {
+		r.err = &FieldError{Header: r.header, Index: index, Field: name, Value: value, Err: err}
+	}
+}
+
+// next returns the raw value of the next field.
+func (r *fieldReader) next(name string) (string, int, bool) {
+	if r.err != nil {
+		return "", r.pos, false
+	}
+	if r.pos >= len(r.fields) {
+		r.fail(r.pos, name, "", ErrMissingField)
+		return "", r.pos, false
+	}
+	r.pos++
+	return r.fields[r.pos-1], r.pos - 1, true
+}
+
+// str reads a field verbatim.
+func (r *fieldReader) str(name string) string {
+	v, _, _ := r.next(name)
+	return v
+}
+
+// oneOf reads a field that must be one of the allowed values.
+func (r *fieldReader) oneOf(name string, allowed ...string) string {
+	v, i, ok := r.next(name)
+	if !ok {
+		return ""
+	}
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+	r.fail(i, name, v, ErrInvalidValue)
+	return ""
+}
+
+// address reads a strictly hex encoded address.
+func (r *fieldReader) address(name string) common.Address {
+	var addr common.Address
+	if v, i, ok := r.next(name); ok {
+		if err := addr.UnmarshalText1([]byte(v)); err != nil {
+			r.fail(i, name, v, err)
+		}
+	}
+	return addr
+}
+
+// optAddress reads an address that may be left empty, an empty field yields the zero address.
+func (r *fieldReader) optAddress(name string) common.Address {
+	if r.err == nil && r.pos < len(r.fields) && len(r.fields[r.pos]) == 0 {
+		r.pos++
+		return common.Address{}
+	}
+	return r.address(name)
+}
+
+// looseAddress reads an address the lenient way (common.HexToAddress), it never fails on content.
+func (r *fieldReader) looseAddress(name string) common.Address {
+	v, _, _ := r.next(name)
+	return common.HexToAddress(v)
+}
+
+// hash reads a strictly hex encoded hash. An empty field yields the zero hash.
+func (r *fieldReader) hash(name string) common.Hash {
+	var hash common.Hash
+	if v, i, ok := r.next(name); ok {
+		if err := hash.UnmarshalText1([]byte(v)); err != nil {
+			r.fail(i, name, v, err)
+		}
+	}
+	return hash
+}
+
+// optHash reads a hex encoded hash that may be malformed, a field that does not
+// decode yields the zero hash.
+func (r *fieldReader) optHash(name string) common.Hash {
+	var hash common.Hash
+	if v, _, ok := r.next(name); ok {
+		hash.UnmarshalText([]byte(v))
+	}
+	return hash
+}
+
+// looseHash reads a hash the lenient way (common.HexToHash), it never fails on content.
+func (r *fieldReader) looseHash(name string) common.Hash {
+	v, _, _ := r.next(name)
+	return common.HexToHash(v)
+}
+
+// decimal reads a base 10 number, the fractional part is truncated.
+func (r *fieldReader) decimal(name string) *big.Int {
+	v, i, ok := r.next(name)
+	if !ok {
+		return nil
+	}
+	d, err := decimal.NewFromString(v)
+	if err != nil {
+		r.fail(i, name, v, err)
+		return nil
+	}
+	return d.BigInt()
+}
+
+// hexBig reads a hex number with optional 0x prefix.
+func (r *fieldReader) hexBig(name string) *big.Int {
+	v, i, ok := r.next(name)
+	if !ok {
+		return nil
+	}
+	n, err := hexutil.UnmarshalText1([]byte(v))
+	if err != nil {
+		r.fail(i, name, v, err)
+		return nil
+	}
+	return n
+}
+
+// bigText reads a number in big.Int text format (decimal or 0x prefixed hex).
+func (r *fieldReader) bigText(name string) *big.Int {
+	v, i, ok := r.next(name)
+	if !ok {
+		return nil
+	}
+	n := new(big.Int)
+	if err := n.UnmarshalText([]byte(v)); err != nil {
+		r.fail(i, name, v, err)
+		return nil
+	}
+	return n
+}
+
+// uint reads an unsigned integer of the given base and bit size.
+func (r *fieldReader) uint(name string, base int, bitSize int) uint64 {
+	v, i, ok := r.next(name)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(v, base, bitSize)
+	if err != nil {
+		r.fail(i, name, v, err)
+		return 0
+	}
+	return n
+}
+
+// int reads a base 10 integer.
+func (r *fieldReader) int(name string) int {
+	v, i, ok := r.next(name)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.fail(i, name, v, err)
+		return 0
+	}
+	return n
+}
+
+// fieldWriter builds a payload in wire format.
+type fieldWriter struct {
+	fields []string
+}
+
+func newFieldWriter(h Header) *fieldWriter {
+	w := &fieldWriter{fields: []string{h.Prefix, h.Version, h.Category}}
+	if h.Event != "" {
+		w.fields = append(w.fields, h.Event)
+	}
+	return w
+}
+
+func (w *fieldWriter) str(v string) *fieldWriter {
+	w.fields = append(w.fields, v)
+	return w
+}
+
+func (w *fieldWriter) address(a common.Address) *fieldWriter {
+	return w.str(a.Hex())
+}
+
+// optAddress writes the zero address as an empty field.
+func (w *fieldWriter) optAddress(a common.Address) *fieldWriter {
+	if a == (common.Address{}) {
+		return w.str("")
+	}
+	return w.address(a)
+}
+
+func (w *fieldWriter) hash(h common.Hash) *fieldWriter {
+	return w.str(h.Hex())
+}
+
+func (w *fieldWriter) decimal(n *big.Int) *fieldWriter {
+	if n == nil {
+		return w.str("0")
+	}
+	return w.str(n.String())
+}
+
+func (w *fieldWriter) hexBig(n *big.Int) *fieldWriter {
+	if n == nil {
+		n = new(big.Int)
+	}
+	return w.str(hexutil.EncodeBig(n))
+}
+
+func (w *fieldWriter) uint(n uint64, base int) *fieldWriter {
+	return w.str(strconv.FormatUint(n, base))
+}
+
+func (w *fieldWriter) int(n int) *fieldWriter {
+	return w.str(strconv.Itoa(n))
+}
+
+func (w *fieldWriter) bytes() []byte {
+	return []byte(strings.Join(w.fields, Separator))
+}
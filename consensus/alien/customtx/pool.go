@@ -0,0 +1,220 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+const (
+	CategorySPApply           = "addsp"
+	CategorySPAdjustPledge    = "spchpg"
+	CategorySPRemoveSN        = "spremovesn"
+	CategorySPEntrust         = "spwtpg"
+	CategorySPEntrustTransfer = "spwtfd"
+	CategorySPEntrustExit     = "spwtexit"
+	CategorySPExit            = "spexit"
+	CategorySPFee             = "spfee"
+	CategorySPEntrustRate     = "spetrate"
+	CategorySPRevenueBind     = "sprvebind"
+
+	SPBindTypeBind   = "bind"
+	SPBindTypeUnbind = "unbind"
+)
+
+var (
+	headerSPApply           = utgHeader(CategorySPApply)
+	headerSPAdjustPledge    = utgHeader(CategorySPAdjustPledge)
+	headerSPRemoveSN        = utgHeader(CategorySPRemoveSN)
+	headerSPEntrust         = utgHeader(CategorySPEntrust)
+	headerSPEntrustTransfer = utgHeader(CategorySPEntrustTransfer)
+	headerSPEntrustExit     = utgHeader(CategorySPEntrustExit)
+	headerSPExit            = utgHeader(CategorySPExit)
+	headerSPFee             = utgHeader(CategorySPFee)
+	headerSPEntrustRate     = utgHeader(CategorySPEntrustRate)
+	headerSPRevenueBind     = utgHeader(CategorySPRevenueBind)
+)
+
+func init() {
+	register(headerSPApply, func(r *fieldReader) Payload {
+		p := &SPApply{
+			PledgeAmount: r.decimal("amount"),
+			Fee:          r.int("fee"),
+			EntrustRate:  r.int("entrustrate"),
+		}
+		if r.more() {
+			p.Revenue = r.address("revenue")
+		}
+		return p
+	})
+	register(headerSPAdjustPledge, func(r *fieldReader) Payload {
+		return &SPAdjustPledge{PoolHash: r.hash("sphash"), Amount: r.decimal("amount")}
+	})
+	register(headerSPRemoveSN, func(r *fieldReader) Payload {
+		return &SPRemoveSN{PoolHash: r.hash("sphash"), Node: r.address("sn")}
+	})
+	register(headerSPEntrust, func(r *fieldReader) Payload {
+		return &SPEntrust{PoolHash: r.hash("sphash"), Amount: r.decimal("amount")}
+	})
+	register(headerSPEntrustTransfer, func(r *fieldReader) Payload {
+		p := &SPEntrustTransfer{PoolHash: r.hash("sphash")}
+		p.Transfer = readTransferTarget(r)
+		return p
+	})
+	register(headerSPEntrustExit, func(r *fieldReader) Payload {
+		return &SPEntrustExit{PoolHash: r.hash("sphash"), EntrustHash: r.hash("entrusthash")}
+	})
+	register(headerSPExit, func(r *fieldReader) Payload {
+		return &SPExit{PoolHash: r.hash("sphash")}
+	})
+	register(headerSPFee, func(r *fieldReader) Payload {
+		return &SPFee{PoolHash: r.hash("sphash"), Fee: r.int("fee")}
+	})
+	register(headerSPEntrustRate, func(r *fieldReader) Payload {
+		return &SPEntrustRate{PoolHash: r.hash("sphash"), EntrustRate: r.int("entrustrate")}
+	})
+	register(headerSPRevenueBind, func(r *fieldReader) Payload {
+		p := &SPRevenueBind{PoolHash: r.hash("sphash")}
+		p.Bind = r.oneOf("bindtype", SPBindTypeBind, SPBindTypeUnbind) == SPBindTypeBind
+		if p.Bind {
+			p.Revenue = r.address("revenue")
+		}
+		return p
+	})
+}
+
+// SPApply is "UTG:1:addsp:<amount>:<fee>:<entrustrate>[:<revenue>]".
+type SPApply struct {
+	PledgeAmount *big.Int
+	Fee          int
+	EntrustRate  int
+	Revenue      common.Address
+}
+
+func (p *SPApply) Header() Header { return headerSPApply }
+func (p *SPApply) Encode() []byte {
+	w := newFieldWriter(headerSPApply).decimal(p.PledgeAmount).int(p.Fee).int(p.EntrustRate)
+	if p.Revenue != (common.Address{}) {
+		w.address(p.Revenue)
+	}
+	return w.bytes()
+}
+
+// SPAdjustPledge is "UTG:1:spchpg:<sp hash>:<amount>".
+type SPAdjustPledge struct {
+	PoolHash common.Hash
+	Amount   *big.Int
+}
+
+func (p *SPAdjustPledge) Header() Header { return headerSPAdjustPledge }
+func (p *SPAdjustPledge) Encode() []byte {
+	return newFieldWriter(headerSPAdjustPledge).hash(p.PoolHash).decimal(p.Amount).bytes()
+}
+
+// SPRemoveSN is "UTG:1:spremovesn:<sp hash>:<sn address>".
+type SPRemoveSN struct {
+	PoolHash common.Hash
+	Node     common.Address
+}
+
+func (p *SPRemoveSN) Header() Header { return headerSPRemoveSN }
+func (p *SPRemoveSN) Encode() []byte {
+	return newFieldWriter(headerSPRemoveSN).hash(p.PoolHash).address(p.Node).bytes()
+}
+
+// SPEntrust is "UTG:1:spwtpg:<sp hash>:<amount>".
+type SPEntrust struct {
+	PoolHash common.Hash
+	Amount   *big.Int
+}
+
+func (p *SPEntrust) Header() Header { return headerSPEntrust }
+func (p *SPEntrust) Encode() []byte {
+	return newFieldWriter(headerSPEntrust).hash(p.PoolHash).decimal(p.Amount).bytes()
+}
+
+// SPEntrustTransfer is "UTG:1:spwtfd:<sp hash>:<PoS|SN|SP>:<target>".
+type SPEntrustTransfer struct {
+	PoolHash common.Hash
+	Transfer TransferTarget
+}
+
+func (p *SPEntrustTransfer) Header() Header { return headerSPEntrustTransfer }
+func (p *SPEntrustTransfer) Encode() []byte {
+	return p.Transfer.write(newFieldWriter(headerSPEntrustTransfer).hash(p.PoolHash)).bytes()
+}
+
+// SPEntrustExit is "UTG:1:spwtexit:<sp hash>:<entrust hash>".
+type SPEntrustExit struct {
+	PoolHash    common.Hash
+	EntrustHash common.Hash
+}
+
+func (p *SPEntrustExit) Header() Header { return headerSPEntrustExit }
+func (p *SPEntrustExit) Encode() []byte {
+	return newFieldWriter(headerSPEntrustExit).hash(p.PoolHash).hash(p.EntrustHash).bytes()
+}
+
+// SPExit is "UTG:1:spexit:<sp hash>".
+type SPExit struct {
+	PoolHash common.Hash
+}
+
+func (p *SPExit) Header() Header { return headerSPExit }
+func (p *SPExit) Encode() []byte {
+	return newFieldWriter(headerSPExit).hash(p.PoolHash).bytes()
+}
+
+// SPFee is "UTG:1:spfee:<sp hash>:<fee>".
+type SPFee struct {
+	PoolHash common.Hash
+	Fee      int
+}
+
+func (p *SPFee) Header() Header { return headerSPFee }
+func (p *SPFee) Encode() []byte {
+	return newFieldWriter(headerSPFee).hash(p.PoolHash).int(p.Fee).bytes()
+}
+
+// SPEntrustRate is "UTG:1:spetrate:<sp hash>:<entrustrate>".
+type SPEntrustRate struct {
+	PoolHash    common.Hash
+	EntrustRate int
+}
+
+func (p *SPEntrustRate) Header() Header { return headerSPEntrustRate }
+func (p *SPEntrustRate) Encode() []byte {
+	return newFieldWriter(headerSPEntrustRate).hash(p.PoolHash).int(p.EntrustRate).bytes()
+}
+
+// SPRevenueBind is "UTG:1:sprvebind:<sp hash>:bind:<revenue>" or "UTG:1:sprvebind:<sp hash>:unbind".
+type SPRevenueBind struct {
+	PoolHash common.Hash
+	Bind     bool
+	Revenue  common.Address
+}
+
+func (p *SPRevenueBind) Header() Header { return headerSPRevenueBind }
+func (p *SPRevenueBind) Encode() []byte {
+	w := newFieldWriter(headerSPRevenueBind).hash(p.PoolHash)
+	if p.Bind {
+		return w.str(SPBindTypeBind).address(p.Revenue).bytes()
+	}
+	return w.str(SPBindTypeUnbind).bytes()
+}
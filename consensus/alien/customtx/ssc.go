@@ -0,0 +1,158 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+const (
+	CategoryExchRate = "ExchRate"
+	CategoryDeposit  = "Deposit"
+	CategoryCndLock  = "CndLock"
+	CategoryFlwLock  = "FlwLock"
+	CategoryRwdLock  = "RwdLock"
+	CategoryOffLine  = "OffLine"
+	CategoryQOS      = "QOS"
+	CategoryWdthPnsh = "WdthPnsh"
+	CategoryManager  = "Manager"
+)
+
+func sscHeader(category string) Header {
+	return Header{Prefix: PrefixSSC, Version: Version1, Category: category}
+}
+
+var (
+	headerExchRate = sscHeader(CategoryExchRate)
+	headerDeposit  = sscHeader(CategoryDeposit)
+	headerOffLine  = sscHeader(CategoryOffLine)
+	headerQOS      = sscHeader(CategoryQOS)
+	headerWdthPnsh = sscHeader(CategoryWdthPnsh)
+	headerManager  = sscHeader(CategoryManager)
+)
+
+func init() {
+	register(headerExchRate, func(r *fieldReader) Payload {
+		return &ExchRate{Rate: uint32(r.uint("exchrate", 10, 32))}
+	})
+	register(headerDeposit, func(r *fieldReader) Payload {
+		return &Deposit{Amount: r.hexBig("deposit"), Who: uint32(r.uint("who", 10, 32))}
+	})
+	for _, category := range []string{CategoryCndLock, CategoryFlwLock, CategoryRwdLock} {
+		category := category
+		register(sscHeader(category), func(r *fieldReader) Payload {
+			return &LockConfig{
+				Kind:       category,
+				LockPeriod: uint32(r.uint("lockperiod", 16, 32)),
+				RlsPeriod:  uint32(r.uint("rlsperiod", 16, 32)),
+				Interval:   uint32(r.uint("interval", 16, 32)),
+			}
+		})
+	}
+	register(headerOffLine, func(r *fieldReader) Payload {
+		return &OffLine{Value: uint32(r.uint("offline", 10, 32))}
+	})
+	register(headerQOS, func(r *fieldReader) Payload {
+		return &QOS{ISPID: uint32(r.uint("ispid", 10, 32)), QOS: uint32(r.uint("qos", 10, 32))}
+	})
+	register(headerWdthPnsh, func(r *fieldReader) Payload {
+		return &WdthPnsh{Target: r.address("target"), Punish: uint32(r.uint("punish", 16, 32))}
+	})
+	register(headerManager, func(r *fieldReader) Payload {
+		return &Manager{Who: uint32(r.uint("who", 10, 32)), Address: r.address("address")}
+	})
+}
+
+// ExchRate is "SSC:1:ExchRate:<rate>".
+type ExchRate struct {
+	Rate uint32
+}
+
+func (p *ExchRate) Header() Header { return headerExchRate }
+func (p *ExchRate) Encode() []byte {
+	return newFieldWriter(headerExchRate).uint(uint64(p.Rate), 10).bytes()
+}
+
+// Deposit is "SSC:1:Deposit:<hex amount>:<who>".
+type Deposit struct {
+	Amount *big.Int
+	Who    uint32
+}
+
+func (p *Deposit) Header() Header { return headerDeposit }
+func (p *Deposit) Encode() []byte {
+	return newFieldWriter(headerDeposit).hexBig(p.Amount).uint(uint64(p.Who), 10).bytes()
+}
+
+// LockConfig is "SSC:1:<CndLock|FlwLock|RwdLock>:<hex lock>:<hex release>:<hex interval>".
+type LockConfig struct {
+	Kind       string // one of CategoryCndLock, CategoryFlwLock, CategoryRwdLock
+	LockPeriod uint32
+	RlsPeriod  uint32
+	Interval   uint32
+}
+
+func (p *LockConfig) Header() Header { return sscHeader(p.Kind) }
+func (p *LockConfig) Encode() []byte {
+	return newFieldWriter(p.Header()).uint(uint64(p.LockPeriod), 16).uint(uint64(p.RlsPeriod), 16).
+		uint(uint64(p.Interval), 16).bytes()
+}
+
+// OffLine is "SSC:1:OffLine:<value>".
+type OffLine struct {
+	Value uint32
+}
+
+func (p *OffLine) Header() Header { return headerOffLine }
+func (p *OffLine) Encode() []byte {
+	return newFieldWriter(headerOffLine).uint(uint64(p.Value), 10).bytes()
+}
+
+// QOS is "SSC:1:QOS:<isp id>:<qos>".
+type QOS struct {
+	ISPID uint32
+	QOS   uint32
+}
+
+func (p *QOS) Header() Header { return headerQOS }
+func (p *QOS) Encode() []byte {
+	return newFieldWriter(headerQOS).uint(uint64(p.ISPID), 10).uint(uint64(p.QOS), 10).bytes()
+}
+
+// WdthPnsh is "SSC:1:WdthPnsh:<target>:<hex punish>".
+type WdthPnsh struct {
+	Target common.Address
+	Punish uint32
+}
+
+func (p *WdthPnsh) Header() Header { return headerWdthPnsh }
+func (p *WdthPnsh) Encode() []byte {
+	return newFieldWriter(headerWdthPnsh).address(p.Target).uint(uint64(p.Punish), 16).bytes()
+}
+
+// Manager is "SSC:1:Manager:<who>:<address>".
+type Manager struct {
+	Who     uint32
+	Address common.Address
+}
+
+func (p *Manager) Header() Header { return headerManager }
+func (p *Manager) Encode() []byte {
+	return newFieldWriter(headerManager).uint(uint64(p.Who), 10).address(p.Address).bytes()
+}
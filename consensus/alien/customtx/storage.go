@@ -0,0 +1,485 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+const (
+	CategoryStorageDeclare     = "stReq"
+	CategoryStorageExit        = "stExit"
+	CategoryRentRequest        = "stRent"
+	CategoryRentPledge         = "stRentPg"
+	CategoryRentRenewal        = "stReNew"
+	CategoryRentRenewalPledge  = "stReNewPg"
+	CategoryRentRescind        = "stRescind"
+	CategoryStorageRecover     = "stReValid"
+	CategoryStorageProof       = "stProof"
+	CategoryStoragePrice       = "chPrice"
+	CategoryStorageBandwidth   = "chbw"
+	CategoryStorageCatchUp     = "stCatchUp"
+	CategoryStorageManager     = "editmgaddr"
+	CategoryStorageComplete    = "stchpg"
+	CategoryStorageRewardRatio = "stwtreward"
+	CategoryStorageSetPool     = "setsp"
+	CategoryStorageExitPool    = "exitsp"
+	CategoryStorageMigration   = "streplace"
+	CategoryStorageEntrust     = "stwtpg"
+	CategoryStorageTransfer    = "wtfd"
+	CategoryStorageEntrustExit = "wtpgexit"
+
+	// leaseHashMinLen is the length a storage proof lease hash field must exceed,
+	// shorter values mean the proof is for the pledged space itself.
+	leaseHashMinLen = 10
+)
+
+var (
+	headerStorageDeclare     = utgHeader(CategoryStorageDeclare)
+	headerStorageExit        = utgHeader(CategoryStorageExit)
+	headerRentRequest        = utgHeader(CategoryRentRequest)
+	headerRentPledge         = utgHeader(CategoryRentPledge)
+	headerRentRenewal        = utgHeader(CategoryRentRenewal)
+	headerRentRenewalPledge  = utgHeader(CategoryRentRenewalPledge)
+	headerRentRescind        = utgHeader(CategoryRentRescind)
+	headerStorageRecover     = utgHeader(CategoryStorageRecover)
+	headerStorageProof       = utgHeader(CategoryStorageProof)
+	headerStoragePrice       = utgHeader(CategoryStoragePrice)
+	headerStorageBandwidth   = utgHeader(CategoryStorageBandwidth)
+	headerStorageCatchUp     = utgHeader(CategoryStorageCatchUp)
+	headerStorageManager     = utgHeader(CategoryStorageManager)
+	headerStorageComplete    = utgHeader(CategoryStorageComplete)
+	headerStorageRewardRatio = utgHeader(CategoryStorageRewardRatio)
+	headerStorageSetPool     = utgHeader(CategoryStorageSetPool)
+	headerStorageExitPool    = utgHeader(CategoryStorageExitPool)
+	headerStorageMigration   = utgHeader(CategoryStorageMigration)
+	headerStorageEntrust     = utgHeader(CategoryStorageEntrust)
+	headerStorageTransfer    = utgHeader(CategoryStorageTransfer)
+	headerStorageEntrustExit = utgHeader(CategoryStorageEntrustExit)
+)
+
+func init() {
+	register(headerStorageDeclare, func(r *fieldReader) Payload {
+		p := &StorageDeclare{
+			Pledge:   r.looseAddress("pledge"),
+			Price:    r.decimal("price"),
+			Capacity: r.decimal("capacity"),
+			Proof:    readPackageProof(r),
+		}
+		p.Bandwidth = r.decimal("bandwidth")
+		if r.more() {
+			p.PledgeRate = r.decimal("pledgerate")
+			p.EntrustRate = r.decimal("entrustrate")
+		}
+		return p
+	})
+	register(headerStorageExit, func(r *fieldReader) Payload {
+		return &StorageExit{Pledge: r.looseAddress("pledge")}
+	})
+	register(headerRentRequest, func(r *fieldReader) Payload {
+		return &RentRequest{
+			Pledge:   r.address("pledge"),
+			Capacity: r.decimal("capacity"),
+			Duration: r.uint("duration", 10, 64),
+			Price:    r.decimal("price"),
+		}
+	})
+	register(headerRentPledge, func(r *fieldReader) Payload {
+		return &RentPledge{
+			Pledge:       r.address("pledge"),
+			LeaseHash:    r.looseHash("leasehash"),
+			Capacity:     r.decimal("capacity"),
+			Proof:        r.str("proof"),
+			LeftCapacity: r.decimal("leftcapacity"),
+			LeftProof:    r.str("leftproof"),
+		}
+	})
+	register(headerRentRenewal, func(r *fieldReader) Payload {
+		return &RentRenewal{
+			Pledge:    r.address("pledge"),
+			LeaseHash: r.looseHash("leasehash"),
+			Duration:  r.uint("duration", 10, 32),
+		}
+	})
+	register(headerRentRenewalPledge, func(r *fieldReader) Payload {
+		return &RentRenewalPledge{
+			Pledge:    r.address("pledge"),
+			LeaseHash: r.looseHash("leasehash"),
+			Capacity:  r.decimal("capacity"),
+			Proof:     r.str("proof"),
+		}
+	})
+	register(headerRentRescind, func(r *fieldReader) Payload {
+		return &RentRescind{Pledge: r.address("pledge"), LeaseHash: r.looseHash("leasehash")}
+	})
+	register(headerStorageRecover, func(r *fieldReader) Payload {
+		p := &StorageRecover{Pledge: r.looseAddress("pledge")}
+		if leases := r.str("leasehashes"); leases != "" {
+			for _, hash := range strings.Split(leases, ",") {
+				p.LeaseHashes = append(p.LeaseHashes, common.HexToHash(hash))
+			}
+		}
+		p.Proof = r.str("proof")
+		return p
+	})
+	register(headerStorageProof, func(r *fieldReader) Payload {
+		p := &StorageProof{Pledge: r.looseAddress("pledge")}
+		if lease := r.str("leasehash"); len(lease) > leaseHashMinLen {
+			p.LeaseHash = common.HexToHash(lease)
+		}
+		p.Capacity = r.str("capacity")
+		p.Proof = r.str("proof")
+		return p
+	})
+	register(headerStoragePrice, func(r *fieldReader) Payload {
+		return &StoragePrice{Pledge: r.looseAddress("pledge"), Price: r.decimal("price")}
+	})
+	register(headerStorageBandwidth, func(r *fieldReader) Payload {
+		return &StorageBandwidth{Pledge: r.looseAddress("pledge"), Bandwidth: r.decimal("bandwidth")}
+	})
+	register(headerStorageCatchUp, func(r *fieldReader) Payload {
+		return &StorageCatchUp{Pledge: r.looseAddress("pledge")}
+	})
+	register(headerStorageManager, func(r *fieldReader) Payload {
+		return &StorageManager{Pledge: r.looseAddress("pledge"), Manager: r.looseAddress("manager")}
+	})
+	register(headerStorageComplete, func(r *fieldReader) Payload {
+		return &StorageComplete{Pledge: r.address("pledge"), Amount: r.decimal("amount")}
+	})
+	register(headerStorageRewardRatio, func(r *fieldReader) Payload {
+		return &StorageRewardRatio{Pledge: r.address("pledge"), Rate: r.decimal("rate")}
+	})
+	register(headerStorageSetPool, func(r *fieldReader) Payload {
+		return &StorageSetPool{Pledge: r.address("pledge"), PoolHash: r.looseHash("sphash")}
+	})
+	register(headerStorageExitPool, func(r *fieldReader) Payload {
+		return &StorageExitPool{Pledge: r.address("pledge")}
+	})
+	register(headerStorageMigration, func(r *fieldReader) Payload {
+		return &StorageMigration{
+			Pledge:   r.looseAddress("pledge"),
+			Capacity: r.decimal("capacity"),
+			Proof:    readPackageProof(r),
+		}
+	})
+	register(headerStorageEntrust, func(r *fieldReader) Payload {
+		return &StorageEntrust{Target: r.address("target"), Amount: r.decimal("amount")}
+	})
+	register(headerStorageTransfer, func(r *fieldReader) Payload {
+		p := &StorageTransfer{Original: r.address("original")}
+		p.Transfer = readTransferTarget(r)
+		return p
+	})
+	register(headerStorageEntrustExit, func(r *fieldReader) Payload {
+		return &StorageEntrustExit{Target: r.address("target"), Hash: r.looseHash("hash")}
+	})
+}
+
+// PackageProof is the proof of capacity sent when declaring or migrating a
+// storage pledge: the package start number, nonce and block hash the capacity
+// file was generated from, and the comma separated proof string.
+type PackageProof struct {
+	StartPkNumber string
+	PkNonce       *big.Int
+	PkBlockHash   string
+	VerifyData    string
+}
+
+func readPackageProof(r *fieldReader) PackageProof {
+	return PackageProof{
+		StartPkNumber: r.str("startpknumber"),
+		PkNonce:       r.decimal("pknonce"),
+		PkBlockHash:   r.str("pkblockhash"),
+		VerifyData:    r.str("verifydata"),
+	}
+}
+
+func (pp PackageProof) write(w *fieldWriter) *fieldWriter {
+	return w.str(pp.StartPkNumber).decimal(pp.PkNonce).str(pp.PkBlockHash).str(pp.VerifyData)
+}
+
+// StorageDeclare is "UTG:1:stReq:<pledge>:<price>:<capacity>:<startpk>:<nonce>:<pkhash>:<proof>:<bandwidth>[:<pledgerate>:<entrustrate>]".
+// The pledge and entrust rates are required once storage pools are active.
+type StorageDeclare struct {
+	Pledge      common.Address
+	Price       *big.Int
+	Capacity    *big.Int
+	Proof       PackageProof
+	Bandwidth   *big.Int
+	PledgeRate  *big.Int
+	EntrustRate *big.Int
+}
+
+func (p *StorageDeclare) Header() Header { return headerStorageDeclare }
+func (p *StorageDeclare) Encode() []byte {
+	w := newFieldWriter(headerStorageDeclare).address(p.Pledge).decimal(p.Price).decimal(p.Capacity)
+	p.Proof.write(w).decimal(p.Bandwidth)
+	if p.PledgeRate != nil || p.EntrustRate != nil {
+		w.decimal(p.PledgeRate).decimal(p.EntrustRate)
+	}
+	return w.bytes()
+}
+
+// StorageExit is "UTG:1:stExit:<pledge>".
+type StorageExit struct {
+	Pledge common.Address
+}
+
+func (p *StorageExit) Header() Header { return headerStorageExit }
+func (p *StorageExit) Encode() []byte {
+	return newFieldWriter(headerStorageExit).address(p.Pledge).bytes()
+}
+
+// RentRequest is "UTG:1:stRent:<pledge>:<capacity>:<days>:<price>".
+type RentRequest struct {
+	Pledge   common.Address
+	Capacity *big.Int
+	Duration uint64
+	Price    *big.Int
+}
+
+func (p *RentRequest) Header() Header { return headerRentRequest }
+func (p *RentRequest) Encode() []byte {
+	return newFieldWriter(headerRentRequest).address(p.Pledge).decimal(p.Capacity).
+		uint(p.Duration, 10).decimal(p.Price).bytes()
+}
+
+// RentPledge is "UTG:1:stRentPg:<pledge>:<lease>:<capacity>:<proof>:<leftcapacity>:<leftproof>".
+// The left proof is only checked if the left capacity is not zero.
+type RentPledge struct {
+	Pledge       common.Address
+	LeaseHash    common.Hash
+	Capacity     *big.Int
+	Proof        string
+	LeftCapacity *big.Int
+	LeftProof    string
+}
+
+func (p *RentPledge) Header() Header { return headerRentPledge }
+func (p *RentPledge) Encode() []byte {
+	return newFieldWriter(headerRentPledge).address(p.Pledge).hash(p.LeaseHash).decimal(p.Capacity).
+		str(p.Proof).decimal(p.LeftCapacity).str(p.LeftProof).bytes()
+}
+
+// RentRenewal is "UTG:1:stReNew:<pledge>:<lease>:<days>".
+type RentRenewal struct {
+	Pledge    common.Address
+	LeaseHash common.Hash
+	Duration  uint64
+}
+
+func (p *RentRenewal) Header() Header { return headerRentRenewal }
+func (p *RentRenewal) Encode() []byte {
+	return newFieldWriter(headerRentRenewal).address(p.Pledge).hash(p.LeaseHash).uint(p.Duration, 10).bytes()
+}
+
+// RentRenewalPledge is "UTG:1:stReNewPg:<pledge>:<lease>:<capacity>:<proof>".
+type RentRenewalPledge struct {
+	Pledge    common.Address
+	LeaseHash common.Hash
+	Capacity  *big.Int
+	Proof     string
+}
+
+func (p *RentRenewalPledge) Header() Header { return headerRentRenewalPledge }
+func (p *RentRenewalPledge) Encode() []byte {
+	return newFieldWriter(headerRentRenewalPledge).address(p.Pledge).hash(p.LeaseHash).
+		decimal(p.Capacity).str(p.Proof).bytes()
+}
+
+// RentRescind is "UTG:1:stRescind:<pledge>:<lease>".
+type RentRescind struct {
+	Pledge    common.Address
+	LeaseHash common.Hash
+}
+
+func (p *RentRescind) Header() Header { return headerRentRescind }
+func (p *RentRescind) Encode() []byte {
+	return newFieldWriter(headerRentRescind).address(p.Pledge).hash(p.LeaseHash).bytes()
+}
+
+// StorageRecover is "UTG:1:stReValid:<pledge>:<lease,lease,...>:<proof>".
+type StorageRecover struct {
+	Pledge      common.Address
+	LeaseHashes []common.Hash
+	Proof       string
+}
+
+func (p *StorageRecover) Header() Header { return headerStorageRecover }
+func (p *StorageRecover) Encode() []byte {
+	leases := make([]string, len(p.LeaseHashes))
+	for i, hash := range p.LeaseHashes {
+		leases[i] = hash.Hex()
+	}
+	return newFieldWriter(headerStorageRecover).address(p.Pledge).str(strings.Join(leases, ",")).
+		str(p.Proof).bytes()
+}
+
+// StorageProof is "UTG:1:stProof:<pledge>:<lease>:<capacity>:<proof>". A zero
+// lease hash proves the unleased space of the pledge. The capacity is only
+// read by blocks before the pledge revert lock fork.
+type StorageProof struct {
+	Pledge    common.Address
+	LeaseHash common.Hash
+	Capacity  string
+	Proof     string
+}
+
+func (p *StorageProof) Header() Header { return headerStorageProof }
+func (p *StorageProof) Encode() []byte {
+	w := newFieldWriter(headerStorageProof).address(p.Pledge)
+	if p.LeaseHash == (common.Hash{}) {
+		w.str("0")
+	} else {
+		w.hash(p.LeaseHash)
+	}
+	return w.str(p.Capacity).str(p.Proof).bytes()
+}
+
+// StoragePrice is "UTG:1:chPrice:<pledge>:<price>".
+type StoragePrice struct {
+	Pledge common.Address
+	Price  *big.Int
+}
+
+func (p *StoragePrice) Header() Header { return headerStoragePrice }
+func (p *StoragePrice) Encode() []byte {
+	return newFieldWriter(headerStoragePrice).address(p.Pledge).decimal(p.Price).bytes()
+}
+
+// StorageBandwidth is "UTG:1:chbw:<pledge>:<bandwidth>".
+type StorageBandwidth struct {
+	Pledge    common.Address
+	Bandwidth *big.Int
+}
+
+func (p *StorageBandwidth) Header() Header { return headerStorageBandwidth }
+func (p *StorageBandwidth) Encode() []byte {
+	return newFieldWriter(headerStorageBandwidth).address(p.Pledge).decimal(p.Bandwidth).bytes()
+}
+
+// StorageCatchUp is "UTG:1:stCatchUp:<pledge>".
+type StorageCatchUp struct {
+	Pledge common.Address
+}
+
+func (p *StorageCatchUp) Header() Header { return headerStorageCatchUp }
+func (p *StorageCatchUp) Encode() []byte {
+	return newFieldWriter(headerStorageCatchUp).address(p.Pledge).bytes()
+}
+
+// StorageManager is "UTG:1:editmgaddr:<pledge>:<manager>".
+type StorageManager struct {
+	Pledge  common.Address
+	Manager common.Address
+}
+
+func (p *StorageManager) Header() Header { return headerStorageManager }
+func (p *StorageManager) Encode() []byte {
+	return newFieldWriter(headerStorageManager).address(p.Pledge).address(p.Manager).bytes()
+}
+
+// StorageComplete is "UTG:1:stchpg:<pledge>:<amount>".
+type StorageComplete struct {
+	Pledge common.Address
+	Amount *big.Int
+}
+
+func (p *StorageComplete) Header() Header { return headerStorageComplete }
+func (p *StorageComplete) Encode() []byte {
+	return newFieldWriter(headerStorageComplete).address(p.Pledge).decimal(p.Amount).bytes()
+}
+
+// StorageRewardRatio is "UTG:1:stwtreward:<pledge>:<rate>".
+type StorageRewardRatio struct {
+	Pledge common.Address
+	Rate   *big.Int
+}
+
+func (p *StorageRewardRatio) Header() Header { return headerStorageRewardRatio }
+func (p *StorageRewardRatio) Encode() []byte {
+	return newFieldWriter(headerStorageRewardRatio).address(p.Pledge).decimal(p.Rate).bytes()
+}
+
+// StorageSetPool is "UTG:1:setsp:<pledge>:<sp hash>".
+type StorageSetPool struct {
+	Pledge   common.Address
+	PoolHash common.Hash
+}
+
+func (p *StorageSetPool) Header() Header { return headerStorageSetPool }
+func (p *StorageSetPool) Encode() []byte {
+	return newFieldWriter(headerStorageSetPool).address(p.Pledge).hash(p.PoolHash).bytes()
+}
+
+// StorageExitPool is "UTG:1:exitsp:<pledge>".
+type StorageExitPool struct {
+	Pledge common.Address
+}
+
+func (p *StorageExitPool) Header() Header { return headerStorageExitPool }
+func (p *StorageExitPool) Encode() []byte {
+	return newFieldWriter(headerStorageExitPool).address(p.Pledge).bytes()
+}
+
+// StorageMigration is "UTG:1:streplace:<pledge>:<capacity>:<startpk>:<nonce>:<pkhash>:<proof>".
+type StorageMigration struct {
+	Pledge   common.Address
+	Capacity *big.Int
+	Proof    PackageProof
+}
+
+func (p *StorageMigration) Header() Header { return headerStorageMigration }
+func (p *StorageMigration) Encode() []byte {
+	return p.Proof.write(newFieldWriter(headerStorageMigration).address(p.Pledge).decimal(p.Capacity)).bytes()
+}
+
+// StorageEntrust is "UTG:1:stwtpg:<target>:<amount>".
+type StorageEntrust struct {
+	Target common.Address
+	Amount *big.Int
+}
+
+func (p *StorageEntrust) Header() Header { return headerStorageEntrust }
+func (p *StorageEntrust) Encode() []byte {
+	return newFieldWriter(headerStorageEntrust).address(p.Target).decimal(p.Amount).bytes()
+}
+
+// StorageTransfer is "UTG:1:wtfd:<original>:<PoS|SN|SP>:<target>".
+type StorageTransfer struct {
+	Original common.Address
+	Transfer TransferTarget
+}
+
+func (p *StorageTransfer) Header() Header { return headerStorageTransfer }
+func (p *StorageTransfer) Encode() []byte {
+	return p.Transfer.write(newFieldWriter(headerStorageTransfer).address(p.Original)).bytes()
+}
+
+// StorageEntrustExit is "UTG:1:wtpgexit:<target>:<entrust hash>".
+type StorageEntrustExit struct {
+	Target common.Address
+	Hash   common.Hash
+}
+
+func (p *StorageEntrustExit) Header() Header { return headerStorageEntrustExit }
+func (p *StorageEntrustExit) Encode() []byte {
+	return newFieldWriter(headerStorageEntrustExit).address(p.Target).hash(p.Hash).bytes()
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+const (
+	CategoryEvent = "event"
+	CategorySC    = "sc"
+
+	EventVote          = "vote"
+	EventConfirm       = "confirm"
+	EventProposal      = "proposal"
+	EventDeclare       = "declare"
+	EventSetCoinbase   = "setcb"
+	EventDelCoinbase   = "delcb"
+	EventFlowReport    = "flwrpt"
+	EventFlowReportMgr = "flwrptm"
+)
+
+var (
+	headerVote          = Header{PrefixUFO, Version1, CategoryEvent, EventVote}
+	headerConfirm       = Header{PrefixUFO, Version1, CategoryEvent, EventConfirm}
+	headerProposal      = Header{PrefixUFO, Version1, CategoryEvent, EventProposal}
+	headerDeclare       = Header{PrefixUFO, Version1, CategoryEvent, EventDeclare}
+	headerSCConfirm     = Header{PrefixUFO, Version1, CategorySC, EventConfirm}
+	headerSetCoinbase   = Header{PrefixUFO, Version1, CategorySC, EventSetCoinbase}
+	headerDelCoinbase   = Header{PrefixUFO, Version1, CategorySC, EventDelCoinbase}
+	headerFlowReport    = Header{PrefixUFO, Version1, CategorySC, EventFlowReport}
+	headerFlowReportMgr = Header{PrefixUFO, Version1, CategorySC, EventFlowReportMgr}
+)
+
+func init() {
+	register(headerVote, func(r *fieldReader) Payload { return &Vote{} })
+	register(headerConfirm, func(r *fieldReader) Payload {
+		return &Confirm{BlockNumber: r.bigText("number")}
+	})
+	register(headerProposal, decodeProposal)
+	register(headerDeclare, decodeDeclare)
+	register(headerSCConfirm, func(r *fieldReader) Payload {
+		return &SCConfirm{
+			SCHash:       r.looseHash("schash"),
+			Number:       r.bigText("number"),
+			Time:         r.bigText("time"),
+			LoopInfo:     r.str("loopinfo"),
+			ChargingInfo: r.str("charginginfo"),
+		}
+	})
+	register(headerSetCoinbase, func(r *fieldReader) Payload {
+		return &SCSetCoinbase{SCHash: r.looseHash("schash")}
+	})
+	register(headerDelCoinbase, func(r *fieldReader) Payload {
+		return &SCDelCoinbase{SCHash: r.looseHash("schash")}
+	})
+	register(headerFlowReport, func(r *fieldReader) Payload {
+		return &FlowReport{Report: common.FromHex(r.str("report"))}
+	})
+	register(headerFlowReportMgr, func(r *fieldReader) Payload {
+		return &FlowReportManager{Report: common.Hex2Bytes(r.str("report"))}
+	})
+}
+
+// Vote is "ufo:1:event:vote", the candidate voted for is the tx recipient.
+type Vote struct{}
+
+func (p *Vote) Header() Header { return headerVote }
+func (p *Vote) Encode() []byte { return newFieldWriter(headerVote).bytes() }
+
+// Confirm is "ufo:1:event:confirm:<number>", sent by signers to confirm a block.
+type Confirm struct {
+	BlockNumber *big.Int
+}
+
+func (p *Confirm) Header() Header { return headerConfirm }
+func (p *Confirm) Encode() []byte {
+	return newFieldWriter(headerConfirm).decimal(p.BlockNumber).bytes()
+}
+
+// Proposal is "ufo:1:event:proposal:<key>:<value>:...". Zero valued fields are
+// not encoded, so the engine applies its defaults for them.
+type Proposal struct {
+	ProposalType           uint64         // proposal_type
+	ValidationLoopCnt      uint64         // vlcnt
+	Candidate              common.Address // candidate
+	SCHash                 common.Hash    // schash
+	SCBlockCountPerPeriod  uint64         // sccount
+	SCBlockRewardPerPeriod uint64         // screward
+	MinerRewardPerThousand uint64         // mrpt
+	MinVoterBalance        uint64         // mvb
+	ProposalDeposit        uint64         // mpd
+	SCRentTarget           common.Address // scrt
+	SCRentFee              uint64         // scrf
+	SCRentRate             uint64         // scrr
+	SCRentLength           uint64         // scrl
+}
+
+// uintParam binds a numeric proposal key to its field.
+type uintParam struct {
+	key string
+	val *uint64
+}
+
+func (p *Proposal) uintParams() []uintParam {
+	return []uintParam{
+		{"proposal_type", &p.ProposalType},
+		{"vlcnt", &p.ValidationLoopCnt},
+		{"sccount", &p.SCBlockCountPerPeriod},
+		{"screward", &p.SCBlockRewardPerPeriod},
+		{"mrpt", &p.MinerRewardPerThousand},
+		{"mvb", &p.MinVoterBalance},
+		{"mpd", &p.ProposalDeposit},
+		{"scrf", &p.SCRentFee},
+		{"scrr", &p.SCRentRate},
+		{"scrl", &p.SCRentLength},
+	}
+}
+
+// readPairs reads the key:value list of proposal and declare events. At least
+// one pair is required and a trailing key without value is ignored.
+func readPairs(r *fieldReader, fn func(key string)) {
+	if len(r.fields)-r.pos < 2 {
+		r.fail(len(r.fields), "key/value", "", ErrMissingField)
+		return
+	}
+	for r.more() && r.pos+1 < len(r.fields) {
+		fn(r.str("key"))
+	}
+}
+
+func decodeProposal(r *fieldReader) Payload {
+	p := new(Proposal)
+	keys := p.uintParams()
+	readPairs(r, func(key string) {
+		switch key {
+		case "candidate":
+			p.Candidate = r.address(key)
+		case "scrt":
+			p.SCRentTarget = r.address(key)
+		case "schash":
+			p.SCHash = r.hash(key)
+		default:
+			found := false
+			for _, k := range keys {
+				if k.key == key {
+					*k.val = r.uint(key, 10, 64)
+					found = true
+					break
+				}
+			}
+			if !found {
+				// Unknown keys are ignored by the engine as well
+				r.str(key)
+			}
+		}
+	})
+	return p
+}
+
+func (p *Proposal) Header() Header { return headerProposal }
+func (p *Proposal) Encode() []byte {
+	w := newFieldWriter(headerProposal)
+	for _, k := range p.uintParams() {
+		if *k.val != 0 {
+			w.str(k.key).str(strconv.FormatUint(*k.val, 10))
+		}
+	}
+	if p.Candidate != (common.Address{}) {
+		w.str("candidate").address(p.Candidate)
+	}
+	if p.SCHash != (common.Hash{}) {
+		w.str("schash").hash(p.SCHash)
+	}
+	if p.SCRentTarget != (common.Address{}) {
+		w.str("scrt").address(p.SCRentTarget)
+	}
+	return w.bytes()
+}
+
+// Declare is "ufo:1:event:declare:hash:<proposal>:decision:<yes|no>". A
+// proposal hash which does not decode declares on the zero hash.
+type Declare struct {
+	ProposalHash common.Hash
+	Decision     bool
+}
+
+func decodeDeclare(r *fieldReader) Payload {
+	p := &Declare{Decision: true}
+	readPairs(r, func(key string) {
+		switch key {
+		case "hash":
+			p.ProposalHash = r.optHash(key)
+		case "decision":
+			p.Decision = r.oneOf(key, "yes", "no") == "yes"
+		default:
+			r.str(key)
+		}
+	})
+	return p
+}
+
+func (p *Declare) Header() Header { return headerDeclare }
+func (p *Declare) Encode() []byte {
+	decision := "no"
+	if p.Decision {
+		decision = "yes"
+	}
+	return newFieldWriter(headerDeclare).str("hash").hash(p.ProposalHash).str("decision").str(decision).bytes()
+}
+
+// SCConfirm is "ufo:1:sc:confirm:<schash>:<number>:<time>:<loopinfo>:<charginginfo>",
+// sent by side chain signers to the main chain.
+type SCConfirm struct {
+	SCHash       common.Hash
+	Number       *big.Int
+	Time         *big.Int
+	LoopInfo     string
+	ChargingInfo string
+}
+
+func (p *SCConfirm) Header() Header { return headerSCConfirm }
+func (p *SCConfirm) Encode() []byte {
+	return newFieldWriter(headerSCConfirm).hash(p.SCHash).decimal(p.Number).decimal(p.Time).
+		str(p.LoopInfo).str(p.ChargingInfo).bytes()
+}
+
+// SCSetCoinbase is "ufo:1:sc:setcb:<schash>", the coinbase is the tx recipient.
+type SCSetCoinbase struct {
+	SCHash common.Hash
+}
+
+func (p *SCSetCoinbase) Header() Header { return headerSetCoinbase }
+func (p *SCSetCoinbase) Encode() []byte {
+	return newFieldWriter(headerSetCoinbase).hash(p.SCHash).bytes()
+}
+
+// SCDelCoinbase is "ufo:1:sc:delcb:<schash>", the coinbase is the tx recipient.
+type SCDelCoinbase struct {
+	SCHash common.Hash
+}
+
+func (p *SCDelCoinbase) Header() Header { return headerDelCoinbase }
+func (p *SCDelCoinbase) Encode() []byte {
+	return newFieldWriter(headerDelCoinbase).hash(p.SCHash).bytes()
+}
+
+// FlowReport is "ufo:1:sc:flwrpt:<hex rlp report>" sent by side chain coinbases.
+type FlowReport struct {
+	Report []byte
+}
+
+func (p *FlowReport) Header() Header { return headerFlowReport }
+func (p *FlowReport) Encode() []byte {
+	return newFieldWriter(headerFlowReport).str(common.Bytes2Hex(p.Report)).bytes()
+}
+
+// FlowReportManager is "ufo:1:sc:flwrptm:<hex report>" sent by the flow report manager.
+type FlowReportManager struct {
+	Report []byte
+}
+
+func (p *FlowReportManager) Header() Header { return headerFlowReportMgr }
+func (p *FlowReportManager) Encode() []byte {
+	return newFieldWriter(headerFlowReportMgr).str(common.Bytes2Hex(p.Report)).bytes()
+}
@@ -0,0 +1,360 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package customtx
+
+import (
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+const (
+	CategoryExch            = "Exch"
+	CategoryMultiSign       = "Multi"
+	CategoryBind            = "Bind"
+	CategoryUnbind          = "Unbind"
+	CategoryRebind          = "Rebind"
+	CategoryCandReq         = "CandReq"
+	CategoryCandExit        = "CandExit"
+	CategoryCandPnsh        = "CandPnsh"
+	CategoryFlwReq          = "FlwReq"
+	CategoryFlwExit         = "FlwExit"
+	CategoryCandEntrust     = "CandEntrust"
+	CategoryCandEntrustExit = "CandETExit"
+	CategoryCandChangeRate  = "CandChaRate"
+	CategoryCandPoSwtfd     = "PoSwtfd"
+
+	// Target types of entrusted pledge transfers
+	TargetTypePoS = "PoS"
+	TargetTypeSN  = "SN"
+	TargetTypeSP  = "SP"
+)
+
+func utgHeader(category string) Header {
+	return Header{Prefix: PrefixUTG, Version: Version1, Category: category}
+}
+
+var (
+	headerExch            = utgHeader(CategoryExch)
+	headerMultiSign       = utgHeader(CategoryMultiSign)
+	headerBind            = utgHeader(CategoryBind)
+	headerUnbind          = utgHeader(CategoryUnbind)
+	headerRebind          = utgHeader(CategoryRebind)
+	headerCandReq         = utgHeader(CategoryCandReq)
+	headerCandExit        = utgHeader(CategoryCandExit)
+	headerCandPnsh        = utgHeader(CategoryCandPnsh)
+	headerFlwReq          = utgHeader(CategoryFlwReq)
+	headerFlwExit         = utgHeader(CategoryFlwExit)
+	headerCandEntrust     = utgHeader(CategoryCandEntrust)
+	headerCandEntrustExit = utgHeader(CategoryCandEntrustExit)
+	headerCandChangeRate  = utgHeader(CategoryCandChangeRate)
+	headerCandPoSwtfd     = utgHeader(CategoryCandPoSwtfd)
+)
+
+func init() {
+	register(headerExch, func(r *fieldReader) Payload {
+		return &Exch{Target: r.address("target"), Amount: r.hexBig("amount")}
+	})
+	register(headerMultiSign, decodeMultiSign)
+	register(headerBind, func(r *fieldReader) Payload {
+		p := &Bind{
+			Device:      r.address("device"),
+			RevenueType: uint32(r.uint("type", 10, 32)),
+			Contract:    r.optAddress("contract"),
+			MultiSign:   r.optAddress("multisign"),
+		}
+		if r.more() {
+			p.Revenue = r.optAddress("revenue")
+		}
+		return p
+	})
+	register(headerUnbind, func(r *fieldReader) Payload {
+		return &Unbind{Device: r.address("device"), RevenueType: uint32(r.uint("type", 10, 32))}
+	})
+	register(headerRebind, func(r *fieldReader) Payload {
+		return &Rebind{
+			Device:      r.address("device"),
+			RevenueType: uint32(r.uint("type", 10, 32)),
+			Contract:    r.optAddress("contract"),
+			MultiSign:   r.optAddress("multisign"),
+			Revenue:     r.address("revenue"),
+		}
+	})
+	register(headerCandReq, func(r *fieldReader) Payload {
+		return &CandReq{Target: r.address("target")}
+	})
+	register(headerCandExit, func(r *fieldReader) Payload {
+		return &CandExit{Target: r.address("target")}
+	})
+	register(headerCandPnsh, func(r *fieldReader) Payload {
+		return &CandPnsh{Target: r.address("target")}
+	})
+	register(headerFlwReq, func(r *fieldReader) Payload {
+		return &FlwReq{
+			Target:    r.address("target"),
+			ISPQosID:  uint32(r.uint("ispqosid", 16, 32)),
+			Bandwidth: uint32(r.uint("bandwidth", 16, 32)),
+		}
+	})
+	register(headerFlwExit, func(r *fieldReader) Payload {
+		return &FlwExit{Target: r.address("target")}
+	})
+	register(headerCandEntrust, func(r *fieldReader) Payload {
+		return &CandEntrust{Target: r.address("target"), Amount: r.hexBig("amount")}
+	})
+	register(headerCandEntrustExit, func(r *fieldReader) Payload {
+		return &CandEntrustExit{Target: r.address("target"), Hash: r.looseHash("hash")}
+	})
+	register(headerCandChangeRate, func(r *fieldReader) Payload {
+		return &CandChangeRate{Target: r.address("target"), Rate: r.hexBig("rate")}
+	})
+	register(headerCandPoSwtfd, func(r *fieldReader) Payload {
+		p := &CandPoSTransfer{Original: r.address("original")}
+		p.Transfer = readTransferTarget(r)
+		return p
+	})
+}
+
+// Exch is "UTG:1:Exch:<target>:<hex amount>". Before the storage fork it exchanges
+// NFC, after it UTG to SRT.
+type Exch struct {
+	Target common.Address
+	Amount *big.Int
+}
+
+func (p *Exch) Header() Header { return headerExch }
+func (p *Exch) Encode() []byte {
+	return newFieldWriter(headerExch).address(p.Target).hexBig(p.Amount).bytes()
+}
+
+// MultiSign is "UTG:1:Multi:<threshold>:<signer>:<signer>...". The threshold
+// ranges from 2 to 10, and must be below the number of distinct signers.
+type MultiSign struct {
+	Threshold uint32
+	Signers   []common.Address
+}
+
+const (
+	multiSignMinThreshold = 2
+	multiSignMaxThreshold = 10
+	multiSignMaxSigners   = 999
+)
+
+func decodeMultiSign(r *fieldReader) Payload {
+	at := r.pos
+	p := &MultiSign{Threshold: uint32(r.uint("threshold", 10, 32))}
+	if r.err == nil && (p.Threshold < multiSignMinThreshold || p.Threshold > multiSignMaxThreshold) {
+		r.fail(at, "threshold", r.fields[at], ErrInvalidValue)
+	}
+	p.Signers = append(p.Signers, r.address("signer"))
+	for r.more() {
+		p.Signers = append(p.Signers, r.address("signer"))
+	}
+	if r.err != nil {
+		return p
+	}
+	if len(p.Signers) > multiSignMaxSigners {
+		r.fail(at+1+multiSignMaxSigners, "signer", r.fields[at+1+multiSignMaxSigners], ErrInvalidValue)
+		return p
+	}
+	distinct := make(map[common.Address]bool)
+	for _, signer := range p.Signers {
+		distinct[signer] = true
+	}
+	if len(distinct) <= int(p.Threshold) {
+		r.fail(at, "threshold", r.fields[at], ErrInvalidValue)
+	}
+	return p
+}
+
+func (p *MultiSign) Header() Header { return headerMultiSign }
+func (p *MultiSign) Encode() []byte {
+	w := newFieldWriter(headerMultiSign).uint(uint64(p.Threshold), 10)
+	for _, signer := range p.Signers {
+		w.address(signer)
+	}
+	return w.bytes()
+}
+
+// Bind is "UTG:1:Bind:<device>:<type>:<contract>:<multisign>[:<revenue>]". The
+// revenue address defaults to the tx sender if omitted.
+type Bind struct {
+	Device      common.Address
+	RevenueType uint32
+	Contract    common.Address
+	MultiSign   common.Address
+	Revenue     common.Address
+}
+
+func (p *Bind) Header() Header { return headerBind }
+func (p *Bind) Encode() []byte {
+	w := newFieldWriter(headerBind).address(p.Device).uint(uint64(p.RevenueType), 10).
+		optAddress(p.Contract).optAddress(p.MultiSign)
+	if p.Revenue != (common.Address{}) {
+		w.address(p.Revenue)
+	}
+	return w.bytes()
+}
+
+// Unbind is "UTG:1:Unbind:<device>:<type>".
+type Unbind struct {
+	Device      common.Address
+	RevenueType uint32
+}
+
+func (p *Unbind) Header() Header { return headerUnbind }
+func (p *Unbind) Encode() []byte {
+	return newFieldWriter(headerUnbind).address(p.Device).uint(uint64(p.RevenueType), 10).bytes()
+}
+
+// Rebind is "UTG:1:Rebind:<device>:<type>:<contract>:<multisign>:<revenue>".
+type Rebind struct {
+	Device      common.Address
+	RevenueType uint32
+	Contract    common.Address
+	MultiSign   common.Address
+	Revenue     common.Address
+}
+
+func (p *Rebind) Header() Header { return headerRebind }
+func (p *Rebind) Encode() []byte {
+	return newFieldWriter(headerRebind).address(p.Device).uint(uint64(p.RevenueType), 10).
+		optAddress(p.Contract).optAddress(p.MultiSign).address(p.Revenue).bytes()
+}
+
+// CandReq is "UTG:1:CandReq:<target>", the pledge is the tx value.
+type CandReq struct {
+	Target common.Address
+}
+
+func (p *CandReq) Header() Header { return headerCandReq }
+func (p *CandReq) Encode() []byte {
+	return newFieldWriter(headerCandReq).address(p.Target).bytes()
+}
+
+// CandExit is "UTG:1:CandExit:<target>".
+type CandExit struct {
+	Target common.Address
+}
+
+func (p *CandExit) Header() Header { return headerCandExit }
+func (p *CandExit) Encode() []byte {
+	return newFieldWriter(headerCandExit).address(p.Target).bytes()
+}
+
+// CandPnsh is "UTG:1:CandPnsh:<target>".
+type CandPnsh struct {
+	Target common.Address
+}
+
+func (p *CandPnsh) Header() Header { return headerCandPnsh }
+func (p *CandPnsh) Encode() []byte {
+	return newFieldWriter(headerCandPnsh).address(p.Target).bytes()
+}
+
+// FlwReq is "UTG:1:FlwReq:<target>:<hex isp qos id>:<hex bandwidth>".
+type FlwReq struct {
+	Target    common.Address
+	ISPQosID  uint32
+	Bandwidth uint32
+}
+
+func (p *FlwReq) Header() Header { return headerFlwReq }
+func (p *FlwReq) Encode() []byte {
+	return newFieldWriter(headerFlwReq).address(p.Target).uint(uint64(p.ISPQosID), 16).
+		uint(uint64(p.Bandwidth), 16).bytes()
+}
+
+// FlwExit is "UTG:1:FlwExit:<target>".
+type FlwExit struct {
+	Target common.Address
+}
+
+func (p *FlwExit) Header() Header { return headerFlwExit }
+func (p *FlwExit) Encode() []byte {
+	return newFieldWriter(headerFlwExit).address(p.Target).bytes()
+}
+
+// CandEntrust is "UTG:1:CandEntrust:<target>:<hex amount>".
+type CandEntrust struct {
+	Target common.Address
+	Amount *big.Int
+}
+
+func (p *CandEntrust) Header() Header { return headerCandEntrust }
+func (p *CandEntrust) Encode() []byte {
+	return newFieldWriter(headerCandEntrust).address(p.Target).hexBig(p.Amount).bytes()
+}
+
+// CandEntrustExit is "UTG:1:CandETExit:<target>:<entrust hash>".
+type CandEntrustExit struct {
+	Target common.Address
+	Hash   common.Hash
+}
+
+func (p *CandEntrustExit) Header() Header { return headerCandEntrustExit }
+func (p *CandEntrustExit) Encode() []byte {
+	return newFieldWriter(headerCandEntrustExit).address(p.Target).hash(p.Hash).bytes()
+}
+
+// CandChangeRate is "UTG:1:CandChaRate:<target>:<hex rate>".
+type CandChangeRate struct {
+	Target common.Address
+	Rate   *big.Int
+}
+
+func (p *CandChangeRate) Header() Header { return headerCandChangeRate }
+func (p *CandChangeRate) Encode() []byte {
+	return newFieldWriter(headerCandChangeRate).address(p.Target).hexBig(p.Rate).bytes()
+}
+
+// TransferTarget is the destination of an entrusted pledge transfer. Address
+// is used for PoS and SN targets, Hash for SP targets.
+type TransferTarget struct {
+	TargetType string
+	Address    common.Address
+	Hash       common.Hash
+}
+
+func readTransferTarget(r *fieldReader) TransferTarget {
+	t := TransferTarget{TargetType: r.oneOf("targettype", TargetTypePoS, TargetTypeSN, TargetTypeSP)}
+	if t.TargetType == TargetTypeSP {
+		t.Hash = r.hash("target")
+	} else {
+		t.Address = r.address("target")
+	}
+	return t
+}
+
+func (t TransferTarget) write(w *fieldWriter) *fieldWriter {
+	w.str(t.TargetType)
+	if t.TargetType == TargetTypeSP {
+		return w.hash(t.Hash)
+	}
+	return w.address(t.Address)
+}
+
+// CandPoSTransfer is "UTG:1:PoSwtfd:<original>:<PoS|SN|SP>:<target>".
+type CandPoSTransfer struct {
+	Original common.Address
+	Transfer TransferTarget
+}
+
+func (p *CandPoSTransfer) Header() Header { return headerCandPoSwtfd }
+func (p *CandPoSTransfer) Encode() []byte {
+	return p.Transfer.write(newFieldWriter(headerCandPoSwtfd).address(p.Original)).bytes()
+}
@@ -0,0 +1,448 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/event"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	maxDoubleSignPunishPerBlock  = 4    // Maximum number of double sign punishments sealed in a block
+	maxPendingDoubleSignEvidence = 256  // Maximum number of evidences waiting in the pool
+	inMemoryDoubleSignHeaders    = 4096 // Number of recent headers kept to detect double signing
+	inMemoryDoubleSigners        = 1024 // Number of recent signers whose evidences are accepted from the network
+	doubleSignEvidenceDays       = 7    // Number of days a double sign can be punished after it was sealed
+	doubleSignPunishRatio        = 10   // Percentage of the pledges of a signer slashed for a double sign
+)
+
+var (
+	// ErrInvalidDoubleSignEvidence is returned if the headers of an evidence are
+	// not two different headers sealed by one signer in the same block slot.
+	// Peers sending such an evidence are dropped.
+	ErrInvalidDoubleSignEvidence = errors.New("invalid double sign evidence")
+
+	// ErrKnownDoubleSignEvidence is returned if an evidence is already pending.
+	ErrKnownDoubleSignEvidence = errors.New("known double sign evidence")
+
+	// errUnknownDoubleSigner is returned if an evidence received from the network
+	// accuses a signer not seen sealing in turn recently.
+	errUnknownDoubleSigner = errors.New("unknown double signer")
+
+	// errDoubleSignEvidencePoolFull is returned if too many evidences are pending.
+	errDoubleSignEvidencePoolFull = errors.New("double sign evidence pool full")
+
+	// errInvalidDoubleSignPunish is returned if the double sign punishments sealed
+	// in a header are not valid against its parent snapshot.
+	errInvalidDoubleSignPunish = errors.New("invalid double sign punishment")
+)
+
+// DoubleSignEvidence is a pair of different headers sealed by the same signer
+// for the same block number within one block period.
+type DoubleSignEvidence struct {
+	First  *types.Header `json:"first"`
+	Second *types.Header `json:"second"`
+}
+
+// DoubleSignPunishRecord slashes the pledges of the signer which sealed both
+// headers of the evidence.
+type DoubleSignPunishRecord struct {
+	Target   common.Address
+	Amount   *big.Int
+	Evidence DoubleSignEvidence
+}
+
+// Number returns the block number both headers of the evidence are sealed at.
+func (ev *DoubleSignEvidence) Number() uint64 {
+	return ev.First.Number.Uint64()
+}
+
+// Hash identifies the evidence independently of the order of its headers.
+func (ev *DoubleSignEvidence) Hash() common.Hash {
+	first, second := ev.First.Hash(), ev.Second.Hash()
+	if bytes.Compare(first[:], second[:]) > 0 {
+		first, second = second, first
+	}
+	return crypto.Keccak256Hash(first[:], second[:])
+}
+
+// verify checks the evidence and returns the signer which sealed both headers.
+// Headers differing only in their signature are not a double sign, as anyone
+// can derive a second valid signature from the first one.
+func (ev *DoubleSignEvidence) verify(period uint64, sigcache *lru.ARCCache) (common.Address, error) {
+	for _, header := range []*types.Header{ev.First, ev.Second} {
+		if header == nil || header.Number == nil {
+			return common.Address{}, fmt.Errorf("%w: missing header", ErrInvalidDoubleSignEvidence)
+		}
+		if len(header.Extra) < extraVanity+extraSeal {
+			return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidDoubleSignEvidence, errMissingSignature)
+		}
+	}
+	if ev.First.Number.Cmp(ev.Second.Number) != 0 {
+		return common.Address{}, fmt.Errorf("%w: numbers %v and %v", ErrInvalidDoubleSignEvidence, ev.First.Number, ev.Second.Number)
+	}
+	if ev.First.Time+period <= ev.Second.Time || ev.Second.Time+period <= ev.First.Time {
+		return common.Address{}, fmt.Errorf("%w: times %d and %d", ErrInvalidDoubleSignEvidence, ev.First.Time, ev.Second.Time)
+	}
+	firstHash, err := sigHash(ev.First)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidDoubleSignEvidence, err)
+	}
+	secondHash, err := sigHash(ev.Second)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidDoubleSignEvidence, err)
+	}
+	if firstHash == secondHash {
+		return common.Address{}, fmt.Errorf("%w: same header", ErrInvalidDoubleSignEvidence)
+	}
+	first, err := ecrecover(ev.First, sigcache)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidDoubleSignEvidence, err)
+	}
+	second, err := ecrecover(ev.Second, sigcache)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidDoubleSignEvidence, err)
+	}
+	if first != second {
+		return common.Address{}, fmt.Errorf("%w: signers %s and %s", ErrInvalidDoubleSignEvidence, first.Hex(), second.Hex())
+	}
+	return first, nil
+}
+
+// signedNumber is the key of the headers seen by the evidence pool.
+type signedNumber struct {
+	signer common.Address
+	number uint64
+}
+
+// evidencePool collects the double sign evidences found among the headers
+// verified by the engine, which the downloader and the block fetcher both pass
+// through, and those received from the network. The evidences wait in the pool
+// until they are punished on chain or can no longer be punished.
+type evidencePool struct {
+	period   uint64
+	sigcache *lru.ARCCache
+	headers  *lru.ARCCache // First header seen sealed by each signer at each number
+	signers  *lru.ARCCache // Signers recently seen sealing in turn
+
+	pending map[common.Hash]*DoubleSignEvidence
+	feed    event.Feed
+	scope   event.SubscriptionScope
+	lock    sync.Mutex
+}
+
+func newEvidencePool(period uint64, sigcache *lru.ARCCache) *evidencePool {
+	headers, _ := lru.NewARC(inMemoryDoubleSignHeaders)
+	signers, _ := lru.NewARC(inMemoryDoubleSigners)
+	return &evidencePool{
+		period:   period,
+		sigcache: sigcache,
+		headers:  headers,
+		signers:  signers,
+		pending:  make(map[common.Hash]*DoubleSignEvidence),
+	}
+}
+
+// observe records a header sealed in turn by signer, and adds an evidence to
+// the pool if the signer already sealed a different header at the same number
+// in the same block slot.
+func (p *evidencePool) observe(header *types.Header, signer common.Address) {
+	p.signers.Add(signer, struct{}{})
+
+	key := signedNumber{signer, header.Number.Uint64()}
+	seen, ok := p.headers.Get(key)
+	if !ok {
+		p.headers.Add(key, header)
+		return
+	}
+	first := seen.(*types.Header)
+	if first.Hash() == header.Hash() {
+		return
+	}
+	ev := &DoubleSignEvidence{First: first, Second: header}
+	if _, err := ev.verify(p.period, p.sigcache); err != nil {
+		return
+	}
+	if err := p.insert(ev); err == nil {
+		log.Warn("Double sign detected", "signer", signer, "number", header.Number, "first", first.Hash(), "second", header.Hash())
+	}
+}
+
+// add verifies an evidence received from the network and adds it to the pool.
+func (p *evidencePool) add(ev *DoubleSignEvidence) error {
+	signer, err := ev.verify(p.period, p.sigcache)
+	if err != nil {
+		return err
+	}
+	if !p.signers.Contains(signer) {
+		return fmt.Errorf("%w: %s", errUnknownDoubleSigner, signer.Hex())
+	}
+	return p.insert(ev)
+}
+
+// insert adds a verified evidence to the pool and announces it.
+func (p *evidencePool) insert(ev *DoubleSignEvidence) error {
+	hash := ev.Hash()
+
+	p.lock.Lock()
+	if _, ok := p.pending[hash]; ok {
+		p.lock.Unlock()
+		return ErrKnownDoubleSignEvidence
+	}
+	if len(p.pending) >= maxPendingDoubleSignEvidence {
+		p.lock.Unlock()
+		return errDoubleSignEvidencePoolFull
+	}
+	p.pending[hash] = ev
+	p.lock.Unlock()
+
+	p.feed.Send(ev)
+	return nil
+}
+
+// list returns the pending evidences, oldest first.
+func (p *evidencePool) list() []*DoubleSignEvidence {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	evs := make([]*DoubleSignEvidence, 0, len(p.pending))
+	for _, ev := range p.pending {
+		evs = append(evs, ev)
+	}
+	sort.Slice(evs, func(i, j int) bool {
+		if evs[i].Number() != evs[j].Number() {
+			return evs[i].Number() < evs[j].Number()
+		}
+		hi, hj := evs[i].Hash(), evs[j].Hash()
+		return bytes.Compare(hi[:], hj[:]) < 0
+	})
+	return evs
+}
+
+// punish returns the punishments to seal in the header at number on top of the
+// given snapshot, and drops the evidences which can no longer be punished.
+func (p *evidencePool) punish(snap *Snapshot, number uint64) []DoubleSignPunishRecord {
+	var (
+		records []DoubleSignPunishRecord
+		targets = make(map[common.Address]struct{})
+	)
+	for _, ev := range p.list() {
+		signer, err := ev.verify(p.period, p.sigcache)
+		if err == nil {
+			err = snap.checkDoubleSign(signer, ev.Number(), number)
+		}
+		if err != nil {
+			if ev.Number() < number {
+				p.drop(ev)
+			}
+			continue
+		}
+		if _, ok := targets[signer]; ok || len(records) >= maxDoubleSignPunishPerBlock {
+			continue
+		}
+		targets[signer] = struct{}{}
+		records = append(records, DoubleSignPunishRecord{
+			Target:   signer,
+			Amount:   snap.doubleSignPunishAmount(signer),
+			Evidence: *ev,
+		})
+	}
+	return records
+}
+
+// prune drops the evidences which can no longer be punished on top of the
+// snapshot of the head at number, as they are punished on chain already or past
+// the punish window.
+func (p *evidencePool) prune(snap *Snapshot, number uint64) {
+	for _, ev := range p.list() {
+		if ev.Number() > number {
+			continue
+		}
+		signer, err := ev.verify(p.period, p.sigcache)
+		if err == nil {
+			err = snap.checkDoubleSign(signer, ev.Number(), number+1)
+		}
+		if err != nil {
+			p.drop(ev)
+		}
+	}
+}
+
+// drop removes an evidence from the pool.
+func (p *evidencePool) drop(ev *DoubleSignEvidence) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.pending, ev.Hash())
+}
+
+// subscribe registers a subscription for the evidences added to the pool.
+func (p *evidencePool) subscribe(ch chan<- *DoubleSignEvidence) event.Subscription {
+	return p.scope.Track(p.feed.Subscribe(ch))
+}
+
+// checkDoubleSign checks that a double sign sealed at evNumber can still be
+// punished in the header at number.
+func (s *Snapshot) checkDoubleSign(signer common.Address, evNumber uint64, number uint64) error {
+	if evNumber >= number {
+		return fmt.Errorf("%w: evidence at %d punished at %d", errInvalidDoubleSignPunish, evNumber, number)
+	}
+	if number-evNumber > doubleSignEvidenceDays*s.getBlockPreDay() {
+		return fmt.Errorf("%w: stale evidence at %d", errInvalidDoubleSignPunish, evNumber)
+	}
+	if last, ok := s.DoubleSigned[signer]; ok && evNumber <= last {
+		return fmt.Errorf("%w: %s already punished at %d", errInvalidDoubleSignPunish, signer.Hex(), last)
+	}
+	return nil
+}
+
+// doubleSignSlash returns the part of a pledge slashed for a double sign.
+func doubleSignSlash(amount *big.Int) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(doubleSignPunishRatio)), big.NewInt(100))
+}
+
+// doubleSignPunishAmount returns the part of the pledges of signer slashed for
+// a double sign. From the PoS rules on the pledges of a signer are its PoS
+// pledge entries, each of which is slashed on its own; before them it is the
+// candidate pledge.
+func (s *Snapshot) doubleSignPunishAmount(signer common.Address) *big.Int {
+	if pos, ok := s.PosPledge[signer]; ok {
+		amount := new(big.Int)
+		for _, detail := range pos.Detail {
+			amount.Add(amount, doubleSignSlash(detail.Amount))
+		}
+		return amount
+	}
+	pledge, ok := s.CandidatePledge[signer]
+	if !ok || pledge.Amount == nil {
+		return big.NewInt(0)
+	}
+	return doubleSignSlash(pledge.Amount)
+}
+
+// verifyDoubleSignPunish checks the double sign punishments sealed in a header
+// against the snapshot of its parent.
+func (s *Snapshot) verifyDoubleSignPunish(records []DoubleSignPunishRecord, number uint64) error {
+	if len(records) > maxDoubleSignPunishPerBlock {
+		return fmt.Errorf("%w: %d punishments", errInvalidDoubleSignPunish, len(records))
+	}
+	targets := make(map[common.Address]struct{})
+	for _, record := range records {
+		signer, err := record.Evidence.verify(s.config.Period, s.sigcache)
+		if err != nil {
+			return err
+		}
+		if signer != record.Target {
+			return fmt.Errorf("%w: target %s signer %s", errInvalidDoubleSignPunish, record.Target.Hex(), signer.Hex())
+		}
+		if _, ok := targets[signer]; ok {
+			return fmt.Errorf("%w: %s punished twice", errInvalidDoubleSignPunish, signer.Hex())
+		}
+		targets[signer] = struct{}{}
+		if err := s.checkDoubleSign(signer, record.Evidence.Number(), number); err != nil {
+			return err
+		}
+		if amount := s.doubleSignPunishAmount(signer); record.Amount == nil || record.Amount.Cmp(amount) != 0 {
+			return fmt.Errorf("%w: amount %v, want %v", errInvalidDoubleSignPunish, record.Amount, amount)
+		}
+	}
+	return nil
+}
+
+// updateDoubleSignPunish slashes the pledges of the double signers and gives
+// them the lowest credit, out of the next signer queues.
+func (s *Snapshot) updateDoubleSignPunish(records []DoubleSignPunishRecord) {
+	if len(records) > 0 && s.DoubleSigned == nil {
+		s.DoubleSigned = make(map[common.Address]uint64)
+	}
+	for _, record := range records {
+		s.DoubleSigned[record.Target] = record.Evidence.Number()
+		if pos, ok := s.PosPledge[record.Target]; ok {
+			for _, detail := range pos.Detail {
+				slash := doubleSignSlash(detail.Amount)
+				detail.Amount = new(big.Int).Sub(detail.Amount, slash)
+				pos.TotalAmount = new(big.Int).Sub(pos.TotalAmount, slash)
+			}
+		} else if pledge, ok := s.CandidatePledge[record.Target]; ok && pledge.Amount.Cmp(record.Amount) >= 0 {
+			pledge.Amount = new(big.Int).Sub(pledge.Amount, record.Amount)
+		}
+		s.Punished[record.Target] = defaultFullCredit
+	}
+}
+
+// verifyDoubleSignPunish checks the double sign punishments sealed in a header.
+func (a *Alien) verifyDoubleSignPunish(snap *Snapshot, header *types.Header) error {
+	headerExtra := HeaderExtra{}
+	if err := decodeHeaderExtra(a.config, header.Number, header.Extra[extraVanity:len(header.Extra)-extraSeal], &headerExtra); err != nil {
+		return err
+	}
+	return snap.verifyDoubleSignPunish(headerExtra.DoubleSignPunish, header.Number.Uint64())
+}
+
+// sealedDoubleSignPunish returns the double sign punishments sealed in the extra
+// of a block being imported if they are valid against the snapshot of its
+// parent, so that invalid ones fail the header extra comparison on import.
+func (a *Alien) sealedDoubleSignPunish(snap *Snapshot, number *big.Int, extra []byte) []DoubleSignPunishRecord {
+	headerExtra := HeaderExtra{}
+	if err := decodeHeaderExtra(a.config, number, extra, &headerExtra); err != nil {
+		return nil
+	}
+	if err := snap.verifyDoubleSignPunish(headerExtra.DoubleSignPunish, number.Uint64()); err != nil {
+		return nil
+	}
+	return headerExtra.DoubleSignPunish
+}
+
+// SubmitDoubleSignEvidence verifies a double sign evidence received from the
+// network and adds it to the evidence pool.
+func (a *Alien) SubmitDoubleSignEvidence(ev *DoubleSignEvidence) error {
+	return a.evidence.add(ev)
+}
+
+// PruneDoubleSignEvidence drops the evidences of the pool which are punished on
+// the chain ending at head, or which can no longer be punished on top of it.
+func (a *Alien) PruneDoubleSignEvidence(chain consensus.ChainHeaderReader, head *types.Header) error {
+	snap, err := a.snapshot(chain, head.Number.Uint64(), head.Hash(), nil, nil, defaultLoopCntRecalculateSigners)
+	if err != nil {
+		return err
+	}
+	a.evidence.prune(snap, head.Number.Uint64())
+	return nil
+}
+
+// PendingDoubleSignEvidence returns the double sign evidences not punished yet.
+func (a *Alien) PendingDoubleSignEvidence() []*DoubleSignEvidence {
+	return a.evidence.list()
+}
+
+// SubscribeDoubleSignEvidence registers a subscription for the double sign
+// evidences added to the evidence pool, for gossiping them.
+func (a *Alien) SubscribeDoubleSignEvidence(ch chan<- *DoubleSignEvidence) event.Subscription {
+	return a.evidence.subscribe(ch)
+}
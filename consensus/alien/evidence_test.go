@@ -0,0 +1,331 @@
+package alien
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// newSignedHeader returns a header at number sealed with key.
+func newSignedHeader(t *testing.T, key []byte, number uint64, time uint64, root common.Hash) *types.Header {
+	priv, err := crypto.ToECDSA(key)
+	if err != nil {
+		t.Fatalf("invalid key: %v", err)
+	}
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Time:       time,
+		Root:       root,
+		Difficulty: big.NewInt(1),
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	hash, err := sigHash(header)
+	if err != nil {
+		t.Fatalf("failed to hash header: %v", err)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), priv)
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return header
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	var (
+		key    = common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		other  = common.FromHex("0x8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		signer = crypto.PubkeyToAddress(mustKey(t, key).PublicKey)
+		period = uint64(3)
+		first  = newSignedHeader(t, key, 100, 300, common.HexToHash("0x01"))
+	)
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+	tests := []struct {
+		second *types.Header
+		err    error
+	}{
+		{newSignedHeader(t, key, 100, 301, common.HexToHash("0x02")), nil},
+		{newSignedHeader(t, key, 101, 300, common.HexToHash("0x02")), ErrInvalidDoubleSignEvidence},
+		{newSignedHeader(t, key, 100, 303, common.HexToHash("0x02")), ErrInvalidDoubleSignEvidence},
+		{newSignedHeader(t, other, 100, 300, common.HexToHash("0x02")), ErrInvalidDoubleSignEvidence},
+		{newSignedHeader(t, key, 100, 300, common.HexToHash("0x01")), ErrInvalidDoubleSignEvidence},
+		{nil, ErrInvalidDoubleSignEvidence},
+	}
+	for i, tt := range tests {
+		ev := &DoubleSignEvidence{First: first, Second: tt.second}
+		have, err := ev.verify(period, sigcache)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if err == nil && have != signer {
+			t.Errorf("test %d: signer mismatch: have %s, want %s", i, have.Hex(), signer.Hex())
+		}
+	}
+	// A second signature of the same header is not a double sign
+	malleated := types.CopyHeader(first)
+	malleated.Extra[len(malleated.Extra)-1] ^= 1
+	ev := &DoubleSignEvidence{First: first, Second: malleated}
+	if _, err := ev.verify(period, sigcache); err == nil {
+		t.Errorf("resigned header accepted as double sign")
+	}
+}
+
+func TestDoubleSignPunish(t *testing.T) {
+	var (
+		key    = common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		signer = crypto.PubkeyToAddress(mustKey(t, key).PublicKey)
+		config = &params.AlienConfig{Period: 3, MaxSignerCount: 3, MinVoterBalance: big.NewInt(100)}
+	)
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+	snap := newSnapshot(config, sigcache, common.Hash{}, nil, defaultLoopCntRecalculateSigners)
+	snap.CandidatePledge[signer] = NewPledgeItem(big.NewInt(1000))
+
+	pool := newEvidencePool(config.Period, sigcache)
+	first := newSignedHeader(t, key, 100, 300, common.HexToHash("0x01"))
+	second := newSignedHeader(t, key, 100, 300, common.HexToHash("0x02"))
+	if err := pool.add(&DoubleSignEvidence{First: first, Second: second}); !errors.Is(err, errUnknownDoubleSigner) {
+		t.Fatalf("evidence against unknown signer: have %v, want %v", err, errUnknownDoubleSigner)
+	}
+	ch := make(chan *DoubleSignEvidence, 1)
+	sub := pool.subscribe(ch)
+	defer sub.Unsubscribe()
+
+	pool.observe(first, signer)
+	pool.observe(first, signer)
+	if evs := pool.list(); len(evs) != 0 {
+		t.Fatalf("evidence from a single header: %v", evs)
+	}
+	pool.observe(second, signer)
+	if evs := pool.list(); len(evs) != 1 {
+		t.Fatalf("pending evidence count mismatch: have %d, want 1", len(evs))
+	}
+	select {
+	case <-ch:
+	default:
+		t.Errorf("evidence not announced")
+	}
+	if err := pool.add(&DoubleSignEvidence{First: second, Second: first}); !errors.Is(err, ErrKnownDoubleSignEvidence) {
+		t.Errorf("known evidence: have %v, want %v", err, ErrKnownDoubleSignEvidence)
+	}
+
+	// Evidences are punished after the double sign only
+	if records := pool.punish(snap, 100); len(records) != 0 {
+		t.Fatalf("double sign punished at its own height")
+	}
+	records := pool.punish(snap, 101)
+	if len(records) != 1 || records[0].Target != signer || records[0].Amount.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("punishment mismatch: %v", records)
+	}
+	if err := snap.verifyDoubleSignPunish(records, 101); err != nil {
+		t.Fatalf("failed to verify punishment: %v", err)
+	}
+	tampered := []DoubleSignPunishRecord{records[0]}
+	tampered[0].Amount = big.NewInt(1000)
+	if err := snap.verifyDoubleSignPunish(tampered, 101); !errors.Is(err, errInvalidDoubleSignPunish) {
+		t.Errorf("tampered amount: have %v, want %v", err, errInvalidDoubleSignPunish)
+	}
+	if err := snap.verifyDoubleSignPunish(append(records, records[0]), 101); !errors.Is(err, errInvalidDoubleSignPunish) {
+		t.Errorf("duplicate punishment: have %v, want %v", err, errInvalidDoubleSignPunish)
+	}
+	stale := 101 + doubleSignEvidenceDays*snap.getBlockPreDay()
+	if err := snap.verifyDoubleSignPunish(records, stale); !errors.Is(err, errInvalidDoubleSignPunish) {
+		t.Errorf("stale punishment: have %v, want %v", err, errInvalidDoubleSignPunish)
+	}
+
+	// A double sign is punished once
+	snap.updateDoubleSignPunish(records)
+	if amount := snap.CandidatePledge[signer].Amount; amount.Cmp(big.NewInt(900)) != 0 {
+		t.Errorf("pledge not slashed: have %v, want 900", amount)
+	}
+	if credit := snap.Punished[signer]; credit != defaultFullCredit {
+		t.Errorf("credit mismatch: have %d, want %d", credit, defaultFullCredit)
+	}
+	if err := snap.verifyDoubleSignPunish(records, 102); !errors.Is(err, errInvalidDoubleSignPunish) {
+		t.Errorf("punished twice: have %v, want %v", err, errInvalidDoubleSignPunish)
+	}
+	if records := pool.punish(snap, 102); len(records) != 0 {
+		t.Errorf("punished evidence still pending")
+	}
+	if evs := pool.list(); len(evs) != 0 {
+		t.Errorf("punished evidence not dropped")
+	}
+}
+
+// Tests that from the PoS rules on a double sign slashes every PoS pledge entry
+// of the signer, its own and the entrusted ones.
+func TestDoubleSignPunishPosPledge(t *testing.T) {
+	defer setForkSchedule(nil)
+	setForkSchedule(params.GenesisAlienForks())
+
+	var (
+		key     = common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		signer  = crypto.PubkeyToAddress(mustKey(t, key).PublicKey)
+		entrust = common.HexToAddress("0x02")
+		config  = &params.AlienConfig{Period: 3, MaxSignerCount: 3, MinVoterBalance: big.NewInt(100)}
+	)
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+	snap := newSnapshot(config, sigcache, common.Hash{}, nil, defaultLoopCntRecalculateSigners)
+	snap.PosPledge = make(map[common.Address]*PosPledgeItem)
+	snap.PosPledge[signer] = &PosPledgeItem{
+		Manager:     signer,
+		TotalAmount: big.NewInt(1505),
+		Detail: map[common.Hash]*PledgeDetail{
+			common.HexToHash("0x01"): {Address: signer, Amount: big.NewInt(1000)},
+			common.HexToHash("0x02"): {Address: entrust, Amount: big.NewInt(505)},
+		},
+		DisRate: big.NewInt(0),
+	}
+	pool := newEvidencePool(config.Period, sigcache)
+	pool.observe(newSignedHeader(t, key, 100, 300, common.HexToHash("0x01")), signer)
+	pool.observe(newSignedHeader(t, key, 100, 300, common.HexToHash("0x02")), signer)
+
+	records := pool.punish(snap, 101)
+	if len(records) != 1 || records[0].Amount.Cmp(big.NewInt(150)) != 0 {
+		t.Fatalf("punishment mismatch: %v", records)
+	}
+	if err := snap.verifyDoubleSignPunish(records, 101); err != nil {
+		t.Fatalf("failed to verify punishment: %v", err)
+	}
+	before := snap.copy()
+	snap.updateDoubleSignPunish(records)
+	pos := snap.PosPledge[signer]
+	if drop := new(big.Int).Sub(before.PosPledge[signer].TotalAmount, pos.TotalAmount); drop.Cmp(records[0].Amount) != 0 {
+		t.Errorf("pledge drop mismatch: have %v, want %v", drop, records[0].Amount)
+	}
+	if own := pos.Detail[common.HexToHash("0x01")].Amount; own.Cmp(big.NewInt(900)) != 0 {
+		t.Errorf("own pledge not slashed: have %v, want 900", own)
+	}
+	if entrusted := pos.Detail[common.HexToHash("0x02")].Amount; entrusted.Cmp(big.NewInt(455)) != 0 {
+		t.Errorf("entrusted pledge not slashed: have %v, want 455", entrusted)
+	}
+	if amount := before.PosPledge[signer].Detail[common.HexToHash("0x01")].Amount; amount.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("parent snapshot modified: have %v, want 1000", amount)
+	}
+}
+
+// Tests that the evidences are dropped from the pool once they are punished on
+// chain or out of the punish window, without the local node sealing them.
+func TestDoubleSignEvidencePrune(t *testing.T) {
+	var (
+		key    = common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		other  = common.FromHex("0x8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		signer = crypto.PubkeyToAddress(mustKey(t, key).PublicKey)
+		config = &params.AlienConfig{Period: 3, MaxSignerCount: 3, MinVoterBalance: big.NewInt(100)}
+	)
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+	snap := newSnapshot(config, sigcache, common.Hash{}, nil, defaultLoopCntRecalculateSigners)
+	pool := newEvidencePool(config.Period, sigcache)
+	pool.observe(newSignedHeader(t, key, 100, 300, common.HexToHash("0x01")), signer)
+	pool.observe(newSignedHeader(t, key, 100, 300, common.HexToHash("0x02")), signer)
+	otherSigner := crypto.PubkeyToAddress(mustKey(t, other).PublicKey)
+	pool.observe(newSignedHeader(t, other, 200, 600, common.HexToHash("0x01")), otherSigner)
+	pool.observe(newSignedHeader(t, other, 200, 600, common.HexToHash("0x02")), otherSigner)
+
+	pool.prune(snap, 150)
+	if evs := pool.list(); len(evs) != 2 {
+		t.Fatalf("punishable evidences dropped: have %d, want 2", len(evs))
+	}
+	// Punished on chain by another signer
+	snap.DoubleSigned = map[common.Address]uint64{signer: 100}
+	pool.prune(snap, 150)
+	if evs := pool.list(); len(evs) != 1 || evs[0].Number() != 200 {
+		t.Fatalf("punished evidence not dropped: %v", evs)
+	}
+	// Past the punish window
+	pool.prune(snap, 200+doubleSignEvidenceDays*snap.getBlockPreDay())
+	if evs := pool.list(); len(evs) != 0 {
+		t.Fatalf("stale evidence not dropped: %v", evs)
+	}
+}
+
+// Tests that the double sign punishments are compared on import like the other
+// header extra fields, and reported by the header extra diff.
+func TestDoubleSignPunishHeaderExtraCheck(t *testing.T) {
+	key := common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	record := DoubleSignPunishRecord{
+		Target: crypto.PubkeyToAddress(mustKey(t, key).PublicKey),
+		Amount: big.NewInt(100),
+		Evidence: DoubleSignEvidence{
+			First:  newSignedHeader(t, key, 100, 300, common.HexToHash("0x01")),
+			Second: newSignedHeader(t, key, 100, 300, common.HexToHash("0x02")),
+		},
+	}
+	current := &HeaderExtra{DoubleSignPunish: []DoubleSignPunishRecord{record}}
+	if err := verifyHeaderExtern(current, &HeaderExtra{DoubleSignPunish: []DoubleSignPunishRecord{record}}); err != nil {
+		t.Fatalf("same punishments rejected: %v", err)
+	}
+	tampered := record
+	tampered.Amount = big.NewInt(1)
+	verify := &HeaderExtra{DoubleSignPunish: []DoubleSignPunishRecord{tampered}}
+	if err := verifyHeaderExtern(current, verify); err == nil {
+		t.Errorf("tampered punishment accepted")
+	}
+	if diff := diffHeaderExtra(current, verify); len(diff) != 1 || diff[0].Field != "DoubleSignPunish" {
+		t.Errorf("punishment diff mismatch: %v", diff)
+	}
+	if err := verifyHeaderExtern(&HeaderExtra{}, current); err == nil {
+		t.Errorf("unexpected punishment accepted")
+	}
+}
+
+func TestDoubleSignPunishEncoding(t *testing.T) {
+	defer setForkSchedule(nil)
+	setForkSchedule(&params.AlienForks{DoubleSignPunishBlock: big.NewInt(100)})
+
+	// Header extras without punishments keep their layout
+	enc, err := rlp.EncodeToBytes(HeaderExtra{})
+	if err != nil {
+		t.Fatalf("failed to encode header extra: %v", err)
+	}
+	content, _, _ := rlp.SplitList(enc)
+	if fields, _ := rlp.CountValues(content); fields != reflect.TypeOf(HeaderExtra{}).NumField()-1 {
+		t.Errorf("field count mismatch: have %d, want %d", fields, reflect.TypeOf(HeaderExtra{}).NumField()-1)
+	}
+	key := common.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	record := DoubleSignPunishRecord{
+		Target: crypto.PubkeyToAddress(mustKey(t, key).PublicKey),
+		Amount: big.NewInt(100),
+		Evidence: DoubleSignEvidence{
+			First:  newSignedHeader(t, key, 100, 300, common.HexToHash("0x01")),
+			Second: newSignedHeader(t, key, 100, 300, common.HexToHash("0x02")),
+		},
+	}
+	val := HeaderExtra{DoubleSignPunish: []DoubleSignPunishRecord{record}}
+
+	// Before the punishments the extras keep the layout of V8
+	enc, err = encodeVersionedHeaderExtra(99, val)
+	if err != nil {
+		t.Fatalf("failed to encode header extra: %v", err)
+	}
+	var dec HeaderExtra
+	if layout, err := decodeVersionedHeaderExtra(enc, &dec); err != nil || layout.Version != headerExtraVersionV8 || len(dec.DoubleSignPunish) != 0 {
+		t.Fatalf("extra before the punishments: layout %+v, punishments %v, err %v", layout, dec.DoubleSignPunish, err)
+	}
+	enc, err = encodeVersionedHeaderExtra(100, val)
+	if err != nil {
+		t.Fatalf("failed to encode header extra: %v", err)
+	}
+	if layout, err := decodeVersionedHeaderExtra(enc, &dec); err != nil || layout.Version != headerExtraVersionV9 {
+		t.Fatalf("failed to decode header extra: layout %+v, err %v", layout, err)
+	}
+	if len(dec.DoubleSignPunish) != 1 || dec.DoubleSignPunish[0].Evidence.Hash() != record.Evidence.Hash() {
+		t.Errorf("punishment mismatch: have %v", dec.DoubleSignPunish)
+	}
+}
+
+func mustKey(t *testing.T, key []byte) *ecdsa.PrivateKey {
+	priv, err := crypto.ToECDSA(key)
+	if err != nil {
+		t.Fatalf("invalid key: %v", err)
+	}
+	return priv
+}
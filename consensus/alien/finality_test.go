@@ -0,0 +1,46 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestFinalizedNumber(t *testing.T) {
+	alien := &Alien{config: &params.AlienConfig{Period: 3, MaxSignerCount: 3}}
+	tests := []struct {
+		number    uint64
+		confirmed uint64
+		want      uint64
+	}{
+		{0, 0, 0},
+		{100, 97, 97},
+		{100, 120, 100},
+	}
+	for _, tt := range tests {
+		header := &types.Header{Number: new(big.Int).SetUint64(tt.number)}
+		if tt.number > 0 {
+			enc, err := encodeHeaderExtra(alien.config, header.Number, HeaderExtra{
+				ConfirmedBlockNumber: tt.confirmed,
+				FlowHarvest:          big.NewInt(0),
+				CurLeaseSpace:        big.NewInt(0),
+			})
+			if err != nil {
+				t.Fatalf("block %d: failed to encode: %v", tt.number, err)
+			}
+			header.Extra = append(append(make([]byte, extraVanity), enc...), make([]byte, extraSeal)...)
+		}
+		have, err := alien.FinalizedNumber(header)
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve finalized number: %v", tt.number, err)
+		}
+		if have != tt.want {
+			t.Errorf("block %d: finalized number mismatch: have %d, want %d", tt.number, have, tt.want)
+		}
+	}
+	if _, err := alien.FinalizedNumber(&types.Header{Number: big.NewInt(1)}); err != errMissingSignature {
+		t.Errorf("missing extra: have %v, want %v", err, errMissingSignature)
+	}
+}
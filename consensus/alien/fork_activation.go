@@ -0,0 +1,86 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+)
+
+// activatePledgeRevertLock sets up the SRT trie and the exit lock of the
+// reverted pledges, on the block before PledgeRevertLockEffectNumber.
+func (s *Snapshot) activatePledgeRevertLock(db ethdb.Database) error {
+	var err error
+	if s.SRT, err = NewSRT(common.Hash{}, db); err != nil {
+		return err
+	}
+	s.FlowRevenue.PosPgExitLock = NewLockData(LOCKPOSEXITDATA)
+	return nil
+}
+
+// activatePosNew moves the candidates onto the new pos pledges, on the block
+// before PosNewEffectNumber.
+func (s *Snapshot) activatePosNew(header *types.Header, db ethdb.Database) {
+	s.PosPledge = make(map[common.Address]*PosPledgeItem, 0)
+	s.FlowRevenue.PosExitLock = NewLockData(LOCKPEXITDATA)
+	s.initPosPledge(header.Number.Uint64())
+	s.initPosExitPunish(header, db)
+}
+
+// activatePoCrsAccCal starts accumulating the total lease space, on the block
+// before PoCrsAccCalNumber.
+func (s *Snapshot) activatePoCrsAccCal() {
+	s.TotalLeaseSpace = new(big.Int).Set(initTotalLeaseSpace)
+}
+
+// activateStorageManager sets up the storage entrusts, the sp pools and their
+// locks, on the block before initStorageManagerNumber.
+func (s *Snapshot) activateStorageManager(number uint64) {
+	s.initStorageManager()
+	s.FlowRevenue.STPEntrustExitLock = NewLockData(LOCKSTPEEXITDATA)
+	s.FlowRevenue.STPEntrustLock = NewLockData(LOCKSTPEDATA)
+	s.FlowRevenue.SpLock = NewLockData(LOCKSPLOCKDATA)
+	s.FlowRevenue.SpEntrustLock = NewLockData(LOCKSPETTTDATA)
+	s.FlowRevenue.SpExitLock = NewLockData(LOCKSPEXITDATA)
+	s.FlowRevenue.SpEntrustExitLock = NewLockData(LOCKSPETTEXITDATA)
+	s.SpData = NewSPSnap()
+	s.initSpData(number)
+}
+
+// activateGenesisForks runs the activations of the rule changes scheduled at
+// block 0 or 1 on the genesis snapshot. They are otherwise run while applying
+// the block before the fork, which for these is the genesis block or none.
+func (s *Snapshot) activateGenesisForks(genesis *types.Header, db ethdb.Database) error {
+	if PledgeRevertLockEffectNumber <= 1 {
+		if err := s.activatePledgeRevertLock(db); err != nil {
+			return err
+		}
+	}
+	if PosNewEffectNumber <= 1 {
+		s.activatePosNew(genesis, db)
+	}
+	if PoCrsAccCalNumber <= 1 {
+		s.activatePoCrsAccCal()
+	}
+	if initStorageManagerNumber <= 1 {
+		s.activateStorageManager(genesis.Number.Uint64())
+	}
+	return nil
+}
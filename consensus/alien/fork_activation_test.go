@@ -0,0 +1,60 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestForkSchedule(t *testing.T) {
+	defer setForkSchedule(nil)
+
+	if StorageEffectBlockNumber != params.MainnetAlienForks.StorageBlock.Uint64() {
+		t.Fatalf("default schedule not mainnet: have %d, want %v", StorageEffectBlockNumber, params.MainnetAlienForks.StorageBlock)
+	}
+	forks := new(params.AlienForks)
+	for _, field := range []**big.Int{
+		&forks.PledgeRevertLockBlock, &forks.PosNewBlock, &forks.PoCrsAccCalBlock, &forks.StorageManagerBlock,
+	} {
+		*field = big.NewInt(1)
+	}
+	forks.SignFixBlock = big.NewInt(0)
+	setForkSchedule(forks)
+	if signFixBlockNumber != 0 || initStorageManagerNumber != 1 {
+		t.Errorf("configured heights not applied: %d %d", signFixBlockNumber, initStorageManagerNumber)
+	}
+	if lockMergeNumber != params.MainnetAlienForks.LockMergeBlock.Uint64() {
+		t.Errorf("unset height not mainnet: have %d, want %v", lockMergeNumber, params.MainnetAlienForks.LockMergeBlock)
+	}
+	if isGEStorageMinCapacityEffect(101899) || !isGEStorageMinCapacityEffect(101900) {
+		t.Errorf("storage capacity minimum not lifted at its mainnet height: %d", StorageMinCapacityEffectNumber)
+	}
+
+	// Rule changes active from block 1 are set up on the genesis snapshot
+	snap := newCodecTestSnapshot()
+	snap.SpData = nil
+	snap.StorageData = NewStorageSnap()
+	genesis := &types.Header{Number: big.NewInt(0)}
+	if err := snap.activateGenesisForks(genesis, rawdb.NewMemoryDatabase()); err != nil {
+		t.Fatalf("failed to activate forks: %v", err)
+	}
+	if snap.SRT == nil || snap.SpData == nil || snap.TotalLeaseSpace == nil {
+		t.Errorf("fork state not set up: srt %v, sp data %v, lease space %v", snap.SRT, snap.SpData, snap.TotalLeaseSpace)
+	}
+	voter := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	if _, ok := snap.PosPledge[voter]; !ok {
+		t.Errorf("tally not moved onto pos pledges")
+	}
+	if snap.FlowRevenue.SpLock == nil || snap.FlowRevenue.PosExitLock == nil {
+		t.Errorf("fork locks not set up")
+	}
+
+	setForkSchedule(nil)
+	if initStorageManagerNumber != params.MainnetAlienForks.StorageManagerBlock.Uint64() {
+		t.Errorf("mainnet schedule not restored: have %d", initStorageManagerNumber)
+	}
+}
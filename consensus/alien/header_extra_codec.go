@@ -0,0 +1,190 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+)
+
+// Header extra versions. From headerExtraVersionNumber on the RLP encoding of
+// the header extra is preceded by its version byte. Extras sealed before carry
+// no version byte, their layout is recognised by the number of fields of their
+// RLP list. Version bytes stay below 0xc0, the smallest leading byte of a list.
+const (
+	headerExtraVersionOld       byte = iota + 1 // OldHeaderExtra
+	headerExtraVersionStorageV1                 // StorageHeaderExtraV1
+	headerExtraVersionStorageV2                 // StorageHeaderExtraV2
+	headerExtraVersionStorageV3                 // StorageHeaderExtraV3
+	headerExtraVersionStorageV4                 // StorageHeaderExtraV4
+	headerExtraVersionV5                        // HeaderExtraV5
+	headerExtraVersionV6                        // HeaderExtraV6
+	headerExtraVersionV7                        // HeaderExtraV7
+	headerExtraVersionV8                        // HeaderExtraV8
+	headerExtraVersionV9                        // HeaderExtra, with the double sign punishments
+
+	headerExtraVersionCurrent = headerExtraVersionV9
+	headerExtraVersionMax     = 0xbf
+)
+
+var (
+	// errUnknownHeaderExtraVersion is returned if a header extra is tagged with
+	// a version without a registered codec.
+	errUnknownHeaderExtraVersion = errors.New("unknown header extra version")
+
+	// errUnknownHeaderExtraLayout is returned if an untagged header extra has
+	// more fields than any registered layout.
+	errUnknownHeaderExtraLayout = errors.New("unknown header extra layout")
+
+	// errHeaderExtraVersionTag is returned if a header extra is tagged before
+	// headerExtraVersionNumber or untagged from then on.
+	errHeaderExtraVersionTag = errors.New("header extra version tag mismatch")
+)
+
+// HeaderExtraLayout identifies the layout a header extra was encoded with.
+type HeaderExtraLayout struct {
+	Version byte   `json:"version"` // Version of the layout
+	Name    string `json:"name"`    // Name of the struct the layout was encoded from
+	Tagged  bool   `json:"tagged"`  // Whether the encoding carries its version byte
+}
+
+// headerExtraCodec is a registered layout of the header extra.
+type headerExtraCodec struct {
+	version byte
+	name    string
+	fields  int                                    // Number of fields of the RLP list
+	decode  func(b []byte, val *HeaderExtra) error // Decodes the RLP list of the layout
+}
+
+// headerExtraCodecs are the registered layouts in version order. The older
+// layouts are prefixes of the current one, so all of them decode straight into
+// a HeaderExtra.
+var headerExtraCodecs = []headerExtraCodec{
+	newHeaderExtraCodec(headerExtraVersionOld, OldHeaderExtra{}),
+	newHeaderExtraCodec(headerExtraVersionStorageV1, StorageHeaderExtraV1{}),
+	newHeaderExtraCodec(headerExtraVersionStorageV2, StorageHeaderExtraV2{}),
+	newHeaderExtraCodec(headerExtraVersionStorageV3, StorageHeaderExtraV3{}),
+	newHeaderExtraCodec(headerExtraVersionStorageV4, StorageHeaderExtraV4{}),
+	newHeaderExtraCodec(headerExtraVersionV5, HeaderExtraV5{}),
+	newHeaderExtraCodec(headerExtraVersionV6, HeaderExtraV6{}),
+	newHeaderExtraCodec(headerExtraVersionV7, HeaderExtraV7{}),
+	newHeaderExtraCodec(headerExtraVersionV8, HeaderExtraV8{}),
+	newHeaderExtraCodec(headerExtraVersionV9, HeaderExtra{}),
+}
+
+func newHeaderExtraCodec(version byte, layout interface{}) headerExtraCodec {
+	typ := reflect.TypeOf(layout)
+	return headerExtraCodec{
+		version: version,
+		name:    typ.Name(),
+		fields:  typ.NumField(),
+		decode: func(b []byte, val *HeaderExtra) error {
+			return rlp.DecodeBytes(b, val)
+		},
+	}
+}
+
+func (c *headerExtraCodec) layout(tagged bool) HeaderExtraLayout {
+	return HeaderExtraLayout{Version: c.version, Name: c.name, Tagged: tagged}
+}
+
+// headerExtraCodecByVersion returns the codec registered for a version.
+func headerExtraCodecByVersion(version byte) (*headerExtraCodec, error) {
+	for i := range headerExtraCodecs {
+		if headerExtraCodecs[i].version == version {
+			return &headerExtraCodecs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %d", errUnknownHeaderExtraVersion, version)
+}
+
+// headerExtraCodecByFields returns the codec of the first layout holding the
+// given number of fields. Fields added to the current struct after the last
+// layout change are missing from the extras sealed before them.
+func headerExtraCodecByFields(fields int) (*headerExtraCodec, error) {
+	for i := range headerExtraCodecs {
+		if headerExtraCodecs[i].fields >= fields {
+			return &headerExtraCodecs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w with %d fields", errUnknownHeaderExtraLayout, fields)
+}
+
+// isTaggedHeaderExtra reports whether an encoded header extra starts with a
+// version byte rather than with its RLP list.
+func isTaggedHeaderExtra(b []byte) bool {
+	return len(b) > 0 && b[0] <= headerExtraVersionMax
+}
+
+// encodeVersionedHeaderExtra encodes the header extra of the block at number in
+// the layout of its height, preceded by its version byte. Before the double
+// sign punishments the extra has the layout of V8, which the current one only
+// extends with the optional punishments.
+func encodeVersionedHeaderExtra(number uint64, val HeaderExtra) ([]byte, error) {
+	version := headerExtraVersionCurrent
+	if !isGEDoubleSignPunishNumber(number) {
+		version, val.DoubleSignPunish = headerExtraVersionV8, nil
+	}
+	enc, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{version}, enc...), nil
+}
+
+// decodeVersionedHeaderExtra decodes a header extra of any registered layout,
+// tagged or not, and returns the layout it was encoded with.
+func decodeVersionedHeaderExtra(b []byte, val *HeaderExtra) (HeaderExtraLayout, error) {
+	if isTaggedHeaderExtra(b) {
+		codec, err := headerExtraCodecByVersion(b[0])
+		if err != nil {
+			return HeaderExtraLayout{}, err
+		}
+		return codec.layout(true), codec.decode(b[1:], val)
+	}
+	content, _, err := rlp.SplitList(b)
+	if err != nil {
+		return HeaderExtraLayout{}, err
+	}
+	fields, err := rlp.CountValues(content)
+	if err != nil {
+		return HeaderExtraLayout{}, err
+	}
+	codec, err := headerExtraCodecByFields(fields)
+	if err != nil {
+		return HeaderExtraLayout{}, err
+	}
+	return codec.layout(false), codec.decode(b, val)
+}
+
+// DecodeHeaderExtra decodes the alien data held in the extra field of a header
+// sealed at any height, without knowledge of the fork schedule of the chain,
+// and returns the layout it was encoded with.
+func DecodeHeaderExtra(extra []byte) (*HeaderExtra, HeaderExtraLayout, error) {
+	if len(extra) < extraVanity+extraSeal {
+		return nil, HeaderExtraLayout{}, errMissingSignature
+	}
+	val := new(HeaderExtra)
+	layout, err := decodeVersionedHeaderExtra(extra[extraVanity:len(extra)-extraSeal], val)
+	if err != nil {
+		return nil, layout, err
+	}
+	return val, layout, nil
+}
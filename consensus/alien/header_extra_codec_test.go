@@ -0,0 +1,87 @@
+package alien
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestHeaderExtraCodec(t *testing.T) {
+	defer setForkSchedule(nil)
+
+	val := HeaderExtra{
+		LoopStartTime:        1000,
+		SignerQueue:          []common.Address{common.HexToAddress("0x01")},
+		ConfirmedBlockNumber: 7,
+		FlowHarvest:          big.NewInt(0),
+		CurLeaseSpace:        big.NewInt(0),
+	}
+	tests := []struct {
+		number  uint64
+		version byte
+	}{
+		{StorageEffectBlockNumber - 1, headerExtraVersionOld},
+		{StorageChBwEffectNumber - 1, headerExtraVersionStorageV1},
+		{PledgeRevertLockEffectNumber - 1, headerExtraVersionStorageV2},
+		{StoragePledgeOptEffectNumber - 1, headerExtraVersionStorageV3},
+		{PosrIncentiveEffectNumber - 1, headerExtraVersionStorageV4},
+		{PosNewEffectNumber - 1, headerExtraVersionV5},
+		{PoCrsAccCalNumber - 1, headerExtraVersionV6},
+		{initStorageManagerNumber - 1, headerExtraVersionV7},
+		{initStorageManagerNumber, headerExtraVersionV8},
+	}
+	for _, tt := range tests {
+		enc, err := encodeHeaderExtra(nil, new(big.Int).SetUint64(tt.number), val)
+		if err != nil {
+			t.Fatalf("block %d: failed to encode: %v", tt.number, err)
+		}
+		var dec HeaderExtra
+		layout, err := decodeVersionedHeaderExtra(enc, &dec)
+		if err != nil {
+			t.Fatalf("block %d: failed to decode: %v", tt.number, err)
+		}
+		if layout.Version != tt.version || layout.Tagged {
+			t.Errorf("block %d: layout mismatch: have %+v, want version %d", tt.number, layout, tt.version)
+		}
+		if dec.LoopStartTime != val.LoopStartTime || !reflect.DeepEqual(dec.SignerQueue, val.SignerQueue) {
+			t.Errorf("block %d: decoded extra mismatch: have %+v", tt.number, dec)
+		}
+	}
+
+	// From the version fork on the extras carry their version, V8 until the
+	// double sign punishments are scheduled
+	setForkSchedule(&params.AlienForks{HeaderExtraVersionBlock: new(big.Int).SetUint64(initStorageManagerNumber + 1)})
+	number := new(big.Int).SetUint64(headerExtraVersionNumber)
+	enc, err := encodeHeaderExtra(nil, number, val)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	if enc[0] != headerExtraVersionV8 {
+		t.Fatalf("version byte mismatch: have %d, want %d", enc[0], headerExtraVersionV8)
+	}
+	var dec HeaderExtra
+	if err := decodeHeaderExtra(nil, number, enc, &dec); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	if dec.ConfirmedBlockNumber != val.ConfirmedBlockNumber {
+		t.Errorf("decoded extra mismatch: have %+v", dec)
+	}
+	if err := decodeHeaderExtra(nil, new(big.Int).Sub(number, common.Big1), enc, &dec); err != errHeaderExtraVersionTag {
+		t.Errorf("tagged extra before the fork: have %v, want %v", err, errHeaderExtraVersionTag)
+	}
+	if err := decodeHeaderExtra(nil, number, enc[1:], &dec); err != errHeaderExtraVersionTag {
+		t.Errorf("untagged extra after the fork: have %v, want %v", err, errHeaderExtraVersionTag)
+	}
+	extra := append(append(make([]byte, extraVanity), enc...), make([]byte, extraSeal)...)
+	if _, layout, err := DecodeHeaderExtra(extra); err != nil || layout.Version != headerExtraVersionV8 || !layout.Tagged {
+		t.Errorf("header extra not decoded: layout %+v, err %v", layout, err)
+	}
+	enc[0] = headerExtraVersionCurrent + 1
+	if _, err := decodeVersionedHeaderExtra(enc, &dec); !errors.Is(err, errUnknownHeaderExtraVersion) {
+		t.Errorf("unknown version: have %v, want %v", err, errUnknownHeaderExtraVersion)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"reflect"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+)
+
+// HeaderExtraFieldDiff is a header extra field whose locally computed value
+// does not match the value sealed in the block.
+type HeaderExtraFieldDiff struct {
+	Field  string      `json:"field"`
+	Error  string      `json:"error"`
+	Local  interface{} `json:"local"`
+	Sealed interface{} `json:"sealed"`
+}
+
+// HeaderExtraDiff lists every header extra field of a block which fails the
+// verification done on import, where verifyHeaderExtern stops at the first one.
+type HeaderExtraDiff struct {
+	Number uint64                 `json:"number"`
+	Hash   common.Hash            `json:"hash"`
+	Fields []HeaderExtraFieldDiff `json:"fields"`
+}
+
+// diffHeaderExtra runs all header extra checks and collects the failing fields.
+func diffHeaderExtra(current *HeaderExtra, verify *HeaderExtra) []HeaderExtraFieldDiff {
+	currentVal, verifyVal := reflect.ValueOf(current).Elem(), reflect.ValueOf(verify).Elem()
+	diff := make([]HeaderExtraFieldDiff, 0)
+	for _, check := range headerExtraChecks {
+		if err := check.verify(current, verify); err != nil {
+			diff = append(diff, HeaderExtraFieldDiff{
+				Field:  check.field,
+				Error:  err.Error(),
+				Local:  currentVal.FieldByName(check.field).Interface(),
+				Sealed: verifyVal.FieldByName(check.field).Interface(),
+			})
+		}
+	}
+	return diff
+}
+
+// DiffHeaderExtra compares the extra of a header finalized locally with the
+// extra of the sealed header of the same block.
+func (a *Alien) DiffHeaderExtra(header *types.Header, sealed *types.Header) (*HeaderExtraDiff, error) {
+	if len(header.Extra) < extraVanity+extraSeal || len(sealed.Extra) < extraVanity+extraSeal {
+		return nil, errMissingSignature
+	}
+	currentHExtra := HeaderExtra{}
+	err := decodeHeaderExtra(a.config, header.Number, header.Extra[extraVanity:len(header.Extra)-extraSeal], &currentHExtra)
+	if err != nil {
+		return nil, err
+	}
+	verifyHExtra := HeaderExtra{}
+	err = decodeHeaderExtra(a.config, sealed.Number, sealed.Extra[extraVanity:len(sealed.Extra)-extraSeal], &verifyHExtra)
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderExtraDiff{
+		Number: sealed.Number.Uint64(),
+		Hash:   sealed.Hash(),
+		Fields: diffHeaderExtra(&currentHExtra, &verifyHExtra),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+func TestDiffHeaderExtra(t *testing.T) {
+	current := &HeaderExtra{
+		ExchangeNFC:    []ExchangeNFCRecord{{Target: common.HexToAddress(addr1), Amount: big.NewInt(60)}},
+		ConfigExchRate: 10,
+		SRTDataRoot:    common.HexToHash("0x01"),
+	}
+	verify := &HeaderExtra{
+		ExchangeNFC:    []ExchangeNFCRecord{{Target: common.HexToAddress(addr1), Amount: big.NewInt(60)}},
+		ConfigExchRate: 11,
+		SRTDataRoot:    common.HexToHash("0x02"),
+	}
+	if diff := diffHeaderExtra(current, current); len(diff) != 0 {
+		t.Fatalf("equal header extras differ: %v", diff)
+	}
+	diff := diffHeaderExtra(current, verify)
+	if len(diff) != 2 {
+		t.Fatalf("diff length mismatch: have %d, want 2: %v", len(diff), diff)
+	}
+	if diff[0].Field != "ConfigExchRate" || diff[0].Local != uint32(10) || diff[0].Sealed != uint32(11) {
+		t.Errorf("ConfigExchRate diff mismatch: %+v", diff[0])
+	}
+	if diff[1].Field != "SRTDataRoot" || diff[1].Local != current.SRTDataRoot || diff[1].Sealed != verify.SRTDataRoot {
+		t.Errorf("SRTDataRoot diff mismatch: %+v", diff[1])
+	}
+	if err := verifyHeaderExtern(current, verify); err == nil || err.Error() != diff[0].Error {
+		t.Errorf("first diff does not match verification error: have %v, want %s", err, diff[0].Error)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+	"github.com/UltronGlow/UltronGlow-Origin/trie"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// errNoStoredSnapshot is returned if no checkpoint snapshot is stored at or
+// below the requested block on the canonical chain.
+var errNoStoredSnapshot = errors.New("no stored snapshot")
+
+// Inspector gives read only access to the checkpoint snapshots stored in a
+// chain database, for offline tools working on the datadir of a stopped node.
+type Inspector struct {
+	db       ethdb.Database
+	config   *params.AlienConfig
+	sigcache *lru.ARCCache
+}
+
+// NewInspector creates an inspector for the snapshots stored in db. It fails
+// if the process already runs on the fork or time table of another config.
+func NewInspector(db ethdb.Database, config *params.AlienConfig) (*Inspector, error) {
+	conf := *config
+	if conf.Period == 0 {
+		conf.Period = defaultBlockPeriod
+	}
+	if err := applySchedule(&conf); err != nil {
+		return nil, err
+	}
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+	return &Inspector{db: db, config: &conf, sigcache: sigcache}, nil
+}
+
+// Snapshot loads the stored snapshot of the last checkpoint at or below number
+// on the canonical chain. Checkpoints whose snapshot was pruned are skipped.
+func (i *Inspector) Snapshot(number uint64) (*Snapshot, error) {
+	for n := number - number%checkpointInterval; ; n -= checkpointInterval {
+		if hash := rawdb.ReadCanonicalHash(i.db, n); hash != (common.Hash{}) {
+			if snap, err := i.SnapshotAtHash(hash); err == nil {
+				return snap, nil
+			}
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("%w at or below %d", errNoStoredSnapshot, number)
+		}
+	}
+}
+
+// SnapshotAtHash loads the stored snapshot of the checkpoint with the given hash.
+func (i *Inspector) SnapshotAtHash(hash common.Hash) (*Snapshot, error) {
+	return openSnapshot(i.config, i.sigcache, i.db, hash, false)
+}
+
+// LockRecords returns the lock records of the given lock type held in the lock
+// trie with the given roots.
+func (i *Inspector) LockRecords(root, releaseRoot common.Hash, lockType uint8) (map[common.Address][]LockRecord, error) {
+	lockTrie, err := NewLockTrie(root, releaseRoot, i.db, i.config.Period)
+	if err != nil {
+		return nil, err
+	}
+	return lockTrie.GetLockRecords(lockType), nil
+}
+
+// SnapshotCheck is the outcome of one consistency check of a stored snapshot.
+type SnapshotCheck struct {
+	Name string `json:"name"`
+	OK   bool   `json:"ok"`
+	Have string `json:"have,omitempty"`
+	Want string `json:"want,omitempty"`
+}
+
+// VerifySnapshot checks that the accumulated hashes kept in a stored snapshot
+// match its content and that its SRT trie is complete. The snapshot is loaded
+// on its own, as recomputing the hashes overwrites them.
+func (i *Inspector) VerifySnapshot(hash common.Hash) ([]SnapshotCheck, error) {
+	snap, err := i.SnapshotAtHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	var checks []SnapshotCheck
+	check := func(name string, have, want common.Hash) {
+		checks = append(checks, SnapshotCheck{Name: name, OK: have == want, Have: have.Hex(), Want: want.Hex()})
+	}
+	// The totals are checked against the stored item hashes before those are recomputed
+	if snap.StorageData != nil {
+		stored := snap.StorageData.Hash
+		check("storage data", snap.StorageData.accumulateHeaderHash(), stored)
+		for addr, pledge := range snap.StorageData.StoragePledge {
+			if pledge.StorageSpaces == nil {
+				continue
+			}
+			stored := pledge.Hash
+			check("storage pledge "+addr.Hex(), snap.StorageData.accumulatePledgeHash(addr), stored)
+		}
+	}
+	if snap.SpData != nil {
+		stored := snap.SpData.Hash
+		check("sp data", snap.SpData.accumulateSpDataHash(), stored)
+		for poolHash, pool := range snap.SpData.PoolPledge {
+			stored := pool.Hash
+			check("sp pool "+poolHash.Hex(), snap.SpData.accumulateSpPledgelHash(poolHash, false), stored)
+		}
+	}
+	if snap.SRT != nil {
+		check("srt root", snap.SRT.Root(), snap.SRTHash)
+		if srt, ok := snap.SRT.(*SrtTrie); ok {
+			it := trie.NewIterator(srt.trie.NodeIterator(nil))
+			for it.Next() {
+			}
+			result := SnapshotCheck{Name: "srt trie", OK: it.Err == nil}
+			if it.Err != nil {
+				result.Have = it.Err.Error()
+			}
+			checks = append(checks, result)
+		}
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks, nil
+}
@@ -0,0 +1,176 @@
+package alien
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestInspectorSnapshot(t *testing.T) {
+	t.Cleanup(resetSchedule)
+
+	db := rawdb.NewMemoryDatabase()
+	snap := newCodecTestSnapshot()
+	snap.Number = checkpointInterval
+	snap.StorageData.accumulateHeaderHash()
+	snap.SpData.accumulateSpDataHash()
+	if _, err := writeSnapshot(db, snap, nil); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	rawdb.WriteCanonicalHash(db, snap.Hash, snap.Number)
+	inspector, err := NewInspector(db, snap.config)
+	if err != nil {
+		t.Fatalf("failed to create inspector: %v", err)
+	}
+
+	loaded, err := inspector.Snapshot(snap.Number + checkpointInterval - 1)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if loaded.Hash != snap.Hash {
+		t.Errorf("snapshot hash mismatch: have %x, want %x", loaded.Hash, snap.Hash)
+	}
+	if _, err := inspector.Snapshot(snap.Number - 1); !errors.Is(err, errNoStoredSnapshot) {
+		t.Errorf("snapshot below the first checkpoint: have %v, want %v", err, errNoStoredSnapshot)
+	}
+
+	checks, err := inspector.VerifySnapshot(snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to verify snapshot: %v", err)
+	}
+	if len(checks) == 0 {
+		t.Fatalf("no checks run")
+	}
+	for _, c := range checks {
+		if !c.OK {
+			t.Errorf("check %q failed: have %s, want %s", c.Name, c.Have, c.Want)
+		}
+	}
+
+	// A snapshot whose accumulated hash does not match its content fails
+	snap.Hash = common.HexToHash("0x05")
+	snap.StorageData.Hash = common.HexToHash("0x06")
+	if _, err := writeSnapshot(db, snap, nil); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	checks, err = inspector.VerifySnapshot(snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to verify snapshot: %v", err)
+	}
+	for _, c := range checks {
+		if c.Name == "storage data" && c.OK {
+			t.Errorf("tampered storage data hash not detected")
+		}
+	}
+}
+
+func TestDiffSnapshotFields(t *testing.T) {
+	stored := newCodecTestSnapshot()
+	blob, _ := encodeSnapshot(stored)
+	replay := new(Snapshot)
+	if _, err := decodeSnapshot(blob, replay); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if diff, err := diffSnapshotFields(replay, stored); err != nil || len(diff) != 0 {
+		t.Fatalf("copied snapshot differs: %v %v", diff, err)
+	}
+	voter := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	replay.Tally[voter] = big.NewInt(1001)
+	replay.SRTHash = common.HexToHash("0x07")
+	delete(replay.Tally, common.HexToAddress("0x02"))
+
+	diff, err := diffSnapshotFields(replay, stored)
+	if err != nil {
+		t.Fatalf("failed to diff snapshots: %v", err)
+	}
+	want := []SnapshotFieldDiff{
+		{Path: "srthash", Replay: common.HexToHash("0x07").Hex(), Stored: common.Hash{}.Hex()},
+		{Path: "tally.0x0000000000000000000000000000000000000002", Stored: "-5"},
+		{Path: "tally." + strings.ToLower(voter.Hex()), Replay: "1001", Stored: "1000"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("diff mismatch\n have %v\n want %v", diff, want)
+	}
+}
+
+func TestReplayDatabase(t *testing.T) {
+	backing := rawdb.NewMemoryDatabase()
+	backing.Put([]byte("a"), []byte("1"))
+	backing.Put([]byte("b"), []byte("2"))
+
+	db := newReplayDatabase(backing)
+	db.Put([]byte("a"), []byte("3"))
+	batch := db.NewBatch()
+	batch.Delete([]byte("b"))
+	batch.Put([]byte("c"), []byte("4"))
+	if err := batch.Write(); err != nil {
+		t.Fatalf("failed to write batch: %v", err)
+	}
+	if val, _ := db.Get([]byte("a")); string(val) != "3" {
+		t.Errorf("overlay value not read: %q", val)
+	}
+	if ok, _ := db.Has([]byte("b")); ok {
+		t.Errorf("deleted key still present")
+	}
+	if val, _ := db.Get([]byte("c")); string(val) != "4" {
+		t.Errorf("batch value not read: %q", val)
+	}
+	if val, _ := backing.Get([]byte("a")); string(val) != "1" {
+		t.Errorf("backing database modified: %q", val)
+	}
+	if ok, _ := backing.Has([]byte("c")); ok {
+		t.Errorf("backing database modified")
+	}
+}
+
+// Tests that the inspector replays the snapshots of a chain running in scaled
+// time with the time table of its config, not the one of the mainnet.
+func TestInspectorReplayScaledTime(t *testing.T) {
+	start := uint64(time.Now().Add(-240 * time.Hour).Unix())
+	clock := &simClock{now: time.Unix(int64(start), 0)}
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	config := &params.AlienConfig{
+		Period:           1,
+		Epoch:            defaultEpochLength,
+		MaxSignerCount:   1,
+		MinVoterBalance:  big.NewInt(0),
+		GenesisTimestamp: start + 1,
+		SelfVoteSigners:  []common.UnprefixedAddress{common.UnprefixedAddress(signer)},
+		Forks:            params.GenesisAlienForks(),
+		Times:            &params.AlienTimes{Scale: 2880},
+	}
+	chain := newSimChain(t, clock, 1337, config, common.Hash{}, core.GenesisAlloc{signer: {Balance: simBalance}}, nil)
+	chain.keys[signer] = key
+
+	// Mine past a lock round, paying the signer rewards of the scaled days. The
+	// snapshot of the last checkpoint is only stored once a block follows it.
+	for number := uint64(1); number <= 3*checkpointInterval+1; number++ {
+		block := chain.mine()
+		clock.advance(block.Time())
+	}
+	// The inspector runs in a process of its own, starting on the mainnet tables
+	resetSchedule()
+	inspector, err := NewInspector(chain.engine.db, config)
+	if err != nil {
+		t.Fatalf("failed to create inspector: %v", err)
+	}
+
+	trusted := rawdb.ReadCanonicalHash(chain.engine.db, checkpointInterval)
+	replay, err := inspector.ReplaySnapshots(trusted, 3*checkpointInterval)
+	if err != nil {
+		t.Fatalf("failed to replay snapshots: %v", err)
+	}
+	if replay.Checked != 2 || len(replay.Diff) != 0 {
+		t.Errorf("replay diverged at %d after %d checkpoints: %v", replay.Number, replay.Checked, replay.Diff)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+)
+
+// The lifecycle of the storage leases is journaled as the headers are applied
+// to the snapshots, since the snapshots only hold the current state of a lease
+// and drop the lease once it is returned. The events of a block are stored
+// under the number and hash of the block, so the events of blocks which left
+// the canonical chain are told apart when read.
+var (
+	leaseBlockPrefix   = []byte("alien-lease-block-")   // leaseBlockPrefix + number (uint64 big endian) + hash -> events of the block
+	leaseHistoryPrefix = []byte("alien-lease-history-") // leaseHistoryPrefix + lease + number (uint64 big endian) + hash -> events of the lease in the block
+	leaseTenantPrefix  = []byte("alien-lease-tenant-")  // leaseTenantPrefix + tenant + lease -> nil
+	leasePledgePrefix  = []byte("alien-lease-pledge-")  // leasePledgePrefix + pledge + lease -> nil
+)
+
+// Kinds of the lease events.
+const (
+	LeaseEventRequested        = "requested"        // The tenant requested the lease
+	LeaseEventPledged          = "pledged"          // The storage pool pledged the lease
+	LeaseEventRenewalRequested = "renewalRequested" // The tenant requested a renewal of the lease
+	LeaseEventRenewed          = "renewed"          // The storage pool pledged a renewal of the lease
+	LeaseEventRescinded        = "rescinded"        // The tenant rescinded the lease
+	LeaseEventExpired          = "expired"          // The lease ran out
+	LeaseEventBreached         = "breached"         // The storage pool failed the verifications of the lease
+	LeaseEventReturned         = "returned"         // The deposits of the lease were returned
+	LeaseEventStatus           = "status"           // Any other change of the lease status
+	LeaseEventRemoved          = "removed"          // The lease was dropped from the storage pledge
+)
+
+// LeaseEvent is a step in the lifecycle of a storage lease.
+type LeaseEvent struct {
+	Lease       common.Hash    `json:"lease"`
+	Pledge      common.Address `json:"pledge"`
+	Tenant      common.Address `json:"tenant"`
+	Kind        string         `json:"kind"`
+	PrevStatus  int            `json:"prevStatus"`
+	Status      int            `json:"status"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+}
+
+// leaseState is the part of a lease the lifecycle is followed on.
+type leaseState struct {
+	pledge  common.Address
+	tenant  common.Address
+	status  int
+	details int // Requests of the lease and its renewals
+	pledged int // Requests pledged by the storage pool
+}
+
+// leasePledges returns the storage pledges whose leases the block of number and
+// headerExtra changes, from the lease and pledge records of the header extra.
+// It returns nil for the blocks of the daily storage verification, which expire,
+// breach and drop leases on every storage pledge.
+func (s *Snapshot) leasePledges(headerExtra HeaderExtra, number uint64) map[common.Address]struct{} {
+	if isFixLeaseCapacity(number) || isStorageVerificationCheck(number, s.Period) {
+		return nil
+	}
+	if isGEInitStorageManagerNumber(number) && isSpDelExit(number, s.Period) {
+		return nil
+	}
+	pledges := make(map[common.Address]struct{})
+	for _, item := range headerExtra.LeaseRequest {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.LeasePledge {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.LeaseRenewal {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.LeaseRenewalPledge {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.LeaseRescind {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.StoragePledgeExit {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.StorageRecoveryData {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.StorageProofRecord {
+		pledges[item.Address] = struct{}{}
+	}
+	for _, item := range headerExtra.SPMigration {
+		pledges[item.Pledge] = struct{}{}
+	}
+	return pledges
+}
+
+// leaseStates captures the lifecycle state of the leases of the given storage
+// pledges, or of every lease in the storage data if pledges is nil.
+func (s *StorageData) leaseStates(pledges map[common.Address]struct{}) map[common.Hash]leaseState {
+	states := make(map[common.Hash]leaseState)
+	if s == nil {
+		return states
+	}
+	if pledges == nil {
+		for pledgeAddr, pledge := range s.StoragePledge {
+			s.addLeaseStates(states, pledgeAddr, pledge)
+		}
+		return states
+	}
+	for pledgeAddr := range pledges {
+		if pledge, ok := s.StoragePledge[pledgeAddr]; ok {
+			s.addLeaseStates(states, pledgeAddr, pledge)
+		}
+	}
+	return states
+}
+
+// addLeaseStates captures the lifecycle state of the leases of a storage pledge.
+func (s *StorageData) addLeaseStates(states map[common.Hash]leaseState, pledgeAddr common.Address, pledge *SPledge) {
+	for hash, lease := range pledge.Lease {
+		state := leaseState{
+			pledge:  pledgeAddr,
+			tenant:  lease.Address,
+			status:  lease.Status,
+			details: len(lease.LeaseList),
+		}
+		for _, detail := range lease.LeaseList {
+			if detail.StartTime != nil && detail.StartTime.Sign() > 0 {
+				state.pledged++
+			}
+		}
+		states[hash] = state
+	}
+}
+
+// leaseStatusEvent returns the kind of the event moving a lease into status.
+func leaseStatusEvent(prev int, status int) string {
+	switch status {
+	case LeaseNormal:
+		if prev == LeaseNotPledged {
+			return LeaseEventPledged
+		}
+	case LeaseUserRescind:
+		return LeaseEventRescinded
+	case LeaseExpiration:
+		return LeaseEventExpired
+	case LeaseBreach:
+		return LeaseEventBreached
+	case LeaseReturn:
+		return LeaseEventReturned
+	}
+	return LeaseEventStatus
+}
+
+// diffLeaseStates returns the events moving the leases from the states before
+// header to those after it, ordered by lease.
+func diffLeaseStates(before, after map[common.Hash]leaseState, header *types.Header) []*LeaseEvent {
+	var (
+		events []*LeaseEvent
+		number = header.Number.Uint64()
+		hash   = header.Hash()
+	)
+	event := func(lease common.Hash, state leaseState, kind string, prev int) {
+		events = append(events, &LeaseEvent{
+			Lease:       lease,
+			Pledge:      state.pledge,
+			Tenant:      state.tenant,
+			Kind:        kind,
+			PrevStatus:  prev,
+			Status:      state.status,
+			BlockNumber: number,
+			BlockHash:   hash,
+		})
+	}
+	for lease, cur := range after {
+		old, ok := before[lease]
+		if !ok {
+			event(lease, cur, LeaseEventRequested, cur.status)
+			if cur.status != LeaseNotPledged {
+				event(lease, cur, leaseStatusEvent(LeaseNotPledged, cur.status), LeaseNotPledged)
+			}
+			continue
+		}
+		if cur.details > old.details {
+			event(lease, cur, LeaseEventRenewalRequested, old.status)
+		}
+		if cur.status != old.status {
+			event(lease, cur, leaseStatusEvent(old.status, cur.status), old.status)
+		} else if cur.pledged > old.pledged && cur.status == LeaseNormal {
+			event(lease, cur, LeaseEventRenewed, old.status)
+		}
+	}
+	for lease, old := range before {
+		if _, ok := after[lease]; !ok {
+			event(lease, old, LeaseEventRemoved, old.status)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return bytes.Compare(events[i].Lease[:], events[j].Lease[:]) < 0
+	})
+	return events
+}
+
+func leaseBlockKey(number uint64, hash common.Hash) []byte {
+	key := make([]byte, len(leaseBlockPrefix)+8+common.HashLength)
+	copy(key, leaseBlockPrefix)
+	binary.BigEndian.PutUint64(key[len(leaseBlockPrefix):], number)
+	copy(key[len(leaseBlockPrefix)+8:], hash[:])
+	return key
+}
+
+func leaseHistoryKey(lease common.Hash, number uint64, hash common.Hash) []byte {
+	key := make([]byte, len(leaseHistoryPrefix)+common.HashLength+8+common.HashLength)
+	n := copy(key, leaseHistoryPrefix)
+	n += copy(key[n:], lease[:])
+	binary.BigEndian.PutUint64(key[n:], number)
+	copy(key[n+8:], hash[:])
+	return key
+}
+
+func leaseOwnerKey(prefix []byte, owner common.Address, lease common.Hash) []byte {
+	key := make([]byte, 0, len(prefix)+common.AddressLength+common.HashLength)
+	key = append(key, prefix...)
+	key = append(key, owner[:]...)
+	return append(key, lease[:]...)
+}
+
+// journalLeaseEvents stores the lease events of a block. Applying the same
+// block again overwrites its entries with the same events.
+func journalLeaseEvents(db ethdb.Database, events []*LeaseEvent) error {
+	if db == nil || len(events) == 0 {
+		return nil
+	}
+	batch := db.NewBatch()
+	blob, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(leaseBlockKey(events[0].BlockNumber, events[0].BlockHash), blob); err != nil {
+		return err
+	}
+	for i := 0; i < len(events); {
+		j := i + 1
+		for j < len(events) && events[j].Lease == events[i].Lease {
+			j++
+		}
+		ev := events[i]
+		if blob, err = json.Marshal(events[i:j]); err != nil {
+			return err
+		}
+		if err := batch.Put(leaseHistoryKey(ev.Lease, ev.BlockNumber, ev.BlockHash), blob); err != nil {
+			return err
+		}
+		if err := batch.Put(leaseOwnerKey(leaseTenantPrefix, ev.Tenant, ev.Lease), nil); err != nil {
+			return err
+		}
+		if err := batch.Put(leaseOwnerKey(leasePledgePrefix, ev.Pledge, ev.Lease), nil); err != nil {
+			return err
+		}
+		i = j
+	}
+	return batch.Write()
+}
+
+// readBlockLeaseEvents retrieves the lease events journaled for a block, none if
+// the block changed no lease or was not applied yet.
+func readBlockLeaseEvents(db ethdb.Database, number uint64, hash common.Hash) ([]*LeaseEvent, error) {
+	blob, err := db.Get(leaseBlockKey(number, hash))
+	if err != nil {
+		return nil, nil
+	}
+	var events []*LeaseEvent
+	if err := json.Unmarshal(blob, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// readLeaseHistory retrieves the events journaled for a lease, oldest first,
+// keeping only those of the blocks canonical accepts.
+func readLeaseHistory(db ethdb.Database, lease common.Hash, canonical func(number uint64, hash common.Hash) bool) ([]*LeaseEvent, error) {
+	prefix := append(append([]byte{}, leaseHistoryPrefix...), lease[:]...)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var history []*LeaseEvent
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if !canonical(number, common.BytesToHash(key[len(prefix)+8:])) {
+			continue
+		}
+		var events []*LeaseEvent
+		if err := json.Unmarshal(it.Value(), &events); err != nil {
+			return nil, err
+		}
+		history = append(history, events...)
+	}
+	return history, it.Error()
+}
+
+// readOwnerLeases retrieves the leases journaled for a tenant or a storage pledge.
+func readOwnerLeases(db ethdb.Database, prefix []byte, owner common.Address) ([]common.Hash, error) {
+	prefix = append(append([]byte{}, prefix...), owner[:]...)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var leases []common.Hash
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+common.HashLength {
+			leases = append(leases, common.BytesToHash(key[len(prefix):]))
+		}
+	}
+	return leases, it.Error()
+}
+
+// journalLeases journals the events moving the leases of the given storage
+// pledges from the states before header to the current ones.
+func (s *Snapshot) journalLeases(before map[common.Hash]leaseState, pledges map[common.Address]struct{}, header *types.Header, db ethdb.Database) {
+	if db == nil || (pledges != nil && len(pledges) == 0) {
+		return
+	}
+	events := diffLeaseStates(before, s.StorageData.leaseStates(pledges), header)
+	if err := journalLeaseEvents(db, events); err != nil {
+		log.Warn("Failed to journal lease events", "number", header.Number, "err", err)
+	}
+}
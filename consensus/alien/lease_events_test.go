@@ -0,0 +1,147 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+)
+
+// Tests that the lease events follow a lease from its request through its
+// renewal to the return of its deposits, and that they are read back for the
+// canonical blocks only.
+func TestLeaseEvents(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		pledge = common.HexToAddress("0x01")
+		tenant = common.HexToAddress("0x02")
+		leaseH = common.HexToHash("0x03")
+		renewH = common.HexToHash("0x04")
+		sd     = &StorageData{StoragePledge: map[common.Address]*SPledge{
+			pledge: {Lease: make(map[common.Hash]*Lease)},
+		}}
+		headers = make(map[uint64]*types.Header)
+	)
+	step := func(number uint64, extra byte, change func()) []*LeaseEvent {
+		before := sd.leaseStates(nil)
+		change()
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{extra}}
+		if extra == 0 {
+			headers[number] = header
+		}
+		events := diffLeaseStates(before, sd.leaseStates(nil), header)
+		if err := journalLeaseEvents(db, events); err != nil {
+			t.Fatalf("failed to journal block %d: %v", number, err)
+		}
+		return events
+	}
+	detail := func(requested int64) *LeaseDetail {
+		return &LeaseDetail{RequestTime: big.NewInt(requested), StartTime: big.NewInt(0)}
+	}
+	var lease *Lease
+	steps := []struct {
+		change func()
+		kinds  []string
+	}{
+		{func() {
+			lease = &Lease{Address: tenant, LeaseList: map[common.Hash]*LeaseDetail{leaseH: detail(1)}}
+			sd.StoragePledge[pledge].Lease[leaseH] = lease
+		}, []string{LeaseEventRequested}},
+		{func() {
+			lease.LeaseList[leaseH].StartTime = big.NewInt(2)
+			lease.Status = LeaseNormal
+		}, []string{LeaseEventPledged}},
+		{func() {}, nil},
+		{func() { lease.LeaseList[renewH] = detail(4) }, []string{LeaseEventRenewalRequested}},
+		{func() { lease.LeaseList[renewH].StartTime = big.NewInt(5) }, []string{LeaseEventRenewed}},
+		{func() { lease.Status = LeaseExpiration }, []string{LeaseEventExpired}},
+		{func() { lease.Status = LeaseReturn }, []string{LeaseEventReturned}},
+		{func() { delete(sd.StoragePledge[pledge].Lease, leaseH) }, []string{LeaseEventRemoved}},
+	}
+	var want []string
+	for i, s := range steps {
+		number := uint64(i + 1)
+		events := step(number, 0, s.change)
+		if len(events) != len(s.kinds) {
+			t.Fatalf("block %d: have %d events, want %d", number, len(events), len(s.kinds))
+		}
+		for j, ev := range events {
+			if ev.Kind != s.kinds[j] || ev.Lease != leaseH || ev.Pledge != pledge || ev.Tenant != tenant {
+				t.Errorf("block %d: unexpected event %+v, want kind %s", number, ev, s.kinds[j])
+			}
+		}
+		want = append(want, s.kinds...)
+	}
+	// The events of a block on another branch must not show in the history
+	step(6, 1, func() { sd.StoragePledge[pledge].Lease[leaseH] = lease; lease.Status = LeaseBreach })
+
+	canonical := func(number uint64, hash common.Hash) bool {
+		header, ok := headers[number]
+		return ok && header.Hash() == hash
+	}
+	history, err := readLeaseHistory(db, leaseH, canonical)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != len(want) {
+		t.Fatalf("history of %d events, want %d", len(history), len(want))
+	}
+	for i, ev := range history {
+		if ev.Kind != want[i] {
+			t.Errorf("history event %d: have %s, want %s", i, ev.Kind, want[i])
+		}
+	}
+	for _, prefix := range [][]byte{leaseTenantPrefix, leasePledgePrefix} {
+		owner := tenant
+		if string(prefix) == string(leasePledgePrefix) {
+			owner = pledge
+		}
+		leases, err := readOwnerLeases(db, prefix, owner)
+		if err != nil || len(leases) != 1 || leases[0] != leaseH {
+			t.Errorf("leases of %x: have %v, %v", owner, leases, err)
+		}
+	}
+	events, err := readBlockLeaseEvents(db, 6, headers[6].Hash())
+	if err != nil || len(events) != 1 || events[0].Kind != LeaseEventExpired {
+		t.Errorf("events of block 6: have %v, %v", events, err)
+	}
+}
+
+// Tests that only the storage pledges named by the lease records of a block are
+// followed, except on the blocks of the daily storage verification.
+func TestLeasePledges(t *testing.T) {
+	var (
+		touched = common.HexToAddress("0x01")
+		other   = common.HexToAddress("0x02")
+		snap    = &Snapshot{Period: 10}
+		sd      = &StorageData{StoragePledge: map[common.Address]*SPledge{
+			touched: {Lease: map[common.Hash]*Lease{common.HexToHash("0x03"): {Address: other}}},
+			other:   {Lease: map[common.Hash]*Lease{common.HexToHash("0x04"): {Address: touched}}},
+		}}
+		extra  = HeaderExtra{LeaseRescind: []LeaseRescindRecord{{Address: touched, Hash: common.HexToHash("0x03")}}}
+		number = StorageEffectBlockNumber + 1
+	)
+	if isStorageVerificationCheck(number, snap.Period) || isSpDelExit(number, snap.Period) {
+		number++
+	}
+	pledges := snap.leasePledges(extra, number)
+	if _, ok := pledges[touched]; !ok || len(pledges) != 1 {
+		t.Fatalf("pledges of the rescind: have %v, want %x", pledges, touched)
+	}
+	if states := sd.leaseStates(pledges); len(states) != 1 || states[common.HexToHash("0x03")].pledge != touched {
+		t.Errorf("states of the rescind: have %v", states)
+	}
+	if pledges := snap.leasePledges(HeaderExtra{}, number); pledges == nil || len(pledges) != 0 {
+		t.Errorf("pledges of an empty block: have %v, want none", pledges)
+	}
+	blockPerDay := secondsPerDay / snap.Period
+	check := (number/blockPerDay+1)*blockPerDay + storageVerificationCheck/snap.Period
+	if pledges := snap.leasePledges(HeaderExtra{}, check); pledges != nil {
+		t.Errorf("pledges of the verification block: have %v, want all", pledges)
+	}
+	if states := sd.leaseStates(nil); len(states) != 2 {
+		t.Errorf("states of all pledges: have %d, want 2", len(states))
+	}
+}
@@ -895,6 +895,9 @@ func (s *LockProfitSnap) updateLockData(snap *Snapshot, LockReward []LockRewardR
 	currentLockReward := make([]LockRewardNewRecord, 0)
 	blockNumber := headerNumber.Uint64()
 	for _, item := range LockReward {
+		if isGELockTrieNumber(blockNumber) && isLockTrieReward(item.IsReward) {
+			continue
+		}
 		if sscEnumSignerReward == item.IsReward {
 			if islockSimplifyEffectBlocknumber(blockNumber) {
 				s.RewardLock.addLockData(snap, item, headerNumber)
@@ -944,21 +947,7 @@ func (s *LockProfitSnap) updateLockData(snap *Snapshot, LockReward []LockRewardR
 		}
 	}
 	if isGEInitStorageManagerNumber(blockNumber) {
-		for miner, amount := range distribute {
-			details := snap.StorageData.StorageEntrust[miner].Detail
-			totalAmount := snap.StorageData.StorageEntrust[miner].PledgeAmount
-			for _, item := range details {
-				entrustAmount := new(big.Int).Mul(amount, item.Amount)
-				entrustAmount = new(big.Int).Div(entrustAmount, totalAmount)
-				currentLockReward = append(currentLockReward, LockRewardNewRecord{
-					Target:         item.Address,
-					Amount:         new(big.Int).Set(entrustAmount),
-					IsReward:       uint32(sscEnumSTEntrustLockReward),
-					SourceAddress:  miner,
-					RevenueAddress: item.Address,
-				})
-			}
-		}
+		currentLockReward = stpEntrustLockRecords(snap, distribute, currentLockReward)
 		snap.FlowRevenue.updateLockDataV1(snap, currentLockReward, headerNumber)
 		if len(distributePool) > 0 {
 			s.BandwidthLock.distributeSpReward(snap, distributePool, headerNumber)
@@ -1752,7 +1741,38 @@ func (s *LockData) updatePosEnExitLockData(snap *Snapshot, itemAmount *big.Int,
 	lockBalance[itemIsReward].Amount = new(big.Int).Add(lockBalance[itemIsReward].Amount, flowRevenusTarget.RewardBalance[itemIsReward])
 	flowRevenusTarget.RewardBalance[itemIsReward] = big.NewInt(0)
 }
+
+// stpEntrustLockRecords appends the shares of the entrusters of the storage
+// miners in distribute to currentLockReward.
+func stpEntrustLockRecords(snap *Snapshot, distribute map[common.Address]*big.Int, currentLockReward []LockRewardNewRecord) []LockRewardNewRecord {
+	for miner, amount := range distribute {
+		details := snap.StorageData.StorageEntrust[miner].Detail
+		totalAmount := snap.StorageData.StorageEntrust[miner].PledgeAmount
+		for _, item := range details {
+			entrustAmount := new(big.Int).Mul(amount, item.Amount)
+			entrustAmount = new(big.Int).Div(entrustAmount, totalAmount)
+			currentLockReward = append(currentLockReward, LockRewardNewRecord{
+				Target:         item.Address,
+				Amount:         new(big.Int).Set(entrustAmount),
+				IsReward:       uint32(sscEnumSTEntrustLockReward),
+				SourceAddress:  miner,
+				RevenueAddress: item.Address,
+			})
+		}
+	}
+	return currentLockReward
+}
+
 func (s *LockData) distributeSpReward(snap *Snapshot, spRewardRecord map[common.Hash]*big.Int, headerNumber *big.Int) {
+	currentLockReward := spRewardLockRecords(snap, spRewardRecord)
+	if len(currentLockReward) > 0 {
+		snap.FlowRevenue.updateLockDataV1(snap, currentLockReward, headerNumber)
+	}
+}
+
+// spRewardLockRecords splits the rewards of the storage pools between their
+// managers and their entrusters.
+func spRewardLockRecords(snap *Snapshot, spRewardRecord map[common.Hash]*big.Int) []LockRewardNewRecord {
 	currentLockReward := make([]LockRewardNewRecord, 0)
 	for spHash, amount := range spRewardRecord {
 		if sp, ok := snap.SpData.PoolPledge[spHash]; ok {
@@ -1786,10 +1806,7 @@ func (s *LockData) distributeSpReward(snap *Snapshot, spRewardRecord map[common.
 
 		}
 	}
-
-	if len(currentLockReward) > 0 {
-		snap.FlowRevenue.updateLockDataV1(snap, currentLockReward, headerNumber)
-	}
+	return currentLockReward
 }
 func (s *LockProfitSnap) updateLockDataV1(snap *Snapshot, LockReward []LockRewardNewRecord, headerNumber *big.Int) {
 	for _, item := range LockReward {
@@ -1865,7 +1882,7 @@ func (s *LockData) updateLockDataV1(snap *Snapshot, item LockRewardNewRecord, he
 	lockBalanceV1 := flowRevenusTarget.LockBalanceV1[lockNumber]
 	rlsPeriod := snap.SystemConfig.LockParameters[sscEnumRwdLock].RlsPeriod
 	interval := snap.SystemConfig.LockParameters[sscEnumRwdLock].Interval
-	interval = interval * utgLockRewardInterval
+	interval = interval * uint32(utgLockRewardInterval)
 	if _, ok := lockBalanceV1[item.IsReward]; !ok {
 		lockBalanceV1[item.IsReward] = make(map[common.Address]*PledgeItem, 0)
 	}
@@ -1902,7 +1919,7 @@ func (s *LockData) updateAllLockDataV1(snap *Snapshot, isReward uint32, headerNu
 		//lockPeriod := snap.SystemConfig.LockParameters[sscEnumRwdLock].LockPeriod
 		rlsPeriod := snap.SystemConfig.LockParameters[sscEnumRwdLock].RlsPeriod
 		interval := snap.SystemConfig.LockParameters[sscEnumRwdLock].Interval
-		interval = interval * utgLockRewardInterval
+		interval = interval * uint32(utgLockRewardInterval)
 		multiSignature := common.Address{}
 		for nodeAddr, item := range locktmpData {
 			revenueAddress := snap.getRevenueAddressByType(isReward, nodeAddr, item.RevenueAddress)
@@ -0,0 +1,468 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+)
+
+// The payments out of the locks of the LockProfitSnap are only sealed in the
+// header as a hash, so they are journaled as the headers are applied to the
+// snapshots, under both the owner and the revenue address of the lock. The
+// releases of the lock trie are not journaled, they are replayed on the lock
+// trie of the parent block when asked for.
+var (
+	lockReleasePrefix = []byte("alien-release-") // lockReleasePrefix + address + number (uint64 big endian) + hash -> releases of the address in the block
+)
+
+// LockReleaseEvent is a payment out of a lock.
+type LockReleaseEvent struct {
+	Target         common.Address `json:"target"`         // Owner of the lock
+	RevenueAddress common.Address `json:"revenueAddress"` // Address the lock is paid to
+	LockType       uint32         `json:"lockType"`
+	LockNumber     uint64         `json:"lockNumber"` // Block the amount was locked in
+	Amount         *big.Int       `json:"amount"`     // Amount taken out of the lock
+	Released       *big.Int       `json:"released,omitempty"`
+	Pledged        *big.Int       `json:"pledged,omitempty"`
+	Burned         *big.Int       `json:"burned,omitempty"`
+	BlockNumber    uint64         `json:"blockNumber"`
+	BlockHash      common.Hash    `json:"blockHash"`
+}
+
+// LockBucket is an amount locked at once for an owner.
+type LockBucket struct {
+	LockType       uint32         `json:"lockType"`
+	Target         common.Address `json:"target"`
+	Source         common.Address `json:"source"` // Storage miner or pool the amount comes from, if any
+	RevenueAddress common.Address `json:"revenueAddress"`
+	LockNumber     uint64         `json:"lockNumber"`
+	Total          *big.Int       `json:"total"`
+	Released       *big.Int       `json:"released"`
+	Pledged        *big.Int       `json:"pledged"`
+	Burned         *big.Int       `json:"burned"`
+	Pending        bool           `json:"pending,omitempty"` // Accrued, not locked yet
+	Trie           bool           `json:"trie,omitempty"`    // Kept in the lock trie
+}
+
+// LockTypeSchedule sums the buckets of a lock type.
+type LockTypeSchedule struct {
+	LockType uint32        `json:"lockType"`
+	Total    *big.Int      `json:"total"`
+	Released *big.Int      `json:"released"`
+	Pledged  *big.Int      `json:"pledged"`
+	Burned   *big.Int      `json:"burned"`
+	Buckets  []*LockBucket `json:"buckets"`
+}
+
+// LockScheduleRelease is a projected release of a lock type.
+type LockScheduleRelease struct {
+	BlockNumber uint64   `json:"blockNumber"`
+	LockType    uint32   `json:"lockType"`
+	Amount      *big.Int `json:"amount"` // Amount paid to the revenue addresses
+}
+
+// LockSchedule is the vesting schedule of the locks of an address.
+type LockSchedule struct {
+	Address  common.Address         `json:"address"`
+	Number   uint64                 `json:"number"`
+	Types    []*LockTypeSchedule    `json:"types"`
+	Calendar []*LockScheduleRelease `json:"calendar"`
+}
+
+func lockReleaseKey(address common.Address, number uint64, hash common.Hash) []byte {
+	key := make([]byte, len(lockReleasePrefix)+common.AddressLength+8+common.HashLength)
+	n := copy(key, lockReleasePrefix)
+	n += copy(key[n:], address[:])
+	binary.BigEndian.PutUint64(key[n:], number)
+	copy(key[n+8:], hash[:])
+	return key
+}
+
+// journalLockReleases stores the payments out of the locks of a block.
+func journalLockReleases(db ethdb.Database, events []*LockReleaseEvent) error {
+	if db == nil || len(events) == 0 {
+		return nil
+	}
+	byAddress := make(map[common.Address][]*LockReleaseEvent)
+	for _, ev := range events {
+		byAddress[ev.Target] = append(byAddress[ev.Target], ev)
+		if ev.RevenueAddress != ev.Target {
+			byAddress[ev.RevenueAddress] = append(byAddress[ev.RevenueAddress], ev)
+		}
+	}
+	batch := db.NewBatch()
+	for address, events := range byAddress {
+		blob, err := json.Marshal(events)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(lockReleaseKey(address, events[0].BlockNumber, events[0].BlockHash), blob); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// readLockReleases retrieves the payments journaled for an address from block
+// from to block to, keeping only those of the blocks canonical accepts.
+func readLockReleases(db ethdb.Database, address common.Address, from, to uint64, canonical func(number uint64, hash common.Hash) bool) ([]*LockReleaseEvent, error) {
+	prefix := append(append([]byte{}, lockReleasePrefix...), address[:]...)
+	start := make([]byte, 8)
+	binary.BigEndian.PutUint64(start, from)
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var releases []*LockReleaseEvent
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > to {
+			break
+		}
+		if !canonical(number, common.BytesToHash(key[len(prefix)+8:])) {
+			continue
+		}
+		var events []*LockReleaseEvent
+		if err := json.Unmarshal(it.Value(), &events); err != nil {
+			return nil, err
+		}
+		releases = append(releases, events...)
+	}
+	return releases, it.Error()
+}
+
+// journalGrantProfit journals the payments of grantProfit out of the locks of
+// the LockProfitSnap in header.
+func (s *Snapshot) journalGrantProfit(grantProfit []consensus.GrantProfitRecord, header *types.Header, db ethdb.Database) {
+	var events []*LockReleaseEvent
+	for _, item := range grantProfit {
+		if 0 == item.BlockNumber || item.Amount == nil {
+			continue
+		}
+		events = append(events, &LockReleaseEvent{
+			Target:         item.MinerAddress,
+			RevenueAddress: item.RevenueAddress,
+			LockType:       item.Which,
+			LockNumber:     item.BlockNumber,
+			Amount:         new(big.Int).Set(item.Amount),
+			BlockNumber:    header.Number.Uint64(),
+			BlockHash:      header.Hash(),
+		})
+	}
+	if err := journalLockReleases(db, events); err != nil {
+		log.Warn("Failed to journal lock releases", "number", header.Number, "err", err)
+	}
+}
+
+// lockTrieReleases replays the releases of the lock trie in header, on the lock
+// trie of the roots sealed in the parent block.
+func lockTrieReleases(db ethdb.Database, period uint64, root, releaseRoot common.Hash, header *types.Header) ([]*LockReleaseEvent, error) {
+	trie, err := NewLockTrie(root, releaseRoot, db, period)
+	if err != nil {
+		return nil, err
+	}
+	released := trie.ReleaseBalance(header.Number.Uint64(), nil)
+	var events []*LockReleaseEvent
+	for _, records := range released {
+		for _, record := range records {
+			events = append(events, &LockReleaseEvent{
+				Target:         record.PledgeAddr,
+				RevenueAddress: record.Addr,
+				LockType:       uint32(record.LockType),
+				LockNumber:     record.Number,
+				Amount:         new(big.Int).Add(new(big.Int).Add(record.Released, record.Pledged), record.Destroyed),
+				Released:       record.Released,
+				Pledged:        record.Pledged,
+				Burned:         record.Destroyed,
+				BlockNumber:    header.Number.Uint64(),
+				BlockHash:      header.Hash(),
+			})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if c := bytes.Compare(events[i].Target[:], events[j].Target[:]); c != 0 {
+			return c < 0
+		}
+		return events[i].LockType < events[j].LockType
+	})
+	return events, nil
+}
+
+// lockBuckets lists the buckets of the LockProfitSnap locked for address, with
+// their projected releases after block head.
+func (s *Snapshot) lockBuckets(db ethdb.Database, address common.Address, head uint64) ([]*LockBucket, []*LockScheduleRelease, error) {
+	var (
+		buckets  []*LockBucket
+		calendar []*LockScheduleRelease
+	)
+	if s.FlowRevenue == nil {
+		return buckets, calendar, nil
+	}
+	for _, lock := range s.FlowRevenue.lockDatas() {
+		if revenue, ok := lock.FlowRevenue[address]; ok {
+			for lockType, amount := range revenue.RewardBalance {
+				if amount != nil && amount.Sign() > 0 {
+					buckets = append(buckets, pendingLockBucket(lockType, address, address, address, amount))
+				}
+			}
+			for lockType, items := range revenue.RewardBalanceV1 {
+				for source, item := range items {
+					if item != nil && item.Amount != nil && item.Amount.Sign() > 0 {
+						buckets = append(buckets, pendingLockBucket(lockType, address, source, item.RevenueAddress, item.Amount))
+					}
+				}
+			}
+		}
+		rlsLockBalance, err := lock.loadRlsLockBalanceV1(db)
+		if err != nil {
+			return nil, nil, err
+		}
+		rls, ok := rlsLockBalance[address]
+		if !ok {
+			continue
+		}
+		for _, byType := range rls.LockBalanceV1 {
+			for _, items := range byType {
+				for _, item := range items {
+					burned := new(big.Int)
+					if item.BurnAmount != nil {
+						burned.Set(item.BurnAmount)
+					}
+					buckets = append(buckets, &LockBucket{
+						LockType:       item.PledgeType,
+						Target:         item.TargetAddress,
+						Source:         item.RevenueContract,
+						RevenueAddress: item.RevenueAddress,
+						LockNumber:     item.StartHigh,
+						Total:          new(big.Int).Set(item.Amount),
+						Released:       new(big.Int).Sub(item.Playment, burned),
+						Pledged:        new(big.Int),
+						Burned:         burned,
+					})
+					calendar = append(calendar, pledgeItemReleases(item, head, s.getBlockPreDay())...)
+				}
+			}
+		}
+	}
+	return buckets, calendar, nil
+}
+
+func pendingLockBucket(lockType uint32, target, source, revenue common.Address, amount *big.Int) *LockBucket {
+	return &LockBucket{
+		LockType:       lockType,
+		Target:         target,
+		Source:         source,
+		RevenueAddress: revenue,
+		Total:          new(big.Int).Set(amount),
+		Released:       new(big.Int),
+		Pledged:        new(big.Int),
+		Burned:         new(big.Int),
+		Pending:        true,
+	}
+}
+
+// lockDatas returns the locks of the snapshot set up so far.
+func (s *LockProfitSnap) lockDatas() []*LockData {
+	var locks []*LockData
+	for _, lock := range []*LockData{s.RewardLock, s.FlowLock, s.BandwidthLock, s.PosPgExitLock, s.PosExitLock,
+		s.STPEntrustExitLock, s.STPEntrustLock, s.SpEntrustLock, s.SpLock, s.SpExitLock, s.SpEntrustExitLock} {
+		if lock != nil {
+			locks = append(locks, lock)
+		}
+	}
+	return locks
+}
+
+// pledgeItemVested returns the amount of the pledge item vested at block number.
+func pledgeItemVested(item *PledgeItem, number uint64) *big.Int {
+	lockExpire := item.StartHigh + uint64(item.LockPeriod)
+	if number < lockExpire {
+		return new(big.Int)
+	}
+	if 0 == item.RlsPeriod || 0 == item.Interval || number >= lockExpire+uint64(item.RlsPeriod) {
+		return new(big.Int).Set(item.Amount)
+	}
+	currentPeriod := (number - lockExpire) / uint64(item.Interval)
+	totalPeriod := (item.RlsPeriod + item.Interval - 1) / item.Interval
+	vested := new(big.Int).Mul(item.Amount, new(big.Int).SetUint64(currentPeriod))
+	return vested.Div(vested, new(big.Int).SetUint64(uint64(totalPeriod)))
+}
+
+// pledgeItemReleases projects the releases of the pledge item after block head,
+// by the day of blockPerDay blocks they vest in. The LockProfitSnap pays them on
+// the pay days of the lock type.
+func pledgeItemReleases(item *PledgeItem, head uint64, blockPerDay uint64) []*LockScheduleRelease {
+	var releases []*LockScheduleRelease
+	if blockPerDay == 0 || item.Playment == nil || item.Playment.Cmp(item.Amount) >= 0 {
+		return releases
+	}
+	end := item.StartHigh + uint64(item.LockPeriod) + uint64(item.RlsPeriod)
+	paid := new(big.Int).Set(item.Playment)
+	for number := (head/blockPerDay + 1) * blockPerDay; ; number += blockPerDay {
+		if vested := pledgeItemVested(item, number); vested.Cmp(paid) > 0 {
+			amount := new(big.Int).Sub(vested, paid)
+			paid = vested
+			releases = append(releases, &LockScheduleRelease{
+				BlockNumber: number,
+				LockType:    item.PledgeType,
+				Amount:      amount.Sub(amount, calBurnAmount(item, amount)),
+			})
+		}
+		if number >= end {
+			break
+		}
+	}
+	return releases
+}
+
+// lockTrieBuckets lists the buckets of the lock trie locked for address, with
+// their projected releases after block head.
+func lockTrieBuckets(trie *LockTrie, address common.Address, head uint64) ([]*LockBucket, []*LockScheduleRelease) {
+	var (
+		buckets  []*LockBucket
+		calendar []*LockScheduleRelease
+	)
+	for _, lockType := range []uint32{sscEnumSignerReward, sscEnumFlwReward, sscEnumBandwidthReward, sscEnumStoragePledgeRedeemLock,
+		sscSpLockReward, sscSpEntrustLockReward, sscEnumSTEntrustLockReward} {
+		account := trie.GetAccount(address, uint8(lockType))
+		if account == nil {
+			continue
+		}
+		for _, record := range account.LockRecords {
+			buckets = append(buckets, &LockBucket{
+				LockType:       lockType,
+				Target:         address,
+				RevenueAddress: record.Address,
+				LockNumber:     record.Number,
+				Total:          new(big.Int).Set(record.TotalBalance),
+				Released:       new(big.Int).Set(record.Released),
+				Pledged:        new(big.Int).Set(record.Pledgeed),
+				Burned:         new(big.Int).Set(record.Destroyed),
+				Trie:           true,
+			})
+			calendar = append(calendar, lockRecordReleases(record, account.ReleaseNumberPerDay, trie.day.blocks, lockType, head)...)
+		}
+	}
+	return buckets, calendar
+}
+
+// lockRecordReleases projects the releases of the lock record after block head,
+// on the block of the day its account is released in.
+func lockRecordReleases(record LockRecord, releaseNumber uint32, blockPerDay uint64, lockType uint32, head uint64) []*LockScheduleRelease {
+	var releases []*LockScheduleRelease
+	if record.ReleaseDays == 0 {
+		return releases
+	}
+	from := head
+	if record.ReleaseNumber > from {
+		from = record.ReleaseNumber
+	}
+	number := from - from%blockPerDay + uint64(releaseNumber)
+	if number <= from {
+		number += blockPerDay
+	}
+	days := new(big.Int).SetUint64(uint64(record.ReleaseDays))
+	avg := new(big.Int).Div(record.TotalBalance, days)
+	for idx := record.ReleaseIdx; idx < record.ReleaseDays; number += blockPerDay {
+		if number < record.Number || (number-record.Number)/blockPerDay <= uint64(record.LockDays) {
+			continue
+		}
+		amount := new(big.Int).Set(avg)
+		if idx+1 == record.ReleaseDays {
+			amount.Sub(record.TotalBalance, new(big.Int).Mul(avg, new(big.Int).SetUint64(uint64(record.ReleaseDays-1))))
+		}
+		destroyed := new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(int64(record.DestroyRatio))), big.NewInt(defaultBaseRatio))
+		left := new(big.Int).Sub(amount, destroyed)
+		pledged := new(big.Int).Div(new(big.Int).Mul(left, big.NewInt(int64(record.PledgeRatio))), big.NewInt(defaultBaseRatio))
+		releases = append(releases, &LockScheduleRelease{
+			BlockNumber: number,
+			LockType:    lockType,
+			Amount:      left.Sub(left, pledged),
+		})
+		idx++
+	}
+	return releases
+}
+
+// newLockSchedule groups the buckets by lock type and merges the releases of a
+// lock type in the same block.
+func newLockSchedule(address common.Address, number uint64, buckets []*LockBucket, releases []*LockScheduleRelease) *LockSchedule {
+	schedule := &LockSchedule{Address: address, Number: number, Types: []*LockTypeSchedule{}, Calendar: []*LockScheduleRelease{}}
+	byType := make(map[uint32]*LockTypeSchedule)
+	for _, bucket := range buckets {
+		ts, ok := byType[bucket.LockType]
+		if !ok {
+			ts = &LockTypeSchedule{LockType: bucket.LockType, Total: new(big.Int), Released: new(big.Int), Pledged: new(big.Int), Burned: new(big.Int)}
+			byType[bucket.LockType] = ts
+			schedule.Types = append(schedule.Types, ts)
+		}
+		ts.Total.Add(ts.Total, bucket.Total)
+		ts.Released.Add(ts.Released, bucket.Released)
+		ts.Pledged.Add(ts.Pledged, bucket.Pledged)
+		ts.Burned.Add(ts.Burned, bucket.Burned)
+		ts.Buckets = append(ts.Buckets, bucket)
+	}
+	sort.Slice(schedule.Types, func(i, j int) bool {
+		return schedule.Types[i].LockType < schedule.Types[j].LockType
+	})
+	for _, ts := range schedule.Types {
+		sort.SliceStable(ts.Buckets, func(i, j int) bool {
+			a, b := ts.Buckets[i], ts.Buckets[j]
+			if a.Pending != b.Pending {
+				return a.Pending
+			}
+			if a.LockNumber != b.LockNumber {
+				return a.LockNumber < b.LockNumber
+			}
+			return bytes.Compare(a.Source[:], b.Source[:]) < 0
+		})
+	}
+	type releaseKey struct {
+		number   uint64
+		lockType uint32
+	}
+	merged := make(map[releaseKey]*LockScheduleRelease)
+	for _, release := range releases {
+		key := releaseKey{release.BlockNumber, release.LockType}
+		if have, ok := merged[key]; ok {
+			have.Amount.Add(have.Amount, release.Amount)
+			continue
+		}
+		merged[key] = &LockScheduleRelease{BlockNumber: release.BlockNumber, LockType: release.LockType, Amount: new(big.Int).Set(release.Amount)}
+		schedule.Calendar = append(schedule.Calendar, merged[key])
+	}
+	sort.Slice(schedule.Calendar, func(i, j int) bool {
+		if schedule.Calendar[i].BlockNumber != schedule.Calendar[j].BlockNumber {
+			return schedule.Calendar[i].BlockNumber < schedule.Calendar[j].BlockNumber
+		}
+		return schedule.Calendar[i].LockType < schedule.Calendar[j].LockType
+	})
+	return schedule
+}
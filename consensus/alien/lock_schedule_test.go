@@ -0,0 +1,156 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+// Tests that the lock schedule of an address lists the locks of the lock
+// profit snap and of the lock trie, and projects the releases of the lock trie
+// on the blocks they are paid in.
+func TestLockSchedule(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		owner   = common.HexToAddress("0x01")
+		revenue = common.HexToAddress("0x02")
+	)
+	snap := &Snapshot{
+		config:      &params.AlienConfig{Period: 10},
+		FlowRevenue: NewLockProfitSnap(),
+		SystemConfig: SystemParameter{LockParameters: map[uint32]*LockParameter{
+			sscEnumRwdLock: {RlsPeriod: 2 * blockperday, Interval: 1},
+		}},
+	}
+	blockPerDay := snap.getBlockPreDay()
+	snap.FlowRevenue.RewardLock.FlowRevenue[owner] = &LockBalanceData{
+		RewardBalance: map[uint32]*big.Int{sscEnumSignerReward: big.NewInt(50)},
+		LockBalanceV1: map[uint64]map[uint32]map[common.Address]*PledgeItem{
+			100: {sscEnumSignerReward: {common.Address{}: {
+				Amount:         big.NewInt(900),
+				PledgeType:     sscEnumSignerReward,
+				Playment:       big.NewInt(300),
+				RlsPeriod:      uint32(3 * blockPerDay),
+				Interval:       uint32(blockPerDay),
+				StartHigh:      100,
+				TargetAddress:  owner,
+				RevenueAddress: revenue,
+				BurnAmount:     big.NewInt(0),
+			}}},
+		},
+	}
+	head := blockPerDay + 200
+	buckets, calendar, err := snap.lockBuckets(db, owner, head)
+	if err != nil {
+		t.Fatalf("failed to list locks: %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("have %d buckets, want 2", len(buckets))
+	}
+	wantCalendar := []struct{ number, amount uint64 }{{3 * blockPerDay, 300}, {4 * blockPerDay, 300}}
+	if len(calendar) != len(wantCalendar) {
+		t.Fatalf("have %d releases, want %d", len(calendar), len(wantCalendar))
+	}
+	for i, want := range wantCalendar {
+		if calendar[i].BlockNumber != want.number || calendar[i].Amount.Uint64() != want.amount {
+			t.Errorf("release %d: have %d at %d, want %d at %d", i, calendar[i].Amount, calendar[i].BlockNumber, want.amount, want.number)
+		}
+	}
+
+	// Lock a reward in the lock trie and replay its releases
+	number := uint64(5 * blockperday)
+	trie, err := NewLockTrie(common.Hash{}, common.Hash{}, db, blockperoid)
+	if err != nil {
+		t.Fatalf("failed to open lock trie: %v", err)
+	}
+	trie.AddBalance(number, revenue, owner, big.NewInt(1001), sscEnumSignerReward, 0, 0, 0, 2)
+	root, releaseRoot, err := trie.Commit()
+	if err != nil {
+		t.Fatalf("failed to commit lock trie: %v", err)
+	}
+	trieBuckets, trieCalendar := lockTrieBuckets(trie, owner, number)
+	if len(trieBuckets) != 1 || trieBuckets[0].Total.Int64() != 1001 || trieBuckets[0].RevenueAddress != revenue {
+		t.Fatalf("unexpected lock trie buckets: %v", trieBuckets)
+	}
+	var replayed []*LockReleaseEvent
+	for n := number + 1; n <= number+3*blockperday; n++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(n)}
+		events, err := lockTrieReleases(db, blockperoid, root, releaseRoot, header)
+		if err != nil {
+			t.Fatalf("failed to replay releases at %d: %v", n, err)
+		}
+		if len(events) > 0 {
+			replayed = append(replayed, events...)
+			trie, _ = NewLockTrie(root, releaseRoot, db, blockperoid)
+			trie.ReleaseBalance(n, nil)
+			root, releaseRoot, _ = trie.Commit()
+		}
+	}
+	if len(replayed) != len(trieCalendar) {
+		t.Fatalf("replayed %d releases, projected %d", len(replayed), len(trieCalendar))
+	}
+	for i, ev := range replayed {
+		if ev.BlockNumber != trieCalendar[i].BlockNumber || ev.Released.Cmp(trieCalendar[i].Amount) != 0 || ev.RevenueAddress != revenue {
+			t.Errorf("release %d: replayed %d at %d, projected %d at %d", i, ev.Released, ev.BlockNumber, trieCalendar[i].Amount, trieCalendar[i].BlockNumber)
+		}
+	}
+
+	schedule := newLockSchedule(owner, head, append(buckets, trieBuckets...), append(calendar, trieCalendar...))
+	if len(schedule.Types) != 1 || schedule.Types[0].Total.Int64() != 50+900+1001 || schedule.Types[0].Released.Int64() != 300 {
+		t.Errorf("unexpected lock types: %+v", schedule.Types)
+	}
+	if !schedule.Types[0].Buckets[0].Pending {
+		t.Errorf("pending bucket not listed first")
+	}
+	if len(schedule.Calendar) != 4 {
+		t.Errorf("have %d calendar entries, want 4", len(schedule.Calendar))
+	}
+}
+
+// Tests that the payments out of the lock profit snap are read back for the
+// owner and the revenue address of the lock, on the canonical blocks of the
+// requested range only.
+func TestLockReleaseJournal(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		owner   = common.HexToAddress("0x01")
+		revenue = common.HexToAddress("0x02")
+		headers = make(map[uint64]*types.Header)
+		snap    = &Snapshot{}
+	)
+	for number := uint64(1); number <= 3; number++ {
+		for _, extra := range []byte{0, 1} {
+			header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{extra}}
+			if extra == 0 {
+				headers[number] = header
+			}
+			snap.journalGrantProfit([]consensus.GrantProfitRecord{
+				{Which: sscEnumFlwReward, MinerAddress: owner, BlockNumber: 0, Amount: big.NewInt(1)},
+				{Which: sscEnumSignerReward, MinerAddress: owner, BlockNumber: 1, Amount: big.NewInt(int64(number)), RevenueAddress: revenue},
+			}, header, db)
+		}
+	}
+	canonical := func(number uint64, hash common.Hash) bool {
+		header, ok := headers[number]
+		return ok && header.Hash() == hash
+	}
+	for _, address := range []common.Address{owner, revenue} {
+		releases, err := readLockReleases(db, address, 2, 3, canonical)
+		if err != nil {
+			t.Fatalf("failed to read releases: %v", err)
+		}
+		if len(releases) != 2 {
+			t.Fatalf("have %d releases of %x, want 2", len(releases), address)
+		}
+		for i, ev := range releases {
+			if ev.BlockNumber != uint64(i+2) || ev.Amount.Uint64() != ev.BlockNumber || ev.BlockHash != headers[ev.BlockNumber].Hash() {
+				t.Errorf("release %d of %x: unexpected %+v", i, address, ev)
+			}
+		}
+	}
+}
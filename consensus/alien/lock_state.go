@@ -1,21 +1,34 @@
 package alien
 
 import (
+	"bytes"
+	"math"
+	"math/big"
+	"sort"
+
 	"github.com/UltronGlow/UltronGlow-Origin/common"
 	"github.com/UltronGlow/UltronGlow-Origin/core/state"
 	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
 )
 
 const (
 	signerRewardKey        = "signerReward-%d"
 )
 type LockState interface {
-	PayLockReward(LockAccountsRoot common.Hash, number uint64, state *state.StateDB) error
+	PayLockReward(number uint64, state *state.StateDB) error
 	CommitData() (common.Hash, common.Hash, error)
 	AddLockReward(LockReward []LockRewardRecord, snap *Snapshot, db ethdb.Database, number uint64) ([]LockRewardRecord,error)
 }
 
-func NewLockState(root, lockaccounts common.Hash,number uint64) (LockState, error) {
+// NewLockState opens the lock state of the block number, on the roots sealed in
+// the header extra of its parent. From lockTrieNumber on the locks are kept in
+// a LockTrie, with root the lock trie root and lockaccounts the release root,
+// released on the days of a chain sealing a block every period seconds.
+func NewLockState(root, lockaccounts common.Hash, number uint64, db ethdb.Database, period uint64) (LockState, error) {
+	if isGELockTrieNumber(number) {
+		return NewTrieLockState(root, lockaccounts, db, period)
+	}
 	return NewDefaultLockState(root)
 }
 
@@ -29,7 +42,7 @@ func NewDefaultLockState(root common.Hash) (*DefaultLockState, error) {
 	return &DefaultLockState{}, nil
 }
 
-func (c *DefaultLockState) PayLockReward(LockAccountsRoot common.Hash, number uint64, state *state.StateDB) error {
+func (c *DefaultLockState) PayLockReward(number uint64, state *state.StateDB) error {
 	return nil
 }
 
@@ -39,4 +52,123 @@ func (c *DefaultLockState) CommitData() (common.Hash, common.Hash, error) {
 
 func (c *DefaultLockState) AddLockReward(LockReward []LockRewardRecord, snap *Snapshot, db ethdb.Database, number uint64) ([]LockRewardRecord,error) {
 	return LockReward,nil
-}
\ No newline at end of file
+}
+// TrieLockState keeps the signer, flow and bandwidth rewards and the redeemed
+// storage pledges locked in a LockTrie, in place of the caches of the
+// LockProfitSnap. Each pledge address and lock type has an account in the
+// trie, released on its own block of the day, so a block only visits the
+// accounts released in it. The balances locked before the fork are still paid
+// out of the LockProfitSnap.
+type TrieLockState struct {
+	trie *LockTrie
+}
+
+func NewTrieLockState(root, releaseRoot common.Hash, db ethdb.Database, period uint64) (*TrieLockState, error) {
+	trie, err := NewLockTrie(root, releaseRoot, db, period)
+	if err != nil {
+		return nil, err
+	}
+	return &TrieLockState{trie: trie}, nil
+}
+
+func (c *TrieLockState) PayLockReward(number uint64, state *state.StateDB) error {
+	released := c.trie.ReleaseBalance(number, state)
+	if len(released) > 0 {
+		log.Debug("Released trie locks", "number", number, "accounts", len(released))
+	}
+	return nil
+}
+
+func (c *TrieLockState) CommitData() (common.Hash, common.Hash, error) {
+	return c.trie.Commit()
+}
+
+func (c *TrieLockState) AddLockReward(LockReward []LockRewardRecord, snap *Snapshot, db ethdb.Database, number uint64) ([]LockRewardRecord, error) {
+	lockDays, releaseDays := snap.lockTrieDays()
+	for _, item := range snap.lockTrieRecords(LockReward, new(big.Int).SetUint64(number)) {
+		if item.Amount.Sign() <= 0 {
+			continue
+		}
+		c.trie.AddBalance(number, item.RevenueAddress, item.Target, item.Amount, uint8(item.IsReward), 0, 0, lockDays, releaseDays)
+	}
+	return LockReward, nil
+}
+
+// isLockTrieReward reports whether the lock records of type isReward are kept
+// in the lock trie from lockTrieNumber on.
+func isLockTrieReward(isReward uint32) bool {
+	switch isReward {
+	case sscEnumSignerReward, sscEnumFlwReward, sscEnumBandwidthReward, sscEnumStoragePledgeRedeemLock:
+		return true
+	}
+	return false
+}
+
+// lockTrieRecords splits the lock records kept in the lock trie between their
+// beneficiaries, the way the LockProfitSnap distributes them, ordered by
+// target, lock type and revenue address.
+func (s *Snapshot) lockTrieRecords(LockReward []LockRewardRecord, number *big.Int) []LockRewardNewRecord {
+	distribute := make(map[common.Address]*big.Int)
+	distributePool := make(map[common.Hash]*big.Int)
+	records := make([]LockRewardNewRecord, 0)
+	for _, item := range LockReward {
+		switch item.IsReward {
+		case sscEnumSignerReward:
+			revenueAddress := item.Target
+			if revenue, ok := s.RevenueNormal[item.Target]; ok {
+				revenueAddress = revenue.RevenueAddress
+			}
+			records = append(records, LockRewardNewRecord{
+				Target:         item.Target,
+				Amount:         new(big.Int).Set(item.Amount),
+				IsReward:       item.IsReward,
+				SourceAddress:  item.Target,
+				RevenueAddress: revenueAddress,
+			})
+		case sscEnumFlwReward, sscEnumBandwidthReward:
+			records = s.FlowRevenue.FlowLock.distributeSTPLockData(s, item, number, distribute, distributePool, records, int(item.IsReward))
+		case sscEnumStoragePledgeRedeemLock:
+			records = append(records, LockRewardNewRecord{
+				Target:         item.Target,
+				Amount:         new(big.Int).Set(item.Amount),
+				IsReward:       item.IsReward,
+				SourceAddress:  common.Address{},
+				RevenueAddress: item.Target,
+			})
+		}
+	}
+	records = stpEntrustLockRecords(s, distribute, records)
+	records = append(records, spRewardLockRecords(s, distributePool)...)
+	sort.SliceStable(records, func(i, j int) bool {
+		if c := bytes.Compare(records[i].Target[:], records[j].Target[:]); c != 0 {
+			return c < 0
+		}
+		if records[i].IsReward != records[j].IsReward {
+			return records[i].IsReward < records[j].IsReward
+		}
+		return bytes.Compare(records[i].RevenueAddress[:], records[j].RevenueAddress[:]) < 0
+	})
+	return records
+}
+
+// lockTrieDays returns the lock and release spans of the reward lock parameters
+// in days of the lock trie, releasing over one day at least.
+func (s *Snapshot) lockTrieDays() (uint16, uint16) {
+	param, ok := s.SystemConfig.LockParameters[sscEnumRwdLock]
+	if !ok || param == nil {
+		return 0, 1
+	}
+	blockPerDay := s.getBlockPreDay()
+	lockDays := uint64(param.LockPeriod) / blockPerDay
+	releaseDays := (uint64(param.RlsPeriod) + blockPerDay - 1) / blockPerDay
+	if lockDays > math.MaxUint16 {
+		lockDays = math.MaxUint16
+	}
+	if releaseDays > math.MaxUint16 {
+		releaseDays = math.MaxUint16
+	}
+	if releaseDays == 0 {
+		releaseDays = 1
+	}
+	return uint16(lockDays), uint16(releaseDays)
+}
@@ -0,0 +1,103 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/state"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+// Tests that the rewards and the redeemed storage pledges are locked in the
+// lock trie past its fork, left out of the LockProfitSnap, and released to
+// their revenue addresses over the release days.
+func TestTrieLockState(t *testing.T) {
+	defer func(number uint64) { lockTrieNumber = number }(lockTrieNumber)
+	lockTrieNumber = 0
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		signer  = common.HexToAddress("0x01")
+		revenue = common.HexToAddress("0x02")
+		miner   = common.HexToAddress("0x03")
+		pledge  = common.HexToAddress("0x04")
+		number  = initStorageManagerNumber + 1
+	)
+	snap := &Snapshot{
+		config:        &params.AlienConfig{Period: 10},
+		FlowRevenue:   NewLockProfitSnap(),
+		StorageData:   &StorageData{StorageEntrust: make(map[common.Address]*SEntrust)},
+		SpData:        NewSPSnap(),
+		RevenueNormal: map[common.Address]*RevenueParameter{signer: {RevenueAddress: revenue}},
+		SystemConfig: SystemParameter{LockParameters: map[uint32]*LockParameter{
+			sscEnumRwdLock: {RlsPeriod: 2 * blockperday, Interval: 1},
+		}},
+	}
+	rewards := []LockRewardRecord{
+		{Target: signer, Amount: big.NewInt(1000), IsReward: sscEnumSignerReward},
+		{Target: miner, Amount: big.NewInt(300), IsReward: sscEnumFlwReward},
+		{Target: miner, Amount: big.NewInt(200), IsReward: sscEnumBandwidthReward},
+		{Target: pledge, Amount: big.NewInt(401), IsReward: sscEnumStoragePledgeRedeemLock},
+	}
+	snap.FlowRevenue.updateLockData(snap, rewards, new(big.Int).SetUint64(number))
+	if len(snap.FlowRevenue.RewardLock.FlowRevenue) != 0 || len(snap.FlowRevenue.FlowLock.FlowRevenue) != 0 ||
+		len(snap.FlowRevenue.BandwidthLock.FlowRevenue) != 0 || len(snap.FlowRevenue.PosPgExitLock.FlowRevenue) != 0 {
+		t.Fatalf("trie locks left in the lock profit snap")
+	}
+
+	es, err := NewLockState(common.Hash{}, common.Hash{}, number, db, blockperoid)
+	if err != nil {
+		t.Fatalf("failed to open lock state: %v", err)
+	}
+	if _, err := es.AddLockReward(rewards, snap, db, number); err != nil {
+		t.Fatalf("failed to lock rewards: %v", err)
+	}
+	root, releaseRoot, err := es.CommitData()
+	if err != nil {
+		t.Fatalf("failed to commit lock state: %v", err)
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db), nil)
+	want := map[common.Address]int64{revenue: 1000, miner: 500, pledge: 401}
+	for day := uint64(1); day <= 3; day++ {
+		for n := number + (day-1)*blockperday + 1; n <= number+day*blockperday; n++ {
+			// Reopen the state on the committed roots, as the next block does
+			if es, err = NewLockState(root, releaseRoot, n, db, blockperoid); err != nil {
+				t.Fatalf("failed to reopen lock state at %d: %v", n, err)
+			}
+			if err := es.PayLockReward(n, statedb); err != nil {
+				t.Fatalf("failed to release at %d: %v", n, err)
+			}
+			if root, releaseRoot, err = es.CommitData(); err != nil {
+				t.Fatalf("failed to commit lock state at %d: %v", n, err)
+			}
+		}
+		for addr, amount := range want {
+			have := statedb.GetBalance(addr)
+			switch day {
+			case 1:
+				if have.Sign() != 0 {
+					t.Errorf("day %d: %x released %v while locked", day, addr, have)
+				}
+			case 2:
+				if have.Int64() != amount/2 {
+					t.Errorf("day %d: %x released %v, want %d", day, addr, have, amount/2)
+				}
+			case 3:
+				if have.Int64() != amount {
+					t.Errorf("day %d: %x released %v, want %d", day, addr, have, amount)
+				}
+			}
+		}
+	}
+	trie, err := NewLockTrie(root, releaseRoot, db, blockperoid)
+	if err != nil {
+		t.Fatalf("failed to open lock trie: %v", err)
+	}
+	for _, target := range []common.Address{signer, miner, pledge} {
+		if trie.GetAccount(target, sscEnumSignerReward) != nil || trie.GetAccount(target, sscEnumFlwReward) != nil {
+			t.Errorf("released account of %x left in the trie", target)
+		}
+	}
+}
@@ -16,12 +16,12 @@ import (
 	"sort"
 )
 
+// The release window of a mainnet day, scaled by lockTrieDay to the day of the chain
 const (
 	blockperoid        = 10
-	blockperday        = secondsPerDay / blockperoid
+	blockperday        = 24 * 60 * 60 / blockperoid
 	payStartNumber     = 1260
 	payEndNumber       = 200
-	payPeroid          = blockperday - payStartNumber - payEndNumber
 
 	defaultBaseRatio    = 10000
 
@@ -70,14 +70,55 @@ type ReleaseRecord struct {
 	Destroyed  *big.Int
 }
 
-func newLockAccount(pledgeaddr common.Address, locktype uint8) *LockAccount {
+// lockTrieDay is the day the accounts of a lock trie are released on. Each
+// account is released on its own block of the day, within the window of a
+// mainnet day scaled to the blocks per day of the chain.
+type lockTrieDay struct {
+	blocks   uint64 // blocks per day, secondsPerDay / period
+	payStart uint64 // first block of the day accounts are released in
+	payEnd   uint64 // number of blocks left out at the end of the day
+}
+
+func newLockTrieDay(period uint64) lockTrieDay {
+	if period == 0 {
+		period = blockperoid
+	}
+	blocks := secondsPerDay / period
+	if blocks == 0 {
+		blocks = 1
+	}
+	return lockTrieDay{
+		blocks:   blocks,
+		payStart: payStartNumber * blocks / blockperday,
+		payEnd:   payEndNumber * blocks / blockperday,
+	}
+}
+
+// releaseNumber returns the block of the day of the block number.
+func (d lockTrieDay) releaseNumber(number uint64) uint32 {
+	return uint32(number % d.blocks)
+}
+
+// accountReleaseNumber returns the block of the day the account with the given
+// key is released in.
+func (d lockTrieDay) accountReleaseNumber(key []byte) uint32 {
+	return uint32(uint64(crc32.ChecksumIEEE(key))%(d.blocks-d.payStart-d.payEnd) + d.payStart)
+}
+
+// inPayWindow reports whether accounts are released in the block number.
+func (d lockTrieDay) inPayWindow(number uint64) bool {
+	idx := uint64(d.releaseNumber(number))
+	return idx >= d.payStart && idx <= d.blocks-d.payEnd
+}
+
+func newLockAccount(pledgeaddr common.Address, locktype uint8, day lockTrieDay) *LockAccount {
 	key := generateKey(pledgeaddr, locktype)
 	obj := &LockAccount{
 		PledgeAddr:          pledgeaddr,
 		LockType:            locktype,
 		Balance:             common.Big0,
 		LockRecords:         make([]LockRecord, 0),
-		ReleaseNumberPerDay: crc32.ChecksumIEEE(key)%payPeroid + payStartNumber,
+		ReleaseNumberPerDay: day.accountReleaseNumber(key),
 	}
 	return obj
 }
@@ -188,6 +229,9 @@ func (l *LockAccount) AddBalance(number uint64, addr common.Address, amount *big
 		PledgeRatio:  pledgeRatio,
 		DestroyRatio: destroyRatio,
 		TotalBalance: amount,
+		Released:     new(big.Int),
+		Pledgeed:     new(big.Int),
+		Destroyed:    new(big.Int),
 		LockDays:     lockDays,
 		ReleaseIdx:   0,
 		ReleaseDays:  releaseDays,
@@ -196,7 +240,7 @@ func (l *LockAccount) AddBalance(number uint64, addr common.Address, amount *big
 	return nil
 }
 
-func (l *LockAccount) ReleaseBalance(number uint64, state *state.StateDB) []ReleaseRecord {
+func (l *LockAccount) ReleaseBalance(number uint64, blockPerDay uint64, state *state.StateDB) []ReleaseRecord {
 	var (
 		records []ReleaseRecord
 		amounts map[common.Address]*big.Int = make(map[common.Address]*big.Int)
@@ -209,7 +253,7 @@ func (l *LockAccount) ReleaseBalance(number uint64, state *state.StateDB) []Rele
 		if l.LockRecords[k].ReleaseIdx >= l.LockRecords[k].ReleaseDays {
 			continue
 		}
-		if (number-l.LockRecords[k].Number)/blockperday <= uint64(l.LockRecords[k].LockDays) {
+		if (number-l.LockRecords[k].Number)/blockPerDay <= uint64(l.LockRecords[k].LockDays) {
 			continue
 		}
 		if number <= l.LockRecords[k].ReleaseNumber {
@@ -365,6 +409,8 @@ type LockTrie struct {
 	rdirty bool
 	db     ethdb.Database
 	triedb *trie.Database
+	period uint64
+	day    lockTrieDay
 }
 
 func (l *LockTrie) GetAccount(pledgeaddr common.Address, locktype uint8) *LockAccount {
@@ -385,7 +431,7 @@ func (l *LockTrie) GetOrNewAccount(pledgeaddr common.Address, locktype uint8) *L
 	if obj != nil {
 		return obj
 	}
-	obj = newLockAccount(pledgeaddr, locktype)
+	obj = newLockAccount(pledgeaddr, locktype, l.day)
 	robj := l.GetOrNewReleaseInfo(obj.GetReleaseNumber())
 	b := robj.setAddr(obj.KeyBytes())
 	if len(b) > 0 {
@@ -478,17 +524,17 @@ func (l *LockTrie) Copy() *LockTrie {
 	root, _ := l.CommitLockInfo()
 	r_root, _ := l.CommitReleaseInfo()
 
-	trie, _ := NewLockTrie(root, r_root, l.db)
+	trie, _ := NewLockTrie(root, r_root, l.db, l.period)
 	return trie
 }
 
 func (l *LockTrie) ReleaseBalance(number uint64, state *state.StateDB) map[common.Address][]ReleaseRecord {
 	var released map[common.Address][]ReleaseRecord = make(map[common.Address][]ReleaseRecord)
 
-	if number2ReleaseNumber(number) < payStartNumber || number2ReleaseNumber(number) > blockperday - payEndNumber {
+	if !l.day.inPayWindow(number) {
 		return released
 	}
-	robj := l.GetOrNewReleaseInfo(number2ReleaseNumber(number))
+	robj := l.GetOrNewReleaseInfo(l.day.releaseNumber(number))
 	addrList := robj.GetReleaseList()
 	for idx, _ := range addrList {
 		if len(addrList[idx]) < 21 {
@@ -496,7 +542,7 @@ func (l *LockTrie) ReleaseBalance(number uint64, state *state.StateDB) map[commo
 		}
 		obj := l.GetAccountByKey(addrList[idx])
 		if obj != nil {
-			records := obj.ReleaseBalance(number, state)
+			records := obj.ReleaseBalance(number, l.day.blocks, state)
 			if len(records) > 0 {
 				if _, ok := released[obj.PledgeAddr]; !ok {
 					released[obj.PledgeAddr] = records
@@ -584,7 +630,9 @@ func (l *LockTrie) GetLockRecords(lockType uint8) map[common.Address][]LockRecor
 }
 
 //====================================================================================
-func NewLockTrie(root, r_root common.Hash, db ethdb.Database) (*LockTrie, error) {
+// NewLockTrie opens the lock trie with the given roots, releasing its accounts
+// on the days of a chain sealing a block every period seconds.
+func NewLockTrie(root, r_root common.Hash, db ethdb.Database, period uint64) (*LockTrie, error) {
 	triedb := trie.NewDatabase(db)
 	tr, err := trie.NewSecure(root, triedb)
 	if err != nil {
@@ -604,9 +652,7 @@ func NewLockTrie(root, r_root common.Hash, db ethdb.Database) (*LockTrie, error)
 		db:     db,
 		rdirty: false,
 		triedb: triedb,
+		period: period,
+		day:    newLockTrieDay(period),
 	}, nil
 }
-
-func number2ReleaseNumber(number uint64) uint32 {
-	return uint32(number % blockperday)
-}
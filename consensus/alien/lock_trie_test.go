@@ -40,7 +40,7 @@ func _TestLock(t *testing.T) {
 	}
 	defer ethdb.Close()
 
-	trie, err := NewLockTrie(common.Hash{}, common.Hash{}, ethdb)
+	trie, err := NewLockTrie(common.Hash{}, common.Hash{}, ethdb, blockperoid)
 	if err != nil {
 		t.Error("open trie failed", err)
 		return
@@ -107,7 +107,7 @@ func _TestDecodeObj(t *testing.T) {
 	//addr0 := common.BigToAddress(new(big.Int).SetUint64(0))
 	root := common.HexToHash("uxe0dd41fecd05b0ac10acd58439c13d03aeb80122b09ccd8f33f88978f5477e51")
 	r_root := common.HexToHash("uxc26e4942458c8c1a60876797a888d5bc7c89529a6cedaa90389c10e7fb97a36a")
-	trie, err := NewLockTrie(root, r_root, ethdb)
+	trie, err := NewLockTrie(root, r_root, ethdb, blockperoid)
 	if err != nil {
 		t.Error("open trie failed", err)
 		return
@@ -136,7 +136,7 @@ func _TestCheckRelease(t *testing.T) {
 
 	root = common.HexToHash("ux8025d0a9d85b768fc4a9a3423d1de4e1b216e5e362638a653bde28c51f0d227a")
 	r_root = common.HexToHash("ux824f4462b02cb1ccea15673332dee1d8bcf3b2e785d9cb0953b4235b355cfa04")
-	trie, err := NewLockTrie(root, r_root, ethdb)
+	trie, err := NewLockTrie(root, r_root, ethdb, blockperoid)
 	if err != nil {
 		t.Error("open trie failed", err)
 		return
@@ -153,7 +153,7 @@ func _TestCheckRelease(t *testing.T) {
 		return
 	}
 	var number uint64 = 10
-	t.Log("number:", robj.GetReleaseNumber(), "at releasenumber", number2ReleaseNumber(number), "release addrs:", len(robj.GetReleaseList()))
+	t.Log("number:", robj.GetReleaseNumber(), "at releasenumber", trie.day.releaseNumber(number), "release addrs:", len(robj.GetReleaseList()))
 
 	result := trie.ReleaseBalance(number, nil)
 	if len(result) > 0 {
@@ -224,7 +224,7 @@ func _TestLockTrieWrite(t *testing.T) {
 	}
 
 	begin := time.Now()
-	trie, err := NewLockTrie(common.Hash{}, common.Hash{}, ethdb)
+	trie, err := NewLockTrie(common.Hash{}, common.Hash{}, ethdb, blockperoid)
 	if err != nil {
 		t.Error("open trie failed", err)
 		return
@@ -257,7 +257,7 @@ func _TestLockTrieRead(t *testing.T) {
 
 func testReleasePeroid(root, r_root common.Hash, number uint64, ethdb ethdb.Database) (common.Hash, common.Hash) {
 	begin := time.Now()
-	trie, err := NewLockTrie(root, r_root, ethdb)
+	trie, err := NewLockTrie(root, r_root, ethdb, blockperoid)
 	if err != nil {
 		fmt.Println("open trie failed", err)
 		return common.BigToHash(common.Big0), common.BigToHash(common.Big0)
@@ -273,7 +273,7 @@ func testReleasePeroid(root, r_root common.Hash, number uint64, ethdb ethdb.Data
 	root, _ = trie.CommitLockInfo()
 	r_root, _ = trie.CommitReleaseInfo()
 	if len(result) > 0 {
-		fmt.Println("number:", number, ", releaseIdx:", number2ReleaseNumber(number), ", release addrs:", len(result), ", total records:", total, ", releaseDay:", (number-10)/blockperday, ", spend:", time.Now().Sub(begin).Milliseconds(), "ms")
+		fmt.Println("number:", number, ", releaseIdx:", trie.day.releaseNumber(number), ", release addrs:", len(result), ", total records:", total, ", releaseDay:", (number-10)/blockperday, ", spend:", time.Now().Sub(begin).Milliseconds(), "ms")
 		fmt.Println("number:", number, "root:", root, "r_root:", r_root)
 	}
 	return root, r_root
@@ -287,7 +287,7 @@ func testReleasePeroid2(root, r_root common.Hash) {
 	}
 	defer ethdb.Close()
 
-	trie, err := NewLockTrie(root, r_root, ethdb)
+	trie, err := NewLockTrie(root, r_root, ethdb, blockperoid)
 	if err != nil {
 		fmt.Println("open trie failed", err)
 		return
@@ -307,7 +307,7 @@ func testReleasePeroid2(root, r_root common.Hash) {
 				total += len(result[k])
 				releaseTimes[k] += 1
 			}
-			fmt.Println(time.Now().Format("2006-01-02 15:04:11"), "number:", number+uint64(i), "block in perday:", number2ReleaseNumber(number+uint64(i)), "release addrs:", len(result), "total records:", total, "releaseDay:", (number+uint64(i)-10)/blockperday, "spend:", time.Now().Sub(begin).Milliseconds(), "ms")
+			fmt.Println(time.Now().Format("2006-01-02 15:04:11"), "number:", number+uint64(i), "block in perday:", trie.day.releaseNumber(number+uint64(i)), "release addrs:", len(result), "total records:", total, "releaseDay:", (number+uint64(i)-10)/blockperday, "spend:", time.Now().Sub(begin).Milliseconds(), "ms")
 		}
 	}
 }
@@ -0,0 +1,494 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+	"github.com/UltronGlow/UltronGlow-Origin/rpc"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	inMemoryMainChainHeaders = 1024 // Number of recent main chain headers to keep in memory
+	maxMainChainHeaderSync   = 256  // Max number of main chain headers synced at once before re-anchoring
+)
+
+var (
+	// errMCNoEndpoint is returned if a main chain client is created without any endpoint
+	errMCNoEndpoint = errors.New("main chain endpoint missing")
+
+	// errMCHeaderMismatch is returned if the main chain endpoints disagree on a header
+	errMCHeaderMismatch = errors.New("main chain endpoints disagree on header")
+
+	// errMCInvalidHeader is returned if a main chain header does not link to the synced ones
+	errMCInvalidHeader = errors.New("invalid main chain header")
+
+	// errMCInvalidSnapshot is returned if a main chain snapshot does not match the synced headers
+	errMCInvalidSnapshot = errors.New("invalid main chain snapshot")
+
+	// errMCNoQuorum is returned if too few main chain endpoints agree on a header to anchor on it
+	errMCNoQuorum = errors.New("main chain endpoints short of quorum")
+)
+
+// MainChainBackend is an endpoint of the main chain, as provided by *rpc.Client.
+type MainChainBackend interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// MainChainConfig is the retry and failover policy of a main chain client.
+type MainChainConfig struct {
+	Timeout  time.Duration // Timeout of a single call to an endpoint
+	Retries  int           // Number of attempts after a failed one, each on the next endpoint
+	Backoff  time.Duration // Delay before the first retry, doubled on every other
+	Cooldown time.Duration // Time a failed endpoint is skipped while others are available
+
+	Checkpoint *MainChainCheckpoint // Header trusted without a quorum of the endpoints, if set
+}
+
+// MainChainCheckpoint is a main chain header the header sync may start from
+// without a quorum of the endpoints agreeing on it.
+type MainChainCheckpoint struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// DefaultMainChainConfig is the policy used when none is configured.
+var DefaultMainChainConfig = MainChainConfig{
+	Timeout:  300 * time.Millisecond,
+	Retries:  2,
+	Backoff:  50 * time.Millisecond,
+	Cooldown: 30 * time.Second,
+}
+
+// mainChainEndpoint is an endpoint along with its failure record.
+type mainChainEndpoint struct {
+	backend  MainChainBackend
+	failures int       // Number of consecutive failed calls
+	retryAt  time.Time // Time until which the endpoint is skipped
+}
+
+// MainChainClient is the connection of a side chain to the main chain. Calls
+// fail over between several endpoints with backoff, and the snapshots served by
+// them are only accepted once matched against main chain headers synced by
+// parent hash and seal from a trusted checkpoint or an anchor a quorum of the
+// endpoints agree on.
+type MainChainClient struct {
+	config    MainChainConfig
+	endpoints []*mainChainEndpoint
+	current   int // Index of the endpoint calls are sent to first
+
+	snapshots map[common.Hash]*Snapshot // Verified snapshot of the current loop, per side chain
+	head      *types.Header             // Last main chain header synced
+	headers   *lru.ARCCache             // Main chain headers synced, by number
+	sigcache  *lru.ARCCache             // Signers of the main chain headers, by hash
+
+	lock     sync.Mutex // Protects the endpoint records and the snapshot cache
+	syncLock sync.Mutex // Serialises the header sync
+}
+
+// NewMainChainClient creates a client of the main chain spreading its calls over
+// the given endpoints.
+func NewMainChainClient(backends []MainChainBackend, config MainChainConfig) (*MainChainClient, error) {
+	if len(backends) == 0 {
+		return nil, errMCNoEndpoint
+	}
+	headers, _ := lru.NewARC(inMemoryMainChainHeaders)
+	sigcache, _ := lru.NewARC(inMemorySignatures)
+
+	c := &MainChainClient{
+		config:    config,
+		snapshots: make(map[common.Hash]*Snapshot),
+		headers:   headers,
+		sigcache:  sigcache,
+	}
+	for _, backend := range backends {
+		c.endpoints = append(c.endpoints, &mainChainEndpoint{backend: backend})
+	}
+	return c, nil
+}
+
+// endpoint returns the endpoint the next call is sent to: the current one if it
+// is not cooling down, otherwise the next available one, or the one recovering
+// first if none is.
+func (c *MainChainClient) endpoint() *mainChainEndpoint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(c.endpoints); i++ {
+		index := (c.current + i) % len(c.endpoints)
+		if !now.Before(c.endpoints[index].retryAt) {
+			c.current = index
+			return c.endpoints[index]
+		}
+	}
+	best := c.endpoints[0]
+	for _, e := range c.endpoints[1:] {
+		if e.retryAt.Before(best.retryAt) {
+			best = e
+		}
+	}
+	return best
+}
+
+// succeed clears the failure record of an endpoint.
+func (c *MainChainClient) succeed(e *mainChainEndpoint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e.failures, e.retryAt = 0, time.Time{}
+}
+
+// fail records a failed call to an endpoint, moving the calls to the next one.
+func (c *MainChainClient) fail(e *mainChainEndpoint, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e.failures++
+	e.retryAt = time.Now().Add(c.config.Cooldown)
+	for i, endpoint := range c.endpoints {
+		if endpoint == e && i == c.current {
+			c.current = (i + 1) % len(c.endpoints)
+		}
+	}
+	log.Debug("Main chain endpoint failed", "failures", e.failures, "err", err)
+}
+
+// attempt runs fn against the endpoints until it succeeds, failing over to the
+// next endpoint and backing off between attempts. Errors returned by the main
+// chain itself are not retried, as the other endpoints would answer the same.
+func (c *MainChainClient) attempt(ctx context.Context, fn func(backend MainChainBackend) error) error {
+	var (
+		err   error
+		delay = c.config.Backoff
+	)
+	for i := 0; i <= c.config.Retries; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			delay *= 2
+		}
+		e := c.endpoint()
+		if err = fn(e.backend); err == nil {
+			c.succeed(e)
+			return nil
+		}
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return err
+		}
+		c.fail(e, err)
+	}
+	return err
+}
+
+// call invokes a method of the main chain.
+func (c *MainChainClient) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.attempt(ctx, func(backend MainChainBackend) error {
+		return c.callBackend(ctx, backend, result, method, args...)
+	})
+}
+
+// callBackend invokes a method of a single endpoint, bounded by the call timeout.
+func (c *MainChainClient) callBackend(ctx context.Context, backend MainChainBackend, result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	return backend.CallContext(ctx, result, method, args...)
+}
+
+// SnapshotByTime retrieves the main chain snapshot sealed at the header time of
+// a side chain block. Snapshots are verified against the main chain headers and
+// cached for the rest of their loop.
+func (c *MainChainClient) SnapshotByTime(ctx context.Context, headerTime uint64, scHash common.Hash) (*Snapshot, error) {
+	c.lock.Lock()
+	if ms, ok := c.snapshots[scHash]; ok && headerTime >= ms.LoopStartTime && headerTime < ms.LoopStartTime+ms.Period*uint64(len(ms.Signers)) {
+		c.lock.Unlock()
+		return ms, nil
+	}
+	c.lock.Unlock()
+
+	var ms *Snapshot
+	err := c.attempt(ctx, func(backend MainChainBackend) error {
+		ms = nil
+		if err := c.callBackend(ctx, backend, &ms, "alien_getSnapshotByHeaderTime", headerTime, scHash); err != nil {
+			return err
+		}
+		if ms == nil {
+			return errMCInvalidSnapshot
+		}
+		if ms.Period == 0 {
+			return errMCPeriodMissing
+		}
+		return c.verifySnapshot(ctx, ms, headerTime, scHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	c.snapshots[scHash] = ms
+	c.lock.Unlock()
+
+	return ms, nil
+}
+
+// verifySnapshot checks a snapshot served for the given header time against the
+// main chain header it was taken at, and that the header time falls in its loop.
+// The coinbases the side chain substitutes for the signers are not part of the
+// headers, so the signers are only checked to follow the signer queue with each
+// signer replaced by the same coinbase throughout.
+func (c *MainChainClient) verifySnapshot(ctx context.Context, ms *Snapshot, headerTime uint64, scHash common.Hash) error {
+	header, err := c.syncHeader(ctx, ms.Number)
+	if err != nil {
+		return err
+	}
+	if header.Hash() != ms.Hash {
+		return fmt.Errorf("%w: hash %x, header %x", errMCInvalidSnapshot, ms.Hash, header.Hash())
+	}
+	if header.Time > headerTime {
+		return fmt.Errorf("%w: header time %d after %d", errMCInvalidSnapshot, header.Time, headerTime)
+	}
+	extra, _, err := DecodeHeaderExtra(header.Extra)
+	if err != nil {
+		return err
+	}
+	if extra.LoopStartTime != ms.LoopStartTime || len(extra.SignerQueue) != len(ms.Signers) {
+		return fmt.Errorf("%w: loop mismatch at %d", errMCInvalidSnapshot, ms.Number)
+	}
+	if headerTime < ms.LoopStartTime || headerTime >= ms.LoopStartTime+ms.Period*uint64(len(ms.Signers)) {
+		return fmt.Errorf("%w: time %d outside loop of %d starting at %d", errMCInvalidSnapshot, headerTime, ms.Number, ms.LoopStartTime)
+	}
+	if err := verifySCSigners(extra.SignerQueue, ms.Signers); err != nil {
+		return err
+	}
+	for hash := range ms.SCNoticeMap {
+		if hash != scHash {
+			return fmt.Errorf("%w: notice of side chain %x", errMCInvalidSnapshot, hash)
+		}
+	}
+	return nil
+}
+
+// verifySCSigners checks the signers of a snapshot follow the signer queue, each
+// signer replaced by one coinbase throughout or kept. A coinbase is never another
+// signer of the queue, nor shared by two signers.
+func verifySCSigners(queue []common.Address, signers []*common.Address) error {
+	queued := make(map[common.Address]bool)
+	for _, signer := range queue {
+		queued[signer] = true
+	}
+	coinbases := make(map[common.Address]common.Address)
+	owners := make(map[common.Address]common.Address)
+	for i, signer := range queue {
+		if signers[i] == nil {
+			return fmt.Errorf("%w: signer %d missing", errMCInvalidSnapshot, i)
+		}
+		coinbase := *signers[i]
+		if coinbase != signer && queued[coinbase] {
+			return fmt.Errorf("%w: signer %d is %s, want %s", errMCInvalidSnapshot, i, coinbase.Hex(), signer.Hex())
+		}
+		if last, ok := coinbases[signer]; ok && last != coinbase {
+			return fmt.Errorf("%w: %s replaced by %s and %s", errMCInvalidSnapshot, signer.Hex(), last.Hex(), coinbase.Hex())
+		}
+		if owner, ok := owners[coinbase]; ok && owner != signer {
+			return fmt.Errorf("%w: %s replaces %s and %s", errMCInvalidSnapshot, coinbase.Hex(), owner.Hex(), signer.Hex())
+		}
+		coinbases[signer], owners[coinbase] = coinbase, signer
+	}
+	return nil
+}
+
+// syncHeader retrieves the main chain header at number. It is synced from the
+// last synced header or the checkpoint, checking each header links to its parent
+// and is sealed by a signer of the queue, or anchored on the header a quorum of
+// the endpoints agree on when it is too far from the last synced one.
+func (c *MainChainClient) syncHeader(ctx context.Context, number uint64) (*types.Header, error) {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	if header, ok := c.headers.Get(number); ok {
+		return header.(*types.Header), nil
+	}
+	if cp := c.config.Checkpoint; c.head == nil && cp != nil && number >= cp.Number {
+		header, err := c.anchorHeader(ctx, cp.Number)
+		if err != nil {
+			return nil, err
+		}
+		c.head = header
+		c.headers.Add(cp.Number, header)
+		if number == cp.Number {
+			return header, nil
+		}
+	}
+	if c.head == nil || number <= c.head.Number.Uint64() || number-c.head.Number.Uint64() > maxMainChainHeaderSync {
+		header, err := c.anchorHeader(ctx, number)
+		if err == nil {
+			c.head = header
+			c.headers.Add(number, header)
+			return header, nil
+		}
+		// Without a quorum, sync all the way from the last trusted header
+		if !errors.Is(err, errMCNoQuorum) || c.head == nil || number <= c.head.Number.Uint64() {
+			return nil, err
+		}
+	}
+	for n := c.head.Number.Uint64() + 1; n <= number; n++ {
+		header, err := c.headerByNumber(ctx, n)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.verifyHeader(header, c.head); err != nil {
+			return nil, err
+		}
+		c.head = header
+		c.headers.Add(n, header)
+	}
+	return c.head, nil
+}
+
+// anchorHeader retrieves the header at number from all the endpoints, and
+// returns it if a majority of them serve it and none disagrees. The checkpoint
+// is trusted by its hash instead.
+func (c *MainChainClient) anchorHeader(ctx context.Context, number uint64) (*types.Header, error) {
+	if cp := c.config.Checkpoint; cp != nil && cp.Number == number {
+		header, err := c.headerByNumber(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash() != cp.Hash {
+			return nil, fmt.Errorf("%w: checkpoint %d is %x, want %x", errMCHeaderMismatch, number, header.Hash(), cp.Hash)
+		}
+		return header, nil
+	}
+	var (
+		anchor *types.Header
+		votes  int
+	)
+	for _, e := range c.endpoints {
+		var header *types.Header
+		if err := c.callBackend(ctx, e.backend, &header, "eth_getHeaderByNumber", hexutil.EncodeUint64(number)); err != nil || header == nil || header.Number == nil {
+			continue
+		}
+		if anchor != nil && anchor.Hash() != header.Hash() {
+			return nil, fmt.Errorf("%w: number %d", errMCHeaderMismatch, number)
+		}
+		anchor = header
+		votes++
+	}
+	if anchor == nil {
+		return nil, fmt.Errorf("%w: number %d", errUnknownBlock, number)
+	}
+	if quorum := len(c.endpoints)/2 + 1; votes < quorum {
+		return nil, fmt.Errorf("%w: %d endpoints serve number %d, want %d", errMCNoQuorum, votes, number, quorum)
+	}
+	if err := c.verifyHeader(anchor, nil); err != nil {
+		return nil, err
+	}
+	return anchor, nil
+}
+
+// headerByNumber retrieves the header at number.
+func (c *MainChainClient) headerByNumber(ctx context.Context, number uint64) (*types.Header, error) {
+	var header *types.Header
+	if err := c.call(ctx, &header, "eth_getHeaderByNumber", hexutil.EncodeUint64(number)); err != nil {
+		return nil, err
+	}
+	if header == nil || header.Number == nil || header.Number.Uint64() != number {
+		return nil, fmt.Errorf("%w: number %d", errUnknownBlock, number)
+	}
+	return header, nil
+}
+
+// verifyHeader checks a main chain header is sealed by a signer of its signer
+// queue or of its parent's, and links to its parent if known.
+func (c *MainChainClient) verifyHeader(header *types.Header, parent *types.Header) error {
+	if header.Number.Sign() == 0 {
+		return nil
+	}
+	if parent != nil && (header.ParentHash != parent.Hash() || header.Number.Uint64() != parent.Number.Uint64()+1) {
+		return fmt.Errorf("%w: number %d not linked to its parent", errMCInvalidHeader, header.Number)
+	}
+	signer, err := ecrecover(header, c.sigcache)
+	if err != nil {
+		return err
+	}
+	queues := []*types.Header{header}
+	if parent != nil {
+		queues = append(queues, parent)
+	}
+	for _, h := range queues {
+		extra, _, err := DecodeHeaderExtra(h.Extra)
+		if err != nil {
+			return err
+		}
+		for _, s := range extra.SignerQueue {
+			if s == signer {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: number %d sealed by %s", errMCInvalidHeader, header.Number, signer.Hex())
+}
+
+// SendTransaction sends a signed transaction to the main chain.
+func (c *MainChainClient) SendTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error) {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var hash common.Hash
+	if err := c.call(ctx, &hash, "eth_sendRawTransaction", common.ToHex(data)); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// TransactionCount retrieves the nonce of an account on the main chain.
+func (c *MainChainClient) TransactionCount(ctx context.Context, account common.Address) (uint64, error) {
+	var result hexutil.Uint64
+	if err := c.call(ctx, &result, "eth_getTransactionCount", account.Hex(), "latest"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// NetVersion retrieves the network id of the main chain.
+func (c *MainChainClient) NetVersion(ctx context.Context) (uint64, error) {
+	var result string
+	if err := c.call(ctx, &result, "net_version"); err != nil {
+		return 0, err
+	}
+	netVersion := new(big.Int)
+	if err := netVersion.UnmarshalText([]byte(result)); err != nil {
+		return 0, err
+	}
+	return netVersion.Uint64(), nil
+}
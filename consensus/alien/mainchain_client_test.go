@@ -0,0 +1,399 @@
+package alien
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+	"github.com/UltronGlow/UltronGlow-Origin/rpc"
+)
+
+// testMainChainConfig fails over fast enough for the tests.
+var testMainChainConfig = MainChainConfig{
+	Timeout:  time.Second,
+	Retries:  2,
+	Backoff:  time.Millisecond,
+	Cooldown: time.Minute,
+}
+
+// fakeMainChain is an in-process main chain serving the rpc methods a side chain
+// calls, over headers sealed in turn by a fixed signer queue.
+type fakeMainChain struct {
+	period   uint64
+	keys     []*ecdsa.PrivateKey
+	signers  []common.Address
+	headers  []*types.Header
+	coinbase map[common.Address]common.Address // Coinbase of the signers on the side chain
+	tamper   func(*Snapshot)                   // Modifies the snapshots served, if set
+
+	lock      sync.Mutex
+	nonces    map[common.Address]uint64
+	txs       []*types.Transaction
+	snapCalls int
+}
+
+// newFakeMainChain creates a main chain of n blocks sealed by the given keys,
+// starting at time start.
+func newFakeMainChain(t *testing.T, keys []*ecdsa.PrivateKey, period uint64, start uint64, n int) *fakeMainChain {
+	mc := &fakeMainChain{
+		period:   period,
+		keys:     keys,
+		coinbase: make(map[common.Address]common.Address),
+		nonces:   make(map[common.Address]uint64),
+	}
+	for _, key := range keys {
+		mc.signers = append(mc.signers, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	loop := uint64(len(keys)) * period
+	for i := 0; i < n; i++ {
+		number := uint64(i)
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Time:       start + number*period,
+			Difficulty: big.NewInt(1),
+		}
+		if i > 0 {
+			header.ParentHash = mc.headers[i-1].Hash()
+		}
+		mc.seal(t, header, keys[i%len(keys)], start+number*period/loop*loop)
+		mc.headers = append(mc.headers, header)
+	}
+	return mc
+}
+
+// seal sets the alien extra of a header and signs it with key.
+func (mc *fakeMainChain) seal(t *testing.T, header *types.Header, key *ecdsa.PrivateKey, loopStartTime uint64) {
+	enc, err := encodeVersionedHeaderExtra(header.Number.Uint64(), HeaderExtra{
+		LoopStartTime: loopStartTime,
+		SignerQueue:   mc.signers,
+		FlowHarvest:   big.NewInt(0),
+		CurLeaseSpace: big.NewInt(0),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode header extra: %v", err)
+	}
+	header.Extra = append(append(make([]byte, extraVanity), enc...), make([]byte, extraSeal)...)
+	hash, err := sigHash(header)
+	if err != nil {
+		t.Fatalf("failed to hash header: %v", err)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+}
+
+// dial returns an in-memory rpc client of the main chain.
+func (mc *fakeMainChain) dial(t *testing.T) *rpc.Client {
+	server := rpc.NewServer()
+	for namespace, api := range map[string]interface{}{
+		"alien": &fakeMainChainAlienAPI{mc},
+		"eth":   &fakeMainChainEthAPI{mc},
+		"net":   &fakeMainChainNetAPI{},
+	} {
+		if err := server.RegisterName(namespace, api); err != nil {
+			t.Fatalf("failed to register %s api: %v", namespace, err)
+		}
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+type fakeMainChainAlienAPI struct{ mc *fakeMainChain }
+
+func (api *fakeMainChainAlienAPI) GetSnapshotByHeaderTime(targetTime uint64, scHash common.Hash) (*Snapshot, error) {
+	mc := api.mc
+	mc.lock.Lock()
+	defer mc.lock.Unlock()
+
+	mc.snapCalls++
+	var header *types.Header
+	for _, h := range mc.headers {
+		if h.Time <= targetTime {
+			header = h
+		}
+	}
+	if header == nil || targetTime >= mc.headers[len(mc.headers)-1].Time+mc.period {
+		return nil, errUnknownBlock
+	}
+	extra, _, err := DecodeHeaderExtra(header.Extra)
+	if err != nil {
+		return nil, err
+	}
+	ms := &Snapshot{
+		Period:        mc.period,
+		Number:        header.Number.Uint64(),
+		Hash:          header.Hash(),
+		HeaderTime:    header.Time,
+		LoopStartTime: extra.LoopStartTime,
+	}
+	for _, signer := range mc.signers {
+		signer := signer
+		if coinbase, ok := mc.coinbase[signer]; ok {
+			signer = coinbase
+		}
+		ms.Signers = append(ms.Signers, &signer)
+	}
+	if mc.tamper != nil {
+		mc.tamper(ms)
+	}
+	return ms, nil
+}
+
+type fakeMainChainEthAPI struct{ mc *fakeMainChain }
+
+func (api *fakeMainChainEthAPI) GetHeaderByNumber(number hexutil.Uint64) *types.Header {
+	if uint64(number) >= uint64(len(api.mc.headers)) {
+		return nil
+	}
+	return api.mc.headers[number]
+}
+
+func (api *fakeMainChainEthAPI) GetTransactionCount(address common.Address, tag string) hexutil.Uint64 {
+	api.mc.lock.Lock()
+	defer api.mc.lock.Unlock()
+
+	return hexutil.Uint64(api.mc.nonces[address])
+}
+
+func (api *fakeMainChainEthAPI) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(input, tx); err != nil {
+		return common.Hash{}, err
+	}
+	api.mc.lock.Lock()
+	defer api.mc.lock.Unlock()
+
+	api.mc.txs = append(api.mc.txs, tx)
+	return tx.Hash(), nil
+}
+
+type fakeMainChainNetAPI struct{}
+
+func (api *fakeMainChainNetAPI) Version() string {
+	return "1024"
+}
+
+// deadMainChain is an endpoint that cannot be reached.
+type deadMainChain struct{ calls int }
+
+func (d *deadMainChain) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	d.calls++
+	return errors.New("connection refused")
+}
+
+func newTestMainChainKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestMainChainClientSnapshot(t *testing.T) {
+	var (
+		keys   = newTestMainChainKeys(t, 3)
+		mc     = newFakeMainChain(t, keys, 3, 1000, 40)
+		scHash = common.HexToHash("0x01")
+	)
+	mc.coinbase[mc.signers[1]] = common.HexToAddress("0xc0ffee")
+
+	client, err := NewMainChainClient([]MainChainBackend{mc.dial(t)}, testMainChainConfig)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ms, err := client.SnapshotByTime(context.Background(), 1010, scHash)
+	if err != nil {
+		t.Fatalf("failed to retrieve snapshot: %v", err)
+	}
+	if ms.Number != 3 || ms.LoopStartTime != 1009 || *ms.Signers[1] != common.HexToAddress("0xc0ffee") {
+		t.Fatalf("snapshot mismatch: number %d, loop start %d", ms.Number, ms.LoopStartTime)
+	}
+	// Snapshots are cached for the rest of their loop
+	if _, err := client.SnapshotByTime(context.Background(), 1017, scHash); err != nil {
+		t.Fatalf("failed to retrieve cached snapshot: %v", err)
+	}
+	if mc.snapCalls != 1 {
+		t.Errorf("snapshot of the same loop requested again: %d calls", mc.snapCalls)
+	}
+	// The next loop is synced from the headers of the previous one
+	ms, err = client.SnapshotByTime(context.Background(), 1030, scHash)
+	if err != nil {
+		t.Fatalf("failed to retrieve next loop: %v", err)
+	}
+	if ms.Number != 10 || mc.snapCalls != 2 {
+		t.Errorf("next loop mismatch: number %d, %d calls", ms.Number, mc.snapCalls)
+	}
+	if client.head.Number.Uint64() != 10 {
+		t.Errorf("synced head mismatch: have %d, want 10", client.head.Number)
+	}
+	// Snapshots ahead of the main chain are unknown
+	if _, err := client.SnapshotByTime(context.Background(), 2000, scHash); err == nil {
+		t.Errorf("snapshot ahead of the main chain served")
+	}
+}
+
+func TestMainChainClientFailover(t *testing.T) {
+	var (
+		keys = newTestMainChainKeys(t, 3)
+		mc   = newFakeMainChain(t, keys, 3, 1000, 20)
+		dead = new(deadMainChain)
+	)
+	client, err := NewMainChainClient([]MainChainBackend{dead, mc.dial(t)}, testMainChainConfig)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	nonce, err := client.TransactionCount(context.Background(), mc.signers[0])
+	if err != nil || nonce != 0 {
+		t.Fatalf("failed to retrieve nonce: %d, %v", nonce, err)
+	}
+	if dead.calls != 1 {
+		t.Fatalf("dead endpoint calls mismatch: have %d, want 1", dead.calls)
+	}
+	// The failed endpoint is skipped while cooling down
+	version, err := client.NetVersion(context.Background())
+	if err != nil || version != 1024 {
+		t.Fatalf("failed to retrieve net version: %d, %v", version, err)
+	}
+	tx, err := types.SignTx(types.NewTransaction(0, mc.signers[0], big.NewInt(0), mcTxDefaultGasLimit, mcTxDefaultGasPrice, nil), types.NewEIP155Signer(big.NewInt(1024)), keys[0])
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	hash, err := client.SendTransaction(context.Background(), tx)
+	if err != nil || hash != tx.Hash() || len(mc.txs) != 1 {
+		t.Fatalf("failed to send transaction: %x, %v", hash, err)
+	}
+	if dead.calls != 1 {
+		t.Errorf("cooling endpoint called: %d calls", dead.calls)
+	}
+	// Calls fail once all the endpoints do
+	client, _ = NewMainChainClient([]MainChainBackend{dead, new(deadMainChain)}, testMainChainConfig)
+	if _, err := client.NetVersion(context.Background()); err == nil {
+		t.Errorf("call through dead endpoints succeeded")
+	}
+}
+
+// Tests that the header sync is only anchored on a header a majority of the
+// endpoints serve, or on the configured checkpoint.
+func TestMainChainClientQuorum(t *testing.T) {
+	var (
+		keys   = newTestMainChainKeys(t, 3)
+		mc     = newFakeMainChain(t, keys, 3, 1000, maxMainChainHeaderSync+40)
+		scHash = common.HexToHash("0x01")
+	)
+	client, _ := NewMainChainClient([]MainChainBackend{new(deadMainChain), new(deadMainChain), mc.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); !errors.Is(err, errMCNoQuorum) {
+		t.Errorf("anchor of a minority: have %v, want %v", err, errMCNoQuorum)
+	}
+	client, _ = NewMainChainClient([]MainChainBackend{mc.dial(t), mc.dial(t), new(deadMainChain)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); err != nil {
+		t.Errorf("failed to anchor on a majority: %v", err)
+	}
+	// The checkpoint is trusted without a quorum, and the headers synced from it
+	config := testMainChainConfig
+	config.Checkpoint = &MainChainCheckpoint{Number: 1, Hash: mc.headers[1].Hash()}
+	client, _ = NewMainChainClient([]MainChainBackend{mc.dial(t), new(deadMainChain), new(deadMainChain)}, config)
+	ms, err := client.SnapshotByTime(context.Background(), 1010, scHash)
+	if err != nil || ms.Number != 3 {
+		t.Fatalf("failed to sync from the checkpoint: %v", err)
+	}
+	// Even past the sync distance, where the majority would be asked otherwise
+	far := 1000 + 3*uint64(maxMainChainHeaderSync+20)
+	if ms, err = client.SnapshotByTime(context.Background(), far, scHash); err != nil || ms.HeaderTime != far {
+		t.Fatalf("failed to sync past the sync distance: %v", err)
+	}
+	config.Checkpoint = &MainChainCheckpoint{Number: 1, Hash: common.HexToHash("0xdead")}
+	client, _ = NewMainChainClient([]MainChainBackend{mc.dial(t)}, config)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); !errors.Is(err, errMCHeaderMismatch) {
+		t.Errorf("checkpoint mismatch: have %v, want %v", err, errMCHeaderMismatch)
+	}
+}
+
+func TestMainChainClientVerification(t *testing.T) {
+	var (
+		keys   = newTestMainChainKeys(t, 3)
+		scHash = common.HexToHash("0x01")
+	)
+	// Tampered snapshots are rejected, and served by the honest endpoint instead
+	liar := newFakeMainChain(t, keys, 3, 1000, 20)
+	liar.tamper = func(ms *Snapshot) { ms.LoopStartTime++ }
+	honest := newFakeMainChain(t, keys, 3, 1000, 20)
+
+	client, _ := NewMainChainClient([]MainChainBackend{liar.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); !errors.Is(err, errMCInvalidSnapshot) {
+		t.Errorf("tampered snapshot: have %v, want %v", err, errMCInvalidSnapshot)
+	}
+	client, _ = NewMainChainClient([]MainChainBackend{liar.dial(t), honest.dial(t)}, testMainChainConfig)
+	if ms, err := client.SnapshotByTime(context.Background(), 1010, scHash); err != nil || ms.LoopStartTime != 1009 {
+		t.Errorf("failed to fail over tampered snapshot: %v", err)
+	}
+	// Snapshots of another chain do not match the synced headers
+	liar.tamper = func(ms *Snapshot) { ms.Hash = common.HexToHash("0xdead") }
+	client, _ = NewMainChainClient([]MainChainBackend{liar.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); !errors.Is(err, errMCInvalidSnapshot) {
+		t.Errorf("snapshot of unknown block: have %v, want %v", err, errMCInvalidSnapshot)
+	}
+	// Endpoints disagreeing on the anchor are not trusted
+	fork := newFakeMainChain(t, keys, 3, 1001, 20)
+	client, _ = NewMainChainClient([]MainChainBackend{honest.dial(t), fork.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1010, scHash); !errors.Is(err, errMCHeaderMismatch) {
+		t.Errorf("disagreeing endpoints: have %v, want %v", err, errMCHeaderMismatch)
+	}
+	// Signers out of the queue order, notices of other side chains and snapshots
+	// of another loop are rejected
+	for i, tamper := range []func(*Snapshot){
+		func(ms *Snapshot) { ms.Signers[0], ms.Signers[1] = ms.Signers[1], ms.Signers[0] },
+		func(ms *Snapshot) {
+			coinbase := common.HexToAddress("0xc0ffee")
+			ms.Signers[0], ms.Signers[1] = &coinbase, &coinbase
+		},
+		func(ms *Snapshot) { ms.SCNoticeMap = map[common.Hash]*CCNotice{common.HexToHash("0x02"): {}} },
+		func(ms *Snapshot) {
+			header := liar.headers[0]
+			ms.Number, ms.Hash, ms.HeaderTime, ms.LoopStartTime = 0, header.Hash(), header.Time, header.Time
+		},
+	} {
+		liar.tamper = tamper
+		client, _ = NewMainChainClient([]MainChainBackend{liar.dial(t)}, testMainChainConfig)
+		if _, err := client.SnapshotByTime(context.Background(), 1030, scHash); !errors.Is(err, errMCInvalidSnapshot) {
+			t.Errorf("tampered snapshot %d: have %v, want %v", i, err, errMCInvalidSnapshot)
+		}
+	}
+	liar.tamper = func(ms *Snapshot) { ms.SCNoticeMap = map[common.Hash]*CCNotice{scHash: {}} }
+	client, _ = NewMainChainClient([]MainChainBackend{liar.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1030, scHash); err != nil {
+		t.Errorf("failed to retrieve snapshot with notice: %v", err)
+	}
+	// Headers sealed outside of the signer queue break the sync
+	forged := newFakeMainChain(t, keys, 3, 1000, 20)
+	forged.seal(t, forged.headers[5], newTestMainChainKeys(t, 1)[0], 1009)
+	for i := 6; i < len(forged.headers); i++ {
+		forged.headers[i].ParentHash = forged.headers[i-1].Hash()
+		forged.seal(t, forged.headers[i], keys[i%len(keys)], 1000+uint64(i)*3/9*9)
+	}
+	client, _ = NewMainChainClient([]MainChainBackend{forged.dial(t)}, testMainChainConfig)
+	if _, err := client.SnapshotByTime(context.Background(), 1000, scHash); err != nil {
+		t.Fatalf("failed to anchor: %v", err)
+	}
+	if _, err := client.SnapshotByTime(context.Background(), 1050, scHash); !errors.Is(err, errMCInvalidHeader) {
+		t.Errorf("forged header: have %v, want %v", err, errMCInvalidHeader)
+	}
+}
@@ -0,0 +1,674 @@
+package alien
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/accounts"
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/core/vm"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+	"github.com/UltronGlow/UltronGlow-Origin/rpc"
+)
+
+const (
+	simPeriod      = 3         // Block period of both simulated chains
+	simGasLimit    = 100000000 // Gas limit of the simulated blocks
+	simTxGasLimit  = 500000    // Gas limit of the transactions sent by the tests
+	simSlotSearch  = 64        // Slots searched for a signer in turn
+	simSealTimeout = 5 * time.Second
+)
+
+// simBalance is the genesis balance of the main chain signers.
+var simBalance = new(big.Int).Mul(big.NewInt(1e+18), big.NewInt(1e+6))
+
+// simForks returns a fork table leaving all the rule changes off, as the side
+// chain rules predate them.
+func simForks() *params.AlienForks {
+	forks := new(params.AlienForks)
+	never := reflect.ValueOf(new(big.Int).SetUint64(1 << 40))
+	table := reflect.ValueOf(forks).Elem()
+	for i := 0; i < table.NumField(); i++ {
+		table.Field(i).Set(never)
+	}
+	return forks
+}
+
+// simClock is a wall clock shared by the chains of a simulation, only moved
+// forward by the simulation itself.
+type simClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *simClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// advance moves the clock to the given unix time, if it is later.
+func (c *simClock) advance(unix uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if t := time.Unix(int64(unix), 0); t.After(c.now) {
+		c.now = t
+	}
+}
+
+// simChain is an alien chain sealed in process on a simulated clock, its blocks
+// built, sealed and imported the way a signer running the engine would.
+type simChain struct {
+	t      *testing.T
+	clock  *simClock
+	config *params.ChainConfig
+	engine *Alien
+	chain  *core.BlockChain
+	keys   map[common.Address]*ecdsa.PrivateKey // Keys of all the signers the chain may be sealed by
+
+	lock sync.Mutex
+	pool []*types.Transaction // Transactions waiting for the next block
+}
+
+// newSimChain creates a chain of the given alien config with a genesis at the
+// current time of the clock, sealed by any of keys in turn.
+func newSimChain(t *testing.T, clock *simClock, chainID int64, alien *params.AlienConfig, parentHash common.Hash, alloc core.GenesisAlloc, keys []*ecdsa.PrivateKey) *simChain {
+	config := *params.AllAlienProtocolChanges
+	config.ChainID = big.NewInt(chainID)
+	config.LondonBlock = nil
+	config.Alien = alien
+
+	db := rawdb.NewMemoryDatabase()
+	genesis := &core.Genesis{
+		Config:     &config,
+		Timestamp:  uint64(clock.Now().Unix()),
+		ExtraData:  make([]byte, extraVanity+extraSeal),
+		GasLimit:   simGasLimit,
+		Difficulty: big.NewInt(1),
+		ParentHash: parentHash,
+		Alloc:      alloc,
+	}
+	genesis.MustCommit(db)
+
+	// The engine applies the fork and time tables of its config to the process
+	t.Cleanup(resetSchedule)
+	c := &simChain{
+		t:      t,
+		clock:  clock,
+		config: &config,
+		engine: New(alien, db),
+		keys:   make(map[common.Address]*ecdsa.PrivateKey),
+	}
+	c.engine.now = clock.Now
+	for _, key := range keys {
+		c.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	chain, err := core.NewBlockChain(db, nil, &config, c.engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+	c.chain = chain
+	return c
+}
+
+// dial returns an in-memory rpc client serving the api of the chain a side
+// chain calls.
+func (c *simChain) dial() *rpc.Client {
+	server := rpc.NewServer()
+	for _, api := range c.engine.APIs(c.chain) {
+		if err := server.RegisterName(api.Namespace, api.Service); err != nil {
+			c.t.Fatalf("failed to register %s api: %v", api.Namespace, err)
+		}
+	}
+	if err := server.RegisterName("eth", &simChainEthAPI{c}); err != nil {
+		c.t.Fatalf("failed to register eth api: %v", err)
+	}
+	if err := server.RegisterName("net", &simChainNetAPI{c}); err != nil {
+		c.t.Fatalf("failed to register net api: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	c.t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client
+}
+
+// snapshot returns the snapshot at the head of the chain.
+func (c *simChain) snapshot() *Snapshot {
+	head := c.chain.CurrentHeader()
+	snap, err := c.engine.snapshot(c.chain, head.Number.Uint64(), head.Hash(), nil, nil, defaultLoopCntRecalculateSigners)
+	if err != nil {
+		c.t.Fatalf("failed to get snapshot at %d: %v", head.Number, err)
+	}
+	return snap
+}
+
+// balance returns the balance of an account at the head of the chain.
+func (c *simChain) balance(account common.Address) *big.Int {
+	statedb, err := c.chain.State()
+	if err != nil {
+		c.t.Fatalf("failed to open state: %v", err)
+	}
+	return statedb.GetBalance(account)
+}
+
+// nonce returns the next nonce of an account, counting the pooled transactions.
+func (c *simChain) nonce(account common.Address) uint64 {
+	statedb, err := c.chain.State()
+	if err != nil {
+		c.t.Fatalf("failed to open state: %v", err)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	nonce := statedb.GetNonce(account)
+	signer := types.NewEIP155Signer(c.config.ChainID)
+	for _, tx := range c.pool {
+		if from, _ := types.Sender(signer, tx); from == account {
+			nonce++
+		}
+	}
+	return nonce
+}
+
+// add queues a transaction for the next block.
+func (c *simChain) add(tx *types.Transaction) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pool = append(c.pool, tx)
+}
+
+// send signs a transaction of key carrying data and queues it.
+func (c *simChain) send(key *ecdsa.PrivateKey, to common.Address, value *big.Int, data string) *types.Transaction {
+	nonce := c.nonce(crypto.PubkeyToAddress(key.PublicKey))
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, value, simTxGasLimit, mcTxDefaultGasPrice, []byte(data)), types.NewEIP155Signer(c.config.ChainID), key)
+	if err != nil {
+		c.t.Fatalf("failed to sign tx: %v", err)
+	}
+	c.add(tx)
+	return tx
+}
+
+// inturn returns the signer of keys scheduled to seal the block of the slot on
+// top of parent, if any.
+func (c *simChain) inturn(parent *types.Header, slot uint64) (common.Address, bool) {
+	if c.config.Alien.SideChain {
+		for signer := range c.keys {
+			if _, _, _, _, _, err := c.engine.mcSnapshot(c.chain, signer, slot); err == nil {
+				return signer, true
+			}
+		}
+		return common.Address{}, false
+	}
+	var signer common.Address
+	if parent.Number.Uint64() == 0 {
+		// The genesis snapshot is only taken once the genesis votes are counted on block 1
+		alien := c.config.Alien
+		index := (slot - alien.GenesisTimestamp) / alien.Period % alien.MaxSignerCount
+		signer = common.Address(alien.SelfVoteSigners[index%uint64(len(alien.SelfVoteSigners))])
+	} else {
+		snap, err := c.engine.snapshot(c.chain, parent.Number.Uint64(), parent.Hash(), nil, nil, defaultLoopCntRecalculateSigners)
+		if err != nil {
+			c.t.Fatalf("failed to get snapshot at %d: %v", parent.Number, err)
+		}
+		if len(snap.Signers) == 0 {
+			return common.Address{}, false
+		}
+		signer = *snap.Signers[(slot-snap.LoopStartTime)/c.config.Alien.Period%uint64(len(snap.Signers))]
+	}
+	_, ok := c.keys[signer]
+	return signer, ok
+}
+
+// mine seals the next block at the first slot one of the signers is in turn.
+func (c *simChain) mine() *types.Block {
+	parent := c.chain.CurrentHeader()
+	slot := parent.Time + c.config.Alien.Period
+	if now := uint64(c.clock.Now().Unix()); slot < now {
+		slot = now
+	}
+	for i := uint64(0); i < simSlotSearch; i++ {
+		if signer, ok := c.inturn(parent, slot); ok {
+			return c.seal(parent, slot, signer)
+		}
+		slot += c.config.Alien.Period
+	}
+	c.t.Fatalf("no signer in turn on top of block %d", parent.Number)
+	return nil
+}
+
+// mineAt seals the next block at the given slot, or returns nil if it is too
+// early for a block or none of the signers is in turn.
+func (c *simChain) mineAt(slot uint64) *types.Block {
+	parent := c.chain.CurrentHeader()
+	if parent.Time+c.config.Alien.Period > slot {
+		return nil
+	}
+	signer, ok := c.inturn(parent, slot)
+	if !ok {
+		return nil
+	}
+	return c.seal(parent, slot, signer)
+}
+
+// seal builds the block of signer at slot on top of parent with the pooled
+// transactions, seals it the way the miner does and imports it.
+func (c *simChain) seal(parent *types.Header, slot uint64, signer common.Address) *types.Block {
+	c.clock.advance(slot)
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       slot,
+		Coinbase:   signer,
+	}
+	if err := c.engine.Prepare(c.chain, header); err != nil {
+		c.t.Fatalf("failed to prepare block %d: %v", header.Number, err)
+	}
+	statedb, err := c.chain.StateAt(parent.Root)
+	if err != nil {
+		c.t.Fatalf("failed to open state of block %d: %v", parent.Number, err)
+	}
+	c.lock.Lock()
+	txs := c.pool
+	c.pool = nil
+	c.lock.Unlock()
+
+	var (
+		receipts  []*types.Receipt
+		usedGas   uint64
+		gasReward = new(big.Int)
+		gp        = new(core.GasPool).AddGas(header.GasLimit)
+	)
+	for i, tx := range txs {
+		statedb.Prepare(tx.Hash(), common.Hash{}, i)
+		receipt, reward, err := core.ApplyTransaction(c.config, c.chain, &header.Coinbase, gp, statedb, header, tx, &usedGas, vm.Config{})
+		if err != nil {
+			c.t.Fatalf("failed to apply tx %x to block %d: %v", tx.Hash(), header.Number, err)
+		}
+		receipts = append(receipts, receipt)
+		if reward != nil {
+			gasReward.Add(gasReward, reward)
+		}
+	}
+	header.GasUsed = usedGas
+	_, payProfit := c.engine.GrantProfit(c.chain, header, statedb)
+	block, err := c.engine.FinalizeAndAssemble(c.chain, header, statedb, txs, nil, receipts, payProfit, gasReward)
+	if err != nil {
+		c.t.Fatalf("failed to finalize block %d: %v", header.Number, err)
+	}
+
+	key := c.keys[signer]
+	c.engine.Authorize(signer, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		return types.SignTx(tx, types.NewEIP155Signer(chainID), key)
+	})
+	results := make(chan *types.Block, 1)
+	if err := c.engine.Seal(c.chain, block, results, nil); err != nil {
+		c.t.Fatalf("failed to seal block %d: %v", header.Number, err)
+	}
+	select {
+	case block = <-results:
+	case <-time.After(simSealTimeout):
+		c.t.Fatalf("block %d not sealed", header.Number)
+	}
+	if _, err := c.chain.InsertChain(types.Blocks{block}); err != nil {
+		c.t.Fatalf("failed to import block %d: %v", header.Number, err)
+	}
+	return block
+}
+
+// simChainEthAPI serves the eth methods a side chain calls on the main chain.
+type simChainEthAPI struct{ c *simChain }
+
+func (api *simChainEthAPI) GetHeaderByNumber(number hexutil.Uint64) *types.Header {
+	return api.c.chain.GetHeaderByNumber(uint64(number))
+}
+
+func (api *simChainEthAPI) GetTransactionCount(address common.Address, tag string) hexutil.Uint64 {
+	return hexutil.Uint64(api.c.nonce(address))
+}
+
+func (api *simChainEthAPI) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(input, tx); err != nil {
+		return common.Hash{}, err
+	}
+	api.c.add(tx)
+	return tx.Hash(), nil
+}
+
+type simChainNetAPI struct{ c *simChain }
+
+func (api *simChainNetAPI) Version() string {
+	return api.c.config.ChainID.String()
+}
+
+// sideChainSim is a main chain and a side chain of it sealed on a shared
+// simulated clock, the side chain reaching the main chain over in-memory rpc.
+type sideChainSim struct {
+	t         *testing.T
+	clock     *simClock
+	main      *simChain
+	side      *simChain
+	scHash    common.Hash         // Hash the side chain is known by on the main chain
+	signers   []*ecdsa.PrivateKey // Main chain signers
+	coinbases []*ecdsa.PrivateKey // Side chain coinbases of the main chain signers
+}
+
+// newSideChainSim creates a main chain of n self voted signers and a side chain
+// of it, each signer holding a coinbase it can set on the side chain.
+func newSideChainSim(t *testing.T, n int) *sideChainSim {
+	// The main chain parameters the side chain engine learns are kept globally
+	mcLoopStartTime, mcPeriod, mcSignerLength, mcNetVersion = 0, 0, 0, 0
+	t.Cleanup(func() {
+		mcLoopStartTime, mcPeriod, mcSignerLength, mcNetVersion = 0, 0, 0, 0
+	})
+	start := uint64(time.Now().Add(-24 * time.Hour).Unix())
+	s := &sideChainSim{
+		t:      t,
+		clock:  &simClock{now: time.Unix(int64(start), 0)},
+		scHash: common.HexToHash("0x3210000000000000000000000000000000000000000000000000000000000000"),
+	}
+	alloc := make(core.GenesisAlloc)
+	mainConfig := &params.AlienConfig{
+		Period:           simPeriod,
+		Epoch:            defaultEpochLength,
+		MaxSignerCount:   uint64(n),
+		MinVoterBalance:  big.NewInt(0),
+		GenesisTimestamp: start + simPeriod,
+		Forks:            simForks(),
+	}
+	for i := 0; i < n; i++ {
+		signer, _ := crypto.GenerateKey()
+		coinbase, _ := crypto.GenerateKey()
+		s.signers = append(s.signers, signer)
+		s.coinbases = append(s.coinbases, coinbase)
+
+		address := crypto.PubkeyToAddress(signer.PublicKey)
+		mainConfig.SelfVoteSigners = append(mainConfig.SelfVoteSigners, common.UnprefixedAddress(address))
+		alloc[address] = core.GenesisAccount{Balance: simBalance}
+	}
+	s.main = newSimChain(t, s.clock, 1337, mainConfig, common.Hash{}, alloc, s.signers)
+
+	sideConfig := &params.AlienConfig{
+		Period:           simPeriod,
+		Epoch:            defaultEpochLength,
+		MaxSignerCount:   uint64(n),
+		MinVoterBalance:  big.NewInt(0),
+		GenesisTimestamp: start + simPeriod,
+		SideChain:        true,
+		Forks:            simForks(),
+	}
+	s.side = newSimChain(t, s.clock, 1338, sideConfig, s.scHash, nil, append(append([]*ecdsa.PrivateKey{}, s.signers...), s.coinbases...))
+
+	client, err := NewMainChainClient([]MainChainBackend{s.main.dial()}, testMainChainConfig)
+	if err != nil {
+		t.Fatalf("failed to create main chain client: %v", err)
+	}
+	s.side.engine.SetMainChainClient(client)
+	return s
+}
+
+// step seals the next main chain block, and the side chain block of the same
+// slot.
+func (s *sideChainSim) step() {
+	block := s.main.mine()
+	s.side.mineAt(block.Time())
+}
+
+// run steps the simulation until the main chain reaches the given number.
+func (s *sideChainSim) run(number uint64) {
+	for s.main.chain.CurrentHeader().Number.Uint64() < number {
+		s.step()
+	}
+}
+
+// runUntil steps the simulation until cond holds, failing after limit main
+// chain blocks.
+func (s *sideChainSim) runUntil(limit int, what string, cond func() bool) {
+	for i := 0; !cond(); i++ {
+		if i == limit {
+			s.t.Fatalf("%s not reached within %d blocks", what, limit)
+		}
+		s.step()
+	}
+}
+
+// signer returns the main chain address of the i-th signer.
+func (s *sideChainSim) signer(i int) common.Address {
+	return crypto.PubkeyToAddress(s.signers[i].PublicKey)
+}
+
+// coinbase returns the side chain coinbase of the i-th signer.
+func (s *sideChainSim) coinbase(i int) common.Address {
+	return crypto.PubkeyToAddress(s.coinbases[i].PublicKey)
+}
+
+// setCoinbase sends the transaction of the i-th signer setting or deleting its
+// coinbase on the side chain.
+func (s *sideChainSim) setCoinbase(i int, set bool) {
+	event, value := ufoEventDelCoinbase, new(big.Int)
+	if set {
+		event, value = ufoEventSetCoinbase, minSCSetCoinbaseValue
+	}
+	data := fmt.Sprintf("%s:%s:%s:%s:%s", ufoPrefix, ufoVersion, ufoCategorySC, event, s.scHash.Hex())
+	s.main.send(s.signers[i], s.coinbase(i), value, data)
+}
+
+// propose sends a proposal of the first signer with the given fields, and the
+// declarations of all the signers for it in the next block.
+func (s *sideChainSim) propose(proposalType uint64, fields ...string) *Proposal {
+	data := fmt.Sprintf("%s:%s:%s:%s:proposal_type:%d:schash:%s:vlcnt:%d", ufoPrefix, ufoVersion, ufoCategoryEvent, ufoEventPorposal,
+		proposalType, s.scHash.Hex(), minValidationLoopCnt)
+	for _, field := range fields {
+		data += ":" + field
+	}
+	tx := s.main.send(s.signers[0], s.signer(0), new(big.Int), data)
+	s.step()
+	proposal, ok := s.main.snapshot().Proposals[tx.Hash()]
+	if !ok {
+		s.t.Fatalf("proposal %s not received", data)
+	}
+	for i, key := range s.signers {
+		s.main.send(key, s.signer(i), new(big.Int), fmt.Sprintf("%s:%s:%s:%s:hash:%s:decision:yes", ufoPrefix, ufoVersion, ufoCategoryEvent, ufoEventDeclare, tx.Hash().Hex()))
+	}
+	s.step()
+	return proposal
+}
+
+// addSideChain passes the proposal adding the side chain to the main chain.
+func (s *sideChainSim) addSideChain(countPerPeriod uint64, rewardPerPeriod uint64) {
+	proposal := s.propose(proposalTypeSideChainAdd, fmt.Sprintf("sccount:%d", countPerPeriod), fmt.Sprintf("screward:%d", rewardPerPeriod))
+	s.run(proposal.ReceivedNumber.Uint64() + proposal.ValidationLoopCnt*uint64(len(s.signers)) + 1)
+	if !s.main.snapshot().isSideChainExist(s.scHash) {
+		s.t.Fatalf("side chain not added")
+	}
+}
+
+// sideCoinbases returns the coinbases of the side chain blocks sealed after the
+// given number.
+func (s *sideChainSim) sideCoinbases(after uint64) map[common.Address]int {
+	coinbases := make(map[common.Address]int)
+	head := s.side.chain.CurrentHeader().Number.Uint64()
+	for n := after + 1; n <= head; n++ {
+		coinbases[s.side.chain.GetHeaderByNumber(n).Coinbase]++
+	}
+	return coinbases
+}
+
+func TestSideChainCoinbase(t *testing.T) {
+	sim := newSideChainSim(t, 3)
+	sim.run(3)
+	if coinbases := sim.sideCoinbases(0); len(coinbases) != 3 {
+		t.Fatalf("side chain sealed by %d signers, want 3", len(coinbases))
+	}
+	for i := range sim.signers {
+		sim.setCoinbase(i, true)
+	}
+	sim.step()
+	snap := sim.main.snapshot()
+	for i := range sim.signers {
+		if signer := snap.SCCoinbase[sim.scHash][sim.coinbase(i)]; signer != sim.signer(i) {
+			t.Fatalf("coinbase %d: have signer %v, want %v", i, signer, sim.signer(i))
+		}
+	}
+	// Once the main chain serves the coinbases, the side chain is sealed by them
+	sim.run(snap.Number + 3)
+	from := sim.side.chain.CurrentHeader().Number.Uint64()
+	sim.run(snap.Number + 9)
+	coinbases := sim.sideCoinbases(from)
+	for i := range sim.signers {
+		if coinbases[sim.coinbase(i)] == 0 || coinbases[sim.signer(i)] != 0 {
+			t.Fatalf("side chain of signer %d sealed by %v", i, coinbases)
+		}
+	}
+
+	// Deleting a coinbase gives the slots of the signer back to its main chain key
+	sim.setCoinbase(0, false)
+	sim.step()
+	snap = sim.main.snapshot()
+	if _, ok := snap.SCCoinbase[sim.scHash][sim.coinbase(0)]; ok {
+		t.Fatalf("coinbase not deleted")
+	}
+	if len(snap.SCCoinbase[sim.scHash]) != 2 {
+		t.Fatalf("coinbases left: have %d, want 2", len(snap.SCCoinbase[sim.scHash]))
+	}
+	sim.run(snap.Number + 3)
+	from = sim.side.chain.CurrentHeader().Number.Uint64()
+	sim.run(snap.Number + 9)
+	coinbases = sim.sideCoinbases(from)
+	if coinbases[sim.signer(0)] == 0 || coinbases[sim.coinbase(0)] != 0 {
+		t.Fatalf("side chain of signer 0 sealed by %v", coinbases)
+	}
+	if coinbases[sim.coinbase(1)] == 0 || coinbases[sim.coinbase(2)] == 0 {
+		t.Fatalf("side chain of signers 1 and 2 sealed by %v", coinbases)
+	}
+}
+
+func TestSideChainConfirm(t *testing.T) {
+	sim := newSideChainSim(t, 3)
+	for i := range sim.signers {
+		sim.setCoinbase(i, true)
+	}
+	sim.addSideChain(1, 50)
+
+	// The side chain blocks are confirmed by the coinbases on the main chain,
+	// each confirmed block scoring its coinbase the full reward of the period
+	scored := make(map[common.Address]uint64)
+	sim.runUntil(60, "side chain rewards", func() bool {
+		snap := sim.main.snapshot()
+		if reward, ok := snap.SCRewardMap[sim.scHash]; ok {
+			if reward, ok := reward.SCBlockRewardMap[snap.Number]; ok {
+				for coinbase, score := range reward.RewardScoreMap {
+					scored[coinbase] += score
+				}
+				return len(scored) == len(sim.signers)
+			}
+		}
+		return false
+	})
+	for i := range sim.signers {
+		if score := scored[sim.coinbase(i)]; score%SCCurrentBlockReward[1][1] != 0 {
+			t.Errorf("coinbase %d scored %d, want a multiple of %d", i, score, SCCurrentBlockReward[1][1])
+		}
+	}
+	snap := sim.main.snapshot()
+	record := snap.SCRecordMap[sim.scHash]
+	if head := sim.side.chain.CurrentHeader().Number.Uint64(); record.LastConfirmedNumber == 0 || record.LastConfirmedNumber >= head {
+		t.Fatalf("last confirmed number %d, side chain at %d", record.LastConfirmedNumber, head)
+	}
+	// The side chain takes its share of the block reward of the main chain
+	minerReward := big.NewInt(1e+18)
+	rewards, minerLeft := snap.calculateSCReward(minerReward)
+	if want := big.NewInt(1e+18 / 1000 * (1000 - 50)); minerLeft.Cmp(want) != 0 {
+		t.Errorf("miner reward left: have %v, want %v", minerLeft, want)
+	}
+	for coinbase, score := range snap.SCRewardMap[sim.scHash].SCBlockRewardMap[snap.Number].RewardScoreMap {
+		want := big.NewInt(1e+18 / 1000 * 50 * 50 / 1000 * int64(score) / 100)
+		if rewards[coinbase].Cmp(want) != 0 {
+			t.Errorf("reward of %v: have %v, want %v", coinbase, rewards[coinbase], want)
+		}
+	}
+}
+
+func TestSideChainRent(t *testing.T) {
+	sim := newSideChainSim(t, 3)
+	for i := range sim.signers {
+		sim.setCoinbase(i, true)
+	}
+	sim.addSideChain(1, 50)
+
+	// Side chains younger than the clear delay of the notices drop them as soon
+	// as they are confirmed, before charging the gas
+	clearDelay := uint64(len(sim.signers)) * scNoticeClearDelayLoopCount
+	sim.runUntil(int(clearDelay)*2, "notice clear delay", func() bool {
+		return sim.side.chain.CurrentHeader().Number.Uint64() >= clearDelay
+	})
+
+	// Rent gas on the side chain for an account of it
+	target := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	const rate = 2
+	proposal := sim.propose(proposalTypeRentSideChain, "scrt:"+target.Hex(), fmt.Sprintf("scrf:%d", minSCRentFee),
+		fmt.Sprintf("scrr:%d", rate), fmt.Sprintf("scrl:%d", minSCRentLength))
+	sim.run(proposal.ReceivedNumber.Uint64() + proposal.ValidationLoopCnt*uint64(len(sim.signers)) + 1)
+
+	snap := sim.main.snapshot()
+	rent, ok := snap.SCRecordMap[sim.scHash].RentReward[proposal.Hash]
+	if !ok {
+		t.Fatalf("rent not recorded")
+	}
+	if want := new(big.Int).Div(new(big.Int).Mul(big.NewInt(minSCRentFee), big.NewInt(1e+18)), big.NewInt(minSCRentLength)); rent.RentPerPeriod.Cmp(want) != 0 {
+		t.Errorf("rent per period: have %v, want %v", rent.RentPerPeriod, want)
+	}
+	charge := snap.SCNoticeMap[sim.scHash].CurrentCharging[proposal.Hash]
+	if charge.Target != target || charge.Volume != minSCRentFee*rate {
+		t.Fatalf("gas charging: have %v %d, want %v %d", charge.Target, charge.Volume, target, minSCRentFee*rate)
+	}
+
+	// The side chain signers take the notice into their headers, and charge the
+	// gas once enough of them did
+	want := new(big.Int).Mul(big.NewInt(minSCRentFee*rate), big.NewInt(1e+18))
+	sim.runUntil(30, "gas charging", func() bool { return sim.side.balance(target).Sign() > 0 })
+	if balance := sim.side.balance(target); balance.Cmp(want) != 0 {
+		t.Fatalf("charged balance: have %v, want %v", balance, want)
+	}
+	if received := sim.side.snapshot().LocalNotice.ConfirmReceived[proposal.Hash]; !received.Success {
+		t.Errorf("notice not confirmed on the side chain")
+	}
+	for i := range sim.signers {
+		if !sim.side.snapshot().LocalNotice.ConfirmReceived[proposal.Hash].NRecord[sim.coinbase(i)] {
+			t.Errorf("notice not taken by coinbase %d", i)
+		}
+	}
+
+	// The main chain stops the notice once the coinbases confirmed it
+	sim.runUntil(30, "notice confirmation", func() bool {
+		return sim.main.snapshot().SCNoticeMap[sim.scHash].ConfirmReceived[proposal.Hash].Success
+	})
+	sim.runUntil(30, "notice removal", func() bool {
+		_, ok := sim.main.snapshot().SCNoticeMap[sim.scHash].CurrentCharging[proposal.Hash]
+		return !ok
+	})
+	sim.run(sim.main.chain.CurrentHeader().Number.Uint64() + 12)
+	if balance := sim.side.balance(target); balance.Cmp(want) != 0 {
+		t.Fatalf("gas charged again: have %v, want %v", balance, want)
+	}
+}
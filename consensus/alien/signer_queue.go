@@ -551,4 +551,22 @@ func (s *Snapshot) selectSecondMinerNewInsufficient(tallyMiner TallySlice, signe
 		signerSlice = append(signerSlice, SignerItem{catallyMiner[i%minerNum].addr, s.HistoryHash[len(s.HistoryHash)-1-i]})
 	}
 	return signerSlice
-}
\ No newline at end of file
+}
+// electionScores returns the main and the second miner candidates which pass
+// the pre-selection of the next election, scored by calculateMinerState and
+// ranked the way the election picks them.
+func (s *Snapshot) electionScores() (TallySlice, TallySlice) {
+	var mainMinerSlice, secondMinerSlice TallySlice
+	if s.Number+1 > PosNewEffectNumber {
+		mainMinerSlice = s.buildTallySliceV2()
+		secondMinerSlice = s.buildTallyMinerV2()
+		if queueLength := int(s.config.MaxSignerCount); queueLength >= defaultOfficialMaxSignerCount {
+			secondMinerSlice = s.selectSecondMinerByPre(secondMinerSlice, 12*queueLength/defaultOfficialMaxSignerCount)
+			mainMinerSlice = s.selectMainMinerByPre(mainMinerSlice)
+		}
+	} else {
+		mainMinerSlice = s.buildTallySlice()
+		secondMinerSlice = s.buildTallyMiner()
+	}
+	return s.reBuildMainMiner(mainMinerSlice), s.reBuildMiner(secondMinerSlice)
+}
@@ -19,7 +19,6 @@
 package alien
 
 import (
-	"encoding/json"
 	"errors"
 	"github.com/UltronGlow/UltronGlow-Origin/common"
 	"github.com/UltronGlow/UltronGlow-Origin/consensus"
@@ -256,6 +255,7 @@ type Snapshot struct {
 	PosPledge          map[common.Address]*PosPledgeItem    `json:"pospledge"`
 	TotalLeaseSpace    *big.Int                             `json:"totalleasespace"`
 	SpData             *SpData                              `json:"SpoolData"`
+	DoubleSigned       map[common.Address]uint64            `json:"doublesigned"` // Number of the last double sign punished for each signer
 }
 
 var (
@@ -395,14 +395,16 @@ func newSnapshot(config *params.AlienConfig, sigcache *lru.ARCCache, hash common
 
 // loadSnapshot loads an existing snapshot from the database.
 func loadSnapshot(config *params.AlienConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte("alien-"), hash[:]...))
+	return openSnapshot(config, sigcache, db, hash, true)
+}
+
+// openSnapshot loads an existing snapshot from the database, rewriting it in the
+// current format if it is a legacy one and migrate is set.
+func openSnapshot(config *params.AlienConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash, migrate bool) (*Snapshot, error) {
+	snap, err := readSnapshot(db, hash, migrate)
 	if err != nil {
 		return nil, err
 	}
-	snap := new(Snapshot)
-	if err := json.Unmarshal(blob, snap); err != nil {
-		return nil, err
-	}
 	snap.config = config
 	snap.sigcache = sigcache
 
@@ -505,29 +507,25 @@ func loadSnapshot(config *params.AlienConfig, sigcache *lru.ARCCache, db ethdb.D
 	return snap, nil
 }
 
-// store inserts the snapshot into the database.
-func (s *Snapshot) store(db ethdb.Database) error {
+// store inserts the snapshot into the database, as a delta against the parent
+// layer if one is given. It returns the layer of the stored snapshot.
+func (s *Snapshot) store(db ethdb.Database, parent *snapshotLayer) (*snapshotLayer, error) {
 	err := s.FlowRevenue.saveCacheL1(db)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = s.FlowMiner.store(db, s.Number)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if s.SRT != nil {
 		s.SRTHash, err = s.SRT.Save(db)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		//s.SRTHash=s.SRT.Root()
 	}
-
-	blob, err := json.Marshal(s)
-	if err != nil {
-		return err
-	}
-	return db.Put(append([]byte("alien-"), s.Hash[:]...), blob)
+	return writeSnapshot(db, s, parent)
 }
 
 // copy creates a deep copy of the snapshot, though not the individual votes.
@@ -636,6 +634,12 @@ func (s *Snapshot) copy() *Snapshot {
 	if s.TotalLeaseSpace != nil {
 		cpy.TotalLeaseSpace = new(big.Int).Set(s.TotalLeaseSpace)
 	}
+	if s.DoubleSigned != nil {
+		cpy.DoubleSigned = make(map[common.Address]uint64, len(s.DoubleSigned))
+		for signer, number := range s.DoubleSigned {
+			cpy.DoubleSigned[signer] = number
+		}
+	}
 	copy(cpy.HistoryHash, s.HistoryHash)
 	copy(cpy.Signers, s.Signers)
 	copy(cpy.SignerMissing, s.SignerMissing)
@@ -854,23 +858,23 @@ func (s *Snapshot) copy() *Snapshot {
 	}
 	if _, ok := cpy.SystemConfig.LockParameters[sscEnumCndLock]; !ok {
 		cpy.SystemConfig.LockParameters[sscEnumCndLock] = &LockParameter{
-			LockPeriod: uint32(180 * 24 * 60 * 60 / cpy.Period),
-			RlsPeriod:  0,
-			Interval:   0,
+			LockPeriod: uint32(signerPledgeLockParamPeriod / cpy.Period),
+			RlsPeriod:  signerPledgeLockParamRlsPeriod,
+			Interval:   signerPledgeLockParamInterval,
 		}
 	}
 	if _, ok := cpy.SystemConfig.LockParameters[sscEnumFlwLock]; !ok {
 		cpy.SystemConfig.LockParameters[sscEnumFlwLock] = &LockParameter{
-			LockPeriod: uint32(180 * 24 * 60 * 60 / cpy.Period),
-			RlsPeriod:  0,
-			Interval:   0,
+			LockPeriod: uint32(flowPledgeLockParamPeriod / cpy.Period),
+			RlsPeriod:  flowPledgeLockParamRlsPeriod,
+			Interval:   flowPledgeLockParamInterval,
 		}
 	}
 	if _, ok := cpy.SystemConfig.LockParameters[sscEnumRwdLock]; !ok {
 		cpy.SystemConfig.LockParameters[sscEnumRwdLock] = &LockParameter{
-			LockPeriod: uint32(30 * 24 * 60 * 60 / cpy.Period),
-			RlsPeriod:  uint32(180 * 24 * 60 * 60 / cpy.Period),
-			Interval:   uint32(24 * 60 * 60 / cpy.Period),
+			LockPeriod: uint32(rewardLockParamPeriod / cpy.Period),
+			RlsPeriod:  uint32(rewardLockParamRlsPeriod / cpy.Period),
+			Interval:   uint32(rewardLockParamInterval / cpy.Period),
 		}
 	}
 	if _, ok := cpy.SystemConfig.ManagerAddress[sscEnumExchRate]; !ok {
@@ -995,14 +999,16 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 		snap.updateFlowMiner(header, db)
 		snap.updateMinerStack(headerExtra.MinerStake, header.Number.Uint64())
 
+		grantProfit := headerExtra.GrantProfit
 		if header.Number.Uint64() < PosrIncentiveEffectNumber {
 			snap.updateGrantProfit(headerExtra.GrantProfit, db, header.Hash(), header.Number.Uint64())
 		} else {
-			err = snap.updateGrantProfit2(headerExtra.GrantProfitHash, db, header)
+			grantProfit, err = snap.updateGrantProfit2(headerExtra.GrantProfitHash, db, header)
 			if err != nil {
 				return nil, err
 			}
 		}
+		snap.journalGrantProfit(grantProfit, header, db)
 		if header.Number.Uint64() == lockMergeNumber {
 			snap.FlowRevenue.updateMergeLockData(db, snap.Period, snap.Hash)
 		}
@@ -1011,6 +1017,9 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 		snap.updateDeviceBind(headerExtra.DeviceBind, header.Number.Uint64())
 		snap.updateCandidatePledge(headerExtra.CandidatePledge)
 		snap.updateCandidatePunish(headerExtra.CandidatePunish, header.Number.Uint64())
+		if isGEDoubleSignPunishNumber(header.Number.Uint64()) {
+			snap.updateDoubleSignPunish(headerExtra.DoubleSignPunish)
+		}
 		snap.updateCandidateExit(headerExtra.CandidateExit, header.Number)
 		snap.updateClaimedBandwidth(headerExtra.ClaimedBandwidth)
 		snap.updateFlowMinerExit(headerExtra.FlowMinerExit, header.Number)
@@ -1026,7 +1035,15 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 		if header.Number.Uint64()%(snap.config.MaxSignerCount*snap.LCRS) == 0 && header.Number.Uint64() >= signFixBlockNumber {
 			snap.updateSignerNumber(headerExtra.SignerQueue, header.Number.Uint64())
 		}
+		var (
+			leases       map[common.Hash]leaseState
+			leasePledges map[common.Address]struct{}
+		)
 		if header.Number.Uint64() >= StorageEffectBlockNumber {
+			if db != nil {
+				leasePledges = snap.leasePledges(headerExtra, header.Number.Uint64())
+				leases = snap.StorageData.leaseStates(leasePledges)
+			}
 			reSnap, err := snap.storageApply(headerExtra, header, db)
 			if err != nil {
 				log.Error("snap.storageApply", "err", err)
@@ -1040,6 +1057,9 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 				return reSnap, nil
 			}
 		}
+		if header.Number.Uint64() >= StorageEffectBlockNumber {
+			snap.journalLeases(leases, leasePledges, header, db)
+		}
 		if header.Number.Uint64() == (StorageEffectBlockNumber - 1) {
 			snap.StorageData = NewStorageSnap()
 		}
@@ -1047,11 +1067,9 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 			snap.updateStorageBandWidth(headerExtra.StorageExchangeBw, header.Number, nil)
 		}
 		if header.Number.Uint64() == (PledgeRevertLockEffectNumber - 1) {
-			snap.SRT, err = NewSRT(common.Hash{}, db)
-			if err != nil {
+			if err = snap.activatePledgeRevertLock(db); err != nil {
 				return snap, nil
 			}
-			snap.FlowRevenue.PosPgExitLock = NewLockData(LOCKPOSEXITDATA)
 		}
 		if header.Number.Uint64() == StoragePledgeOptEffectNumber {
 			snap.initBandwidthMakeup(header.Number)
@@ -1066,10 +1084,7 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 			snap.fixStorageRevertRevenue(header, db)
 		}
 		if header.Number.Uint64() == (PosNewEffectNumber - 1) {
-			snap.PosPledge = make(map[common.Address]*PosPledgeItem, 0)
-			snap.FlowRevenue.PosExitLock = NewLockData(LOCKPEXITDATA)
-			snap.initPosPledge(header.Number.Uint64())
-			snap.initPosExitPunish(header, db)
+			snap.activatePosNew(header, db)
 		}
 		if isGEPOSNewEffect(header.Number.Uint64()) {
 			snap.updateCandidatePledgeNew(headerExtra.CandidatePledgeNew, header.Number.Uint64())
@@ -1086,21 +1101,13 @@ func (s *Snapshot) apply(headers []*types.Header, db ethdb.Database, chain conse
 			snap.initPosExitPunishFix()
 		}
 		if header.Number.Uint64() == PoCrsAccCalNumber-1 {
-			snap.TotalLeaseSpace = new(big.Int).Set(initTotalLeaseSpace)
+			snap.activatePoCrsAccCal()
 		}
 		if isGEPoCrsAccCalNumber(header.Number.Uint64()) {
 			snap.updateTotalLeaseSpace(headerExtra.CurLeaseSpace)
 		}
 		if header.Number.Uint64() == (initStorageManagerNumber - 1) {
-			snap.initStorageManager()
-			snap.FlowRevenue.STPEntrustExitLock = NewLockData(LOCKSTPEEXITDATA)
-			snap.FlowRevenue.STPEntrustLock = NewLockData(LOCKSTPEDATA)
-			snap.FlowRevenue.SpLock = NewLockData(LOCKSPLOCKDATA)
-			snap.FlowRevenue.SpEntrustLock = NewLockData(LOCKSPETTTDATA)
-			snap.FlowRevenue.SpExitLock = NewLockData(LOCKSPEXITDATA)
-			snap.FlowRevenue.SpEntrustExitLock = NewLockData(LOCKSPETTEXITDATA)
-			snap.SpData = NewSPSnap()
-			snap.initSpData(header.Number.Uint64())
+			snap.activateStorageManager(header.Number.Uint64())
 		}
 
 		if isGEInitStorageManagerNumber(header.Number.Uint64()){
@@ -1804,7 +1811,7 @@ func (s *Snapshot) updateSnapshotByDeclares(declares []Declare, headerNumber *bi
 	for _, declare := range declares {
 		if proposal, ok := s.Proposals[declare.ProposalHash]; ok {
 			// check the proposal enable status and valid block number
-			if proposal.ReceivedNumber.Uint64()+proposal.ValidationLoopCnt*s.config.MaxSignerCount < headerNumber.Uint64() || !s.isCandidate(declare.Declarer) {
+			if s.proposalDeadline(proposal) < headerNumber.Uint64() || !s.isCandidate(declare.Declarer) {
 				continue
 			}
 			// check if this signer already declare on this proposal
@@ -1836,7 +1843,7 @@ func (s *Snapshot) calculateProposalResult(headerNumber *big.Int) {
 
 	for hashKey, proposal := range s.Proposals {
 		// the result will be calculate at receiverdNumber + vlcnt + 1
-		if proposal.ReceivedNumber.Uint64()+proposal.ValidationLoopCnt*s.config.MaxSignerCount+1 == headerNumber.Uint64() {
+		if s.proposalDeadline(proposal)+1 == headerNumber.Uint64() {
 			//return deposit for proposal
 			if _, ok := s.ProposalRefund[headerNumber.Uint64()]; !ok {
 				s.ProposalRefund[headerNumber.Uint64()] = make(map[common.Address]*big.Int)
@@ -1848,21 +1855,9 @@ func (s *Snapshot) calculateProposalResult(headerNumber *big.Int) {
 			}
 
 			// calculate the current stake of this proposal
-			judegmentStake := big.NewInt(0)
-			for _, tally := range s.Tally {
-				judegmentStake.Add(judegmentStake, tally)
-			}
-			judegmentStake.Mul(judegmentStake, big.NewInt(2))
-			judegmentStake.Div(judegmentStake, big.NewInt(3))
+			judegmentStake := s.proposalJudgementStake()
 			// calculate declare stake
-			yesDeclareStake := big.NewInt(0)
-			for _, declare := range proposal.Declares {
-				if declare.Decision {
-					if _, ok := s.Tally[declare.Declarer]; ok {
-						yesDeclareStake.Add(yesDeclareStake, s.Tally[declare.Declarer])
-					}
-				}
-			}
+			yesDeclareStake := s.proposalDeclareStake(proposal, true)
 			if yesDeclareStake.Cmp(judegmentStake) > 0 {
 				// process add candidate
 				switch proposal.ProposalType {
@@ -1936,6 +1931,37 @@ func (s *Snapshot) calculateProposalResult(headerNumber *big.Int) {
 
 }
 
+// proposalDeadline returns the last block declares on a proposal are accepted
+// in. The result of the proposal is calculated in the next block.
+func (s *Snapshot) proposalDeadline(proposal *Proposal) uint64 {
+	return proposal.ReceivedNumber.Uint64() + proposal.ValidationLoopCnt*s.config.MaxSignerCount
+}
+
+// proposalJudgementStake returns the stake the yes declares on a proposal need
+// to exceed for it to pass, two thirds of the stake of all candidates.
+func (s *Snapshot) proposalJudgementStake() *big.Int {
+	judegmentStake := big.NewInt(0)
+	for _, tally := range s.Tally {
+		judegmentStake.Add(judegmentStake, tally)
+	}
+	judegmentStake.Mul(judegmentStake, big.NewInt(2))
+	return judegmentStake.Div(judegmentStake, big.NewInt(3))
+}
+
+// proposalDeclareStake returns the stake of the declarers which took the given
+// decision on a proposal.
+func (s *Snapshot) proposalDeclareStake(proposal *Proposal, decision bool) *big.Int {
+	stake := big.NewInt(0)
+	for _, declare := range proposal.Declares {
+		if declare.Decision == decision {
+			if _, ok := s.Tally[declare.Declarer]; ok {
+				stake.Add(stake, s.Tally[declare.Declarer])
+			}
+		}
+	}
+	return stake
+}
+
 func (s *Snapshot) updateSnapshotByProposals(proposals []Proposal, headerNumber *big.Int) {
 	for _, proposal := range proposals {
 		proposal.ReceivedNumber = new(big.Int).Set(headerNumber)
@@ -2607,18 +2633,18 @@ func (s *Snapshot) calPayProfit(db ethdb.Database, header *types.Header) ([]cons
 	return playGrantProfit, nil
 }
 
-func (snap *Snapshot) updateGrantProfit2(grantProfitHash common.Hash, db ethdb.Database, header *types.Header) error {
+func (snap *Snapshot) updateGrantProfit2(grantProfitHash common.Hash, db ethdb.Database, header *types.Header) ([]consensus.GrantProfitRecord, error) {
 	grantProfit, err := snap.calPayProfit(db, header)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	calGrantProfitHash := snap.calGrantProfitHash(grantProfit)
 	if grantProfitHash != calGrantProfitHash {
 		log.Error("grantProfitHash is not same", "head", grantProfitHash.String(), "cal", calGrantProfitHash.String())
-		return errors.New("grantProfitHash is not same,head:" + grantProfitHash.String() + "cal:" + calGrantProfitHash.String())
+		return nil, errors.New("grantProfitHash is not same,head:" + grantProfitHash.String() + "cal:" + calGrantProfitHash.String())
 	}
 	snap.updateGrantProfit(grantProfit, db, header.Hash(), header.Number.Uint64())
-	return nil
+	return grantProfit, nil
 }
 
 func (snap *Snapshot) updateCandidatePledgeNew(candidatePledge []CandidatePledgeNewRecord, number uint64) {
@@ -0,0 +1,575 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+)
+
+// Snapshots are persisted as a schema version byte followed by the payload.
+// Snapshots written before the binary codec are plain JSON objects, which is
+// recognised by their leading '{'.
+//
+// The binary payload is RLP. Structs are lists of their exported fields in
+// declaration order, skipping fields tagged `json:"-"` so that the same data is
+// persisted as in the JSON format. Maps are lists of key/value pairs sorted by
+// encoded key, and nil pointers, maps and slices are kept apart from empty ones
+// by wrapping them in a list holding zero or one element. Fields may be appended
+// to the persisted structs without a version bump: missing trailing fields are
+// left zero on load and unknown trailing fields are skipped. Removing or
+// reordering fields requires a new schema version.
+const (
+	snapshotVersionJSON    byte = '{'
+	snapshotVersionRLP     byte = 0x01
+	snapshotVersionCurrent      = snapshotVersionRLP
+)
+
+var (
+	// errUnknownSnapshotVersion is returned if a stored snapshot has a schema
+	// version this node does not know.
+	errUnknownSnapshotVersion = errors.New("unknown snapshot version")
+
+	// errEmptySnapshot is returned if a stored snapshot blob is empty.
+	errEmptySnapshot = errors.New("empty snapshot")
+
+	bigIntType = reflect.TypeOf(big.Int{})
+)
+
+// encodeSnapshot serializes a snapshot with the current schema version.
+func encodeSnapshot(s *Snapshot) ([]byte, error) {
+	w := new(snapEncoder)
+	w.str = make([]byte, 1, 4096)
+	w.str[0] = snapshotVersionCurrent
+	if err := w.encode(reflect.ValueOf(s).Elem()); err != nil {
+		return nil, err
+	}
+	return w.bytes(), nil
+}
+
+// snapEncodeValue returns the encoding of a single value.
+func snapEncodeValue(v reflect.Value) ([]byte, error) {
+	w := new(snapEncoder)
+	if err := w.encode(v); err != nil {
+		return nil, err
+	}
+	return w.bytes(), nil
+}
+
+// decodeSnapshot deserializes a stored snapshot of any known schema version and
+// reports whether it was in the legacy JSON format.
+func decodeSnapshot(blob []byte, s *Snapshot) (bool, error) {
+	if len(blob) == 0 {
+		return false, errEmptySnapshot
+	}
+	switch blob[0] {
+	case snapshotVersionJSON:
+		return true, json.Unmarshal(blob, s)
+	case snapshotVersionRLP:
+		stream := rlp.NewStream(bytes.NewReader(blob[1:]), uint64(len(blob)-1))
+		return false, snapDecodeValue(stream, reflect.ValueOf(s).Elem())
+	default:
+		return false, fmt.Errorf("%w: %d", errUnknownSnapshotVersion, blob[0])
+	}
+}
+
+// snapFieldCache holds the persisted field indexes of the struct types seen so far.
+var snapFieldCache sync.Map
+
+// snapFields returns the indexes of the fields of a struct type which are persisted.
+func snapFields(typ reflect.Type) []int {
+	if fields, ok := snapFieldCache.Load(typ); ok {
+		return fields.([]int)
+	}
+	var fields []int
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" || strings.Split(f.Tag.Get("json"), ",")[0] == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	snapFieldCache.Store(typ, fields)
+	return fields
+}
+
+// snapListHead is the position of a list header in the string data of an
+// encoder, along with the size of the list.
+type snapListHead struct {
+	offset int // Index of the header in the string data
+	size   int // Size of the list content, nested list headers included
+}
+
+// snapEncoder writes the RLP encoding of a snapshot into a single buffer. Like
+// the encoder of the rlp package, list headers are kept aside until the size of
+// their content is known and spliced in when the buffer is assembled.
+type snapEncoder struct {
+	str     []byte         // String data, everything but the list headers
+	lheads  []snapListHead // Headers of all the lists
+	lhsize  int            // Total size of the list headers
+	sizebuf [9]byte        // Scratch space of the size and integer encoding
+
+	keys  []*snapEncoder // Scratch encoders of the map keys, one per map nesting level
+	depth int            // Number of maps being encoded
+}
+
+// list opens a list and returns its index, to be passed to listEnd once the
+// content of the list is written.
+func (w *snapEncoder) list() int {
+	w.lheads = append(w.lheads, snapListHead{offset: len(w.str), size: w.lhsize})
+	return len(w.lheads) - 1
+}
+
+// listEnd closes the list opened at index.
+func (w *snapEncoder) listEnd(index int) {
+	lh := &w.lheads[index]
+	lh.size = len(w.str) + w.lhsize - lh.offset - lh.size
+	w.lhsize += snapHeadSize(lh.size)
+}
+
+// reset empties the encoder, keeping its buffers.
+func (w *snapEncoder) reset() {
+	w.str, w.lheads, w.lhsize = w.str[:0], w.lheads[:0], 0
+}
+
+// bytes assembles the string data and the list headers.
+func (w *snapEncoder) bytes() []byte {
+	if len(w.lheads) == 0 {
+		return w.str
+	}
+	out := make([]byte, len(w.str)+w.lhsize)
+	strpos, pos := 0, 0
+	for _, lh := range w.lheads {
+		n := copy(out[pos:], w.str[strpos:lh.offset])
+		pos += n
+		strpos += n
+		pos += snapPutHead(out[pos:], 0xC0, uint64(lh.size))
+	}
+	copy(out[pos:], w.str[strpos:])
+	return out
+}
+
+// snapHeadSize returns the size of the header of a string or list of size bytes.
+func snapHeadSize(size int) int {
+	if size < 56 {
+		return 1
+	}
+	return 1 + (bits.Len64(uint64(size))+7)/8
+}
+
+// snapPutHead writes the header of a string or list of size bytes into buf, which
+// must be at least 9 bytes long, and returns its length. The tag is 0x80 for a
+// string and 0xC0 for a list.
+func snapPutHead(buf []byte, tag byte, size uint64) int {
+	if size < 56 {
+		buf[0] = tag + byte(size)
+		return 1
+	}
+	n := (bits.Len64(size) + 7) / 8
+	for i := n; i > 0; i-- {
+		buf[i] = byte(size)
+		size >>= 8
+	}
+	buf[0] = tag + 55 + byte(n)
+	return n + 1
+}
+
+// stringHeader writes the header of a string of size bytes.
+func (w *snapEncoder) stringHeader(size int) {
+	n := snapPutHead(w.sizebuf[:], 0x80, uint64(size))
+	w.str = append(w.str, w.sizebuf[:n]...)
+}
+
+// writeBytes writes a byte string.
+func (w *snapEncoder) writeBytes(b []byte) {
+	if len(b) == 1 && b[0] <= 0x7F {
+		w.str = append(w.str, b[0])
+		return
+	}
+	w.stringHeader(len(b))
+	w.str = append(w.str, b...)
+}
+
+// writeString writes a string without copying it to a byte slice first.
+func (w *snapEncoder) writeString(s string) {
+	if len(s) == 1 && s[0] <= 0x7F {
+		w.str = append(w.str, s[0])
+		return
+	}
+	w.stringHeader(len(s))
+	w.str = append(w.str, s...)
+}
+
+// writeUint writes an integer as a big endian string without leading zeroes.
+func (w *snapEncoder) writeUint(i uint64) {
+	if i == 0 {
+		w.str = append(w.str, 0x80)
+		return
+	}
+	if i < 0x80 {
+		w.str = append(w.str, byte(i))
+		return
+	}
+	n := (bits.Len64(i) + 7) / 8
+	w.sizebuf[0] = 0x80 + byte(n)
+	for j := n; j > 0; j-- {
+		w.sizebuf[j] = byte(i)
+		i >>= 8
+	}
+	w.str = append(w.str, w.sizebuf[:n+1]...)
+}
+
+// writeBigInt writes an integer in its gob encoding: the version and sign byte
+// followed by the absolute value.
+func (w *snapEncoder) writeBigInt(b *big.Int) {
+	form := byte(1 << 1)
+	if b.Sign() < 0 {
+		form |= 1
+	}
+	n := (b.BitLen() + 7) / 8
+	if n == 0 {
+		w.str = append(w.str, form)
+		return
+	}
+	w.stringHeader(1 + n)
+	w.str = append(w.str, form)
+	start := len(w.str)
+	for i := 0; i < n; i++ {
+		w.str = append(w.str, 0)
+	}
+	b.FillBytes(w.str[start:])
+}
+
+// writeByteArray writes a byte array, which may not be addressable.
+func (w *snapEncoder) writeByteArray(v reflect.Value) {
+	n := v.Len()
+	if n == 1 && v.Index(0).Uint() <= 0x7F {
+		w.str = append(w.str, byte(v.Index(0).Uint()))
+		return
+	}
+	w.stringHeader(n)
+	start := len(w.str)
+	for i := 0; i < n; i++ {
+		w.str = append(w.str, 0)
+	}
+	reflect.Copy(reflect.ValueOf(w.str[start:]), v)
+}
+
+// encode writes the encoding of a value.
+func (w *snapEncoder) encode(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			w.str = append(w.str, 0x01)
+		} else {
+			w.str = append(w.str, 0x80)
+		}
+	case reflect.String:
+		w.writeString(v.String())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		w.writeUint(v.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.writeUint(uint64(v.Int()))
+	case reflect.Ptr, reflect.Map:
+		index := w.list()
+		if !v.IsNil() {
+			var err error
+			if v.Kind() == reflect.Ptr {
+				err = w.encode(v.Elem())
+			} else {
+				err = w.encodeMap(v)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		w.listEnd(index)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.writeBytes(v.Bytes())
+			return nil
+		}
+		index := w.list()
+		if !v.IsNil() {
+			if err := w.encodeList(v); err != nil {
+				return err
+			}
+		}
+		w.listEnd(index)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.writeByteArray(v)
+			return nil
+		}
+		return w.encodeList(v)
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			if v.CanAddr() {
+				w.writeBigInt(v.Addr().Interface().(*big.Int))
+			} else {
+				b := v.Interface().(big.Int)
+				w.writeBigInt(&b)
+			}
+			return nil
+		}
+		index := w.list()
+		for _, field := range snapFields(v.Type()) {
+			if err := w.encode(v.Field(field)); err != nil {
+				return fmt.Errorf("%s.%s: %v", v.Type(), v.Type().Field(field).Name, err)
+			}
+		}
+		w.listEnd(index)
+	default:
+		return fmt.Errorf("unsupported snapshot type %s", v.Type())
+	}
+	return nil
+}
+
+// encodeList writes the elements of a slice or array as a list.
+func (w *snapEncoder) encodeList(v reflect.Value) error {
+	index := w.list()
+	for i := 0; i < v.Len(); i++ {
+		if err := w.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	w.listEnd(index)
+	return nil
+}
+
+// snapMapEntry is a map entry along with the position of its encoded key.
+type snapMapEntry struct {
+	start, end int
+	val        reflect.Value
+}
+
+// encodeMap writes a map as a list of key/value pairs sorted by encoded key, so
+// that equal snapshots are stored as equal blobs. The keys are encoded back to
+// back into a scratch encoder to be sorted, then copied over.
+func (w *snapEncoder) encodeMap(v reflect.Value) error {
+	if w.depth == len(w.keys) {
+		w.keys = append(w.keys, new(snapEncoder))
+	}
+	keys := w.keys[w.depth]
+	keys.reset()
+
+	w.depth++
+	defer func() { w.depth-- }()
+
+	entries := make([]snapMapEntry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		start := len(keys.str)
+		if err := keys.encode(iter.Key()); err != nil {
+			return err
+		}
+		if len(keys.lheads) > 0 {
+			// Compound keys are assembled apart from the others
+			enc := keys.bytes()[start:]
+			keys.str, keys.lheads, keys.lhsize = append(keys.str[:start], enc...), keys.lheads[:0], 0
+		}
+		entries = append(entries, snapMapEntry{start, len(keys.str), iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(keys.str[entries[i].start:entries[i].end], keys.str[entries[j].start:entries[j].end]) < 0
+	})
+	list := w.list()
+	for _, entry := range entries {
+		index := w.list()
+		w.str = append(w.str, keys.str[entry.start:entry.end]...)
+		if err := w.encode(entry.val); err != nil {
+			return err
+		}
+		w.listEnd(index)
+	}
+	w.listEnd(list)
+	return nil
+}
+
+// snapDecodeValue decodes the next value of the stream into v, which must be settable.
+func snapDecodeValue(s *rlp.Stream, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := s.Bool()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.String:
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := s.Uint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := s.Uint()
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Ptr, reflect.Map:
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		if _, _, err := s.Kind(); err == rlp.EOL {
+			v.Set(reflect.Zero(v.Type()))
+			return s.ListEnd()
+		}
+		if v.Kind() == reflect.Ptr {
+			elem := reflect.New(v.Type().Elem())
+			if err := snapDecodeValue(s, elem.Elem()); err != nil {
+				return err
+			}
+			v.Set(elem)
+		} else if err := snapDecodeMap(s, v); err != nil {
+			return err
+		}
+		return s.ListEnd()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := s.Bytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		if _, _, err := s.Kind(); err == rlp.EOL {
+			v.Set(reflect.Zero(v.Type()))
+			return s.ListEnd()
+		}
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for i := 0; ; i++ {
+			if _, _, err := s.Kind(); err == rlp.EOL {
+				break
+			}
+			list = reflect.Append(list, reflect.Zero(v.Type().Elem()))
+			if err := snapDecodeValue(s, list.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(list)
+		if err := s.ListEnd(); err != nil {
+			return err
+		}
+		return s.ListEnd()
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := s.Bytes()
+			if err != nil {
+				return err
+			}
+			if len(b) != v.Len() {
+				return fmt.Errorf("%s: have %d bytes, want %d", v.Type(), len(b), v.Len())
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := snapDecodeValue(s, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return s.ListEnd()
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			b, err := s.Bytes()
+			if err != nil {
+				return err
+			}
+			return v.Addr().Interface().(*big.Int).GobDecode(b)
+		}
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		for _, index := range snapFields(v.Type()) {
+			if _, _, err := s.Kind(); err == rlp.EOL {
+				break
+			}
+			if err := snapDecodeValue(s, v.Field(index)); err != nil {
+				return fmt.Errorf("%s.%s: %v", v.Type(), v.Type().Field(index).Name, err)
+			}
+		}
+		// Skip fields appended by a newer release
+		for {
+			if _, _, err := s.Kind(); err == rlp.EOL {
+				break
+			}
+			if _, err := s.Raw(); err != nil {
+				return err
+			}
+		}
+		return s.ListEnd()
+	default:
+		return fmt.Errorf("unsupported snapshot type %s", v.Type())
+	}
+	return nil
+}
+
+func snapDecodeMap(s *rlp.Stream, v reflect.Value) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	m := reflect.MakeMap(v.Type())
+	for {
+		if _, _, err := s.Kind(); err == rlp.EOL {
+			break
+		}
+		if _, err := s.List(); err != nil {
+			return err
+		}
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := snapDecodeValue(s, key); err != nil {
+			return err
+		}
+		val := reflect.New(v.Type().Elem()).Elem()
+		if err := snapDecodeValue(s, val); err != nil {
+			return err
+		}
+		if err := s.ListEnd(); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, val)
+	}
+	v.Set(m)
+	return s.ListEnd()
+}
@@ -0,0 +1,209 @@
+package alien
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+)
+
+func newCodecTestSnapshot() *Snapshot {
+	config := &params.AlienConfig{Period: 3, MaxSignerCount: 3, MinVoterBalance: big.NewInt(100)}
+	voter := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	votes := []*Vote{{Voter: voter, Candidate: voter, Stake: big.NewInt(1000)}}
+	snap := newSnapshot(config, nil, common.HexToHash("0x01"), votes, defaultLoopCntRecalculateSigners)
+	snap.Number = 1024
+	snap.Tally[common.HexToAddress("0x02")] = big.NewInt(-5)
+	snap.Confirmations[12] = []*common.Address{&voter}
+	snap.ProposalRefund[7] = map[common.Address]*big.Int{voter: big.NewInt(3)}
+	snap.SCFlowPledge[voter] = true
+	snap.StorageData.StoragePledge[voter] = &SPledge{
+		Address: voter,
+		Number:  big.NewInt(99),
+		Lease: map[common.Hash]*Lease{
+			common.HexToHash("0x03"): {
+				Address:  voter,
+				Capacity: big.NewInt(1 << 40),
+				Status:   -1,
+				LeaseList: map[common.Hash]*LeaseDetail{
+					common.HexToHash("0x04"): {RequestTime: big.NewInt(5), Revert: 1},
+				},
+			},
+		},
+	}
+	return snap
+}
+
+func TestSnapshotCodecRoundTrip(t *testing.T) {
+	snap := newCodecTestSnapshot()
+	blob, err := encodeSnapshot(snap)
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	if blob[0] != snapshotVersionCurrent {
+		t.Fatalf("snapshot version mismatch: have %d, want %d", blob[0], snapshotVersionCurrent)
+	}
+	dec := new(Snapshot)
+	legacy, err := decodeSnapshot(blob, dec)
+	if err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if legacy {
+		t.Errorf("binary snapshot reported as legacy")
+	}
+	want, _ := json.Marshal(snap)
+	have, _ := json.Marshal(dec)
+	if !bytes.Equal(have, want) {
+		t.Errorf("snapshot mismatch after round trip\n have %s\n want %s", have, want)
+	}
+	if dec.StorageData.StorageEntrust != nil || dec.SpData.PoolPledge == nil {
+		t.Errorf("nil and empty values not preserved")
+	}
+	again, _ := encodeSnapshot(dec)
+	if !bytes.Equal(again, blob) {
+		t.Errorf("snapshot encoding not deterministic")
+	}
+}
+
+func TestSnapshotCodecLegacyJSON(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	snap := newCodecTestSnapshot()
+	blob, _ := json.Marshal(snap)
+	key := append([]byte("alien-"), snap.Hash[:]...)
+	if err := db.Put(key, blob); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadSnapshot(snap.config, nil, db, snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to load legacy snapshot: %v", err)
+	}
+	if loaded.Number != snap.Number || loaded.Tally[common.HexToAddress("0x02")].Int64() != -5 {
+		t.Errorf("legacy snapshot content mismatch")
+	}
+	migrated, _ := db.Get(key)
+	if migrated[0] != snapshotVersionCurrent {
+		t.Fatalf("legacy snapshot not migrated, version %d", migrated[0])
+	}
+	reloaded, err := loadSnapshot(snap.config, nil, db, snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to load migrated snapshot: %v", err)
+	}
+	want, _ := json.Marshal(loaded)
+	have, _ := json.Marshal(reloaded)
+	if !bytes.Equal(have, want) {
+		t.Errorf("migrated snapshot mismatch\n have %s\n want %s", have, want)
+	}
+	if _, err := decodeSnapshot([]byte{0x7f}, new(Snapshot)); !errors.Is(err, errUnknownSnapshotVersion) {
+		t.Errorf("unknown version error mismatch: %v", err)
+	}
+}
+
+func TestSnapshotCodecAppendedFields(t *testing.T) {
+	type recordV1 struct {
+		Number uint64
+		Amount *big.Int
+	}
+	type recordV2 struct {
+		Number uint64
+		Amount *big.Int
+		Owners map[common.Address]bool
+	}
+	v2 := recordV2{Number: 5, Amount: big.NewInt(7), Owners: map[common.Address]bool{{1}: true}}
+	enc, _ := snapEncodeValue(reflect.ValueOf(v2))
+
+	var v1 recordV1
+	if err := snapDecodeValue(rlp.NewStream(bytes.NewReader(enc), 0), reflect.ValueOf(&v1).Elem()); err != nil {
+		t.Fatalf("failed to decode newer record: %v", err)
+	}
+	if v1.Number != 5 || v1.Amount.Int64() != 7 {
+		t.Errorf("newer record mismatch: %+v", v1)
+	}
+	enc, _ = snapEncodeValue(reflect.ValueOf(v1))
+
+	var dec recordV2
+	if err := snapDecodeValue(rlp.NewStream(bytes.NewReader(enc), 0), reflect.ValueOf(&dec).Elem()); err != nil {
+		t.Fatalf("failed to decode older record: %v", err)
+	}
+	if dec.Number != 5 || dec.Amount.Int64() != 7 || dec.Owners != nil {
+		t.Errorf("older record mismatch: %+v", dec)
+	}
+}
+
+// newLargeCodecTestSnapshot returns the codec test snapshot grown to the given
+// number of voters, and a storage pledge with ten leases for every tenth of them.
+func newLargeCodecTestSnapshot(voters int) *Snapshot {
+	snap := newCodecTestSnapshot()
+	for i := 0; i < voters; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i + 1)))
+		stake := new(big.Int).Mul(big.NewInt(int64(i+1)), big.NewInt(1e18))
+		snap.Votes[addr] = &Vote{Voter: addr, Candidate: addr, Stake: stake}
+		snap.Tally[addr] = stake
+		snap.Voters[addr] = big.NewInt(int64(i))
+		snap.Candidates[addr] = candidateStateNormal
+		if i%10 != 0 {
+			continue
+		}
+		pledge := &SPledge{
+			Address:       addr,
+			Number:        big.NewInt(int64(i)),
+			TotalCapacity: big.NewInt(1 << 40),
+			Price:         big.NewInt(1000),
+			Lease:         make(map[common.Hash]*Lease),
+		}
+		for j := 0; j < 10; j++ {
+			hash := common.BigToHash(big.NewInt(int64(i*10 + j)))
+			pledge.Lease[hash] = &Lease{
+				Address:   addr,
+				Capacity:  big.NewInt(1 << 30),
+				UnitPrice: big.NewInt(1000),
+				Duration:  big.NewInt(30),
+				LeaseList: map[common.Hash]*LeaseDetail{hash: {RequestTime: big.NewInt(int64(j)), Revert: 1}},
+			}
+		}
+		snap.StorageData.StoragePledge[addr] = pledge
+	}
+	return snap
+}
+
+// BenchmarkSnapshotCodec compares the binary snapshot codec with the legacy
+// JSON encoding on a large snapshot.
+func BenchmarkSnapshotCodec(b *testing.B) {
+	snap := newLargeCodecTestSnapshot(10000)
+	codecs := []struct {
+		name   string
+		encode func(*Snapshot) ([]byte, error)
+	}{
+		{"rlp", encodeSnapshot},
+		{"json", func(s *Snapshot) ([]byte, error) { return json.Marshal(s) }},
+	}
+	for _, codec := range codecs {
+		blob, err := codec.encode(snap)
+		if err != nil {
+			b.Fatalf("failed to encode snapshot: %v", err)
+		}
+		b.Run(codec.name+"/encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(len(blob)), "bytes/snapshot")
+			for i := 0; i < b.N; i++ {
+				if _, err := codec.encode(snap); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(codec.name+"/decode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := decodeSnapshot(blob, new(Snapshot)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+)
+
+// Checkpoint snapshots are stored in full every snapshotBaseInterval checkpoints.
+// The checkpoints in between are stored as deltas holding only the entries which
+// changed since the snapshot of the previous checkpoint.
+//
+// For a delta the snapshot is flattened into entries keyed by their path from
+// the Snapshot root: the persisted fields of structs are walked into, every map
+// contributes one entry per key holding the whole value, and all other values
+// are single entries. Pointers to structs and maps also get an entry of their
+// own recording whether they are nil.
+const snapshotVersionDelta byte = 0x02
+
+var (
+	snapshotPrefix      = []byte("alien-")       // snapshotPrefix + hash -> snapshot
+	snapshotIndexPrefix = []byte("alien-index-") // snapshotIndexPrefix + number (uint64 big endian) -> hashes of the snapshots stored at number
+
+	// errSnapshotPath is returned if a delta entry does not match the snapshot layout.
+	errSnapshotPath = errors.New("invalid snapshot delta path")
+)
+
+// snapshotDelta is the persisted difference between the snapshot of a checkpoint
+// and the snapshot of the previous checkpoint.
+type snapshotDelta struct {
+	Parent  common.Hash
+	Changed []snapshotDeltaEntry
+	Deleted [][]byte
+}
+
+type snapshotDeltaEntry struct {
+	Path  []byte
+	Value []byte
+}
+
+// snapshotLayer is the flattened form of a stored snapshot, kept in memory so
+// that the delta of the next checkpoint does not need to flatten it again.
+type snapshotLayer struct {
+	hash    common.Hash
+	entries map[string][]byte
+}
+
+func snapshotKey(hash common.Hash) []byte {
+	return append(append([]byte{}, snapshotPrefix...), hash[:]...)
+}
+
+func snapshotIndexKey(number uint64) []byte {
+	key := make([]byte, len(snapshotIndexPrefix)+8)
+	copy(key, snapshotIndexPrefix)
+	binary.BigEndian.PutUint64(key[len(snapshotIndexPrefix):], number)
+	return key
+}
+
+// isSnapshotBase reports whether the checkpoint snapshot at number is stored in full.
+func isSnapshotBase(number uint64) bool {
+	return (number/checkpointInterval)%snapshotBaseInterval == 0
+}
+
+// flattenSnapshot splits a snapshot into the entries a delta is computed from.
+func flattenSnapshot(s *Snapshot) (*snapshotLayer, error) {
+	layer := &snapshotLayer{hash: s.Hash, entries: make(map[string][]byte)}
+	if err := snapFlatten(reflect.ValueOf(s).Elem(), nil, layer.entries); err != nil {
+		return nil, err
+	}
+	return layer, nil
+}
+
+func isSnapStructPtr(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && typ.Elem() != bigIntType
+}
+
+func snapPutEntry(path []rlp.RawValue, val interface{}, entries map[string][]byte) error {
+	key, err := rlp.EncodeToBytes(path)
+	if err != nil {
+		return err
+	}
+	enc, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	entries[string(key)] = enc
+	return nil
+}
+
+func snapFlatten(v reflect.Value, path []rlp.RawValue, entries map[string][]byte) error {
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != bigIntType:
+		for _, index := range snapFields(v.Type()) {
+			comp, _ := rlp.EncodeToBytes(uint64(index))
+			if err := snapFlatten(v.Field(index), append(path, comp), entries); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case isSnapStructPtr(v.Type()):
+		if err := snapPutEntry(path, !v.IsNil(), entries); err != nil || v.IsNil() {
+			return err
+		}
+		return snapFlatten(v.Elem(), path, entries)
+
+	case v.Kind() == reflect.Map:
+		if err := snapPutEntry(path, !v.IsNil(), entries); err != nil || v.IsNil() {
+			return err
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			comp, err := snapEncodeValue(iter.Key())
+			if err != nil {
+				return err
+			}
+			val, err := snapEncodeValue(iter.Value())
+			if err != nil {
+				return err
+			}
+			if err := snapPutEntry(append(path, comp), rlp.RawValue(val), entries); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		val, err := snapEncodeValue(v)
+		if err != nil {
+			return err
+		}
+		return snapPutEntry(path, rlp.RawValue(val), entries)
+	}
+}
+
+// diffSnapshot returns the delta turning the parent layer into the child layer.
+func diffSnapshot(parent, child *snapshotLayer) *snapshotDelta {
+	delta := &snapshotDelta{Parent: parent.hash}
+	for path, val := range child.entries {
+		if prev, ok := parent.entries[path]; !ok || !bytes.Equal(prev, val) {
+			delta.Changed = append(delta.Changed, snapshotDeltaEntry{Path: []byte(path), Value: val})
+		}
+	}
+	for path := range parent.entries {
+		if _, ok := child.entries[path]; !ok {
+			delta.Deleted = append(delta.Deleted, []byte(path))
+		}
+	}
+	sort.Slice(delta.Changed, func(i, j int) bool { return bytes.Compare(delta.Changed[i].Path, delta.Changed[j].Path) < 0 })
+	sort.Slice(delta.Deleted, func(i, j int) bool { return bytes.Compare(delta.Deleted[i], delta.Deleted[j]) < 0 })
+	return delta
+}
+
+// apply replays the delta on top of the snapshot of the previous checkpoint.
+func (d *snapshotDelta) apply(s *Snapshot) error {
+	type op struct {
+		comps []rlp.RawValue
+		value []byte
+	}
+	ops := make([]op, 0, len(d.Changed))
+	for _, entry := range d.Changed {
+		var comps []rlp.RawValue
+		if err := rlp.DecodeBytes(entry.Path, &comps); err != nil {
+			return err
+		}
+		ops = append(ops, op{comps, entry.Value})
+	}
+	// Apply shorter paths first, so pointers and maps exist before their entries are set
+	sort.SliceStable(ops, func(i, j int) bool { return len(ops[i].comps) < len(ops[j].comps) })
+	root := reflect.ValueOf(s).Elem()
+	for _, o := range ops {
+		if err := snapApply(root, o.comps, o.value, false); err != nil {
+			return err
+		}
+	}
+	for _, path := range d.Deleted {
+		var comps []rlp.RawValue
+		if err := rlp.DecodeBytes(path, &comps); err != nil {
+			return err
+		}
+		if err := snapApply(root, comps, nil, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func snapApply(v reflect.Value, comps []rlp.RawValue, value []byte, del bool) error {
+	if len(comps) == 0 {
+		if del {
+			// Entries of nil pointers and maps are removed along with them
+			return nil
+		}
+		if isSnapStructPtr(v.Type()) || v.Kind() == reflect.Map {
+			var present bool
+			if err := rlp.DecodeBytes(value, &present); err != nil {
+				return err
+			}
+			switch {
+			case !present:
+				v.Set(reflect.Zero(v.Type()))
+			case v.IsNil() && v.Kind() == reflect.Map:
+				v.Set(reflect.MakeMap(v.Type()))
+			case v.IsNil():
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			return nil
+		}
+		return snapDecodeValue(rlp.NewStream(bytes.NewReader(value), uint64(len(value))), v)
+	}
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != bigIntType:
+		var index uint64
+		if err := rlp.DecodeBytes(comps[0], &index); err != nil {
+			return err
+		}
+		if index >= uint64(v.NumField()) {
+			// Field added by a newer release
+			return nil
+		}
+		return snapApply(v.Field(int(index)), comps[1:], value, del)
+
+	case isSnapStructPtr(v.Type()):
+		if v.IsNil() {
+			if del {
+				return nil
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return snapApply(v.Elem(), comps, value, del)
+
+	case v.Kind() == reflect.Map && len(comps) == 1:
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := snapDecodeValue(rlp.NewStream(bytes.NewReader(comps[0]), uint64(len(comps[0]))), key); err != nil {
+			return err
+		}
+		if del {
+			if !v.IsNil() {
+				v.SetMapIndex(key, reflect.Value{})
+			}
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := snapDecodeValue(rlp.NewStream(bytes.NewReader(value), uint64(len(value))), elem); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errSnapshotPath, v.Type())
+}
+
+// readSnapshot reads the stored snapshot with the given hash, replaying deltas
+// on top of the full snapshot they are based on. Legacy JSON snapshots are
+// rewritten in the current format if migrate is set.
+func readSnapshot(db ethdb.Database, hash common.Hash, migrate bool) (*Snapshot, error) {
+	var deltas []*snapshotDelta
+	for next := hash; ; {
+		blob, err := db.Get(snapshotKey(next))
+		if err != nil {
+			return nil, err
+		}
+		if len(blob) > 0 && blob[0] == snapshotVersionDelta {
+			delta := new(snapshotDelta)
+			if err := rlp.DecodeBytes(blob[1:], delta); err != nil {
+				return nil, err
+			}
+			if len(deltas) > int(snapshotBaseInterval) {
+				return nil, fmt.Errorf("snapshot delta chain too long at %x", next)
+			}
+			deltas = append(deltas, delta)
+			next = delta.Parent
+			continue
+		}
+		snap := new(Snapshot)
+		legacy, err := decodeSnapshot(blob, snap)
+		if err != nil {
+			return nil, err
+		}
+		if legacy && migrate {
+			// Migrate the JSON snapshot so it is loaded by the binary codec next time
+			if blob, err := encodeSnapshot(snap); err != nil {
+				log.Warn("Failed to encode legacy snapshot", "number", snap.Number, "hash", next, "err", err)
+			} else if err := db.Put(snapshotKey(next), blob); err != nil {
+				log.Warn("Failed to migrate legacy snapshot", "number", snap.Number, "hash", next, "err", err)
+			}
+		}
+		for i := len(deltas) - 1; i >= 0; i-- {
+			if err := deltas[i].apply(snap); err != nil {
+				return nil, err
+			}
+		}
+		return snap, nil
+	}
+}
+
+// writeSnapshot stores a snapshot, as a delta if parent is the layer of the
+// previous checkpoint and the snapshot is not due to be stored in full. The
+// returned layer is the parent for the delta of the next checkpoint.
+func writeSnapshot(db ethdb.Database, s *Snapshot, parent *snapshotLayer) (*snapshotLayer, error) {
+	layer, err := flattenSnapshot(s)
+	if err != nil {
+		return nil, err
+	}
+	var blob []byte
+	if parent == nil || isSnapshotBase(s.Number) {
+		if blob, err = encodeSnapshot(s); err != nil {
+			return nil, err
+		}
+	} else {
+		enc, err := rlp.EncodeToBytes(diffSnapshot(parent, layer))
+		if err != nil {
+			return nil, err
+		}
+		blob = append([]byte{snapshotVersionDelta}, enc...)
+	}
+	if err := db.Put(snapshotKey(s.Hash), blob); err != nil {
+		return nil, err
+	}
+	return layer, indexSnapshot(db, s.Number, s.Hash)
+}
+
+// indexSnapshot records that a snapshot with the given hash is stored at number.
+func indexSnapshot(db ethdb.Database, number uint64, hash common.Hash) error {
+	var hashes []common.Hash
+	key := snapshotIndexKey(number)
+	if blob, err := db.Get(key); err == nil {
+		if err := rlp.DecodeBytes(blob, &hashes); err != nil {
+			return err
+		}
+	}
+	for _, h := range hashes {
+		if h == hash {
+			return nil
+		}
+	}
+	blob, err := rlp.EncodeToBytes(append(hashes, hash))
+	if err != nil {
+		return err
+	}
+	return db.Put(key, blob)
+}
+
+// pruneSnapshots drops the snapshots of the checkpoints which are not stored in
+// full and lie more than snapshotRetainBases full snapshot intervals before
+// number. The full snapshots are kept, older states are rebuilt from the nearest
+// one of them.
+func pruneSnapshots(db ethdb.Database, number uint64) error {
+	span := uint64(checkpointInterval * snapshotBaseInterval * snapshotRetainBases)
+	if number < span {
+		return nil
+	}
+	cutoff := number - span
+	batch := db.NewBatch()
+	it := db.NewIterator(snapshotIndexPrefix, nil)
+	defer it.Release()
+
+	pruned := 0
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(snapshotIndexPrefix)+8 {
+			continue
+		}
+		n := binary.BigEndian.Uint64(key[len(snapshotIndexPrefix):])
+		if n >= cutoff {
+			break
+		}
+		if isSnapshotBase(n) {
+			continue
+		}
+		var hashes []common.Hash
+		if err := rlp.DecodeBytes(it.Value(), &hashes); err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			batch.Delete(snapshotKey(hash))
+			pruned++
+		}
+		batch.Delete(common.CopyBytes(key))
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if pruned > 0 {
+		log.Debug("Pruned checkpoint snapshots", "count", pruned, "before", cutoff)
+	}
+	return batch.Write()
+}
@@ -0,0 +1,96 @@
+package alien
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+)
+
+func TestSnapshotDelta(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	parent := newCodecTestSnapshot()
+	parent.Number = checkpointInterval
+	layer, err := writeSnapshot(db, parent, nil)
+	if err != nil {
+		t.Fatalf("failed to write base snapshot: %v", err)
+	}
+	full, _ := db.Get(snapshotKey(parent.Hash))
+
+	child, err := readSnapshot(db, parent.Hash, true)
+	if err != nil {
+		t.Fatalf("failed to read base snapshot: %v", err)
+	}
+	voter := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	child.Number += checkpointInterval
+	child.Hash = common.HexToHash("0x02")
+	child.Tally[voter] = big.NewInt(2000)
+	delete(child.Tally, common.HexToAddress("0x02"))
+	child.LocalNotice = nil
+	child.StorageData.StorageEntrust = make(map[common.Address]*SEntrust)
+	child.StorageData.StoragePledge[voter].Lease[common.HexToHash("0x03")].LeaseList[common.HexToHash("0x04")].Revert = 2
+	child.HistoryHash = append(child.HistoryHash, child.Hash)
+
+	if _, err := writeSnapshot(db, child, layer); err != nil {
+		t.Fatalf("failed to write delta snapshot: %v", err)
+	}
+	blob, _ := db.Get(snapshotKey(child.Hash))
+	if blob[0] != snapshotVersionDelta {
+		t.Fatalf("snapshot not stored as delta, version %d", blob[0])
+	}
+	if len(blob) >= len(full) {
+		t.Errorf("delta not smaller than full snapshot: %d >= %d", len(blob), len(full))
+	}
+	loaded, err := readSnapshot(db, child.Hash, true)
+	if err != nil {
+		t.Fatalf("failed to read delta snapshot: %v", err)
+	}
+	want, _ := json.Marshal(child)
+	have, _ := json.Marshal(loaded)
+	if !bytes.Equal(have, want) {
+		t.Errorf("snapshot mismatch after delta\n have %s\n want %s", have, want)
+	}
+
+	// Snapshots due to be stored in full ignore the parent layer
+	child.Number = checkpointInterval * snapshotBaseInterval
+	if _, err := writeSnapshot(db, child, layer); err != nil {
+		t.Fatalf("failed to write base snapshot: %v", err)
+	}
+	if blob, _ := db.Get(snapshotKey(child.Hash)); blob[0] != snapshotVersionCurrent {
+		t.Errorf("snapshot not stored in full, version %d", blob[0])
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	span := uint64(checkpointInterval * snapshotBaseInterval * snapshotRetainBases)
+	numbers := []uint64{
+		checkpointInterval,                                 // delta before the window
+		checkpointInterval * snapshotBaseInterval,          // base before the window
+		checkpointInterval * (snapshotBaseInterval + 1),    // delta before the window
+		span + checkpointInterval*snapshotBaseInterval,     // base inside the window
+		span + checkpointInterval*(snapshotBaseInterval+1), // delta inside the window
+	}
+	for i, number := range numbers {
+		hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		if err := db.Put(snapshotKey(hash), []byte{snapshotVersionCurrent}); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexSnapshot(db, number, hash); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pruneSnapshots(db, span+checkpointInterval*snapshotBaseInterval*2); err != nil {
+		t.Fatalf("failed to prune snapshots: %v", err)
+	}
+	kept := []bool{false, true, false, true, true}
+	for i, want := range kept {
+		have, _ := db.Has(snapshotKey(common.BigToHash(big.NewInt(int64(i + 1)))))
+		if have != want {
+			t.Errorf("snapshot %d at %d: kept %v, want %v", i, numbers[i], have, want)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb/memorydb"
+)
+
+// SnapshotFieldDiff is a snapshot field whose replayed value differs from the
+// stored one. Fields missing on one side have an empty value there.
+type SnapshotFieldDiff struct {
+	Path   string `json:"path"`
+	Replay string `json:"replay"`
+	Stored string `json:"stored"`
+}
+
+// SnapshotReplay is the outcome of replaying headers on top of a trusted
+// checkpoint snapshot.
+type SnapshotReplay struct {
+	From    uint64              `json:"from"`    // Number of the trusted checkpoint
+	Number  uint64              `json:"number"`  // Last checkpoint compared, the diverging one if Diff is set
+	Hash    common.Hash         `json:"hash"`    // Hash of the last checkpoint compared
+	Checked int                 `json:"checked"` // Number of stored checkpoint snapshots compared
+	Diff    []SnapshotFieldDiff `json:"diff"`    // Fields differing at the first diverging checkpoint
+}
+
+// ReplaySnapshots rebuilds the checkpoint snapshots of the canonical chain by
+// applying its headers one by one on top of the trusted snapshot, the same way
+// the engine extends them while importing blocks, and compares each rebuilt
+// checkpoint up to number with the stored one. Replaying stops at the first
+// checkpoint whose snapshots differ. Checkpoints with no stored snapshot are
+// replayed but not compared.
+//
+// The state written while applying headers is kept in memory, so the database
+// may be opened read only.
+func (i *Inspector) ReplaySnapshots(trusted common.Hash, number uint64) (*SnapshotReplay, error) {
+	db := newReplayDatabase(i.db)
+	snap, err := openSnapshot(i.config, i.sigcache, db, trusted, false)
+	if err != nil {
+		return nil, err
+	}
+	if snap.Number > number {
+		return nil, fmt.Errorf("trusted snapshot %d above target %d", snap.Number, number)
+	}
+	result := &SnapshotReplay{From: snap.Number, Number: snap.Number, Hash: snap.Hash}
+	for n := snap.Number + 1; n <= number; n++ {
+		hash := rawdb.ReadCanonicalHash(i.db, n)
+		header := rawdb.ReadHeader(i.db, hash, n)
+		if header == nil {
+			return nil, fmt.Errorf("canonical header %d not found", n)
+		}
+		if snap, err = snap.apply([]*types.Header{header}, db, nil); err != nil {
+			return nil, fmt.Errorf("failed to apply header %d: %v", n, err)
+		}
+		if n%checkpointInterval != 0 {
+			continue
+		}
+		stored, err := i.SnapshotAtHash(hash)
+		if err != nil {
+			continue
+		}
+		diff, err := diffSnapshotFields(snap, stored)
+		if err != nil {
+			return nil, err
+		}
+		result.Number, result.Hash, result.Diff = n, hash, diff
+		result.Checked++
+		if len(diff) > 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+// SnapshotFields flattens the JSON form of a snapshot into its leaf values,
+// keyed by their dotted path.
+func SnapshotFields(s *Snapshot) (map[string]string, error) {
+	blob, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string)
+	flattenSnapshotJSON("", tree, fields)
+	return fields, nil
+}
+
+func flattenSnapshotJSON(path string, tree interface{}, fields map[string]string) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch node := tree.(type) {
+	case map[string]interface{}:
+		for key, elem := range node {
+			flattenSnapshotJSON(join(key), elem, fields)
+		}
+	case []interface{}:
+		for i, elem := range node {
+			flattenSnapshotJSON(join(strconv.Itoa(i)), elem, fields)
+		}
+	case nil:
+		fields[path] = "null"
+	default:
+		fields[path] = fmt.Sprint(node)
+	}
+}
+
+// diffSnapshotFields returns the fields of the replayed snapshot which differ
+// from the stored one, sorted by path.
+func diffSnapshotFields(replay, stored *Snapshot) ([]SnapshotFieldDiff, error) {
+	have, err := SnapshotFields(replay)
+	if err != nil {
+		return nil, err
+	}
+	want, err := SnapshotFields(stored)
+	if err != nil {
+		return nil, err
+	}
+	var diff []SnapshotFieldDiff
+	for path, val := range have {
+		if val != want[path] {
+			diff = append(diff, SnapshotFieldDiff{Path: path, Replay: val, Stored: want[path]})
+		}
+	}
+	for path, val := range want {
+		if _, ok := have[path]; !ok {
+			diff = append(diff, SnapshotFieldDiff{Path: path, Stored: val})
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool { return diff[i].Path < diff[j].Path })
+	return diff, nil
+}
+
+// errReplayNotFound is returned by a replayDatabase for keys deleted during the replay.
+var errReplayNotFound = errors.New("not found")
+
+// replayDatabase is a database keeping all writes in memory on top of a backing
+// database which is only read from. Iteration only covers the backing database,
+// which is enough for the tries and lock data written while applying headers.
+type replayDatabase struct {
+	ethdb.Database
+	mem     *memorydb.Database
+	deleted map[string]struct{}
+	lock    sync.RWMutex
+}
+
+func newReplayDatabase(db ethdb.Database) *replayDatabase {
+	return &replayDatabase{Database: db, mem: memorydb.New(), deleted: make(map[string]struct{})}
+}
+
+func (db *replayDatabase) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if _, ok := db.deleted[string(key)]; ok {
+		return false, nil
+	}
+	if ok, _ := db.mem.Has(key); ok {
+		return true, nil
+	}
+	return db.Database.Has(key)
+}
+
+func (db *replayDatabase) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if _, ok := db.deleted[string(key)]; ok {
+		return nil, errReplayNotFound
+	}
+	if val, err := db.mem.Get(key); err == nil {
+		return val, nil
+	}
+	return db.Database.Get(key)
+}
+
+func (db *replayDatabase) Put(key []byte, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	delete(db.deleted, string(key))
+	return db.mem.Put(key, value)
+}
+
+func (db *replayDatabase) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.deleted[string(key)] = struct{}{}
+	return db.mem.Delete(key)
+}
+
+func (db *replayDatabase) NewBatch() ethdb.Batch {
+	return &replayBatch{db: db}
+}
+
+// Close leaves the backing database open, it is owned by the caller.
+func (db *replayDatabase) Close() error {
+	return nil
+}
+
+type replayOp struct {
+	key, value []byte
+	delete     bool
+}
+
+// replayBatch buffers writes until they are flushed into a replayDatabase.
+type replayBatch struct {
+	db   *replayDatabase
+	ops  []replayOp
+	size int
+}
+
+func (b *replayBatch) Put(key, value []byte) error {
+	b.ops = append(b.ops, replayOp{common.CopyBytes(key), common.CopyBytes(value), false})
+	b.size += len(value)
+	return nil
+}
+
+func (b *replayBatch) Delete(key []byte) error {
+	b.ops = append(b.ops, replayOp{common.CopyBytes(key), nil, true})
+	b.size += len(key)
+	return nil
+}
+
+func (b *replayBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *replayBatch) Write() error {
+	return b.Replay(b.db)
+}
+
+func (b *replayBatch) Reset() {
+	b.ops = b.ops[:0]
+	b.size = 0
+}
+
+func (b *replayBatch) Replay(w ethdb.KeyValueWriter) error {
+	for _, op := range b.ops {
+		if op.delete {
+			if err := w.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
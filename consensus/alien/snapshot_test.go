@@ -130,6 +130,8 @@ func (r *testerChainReader) GetHeaderByNumber(number uint64) *types.Header {
 
 // Tests that voting is evaluated correctly for various simple and complex scenarios.
 func TestVoting(t *testing.T) {
+	t.Cleanup(resetSchedule)
+
 	// Define the various voting scenarios to test
 	tests := []struct {
 		addrNames        []string             // accounts used in this case
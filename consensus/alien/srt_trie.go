@@ -3,6 +3,7 @@ package alien
 import (
 	"errors"
 	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
 	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
 	"github.com/UltronGlow/UltronGlow-Origin/log"
 	"github.com/UltronGlow/UltronGlow-Origin/rlp"
@@ -135,6 +136,12 @@ func (s *SrtTrie) Hash() common.Hash {
 	return s.trie.Hash()
 }
 
+// Prove writes the trie nodes proving the account of addr, or its absence, into
+// proofDb, from the root down.
+func (s *SrtTrie) Prove(addr common.Address, proofDb ethdb.KeyValueWriter) error {
+	return s.trie.Prove(crypto.Keccak256(addr.Bytes()), 0, proofDb)
+}
+
 func (s *SrtTrie) commit() (root common.Hash, err error){
 	hash, err := s.trie.Commit(nil)
 	if err != nil {
@@ -0,0 +1,110 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package srtproof verifies the Merkle proofs of SRT balances served by the
+// alien_getSRTProof RPC.
+//
+// The SRT balances of the alien engine are kept in a secure trie, keyed by the
+// Keccak256 hash of the account address and holding the RLP encoding of the
+// address and its balance. A block commits to the root of the trie in the
+// SRTDataRoot of its header extra. A proof lists the RLP encoded trie nodes on
+// the path to the key, starting at the root.
+package srtproof
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/ethdb/memorydb"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+	"github.com/UltronGlow/UltronGlow-Origin/trie"
+)
+
+var (
+	// errNonEmptyProof is returned if a proof against the empty trie holds nodes.
+	errNonEmptyProof = errors.New("proof nodes for an empty trie")
+
+	// errAccountMismatch is returned if the proven account is not the requested one.
+	errAccountMismatch = errors.New("proven account does not match address")
+
+	// errNoSRTRoot is returned if the header does not commit to an SRT trie.
+	errNoSRTRoot = errors.New("header holds no SRT root")
+)
+
+// Account is the SRT account stored in the trie.
+type Account struct {
+	Address common.Address
+	Balance *big.Int
+}
+
+// Key returns the key of the account of address in the SRT trie.
+func Key(address common.Address) []byte {
+	return crypto.Keccak256(address.Bytes())
+}
+
+// Verify checks the proof of the SRT account of address in the trie of the given
+// root, and returns the proven balance. A proof of absence proves a zero balance.
+func Verify(root common.Hash, address common.Address, proof [][]byte) (*big.Int, error) {
+	if root == types.EmptyRootHash {
+		if len(proof) > 0 {
+			return nil, errNonEmptyProof
+		}
+		return new(big.Int), nil
+	}
+	nodes := memorydb.New()
+	for _, node := range proof {
+		if err := nodes.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, err := trie.VerifyProof(root, Key(address), nodes)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return new(big.Int), nil
+	}
+	var account Account
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return nil, fmt.Errorf("invalid SRT account: %v", err)
+	}
+	if account.Address != address {
+		return nil, fmt.Errorf("%w: have %x, want %x", errAccountMismatch, account.Address, address)
+	}
+	if account.Balance == nil {
+		return new(big.Int), nil
+	}
+	return account.Balance, nil
+}
+
+// VerifyHeader checks the proof of the SRT account of address against the SRT
+// root the header commits to in its header extra, and returns the proven
+// balance.
+func VerifyHeader(header *types.Header, address common.Address, proof [][]byte) (*big.Int, error) {
+	extra, _, err := alien.DecodeHeaderExtra(header.Extra)
+	if err != nil {
+		return nil, err
+	}
+	if extra.SRTDataRoot == (common.Hash{}) {
+		return nil, errNoSRTRoot
+	}
+	return Verify(extra.SRTDataRoot, address, proof)
+}
@@ -0,0 +1,103 @@
+package srtproof
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/rlp"
+)
+
+var (
+	address1 = common.HexToAddress("0x823140710bf13990e4500136726d8b55")
+	address2 = common.HexToAddress("0x823140710bf13990e4500136726d8b56")
+	address3 = common.HexToAddress("0x823140710bf13990e4500136726d8b57")
+)
+
+// proofList collects the trie nodes of a proof in the order they are written.
+type proofList [][]byte
+
+func (n *proofList) Put(key []byte, value []byte) error {
+	*n = append(*n, common.CopyBytes(value))
+	return nil
+}
+
+func (n *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// newTestTrie commits an SRT trie holding balances of 100 and 200 to address1
+// and address2.
+func newTestTrie(t *testing.T) (*alien.SrtTrie, common.Hash) {
+	db := rawdb.NewMemoryDatabase()
+	srt, err := alien.NewSrtTrie(common.Hash{}, db)
+	if err != nil {
+		t.Fatalf("failed to open trie: %v", err)
+	}
+	srt.Set(address1, big.NewInt(100))
+	srt.Set(address2, big.NewInt(200))
+	root, err := srt.Save(db)
+	if err != nil {
+		t.Fatalf("failed to commit trie: %v", err)
+	}
+	srt, _ = alien.NewSrtTrie(root, db)
+	return srt, root
+}
+
+func prove(t *testing.T, srt *alien.SrtTrie, addr common.Address) [][]byte {
+	var proof proofList
+	if err := srt.Prove(addr, &proof); err != nil {
+		t.Fatalf("failed to prove %x: %v", addr, err)
+	}
+	return proof
+}
+
+// Tests that the proofs of the SRT trie verify the balances of present and
+// absent accounts, and that a proof fails against another root.
+func TestVerify(t *testing.T) {
+	empty, err := alien.NewSrtTrie(common.Hash{}, rawdb.NewMemoryDatabase())
+	if err != nil {
+		t.Fatalf("failed to open trie: %v", err)
+	}
+	if balance, err := Verify(empty.Root(), address1, nil); err != nil || balance.Sign() != 0 {
+		t.Fatalf("empty trie: have %v, %v", balance, err)
+	}
+	srt, root := newTestTrie(t)
+	for addr, want := range map[common.Address]int64{address1: 100, address2: 200, address3: 0} {
+		nodes := prove(t, srt, addr)
+		balance, err := Verify(root, addr, nodes)
+		if err != nil || balance.Int64() != want {
+			t.Errorf("balance of %x: have %v, %v, want %d", addr, balance, err, want)
+		}
+		if _, err := Verify(common.HexToHash("0x01"), addr, nodes); err == nil {
+			t.Errorf("proof of %x verified against a wrong root", addr)
+		}
+	}
+}
+
+// Tests that the proofs are checked against the SRT root held in the header
+// extra of the header.
+func TestVerifyHeader(t *testing.T) {
+	srt, root := newTestTrie(t)
+	header := func(root common.Hash) *types.Header {
+		enc, err := rlp.EncodeToBytes(alien.HeaderExtra{SRTDataRoot: root})
+		if err != nil {
+			t.Fatalf("failed to encode header extra: %v", err)
+		}
+		extra := append(make([]byte, 32), enc...)
+		return &types.Header{Number: big.NewInt(1), Extra: append(extra, make([]byte, 65)...)}
+	}
+	nodes := prove(t, srt, address2)
+	if balance, err := VerifyHeader(header(root), address2, nodes); err != nil || balance.Int64() != 200 {
+		t.Errorf("have %v, %v, want 200", balance, err)
+	}
+	if _, err := VerifyHeader(header(common.HexToHash("0x01")), address2, nodes); err == nil {
+		t.Errorf("proof verified against the root of another header")
+	}
+	if _, err := VerifyHeader(header(common.Hash{}), address2, nodes); err != errNoSRTRoot {
+		t.Errorf("header without SRT root: have %v, want %v", err, errNoSRTRoot)
+	}
+}
@@ -287,7 +287,7 @@ func (snap *Snapshot) initSpData(number uint64) {
 				snap.SpData.PoolPledge[spHash] = &PoolPledge{
 					Address:        ratio.manager,
 					Manager:        ratio.manager,
-					Number:         big.NewInt(initStorageManagerNumber - 1),
+					Number:         new(big.Int).SetUint64(initStorageManagerNumber - 1),
 					TotalAmount:    common.Big0,
 					TotalCapacity:  new(big.Int).Set(ratio.capacity),
 					UsedCapacity:   new(big.Int).Set(ratio.capacity),
@@ -304,7 +304,7 @@ func (snap *Snapshot) initSpData(number uint64) {
 					if _, ok := snap.StorageData.StoragePledge[snAddr]; ok {
 						if _, ok1 := snap.StorageData.StorageEntrust[snAddr]; ok1 {
 							snap.StorageData.StorageEntrust[snAddr].Sphash = spHash
-							snap.StorageData.StorageEntrust[snAddr].Spheight = big.NewInt(initStorageManagerNumber - 1)
+							snap.StorageData.StorageEntrust[snAddr].Spheight = new(big.Int).SetUint64(initStorageManagerNumber - 1)
 						}
 					}
 				}
@@ -364,6 +364,7 @@ func (s *SpData) accumulateSpDataHash() common.Hash {
 func (a *Alien) spApplyPledge(spCreateParameter []SpApplyRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blockNumber *big.Int, chain consensus.ChainHeaderReader) []SpApplyRecord {
 	if len(txDataInfo) < spCreateParamLen-1 {
 		log.Warn("spApplyPledge", "parameter error len=", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return spCreateParameter
 	}
 	spParamter := SpApplyRecord{
@@ -379,9 +380,11 @@ func (a *Alien) spApplyPledge(spCreateParameter []SpApplyRecord, txDataInfo []st
 
 	if pledgeAmount, err := decimal.NewFromString(txDataInfo[spCreatePledgeIndex]); err != nil {
 		log.Warn("spApplyPledge", "pledgeAmount error", txDataInfo[spCreatePledgeIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return spCreateParameter
 	} else if pledgeAmount.BigInt().Cmp(spMinPledgeAmount) < 0 {
 		log.Warn("spApplyPledge", "Insufficient pledgeAmount", pledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return spCreateParameter
 	} else {
 		spParamter.PledgeAmount = pledgeAmount.BigInt()
@@ -389,18 +392,22 @@ func (a *Alien) spApplyPledge(spCreateParameter []SpApplyRecord, txDataInfo []st
 	spParamter.Capacity = getCapacity(spParamter.PledgeAmount)
 	if fee, err := strconv.Atoi(txDataInfo[spCreateFeeIndex]); err != nil {
 		log.Warn("spApplyPledge", "fee format error", txDataInfo[spCreateFeeIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return spCreateParameter
 	} else if fee < 0 ||fee > 100 {
 		log.Warn("spApplyPledge", "fee < 0 or fee > 100", fee)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return spCreateParameter
 	} else {
 		spParamter.Fee = uint64(fee)
 	}
 	if entrustRate, err := strconv.Atoi(txDataInfo[spCreateEntrustRateIndex]); err != nil {
 		log.Warn("spApplyPledge", "EntrustRate format error", txDataInfo[spCreateEntrustRateIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return spCreateParameter
 	} else if entrustRate < 0 ||entrustRate > 100{
 		log.Warn("spApplyPledge", "EntrustRate< 0 or entrustRate > 100", entrustRate)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return spCreateParameter
 	} else {
 		spParamter.EntrustRate = uint64(entrustRate)
@@ -408,11 +415,13 @@ func (a *Alien) spApplyPledge(spCreateParameter []SpApplyRecord, txDataInfo []st
 	if len(txDataInfo) > spCreateRevenueIndex {
 		if err := spParamter.RevenueAddress.UnmarshalText1([]byte(txDataInfo[spCreateRevenueIndex])); err != nil {
 			log.Warn("spApplyPledge", "RevenueAddress error", txDataInfo[spCreateRevenueIndex])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return spCreateParameter
 		}
 	}
 	if state.GetBalance(txSender).Cmp(spParamter.PledgeAmount) < 0 {
 		log.Warn("spApplyPledge", "balance", state.GetBalance(txSender), "need pay", spParamter.PledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return spCreateParameter
 	}
 	state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), spParamter.PledgeAmount))
@@ -422,6 +431,7 @@ func (a *Alien) spApplyPledge(spCreateParameter []SpApplyRecord, txDataInfo []st
 	topics[1].SetBytes(spParamter.Capacity.Bytes())
 	topics[2].SetBytes(spParamter.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return spCreateParameter
 }
 func (s *Snapshot) updateSpApplyData(pledgeRecord []SpApplyRecord, db ethdb.Database, number *big.Int) {
@@ -461,6 +471,7 @@ func (a *Alien) spAdJustPledge(adjustPledge []SpAdjustPledgeRecord, txDataInfo [
 
 	if len(txDataInfo) <= adJustParamAmountIndex {
 		log.Warn("spAdJustPledge", "paramter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return adjustPledge
 	}
 	adjtPledge := SpAdjustPledgeRecord{
@@ -470,13 +481,16 @@ func (a *Alien) spAdJustPledge(adjustPledge []SpAdjustPledgeRecord, txDataInfo [
 	}
 	if err := adjtPledge.Hash.UnmarshalText1([]byte(txDataInfo[adJustParamSpHashIndex])); err != nil {
 		log.Warn("spAdJustPledge", "Hash error", txDataInfo[adJustParamSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return adjustPledge
 	}
 	if pledgeAmount, err := decimal.NewFromString(txDataInfo[adJustParamAmountIndex]); err != nil {
 		log.Warn("spAdJustPledge", "pledgeAmount error", txDataInfo[adJustParamAmountIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return adjustPledge
 	} else if pledgeAmount.Cmp(decimal.Zero) < 0 {
 		log.Warn("spAdJustPledge", "pledgeAmount  < 0 ", txDataInfo[adJustParamAmountIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return adjustPledge
 	} else {
 		adjtPledge.PledgeAmount = pledgeAmount.BigInt()
@@ -484,20 +498,24 @@ func (a *Alien) spAdJustPledge(adjustPledge []SpAdjustPledgeRecord, txDataInfo [
 	if sp, ok := snap.SpData.PoolPledge[adjtPledge.Hash]; ok {
 		if sp.Manager != txSender {
 			log.Warn("spAdJustPledge", "txSender no role ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return adjustPledge
 		}
 		if sp.Status >= spStatusExited {
 			log.Warn("spAdJustPledge", "SP Status  is exiting or exited ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return adjustPledge
 		}
 		if sp.Number.Uint64() <initStorageManagerNumber && sp.ManagerAmount.Cmp(common.Big0)== 0{
 			if adjtPledge.PledgeAmount.Cmp(spMinPledgeAmount) < 0 {
 				log.Warn("spAdJustPledge", "first manager pledge must > 625 ", adjtPledge.PledgeAmount,"txSender",txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return adjustPledge
 			}
 		}
 	} else {
 		log.Warn("spAdJustPledge", "not find sp by spHash", adjtPledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return adjustPledge
 	}
 	balance := state.GetBalance(txSender)
@@ -505,6 +523,7 @@ func (a *Alien) spAdJustPledge(adjustPledge []SpAdjustPledgeRecord, txDataInfo [
 		state.SubBalance(txSender, adjtPledge.PledgeAmount)
 	} else {
 		log.Warn("spEntrustPledge", "Insufficient Balance", balance, "PledgeAmount", adjtPledge.PledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return adjustPledge
 	}
 	adjustPledge = append(adjustPledge, adjtPledge)
@@ -513,6 +532,7 @@ func (a *Alien) spAdJustPledge(adjustPledge []SpAdjustPledgeRecord, txDataInfo [
 	topics[1].SetBytes(adjtPledge.Hash.Bytes())
 	topics[2].SetBytes(adjtPledge.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return adjustPledge
 }
 func (s *Snapshot) updateSpAdJustPledgeData(pledgeRecord []SpAdjustPledgeRecord, db ethdb.Database, number *big.Int) {
@@ -551,6 +571,7 @@ func (s *Snapshot) updateSpAdJustPledgeData(pledgeRecord []SpAdjustPledgeRecord,
 func (a *Alien) spRemoveSn(removePledge []SpRemoveSnRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpRemoveSnRecord {
 	if len(txDataInfo) <= spRemoveSnAddrIndex {
 		log.Warn("spRemoveSn", "paramter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return removePledge
 	}
 	spRemovePledge := SpRemoveSnRecord{
@@ -559,28 +580,34 @@ func (a *Alien) spRemoveSn(removePledge []SpRemoveSnRecord, txDataInfo []string,
 	}
 	if err := spRemovePledge.Hash.UnmarshalText1([]byte(txDataInfo[spRemoveSpHashIndex])); err != nil {
 		log.Warn("spRemoveSn", "Hash error", txDataInfo[spRemoveSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return removePledge
 	}
 	if err := spRemovePledge.Address.UnmarshalText1([]byte(txDataInfo[spRemoveSnAddrIndex])); err != nil {
 		log.Warn("spRemoveSn", "SN address format error", txDataInfo[spRemoveSnAddrIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return removePledge
 	}
 	if sp, ok := snap.SpData.PoolPledge[spRemovePledge.Hash]; ok {
 		if sp.Manager != txSender {
 			log.Warn("spRemoveSn", "txSender no role ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return removePledge
 		}
 
 	} else {
 		log.Warn("spAdJustPledge", "sp not exit ", spRemovePledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return removePledge
 	}
 
 	if snEntrust, ok := snap.StorageData.StorageEntrust[spRemovePledge.Address]; !ok {
 		log.Warn("spRemoveSn", "SN not exit", spRemovePledge.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return removePledge
 	} else if snEntrust.Sphash != spRemovePledge.Hash {
 		log.Warn("spRemoveSn", "address not rela sp address", spRemovePledge.Address, "sp", spRemovePledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return removePledge
 	}
 	snTotalCapacity:=big.NewInt(0)
@@ -593,6 +620,7 @@ func (a *Alien) spRemoveSn(removePledge []SpRemoveSnRecord, txDataInfo []string,
 	topics[1].SetBytes(snTotalCapacity.Bytes())
 	topics[2].SetBytes(spRemovePledge.Address.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return removePledge
 }
 func (s *Snapshot) updateSpRemoveSnData(removeRecord []SpRemoveSnRecord, db ethdb.Database, number *big.Int) {
@@ -621,6 +649,7 @@ func (s *Snapshot) updateSpRemoveSnData(removeRecord []SpRemoveSnRecord, db ethd
 func (a *Alien) spEntrustPledge(entrustPledge []SpEntrustPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpEntrustPledgeRecord {
 	if len(txDataInfo) <= spEntrustPgAmountIndex {
 		log.Warn("spEntrustPledge", "paramter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return entrustPledge
 	}
 	entrustPg := SpEntrustPledgeRecord{
@@ -633,15 +662,18 @@ func (a *Alien) spEntrustPledge(entrustPledge []SpEntrustPledgeRecord, txDataInf
 	}
 	if err := entrustPg.Hash.UnmarshalText1([]byte(txDataInfo[spEntrustPgSpHashIndex])); err != nil {
 		log.Warn("spEntrustPledge", "Hash error", txDataInfo[spEntrustPgSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustPledge
 	}
 	if sp, ok := snap.SpData.PoolPledge[entrustPg.Hash]; ok {
 		if sp.Status != spStatusActive {
 			log.Warn("spEntrustPledge", "SP Status  need active ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 	} else {
 		log.Warn("spEntrustPledge", "sp not exit ", entrustPg.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return entrustPledge
 	}
 
@@ -649,14 +681,17 @@ func (a *Alien) spEntrustPledge(entrustPledge []SpEntrustPledgeRecord, txDataInf
 	nilAddr := common.Hash{}
 	if targetPool != nilAddr && targetPool != entrustPg.Hash {
 		log.Warn("spEntrustPledge", "one address can only pledge one pool ", targetPool)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return entrustPledge
 	}
 
 	if pledgeAmount, err := decimal.NewFromString(txDataInfo[spEntrustPgAmountIndex]); err != nil {
 		log.Warn("spEntrustPledge", "pledgeAmount error", txDataInfo[spEntrustPgAmountIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustPledge
 	} else if pledgeAmount.Cmp(decimal.Zero) < 0 {
 		log.Warn("spEntrustPledge", "pledgeAmount < 0 ", pledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return entrustPledge
 	} else {
 		entrustPg.PledgeAmount = pledgeAmount.BigInt()
@@ -667,6 +702,7 @@ func (a *Alien) spEntrustPledge(entrustPledge []SpEntrustPledgeRecord, txDataInf
 		state.SubBalance(txSender, entrustPg.PledgeAmount)
 	} else {
 		log.Warn("spEntrustPledge", "Insufficient Balance", balance, "PledgeAmount", entrustPg.PledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return entrustPledge
 	}
 	entrustPledge = append(entrustPledge, entrustPg)
@@ -675,6 +711,7 @@ func (a *Alien) spEntrustPledge(entrustPledge []SpEntrustPledgeRecord, txDataInf
 	topics[1].SetBytes(entrustPg.Hash.Bytes())
 	topics[2].SetBytes(entrustPg.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return entrustPledge
 }
 func (s *Snapshot) updateSpEntrustPledgeData(entrustRecord []SpEntrustPledgeRecord, db ethdb.Database, number *big.Int) {
@@ -708,6 +745,7 @@ func (s *Snapshot) updateSpEntrustPledgeData(entrustRecord []SpEntrustPledgeReco
 func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpEntrustPledgeRecord {
 	if len(txDataInfo) <= spEntrustTransferAddressIndex {
 		log.Warn("spEntrustTransferPledge", "paramter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return entrustPledge
 	}
 	entrustTransferPledge := SpEntrustPledgeRecord{
@@ -721,19 +759,23 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 	}
 	if isInCurrentEntrustPledge(entrustPledge, entrustTransferPledge.Address) {
 		log.Warn("spEntrustTransferPledge", "Address is in entrustPledge", entrustTransferPledge.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return entrustPledge
 	}
 	if err := entrustTransferPledge.Hash.UnmarshalText1([]byte(txDataInfo[spEntrustTransferSpIndex])); err != nil {
 		log.Warn("spEntrustTransferPledge", "Hash error", txDataInfo[spEntrustTransferSpIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustPledge
 	}
 	if sp, ok := snap.SpData.PoolPledge[entrustTransferPledge.Hash]; ok {
 		if sp.Manager == txSender {
 			log.Warn("spEntrustTransferPledge", "manager address no role", txDataInfo[spEntrustTransferSpIndex])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return entrustPledge
 		}
 		if sp.Status != spStatusActive {
 			log.Warn("spEntrustTransferPledge", "SP Status  is need active ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		transAmount := big.NewInt(0)
@@ -743,6 +785,7 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 				pledgeBLock := new(big.Int).Sub(new(big.Int).SetUint64(snap.Number), detail.Height)
 				if pledgeBLock.Cmp(pledgeMinBLock) < 0 {
 					log.Warn("spEntrustTransferPledge", "Entrust hash illegality", txSender)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 					return entrustPledge
 				}
 				transAmount = new(big.Int).Add(transAmount, detail.Amount)
@@ -750,12 +793,14 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 		}
 		if transAmount.Cmp(big.NewInt(0)) <= 0 {
 			log.Warn("spEntrustTransferPledge", "TxSender does not have a transferable deposit ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		entrustTransferPledge.PledgeAmount = transAmount
 
 	} else {
 		log.Warn("spEntrustTransferPledge", "sp not exit ", entrustTransferPledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return entrustPledge
 	}
 
@@ -763,48 +808,58 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 	if TargetTypePos == entrustTransferPledge.TargetType {
 		if err := entrustTransferPledge.TargetAddress.UnmarshalText1([]byte(txDataInfo[spEntrustTransferAddressIndex])); err != nil {
 			log.Warn("spEntrustTransferPledge", "PoS target Address error", txDataInfo[spEntrustTransferAddressIndex])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return entrustPledge
 		}
 		if _, ok := snap.PosPledge[entrustTransferPledge.TargetAddress]; !ok {
 			log.Warn("spEntrustTransferPledge", "PoS node not exit ", entrustTransferPledge.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return entrustPledge
 		}
 		if _, ok := snap.PosPledge[entrustTransferPledge.Address]; ok {
 			log.Warn("spEntrustTransferPledge", "txSender is miner address", entrustTransferPledge.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		targetMiner := snap.findPosTargetMiner(txSender)
 		nilAddr := common.Address{}
 		if targetMiner != nilAddr && targetMiner != entrustTransferPledge.TargetAddress {
 			log.Warn("spEntrustTransferPledge", "one address can only pledge one miner ", targetMiner)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 	} else if TargetTypeSp == entrustTransferPledge.TargetType {
 		if err := entrustTransferPledge.TargetHash.UnmarshalText1([]byte(txDataInfo[spEntrustTransferAddressIndex])); err != nil {
 			log.Warn("spEntrustTransferPledge", "Sp target Hash error", txDataInfo[spEntrustTransferAddressIndex])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return entrustPledge
 		}
 		if _, ok := snap.SpData.PoolPledge[entrustTransferPledge.TargetHash]; !ok {
 			log.Warn("spEntrustTransferPledge", "Sp target not exit ", entrustTransferPledge.TargetHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return entrustPledge
 		}
 	} else if TargetTypeSn == entrustTransferPledge.TargetType {
 		if _, ok := snap.StorageData.StoragePledge[entrustTransferPledge.Address]; ok {
 			log.Warn("spEntrustTransferPledge", "txSender is Storage address", entrustTransferPledge.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		if err := entrustTransferPledge.TargetAddress.UnmarshalText1([]byte(txDataInfo[spEntrustTransferAddressIndex])); err != nil {
 			log.Warn("spEntrustTransferPledge", "SN target Address error", txDataInfo[spEntrustTransferAddressIndex])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return entrustPledge
 		}
 		if _, ok := snap.StorageData.StoragePledge[entrustTransferPledge.Address]; ok {
 			log.Warn("spEntrustTransferPledge", "txSender is Storage address", entrustTransferPledge.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		targetMiner := snap.findStorageTargetMiner(txSender)
 		nilAddr := common.Address{}
 		if targetMiner != nilAddr && targetMiner != entrustTransferPledge.TargetAddress {
 			log.Warn("spEntrustTransferPledge", "one address can only pledge one miner ", targetMiner)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		currBlockTranAmount := big.NewInt(0)
@@ -817,11 +872,13 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 			if snItem, ok1 := snap.StorageData.StoragePledge[entrustTransferPledge.TargetAddress]; ok1 {
 				if snItem.PledgeStatus.Cmp(big.NewInt(SPledgeInactive))!=0{
 					log.Warn("spEntrustTransferPledge", "Sn is not inactive", entrustTransferPledge.TargetAddress)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return entrustPledge
 				}
 				estimateAmountV1 := new(big.Int).Add(currBlockTranAmount, snEtPledge.PledgeAmount)
 				if estimateAmountV1.Cmp(snItem.SpaceDeposit) >= 0 {
 					log.Warn("spEntrustTransferPledge", "Sn entrusted pledge is full", txDataInfo[spEntrustTransferAddressIndex])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return entrustPledge
 				}
 				estimateAmountV2 := new(big.Int).Add(estimateAmountV1, entrustTransferPledge.PledgeAmount)
@@ -843,10 +900,12 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 			}
 		} else {
 			log.Warn("spEntrustTransferPledge", "SN node not exit", entrustTransferPledge.TargetAddress)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return entrustPledge
 		}
 	} else {
 		log.Warn("spEntrustTransferPledge", "TargetType is illegal", entrustTransferPledge.TargetType)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return entrustPledge
 	}
 
@@ -856,6 +915,7 @@ func (a *Alien) spEntrustTransferPledge(entrustPledge []SpEntrustPledgeRecord, t
 	topics[1].SetBytes(entrustTransferPledge.LockAmount.Bytes())
 	topics[2].SetBytes(entrustTransferPledge.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return entrustPledge
 }
 
@@ -948,6 +1008,7 @@ func (s *Snapshot) updateSpEntrustTransferData(entrustRecord []SpEntrustPledgeRe
 func (a *Alien) spEntrustExitPledge(entrustPledge []SpEntrustPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpEntrustPledgeRecord {
 	if len(txDataInfo) <= spEntrustExitEtHashIndex {
 		log.Warn("spEntrustExitPledge", "paramter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return entrustPledge
 	}
 	entrustExitPledge := SpEntrustPledgeRecord{
@@ -959,44 +1020,53 @@ func (a *Alien) spEntrustExitPledge(entrustPledge []SpEntrustPledgeRecord, txDat
 	}
 	if err := entrustExitPledge.Hash.UnmarshalText1([]byte(txDataInfo[spEntrustExitSpHashIndex])); err != nil {
 		log.Warn("spEntrustExitPledge", "SP Hash error", txDataInfo[spEntrustExitSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustPledge
 	}
 	if err := entrustExitPledge.PledgeHash.UnmarshalText1([]byte(txDataInfo[spEntrustExitEtHashIndex])); err != nil {
 		log.Warn("spEntrustExitPledge", "SP Hash error", txDataInfo[spEntrustExitEtHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustPledge
 	}
 
 	if isInCurrentSpEntrustExit(entrustPledge, entrustExitPledge.PledgeHash) {
 		log.Warn("storageEntrustedPledgeExit", "Hash is in currentSEExit", entrustExitPledge.PledgeHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return entrustPledge
 	}
 
 	if sp, ok := snap.SpData.PoolPledge[entrustExitPledge.Hash]; ok {
 		if sp.Manager == txSender {
 			log.Warn("spEntrustExitPledge", "SP manager no role", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return entrustPledge
 		}
 		if sp.Status >= spStatusExited {
 			log.Warn("spEntrustTransferPledge", "SP Status  is exiting or exited ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustPledge
 		}
 		if entrustItem, ok1 := sp.EtDetail[entrustExitPledge.PledgeHash]; ok1 {
 			if txSender != entrustItem.Address {
 				log.Warn("spEntrustExitPledge", "txSender no role", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return entrustPledge
 			}
 			entrustExitPledge.LockAmount = entrustItem.Amount
 			pledgeBLock := new(big.Int).Sub(big.NewInt(int64(snap.Number)), entrustItem.Height)
 			if pledgeBLock.Cmp(a.getEntrustPledgeMinBLock(spEntrustMinDay)) < 0 {
 				log.Warn("spEntrustTransferPledge", "Entrust Pledge time limit 7 days", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return entrustPledge
 			}
 		} else {
 			log.Warn("spEntrustExitPledge", "not find entrust pledge ", entrustExitPledge.PledgeHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return entrustPledge
 		}
 	} else {
 		log.Warn("spEntrustExitPledge", "SP not find ", entrustExitPledge.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return entrustPledge
 	}
 	entrustPledge = append(entrustPledge, entrustExitPledge)
@@ -1005,6 +1075,7 @@ func (a *Alien) spEntrustExitPledge(entrustPledge []SpEntrustPledgeRecord, txDat
 	topics[0].UnmarshalText([]byte("0x6d385a58ea1e7560a01c5a9d543911d47c1b86c5899c0b2df932dab4d7c21020"))
 	topics[1].SetBytes(entrustExitPledge.LockAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return entrustPledge
 }
 
@@ -1041,30 +1112,36 @@ func getCapacity(amount *big.Int) *big.Int {
 func (a *Alien) spExitPledge(exitPledge []common.Hash, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []common.Hash {
 	if len(txDataInfo) <= spExitSpHashIndex {
 		log.Warn("spExitPledge", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return exitPledge
 	}
 	exitHash := common.Hash{}
 	if err := exitHash.UnmarshalText1([]byte(txDataInfo[spExitSpHashIndex])); err != nil {
 		log.Warn("spExitPledge", "SP Hash error", txDataInfo[spExitSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return exitPledge
 	}
 	if sp, ok := snap.SpData.PoolPledge[exitHash]; ok {
 		if sp.Manager != txSender {
 			log.Warn("spExitPledge", "txSender no role ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return exitPledge
 		}
 		if sp.Status >= spStatusExited {
 			log.Warn("spEntrustTransferPledge", "SP Status  is exiting or exited ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return exitPledge
 		}
 		pledgeBLock := new(big.Int).Sub(big.NewInt(int64(snap.Number)), sp.Number)
 		if pledgeBLock.Cmp(a.getEntrustPledgeMinBLock(spPledgeMinDay)) < 0 {
 			log.Warn("spExitPledge", "Pledge time limit 90 days", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return exitPledge
 		}
 
 	} else {
 		log.Warn("spExitPledge", "not find Sp ", exitHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return exitPledge
 	}
 
@@ -1073,6 +1150,7 @@ func (a *Alien) spExitPledge(exitPledge []common.Hash, txDataInfo []string, txSe
 	topics[0].UnmarshalText([]byte("0x6d385a58ea1e7560a01c5a9d543911d47c1b86c5899c0b2df932dab4d7c21033"))
 	topics[1].SetBytes(exitHash.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return exitPledge
 }
 func (s *Snapshot) updateSpExitPledgeData(spExitPledge []common.Hash, db ethdb.Database, number *big.Int) {
@@ -1101,6 +1179,7 @@ func (s *Snapshot) updateSpExitPledgeData(spExitPledge []common.Hash, db ethdb.D
 func (a *Alien) spSetFee(feeRecord []SpFeeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpFeeRecord {
 	if len(txDataInfo) <= spFeeSetFeeIndex {
 		log.Warn("spSetFee", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return feeRecord
 	}
 	apFee := SpFeeRecord{
@@ -1109,26 +1188,32 @@ func (a *Alien) spSetFee(feeRecord []SpFeeRecord, txDataInfo []string, txSender
 	}
 	if err := apFee.Hash.UnmarshalText1([]byte(txDataInfo[spFeeSetSpHashIndex])); err != nil {
 		log.Warn("spSetFee", "SP Hash error", txDataInfo[spFeeSetSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return feeRecord
 	}
 	if sp, ok := snap.SpData.PoolPledge[apFee.Hash]; ok {
 		if sp.Status == spStatusExited {
 			log.Warn("spSetFee", "sp is exited ", apFee.Hash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return feeRecord
 		}
 		if sp.Manager != txSender {
 			log.Warn("spSetFee", "txSender no role ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return feeRecord
 		}
 	} else {
 		log.Warn("spSetFee", "SP not exit ", apFee.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return feeRecord
 	}
 	if fee, err := strconv.Atoi(txDataInfo[spFeeSetFeeIndex]); err != nil {
 		log.Warn("spSetFee", "fee format error ", txDataInfo[spFeeSetFeeIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return feeRecord
 	} else if fee < 0 ||fee > 100 {
 		log.Warn("spSetFee", "fee < 0 or fee > 100", fee)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return feeRecord
 	}else {
 		apFee.Fee = uint64(fee)
@@ -1140,6 +1225,7 @@ func (a *Alien) spSetFee(feeRecord []SpFeeRecord, txDataInfo []string, txSender
 	topics[1].SetBytes(apFee.Hash.Bytes())
 	topics[2].SetBytes([]byte(txDataInfo[spFeeSetFeeIndex]))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return feeRecord
 }
 
@@ -1159,6 +1245,7 @@ func (s *Snapshot) updateSpFeeData(spFee []SpFeeRecord, db ethdb.Database, numbe
 func (a *Alien) spSetEntrustRate(entrustRateRecord []SpEntrustRateRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SpEntrustRateRecord {
 	if len(txDataInfo) <= spEntrustRateIndex {
 		log.Warn("spSetEntrustRate", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return entrustRateRecord
 	}
 	apEtRate := SpEntrustRateRecord{
@@ -1167,26 +1254,32 @@ func (a *Alien) spSetEntrustRate(entrustRateRecord []SpEntrustRateRecord, txData
 	}
 	if err := apEtRate.Hash.UnmarshalText1([]byte(txDataInfo[spEntrustRateSpHashIndex])); err != nil {
 		log.Warn("spSetEntrustRate", "SP Hash error", txDataInfo[spEntrustRateSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustRateRecord
 	}
 	if sp, ok := snap.SpData.PoolPledge[apEtRate.Hash]; ok {
 		if sp.Status == spStatusExited {
 			log.Warn("spSetEntrustRate", "sp is exited ", apEtRate.Hash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return entrustRateRecord
 		}
 		if sp.Manager != txSender {
 			log.Warn("spSetEntrustRate", "txSender no role ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return entrustRateRecord
 		}
 	} else {
 		log.Warn("spSetEntrustRate", "SP not exit ", apEtRate.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return entrustRateRecord
 	}
 	if entrustRate, err := strconv.Atoi(txDataInfo[spEntrustRateIndex]); err != nil {
 		log.Warn("spSetEntrustRate", "EntrustRate format error ", txDataInfo[spEntrustRateIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return entrustRateRecord
 	} else if entrustRate < 0 ||entrustRate > 100{
 		log.Warn("spSetEntrustRate", "EntrustRate< 0 or entrustRate > 100", entrustRate)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return entrustRateRecord
 	} else {
 		apEtRate.EntrustRate = uint64(entrustRate)
@@ -1198,6 +1291,7 @@ func (a *Alien) spSetEntrustRate(entrustRateRecord []SpEntrustRateRecord, txData
 	topics[1].SetBytes(apEtRate.Hash.Bytes())
 	topics[2].SetBytes([]byte(txDataInfo[spEntrustRateIndex]))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return entrustRateRecord
 }
 func (s *Snapshot) updateSpEntrustRateData(spEtRate []SpEntrustRateRecord, db ethdb.Database, number *big.Int) {
@@ -1216,6 +1310,7 @@ func (s *Snapshot) updateSpEntrustRateData(spEtRate []SpEntrustRateRecord, db et
 func (a *Alien) processSpBind(currentSpBind [] SpBindRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot, number uint64) []SpBindRecord {
  if len(txDataInfo) <=spBindTypeIndex {
 	 log.Warn("processSpBind","parameter error",len(txDataInfo))
+	 a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 	 return currentSpBind
  }
 	spBind :=SpBindRecord{
@@ -1225,15 +1320,18 @@ func (a *Alien) processSpBind(currentSpBind [] SpBindRecord, txDataInfo []string
 	}
 	if err := spBind.Hash.UnmarshalText1([]byte(txDataInfo[spBindSpHashIndex])); err != nil {
 		log.Warn("processSpBind", "SP Hash error",err,"Hash", txDataInfo[spBindSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSpBind
 	}
 	if sp,ok:=snap.SpData.PoolPledge[spBind.Hash];ok{
 		 if sp.Manager!=txSender {
 			 log.Warn("processSpBind", "txSender no role", txSender,"manager",sp.Manager)
+			 a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			 return currentSpBind
 		 }
 	}else {
 		log.Warn("processSpBind", "SP not find ", txDataInfo[spBindSpHashIndex])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSpBind
 	}
 	 bindType:=txDataInfo[spBindTypeIndex]
@@ -1241,16 +1339,19 @@ func (a *Alien) processSpBind(currentSpBind [] SpBindRecord, txDataInfo []string
 		 spBind.Bind=true
 		 if len(txDataInfo) <=spBindReveAddrIndex{
 			 log.Warn("processSpBind","parameter error",len(txDataInfo))
+			 a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 			 return currentSpBind
 		 }
 		 if err := spBind.RevenueAddress.UnmarshalText1([]byte(txDataInfo[spBindReveAddrIndex])); err != nil {
 			 log.Warn("processSpBind", "SP RevenueAddress error", txDataInfo[spBindReveAddrIndex])
+			 a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			 return currentSpBind
 		 }
 	 }else if bindType== "unbind"{
 		 spBind.Bind=false
 	 }else {
 		 log.Warn("processSpBind", "Illegal bind Type", txDataInfo[spBindTypeIndex])
+		 a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		 return currentSpBind
 	 }
 
@@ -1260,6 +1361,7 @@ func (a *Alien) processSpBind(currentSpBind [] SpBindRecord, txDataInfo []string
 	topics[1].SetBytes([]byte(bindType))
 	topics[2].SetBytes(spBind.RevenueAddress.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSpBind
 }
 func (s *Snapshot) updateSpBindData(spBind []SpBindRecord, db ethdb.Database, number *big.Int) {
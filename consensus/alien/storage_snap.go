@@ -426,10 +426,14 @@ func (a *Alien) processStorageCustomTx(txDataInfo []string, headerExtra HeaderEx
 	} else if txDataInfo[posCategory] == utgStorageBw {
 		if a.changeBandwidthEnable(number.Uint64()) {
 			headerExtra.StorageExchangeBw, headerExtra.StorageBwPay = a.changeStorageBandwidth(headerExtra.StorageExchangeBw, headerExtra.StorageBwPay, txDataInfo, txSender, tx, receipts, state, snapCache, number)
+} else {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonDisabled)
 		}
 	} else if txDataInfo[posCategory] == utgStoragePledgeCatchUp {
 		if a.isEffectPayPledge(number.Uint64()) {
 			headerExtra.StorageBwPay = a.payStorageBWPledge(headerExtra.StorageBwPay, txDataInfo, txSender, tx, receipts, state, snapCache, number)
+} else {
+			a.addCustomTxResult(tx, receipts, CustomTxReasonDisabled)
 		}
 	}
 	if isGEInitStorageManagerNumber(number.Uint64()) {
@@ -951,16 +955,19 @@ func (s *StorageData) copy() *StorageData {
 func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SPledgeRecord {
 	if len(txDataInfo) < 11 {
 		log.Warn("declareStoragePledge", "parameter error len=", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currStoragePledge
 	}
 	peledgeAddr := common.HexToAddress(txDataInfo[3])
 	if _, ok := snap.StorageData.StoragePledge[peledgeAddr]; ok {
 		log.Warn("Storage Pledge repeat", " peledgeAddr", peledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currStoragePledge
 	}
 	var bigPrice *big.Int
 	if price, err := decimal.NewFromString(txDataInfo[4]); err != nil {
 		log.Warn("Storage Pledge price wrong", "price", txDataInfo[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currStoragePledge
 	} else {
 		bigPrice = price.BigInt()
@@ -973,11 +980,13 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 	}
 	if bigPrice.Cmp(minPrice) < 0 || bigPrice.Cmp(maxPrice) > 0 {
 		log.Warn("price is set too high", " price", bigPrice)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 	storageCapacity, err := decimal.NewFromString(txDataInfo[5])
 	if err != nil {
 		log.Warn("Storage Pledge storageCapacity format error", "storageCapacity", txDataInfo[5])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 	maxPledgeCapacity := maxPledgeStorageCapacity
@@ -988,17 +997,19 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 		maxPledgeCapacity = maxPledgeStorageCapacityV2
 	}
 	minStorageCapacity := minPledgeStorageCapacity
-	if blocknumber.Uint64() >= 101900 {
+	if isGEStorageMinCapacityEffect(blocknumber.Uint64()) {
 		minStorageCapacity = decimal.NewFromInt(1)
 	}
 	if storageCapacity.Cmp(minStorageCapacity) < 0 || storageCapacity.Cmp(maxPledgeCapacity) > 0 {
 		log.Warn("Storage Pledge storageCapacity error", "storageCapacity", storageCapacity, "minPledgeStorageCapacity", minPledgeStorageCapacity, "maxPledgeStorageCapacity", maxPledgeStorageCapacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 	startPkNumber := txDataInfo[6]
 	pkNonce, err := decimal.NewFromString(txDataInfo[7])
 	if err != nil {
 		log.Warn("Storage Pledge package nonce error", "pkNonce", txDataInfo[7])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 	pkBlockHash := txDataInfo[8]
@@ -1013,21 +1024,24 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 	verifyDataArr := strings.Split(verifyData, ",")
 	if len(verifyDataArr) < 10 {
 		log.Warn("Storage Pledge verifyData format error", "verifyData", verifyData, "verifyDataArr", verifyDataArr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge
 	}
 	if !a.notVerifyPkHeader(blocknumber.Uint64()) {
 		pkHeader := chain.GetHeaderByHash(common.HexToHash(pkBlockHash))
 		if pkHeader == nil {
 			log.Warn("Storage Pledge", "pkBlockHash is not exist", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currStoragePledge
 		}
 		if verifyDataArr[4] != storageBlockSize {
 			log.Warn("Storage Pledge storageBlockSize error", "storageBlockSize", storageBlockSize, "verifyDataArr[4]", verifyDataArr[4])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 		if pkHeader.Number.String() != startPkNumber || pkHeader.Nonce.Uint64() != pkNonce.BigInt().Uint64() {
 			log.Warn("Storage Pledge  packege param compare error", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash, " chain", pkHeader.Number)
-
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 	}
@@ -1035,11 +1049,13 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 	if verifyType == "v1" {
 		if !verifyPocStringV1(startPkNumber, txDataInfo[7], pkBlockHash, txDataInfo[9], rootHash, txDataInfo[3]) {
 			log.Warn("Storage Pledge  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 	} else {
 		if !verifyPocString(startPkNumber, txDataInfo[7], pkBlockHash, verifyData, rootHash, txDataInfo[3]) {
 			log.Warn("Storage Pledge  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 	}
@@ -1047,17 +1063,20 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 	storageSize, err := decimal.NewFromString(verifyDataArr[4])
 	if err != nil || storageSize.Cmp(decimal.Zero) <= 0 {
 		log.Warn("Storage Pledge storageSize format error", "storageSize", verifyDataArr[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge
 	}
 	if blocknumber.Uint64() >= SPledgeRevertFixBlockNumber {
 		blocknum, err := decimal.NewFromString(verifyDataArr[5])
 		if err != nil || blocknum.Cmp(decimal.Zero) <= 0 {
 			log.Warn("Storage Pledge blocknum format error", "blocknum", verifyDataArr[5])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 		actblocknum := storageCapacity.Div(storageSize)
 		if actblocknum.Cmp(blocknum) != 0 {
 			log.Warn("Storage Pledge storageCapacity not same in verify", "actblocknum", actblocknum, "blocknum", blocknum.Mul(storageSize))
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge
 		}
 	}
@@ -1066,11 +1085,13 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 
 	if err != nil || bandwidth.BigInt().Cmp(big.NewInt(0)) <= 0 {
 		log.Warn("Storage Pledge  bandwidth error", "bandwidth", bandwidth)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 
 	if err := a.checkPledgeMaxStorageSpace(currStoragePledge, peledgeAddr, snap, blocknumber, storageCapacity.BigInt()); err != nil {
 		log.Warn("Storage Pledge", "checkRevenueStorageBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge
 	}
 	totalStorage := big.NewInt(0)
@@ -1086,6 +1107,7 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 
 	if state.GetBalance(txSender).Cmp(pledgeAmount) < 0 {
 		log.Warn("Claimed sotrage", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currStoragePledge
 	}
 	state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), pledgeAmount))
@@ -1106,6 +1128,7 @@ func (a *Alien) declareStoragePledge(currStoragePledge []SPledgeRecord, txDataIn
 		Bandwidth:       bandwidth.BigInt(),
 	}
 	currStoragePledge = append(currStoragePledge, storageRecord)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currStoragePledge
 }
 func (s *Snapshot) updateStorageData(pledgeRecord []SPledgeRecord, db ethdb.Database) {
@@ -1177,24 +1200,29 @@ func (a *Alien) storagePledgeExit(storagePledgeExit []SPledgeExitRecord, exchang
 	}
 	if len(txDataInfo) < 4 {
 		log.Warn("storage Pledge exit", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storagePledgeExit, exchangeSRT
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
 	if revenue, ok := snap.RevenueStorage[pledgeAddr]; ok {
 		log.Warn("storage Pledge exit", "bind Revenue address", revenue.RevenueAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return storagePledgeExit, exchangeSRT
 	}
 	if pledgeAddr != txSender {
 		log.Warn("storagePledgeExit  no role", " txSender", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return storagePledgeExit, exchangeSRT
 	}
 	storagepledge := snap.StorageData.StoragePledge[pledgeAddr]
 	if storagepledge == nil {
 		log.Warn("storagePledgeExit  pledgeAddr not find  ", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storagePledgeExit, exchangeSRT
 	}
 	if storagepledge.PledgeStatus.Cmp(big.NewInt(SPledgeExit)) == 0 {
 		log.Warn("storagePledgeExit  has exit", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storagePledgeExit, exchangeSRT
 	}
 	if blocknumber.Uint64() >= StoragePledgeOptEffectNumber {
@@ -1202,6 +1230,7 @@ func (a *Alien) storagePledgeExit(storagePledgeExit []SPledgeExitRecord, exchang
 		pledgeTime := new(big.Int).Sub(blocknumber, storagepledge.Number)
 		if pledgeTime.Uint64() <= blockNumPerYear {
 			log.Warn("storagePledgeExit", "  Online for at least one year ")
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return storagePledgeExit, exchangeSRT
 		}
 	}
@@ -1214,6 +1243,7 @@ func (a *Alien) storagePledgeExit(storagePledgeExit []SPledgeExitRecord, exchang
 	}
 	if leaseStatus {
 		log.Warn("storagePledgeExit There are still open leases ", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storagePledgeExit, exchangeSRT
 	}
 	storagePledgeExit = append(storagePledgeExit, SPledgeExitRecord{
@@ -1225,11 +1255,13 @@ func (a *Alien) storagePledgeExit(storagePledgeExit []SPledgeExitRecord, exchang
 	topics[1].SetBytes(pledgeAddr.Bytes())
 	topics[2].SetBytes([]byte("0"))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storagePledgeExit, exchangeSRT
 }
 func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exchangeSRT []ExchangeSRTRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int) ([]SPledgeExitRecord, []ExchangeSRTRecord) {
 	if len(txDataInfo) < 4 {
 		log.Warn("storage Pledge exit", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storagePledgeExit, exchangeSRT
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
@@ -1237,16 +1269,19 @@ func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exch
 		if entrustItem, ok := snap.StorageData.StorageEntrust[pledgeAddr]; ok {
 			if snap.StorageData.StorageEntrust[pledgeAddr].Manager != txSender {
 				log.Warn("isStorageManager", "txSender is not manager", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return storagePledgeExit, exchangeSRT
 			}
 			if entrustItem.Sphash != common.BigToHash(common.Big0) {
 				if blocknumber.Uint64()-entrustItem.Spheight.Uint64() <= sPPoollockDay*snap.getBlockPreDay() {
 					log.Warn("storagePledgeNewExit", "sPPoollockDay not pass", sPPoollockDay)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return storagePledgeExit, exchangeSRT
 				}
 			}
 		} else {
 			log.Warn("storage Pledge exit", "manager is empty", pledgeAddr)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return storagePledgeExit, exchangeSRT
 		}
 	} else {
@@ -1254,6 +1289,7 @@ func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exch
 			if revenue, ok := snap.RevenueStorage[pledgeAddr]; ok {
 				if revenue.RevenueAddress != txSender {
 					log.Warn("storage Pledge exit", "bind Revenue address", revenue.RevenueAddress)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return storagePledgeExit, exchangeSRT
 				}
 			}
@@ -1261,10 +1297,12 @@ func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exch
 			if revenue, ok := snap.RevenueStorage[pledgeAddr]; ok {
 				if revenue.RevenueAddress != txSender {
 					log.Warn("storage Pledge exit", "txSender no role", txSender)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 					return storagePledgeExit, exchangeSRT
 				}
 			} else {
 				log.Warn("storage Pledge exit", "txSender no role", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return storagePledgeExit, exchangeSRT
 			}
 		}
@@ -1272,10 +1310,12 @@ func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exch
 	storagepledge := snap.StorageData.StoragePledge[pledgeAddr]
 	if storagepledge == nil {
 		log.Warn("storagePledgeExit  pledgeAddr not find  ", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storagePledgeExit, exchangeSRT
 	}
 	if storagepledge.PledgeStatus.Cmp(big.NewInt(SPledgeExit)) == 0 {
 		log.Warn("storagePledgeExit  has exit", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storagePledgeExit, exchangeSRT
 	}
 	storagePledgeExit = append(storagePledgeExit, SPledgeExitRecord{
@@ -1287,6 +1327,7 @@ func (a *Alien) storagePledgeNewExit(storagePledgeExit []SPledgeExitRecord, exch
 	topics[1].SetBytes(pledgeAddr.Bytes())
 	topics[2].SetBytes([]byte("0"))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storagePledgeExit, exchangeSRT
 }
 func (s *Snapshot) updateStoragePledgeExit(storagePledgeExit []SPledgeExitRecord, headerNumber *big.Int, db ethdb.Database) {
@@ -1314,6 +1355,7 @@ func (s *Snapshot) updateStoragePledgeExit(storagePledgeExit []SPledgeExitRecord
 func (a *Alien) processRentRequest(currentSRent []LeaseRequestRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, snap *Snapshot, number uint64) []LeaseRequestRecord {
 	if len(txDataInfo) < 7 {
 		log.Warn("sRent", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSRent
 	}
 	sRent := LeaseRequestRecord{
@@ -1327,41 +1369,49 @@ func (a *Alien) processRentRequest(currentSRent []LeaseRequestRecord, txDataInfo
 	postion := 3
 	if err := sRent.Address.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("sRent", "address", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRent
 	}
 	postion++
 	if capacity, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("sRent", "Capacity", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRent
 	} else {
 		sRent.Capacity = capacity.BigInt()
 	}
 	if sRent.Capacity.Cmp(common.Big0) <= 0 {
 		log.Warn("sRent", "Capacity less than or equal 0", txDataInfo[postion], "Capacity", sRent.Capacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRent
 	}
 	if sRent.Capacity.Cmp(minRentSpace) < 0 {
 		log.Warn("sRent", "Capacity less than minRentSpace", txDataInfo[postion], "Capacity", sRent.Capacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRent
 	}
 	postion++
 	if duration, err := strconv.ParseUint(txDataInfo[postion], 10, 64); err != nil {
 		log.Warn("sRent", "duration", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRent
 	} else {
 		sRent.Duration = new(big.Int).SetUint64(duration)
 	}
 	if sRent.Duration.Cmp(snap.SystemConfig.Deposit[sscEnumMinimumRent]) < 0 {
 		log.Warn("sRent", "Duration to small", sRent.Duration)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRent
 	}
 	if sRent.Duration.Cmp(snap.SystemConfig.Deposit[sscEnumMaximumRent]) > 0 {
 		log.Warn("sRent", "Duration to big", sRent.Duration)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRent
 	}
 	postion++
 	if price, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("sRent", "price", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRent
 	} else {
 		sRent.Price = price.BigInt()
@@ -1369,6 +1419,7 @@ func (a *Alien) processRentRequest(currentSRent []LeaseRequestRecord, txDataInfo
 	if number < StoragePledgeOptEffectNumber {
 		if sRent.Price.Cmp(new(big.Int).Mul(snap.SystemConfig.Deposit[sscEnumStoragePrice], big.NewInt(10))) > 0 {
 			log.Warn("price is set too high", " price", sRent.Price)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentSRent
 		}
 		//check price 0.1
@@ -1376,12 +1427,14 @@ func (a *Alien) processRentRequest(currentSRent []LeaseRequestRecord, txDataInfo
 		minPrice = new(big.Int).Div(minPrice, big.NewInt(100))
 		if sRent.Price.Cmp(minPrice) < 0 {
 			log.Info("price is set too low", "price", sRent.Price)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentSRent
 		}
 	}
 	//checkSRT
 	if !snap.checkEnoughSRT(currentSRent, sRent, number-1, a.db) {
 		log.Warn("sRent", "checkEnoughSRT fail", sRent.Tenant)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientSRT)
 		return currentSRent
 	}
 	//checkPledge
@@ -1391,8 +1444,10 @@ func (a *Alien) processRentRequest(currentSRent []LeaseRequestRecord, txDataInfo
 		topics[1].SetBytes(sRent.Address.Bytes())
 		a.addCustomerTxLog(tx, receipts, topics, nil)
 		currentSRent = append(currentSRent, sRent)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	} else {
 		log.Warn("sRent", "checkSRent fail", sRent.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 	}
 	return currentSRent
 }
@@ -1400,6 +1455,7 @@ func (a *Alien) processExchangeSRT(currentExchangeSRT []ExchangeSRTRecord, txDat
 	utgPosExchValue := 4
 	if len(txDataInfo) <= utgPosExchValue {
 		log.Warn("Exchange UTG to SRT fail", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentExchangeSRT
 	}
 	exchangeSRT := ExchangeSRTRecord{
@@ -1408,20 +1464,24 @@ func (a *Alien) processExchangeSRT(currentExchangeSRT []ExchangeSRTRecord, txDat
 	}
 	if err := exchangeSRT.Target.UnmarshalText1([]byte(txDataInfo[3])); err != nil {
 		log.Warn("Exchange UTG to SRT fail", "address", txDataInfo[3])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentExchangeSRT
 	}
 	amount := big.NewInt(0)
 	var err error
 	if amount, err = hexutil.UnmarshalText1([]byte(txDataInfo[utgPosExchValue])); err != nil {
 		log.Warn("Exchange UTG to SRT fail", "number", txDataInfo[utgPosExchValue])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentExchangeSRT
 	}
 	if amount.Cmp(common.Big0) <= 0 {
 		log.Warn("Exchange UTG to SRT fail", "amount less than or equal 0", txDataInfo[utgPosExchValue])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentExchangeSRT
 	}
 	if state.GetBalance(txSender).Cmp(amount) < 0 {
 		log.Warn("Exchange UTG to SRT fail", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentExchangeSRT
 	}
 	exchangeSRT.Amount = new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(int64(snap.SystemConfig.ExchRate))), big.NewInt(10000))
@@ -1437,12 +1497,14 @@ func (a *Alien) processExchangeSRT(currentExchangeSRT []ExchangeSRTRecord, txDat
 	data = append(data, dataList[1].Bytes()...)
 	a.addCustomerTxLog(tx, receipts, topics, data)
 	currentExchangeSRT = append(currentExchangeSRT, exchangeSRT)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentExchangeSRT
 }
 
 func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64, chain consensus.ChainHeaderReader) []LeasePledgeRecord {
 	if len(txDataInfo) < 9 {
 		log.Warn("sRentPg", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSRentPg
 	}
 	sRentPg := LeasePledgeRecord{
@@ -1461,6 +1523,7 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 	postion := 3
 	if err := sRentPg.Address.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("sRentPg", "Hash", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentPg
 	}
 	postion++
@@ -1468,17 +1531,20 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 	postion++
 	if capacity, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("sRentPg", "Capacity", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentPg
 	} else {
 		sRentPg.Capacity = capacity.BigInt()
 	}
 	if sRentPg.Capacity.Cmp(common.Big0) <= 0 {
 		log.Warn("sRentPg Capacity less or equal 0", " Capacity", sRentPg.Capacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRentPg
 	}
 	postion++
 	if rootHash, ok := snap.StorageData.verifyParamsStoragePoc(txDataInfo, postion, chain, number); !ok {
 		log.Warn("sRentPg verify fail", " RootHash1", rootHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentSRentPg
 	} else {
 		sRentPg.RootHash = rootHash
@@ -1486,17 +1552,20 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 	postion++
 	if leftCapacity, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("sRentPg", "Capacity", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentPg
 	} else {
 		sRentPg.LeftCapacity = leftCapacity.BigInt()
 	}
 	if sRentPg.LeftCapacity.Cmp(common.Big0) < 0 { //can be 0
 		log.Warn("sRentPg LeftCapacity less 0", " LeftCapacity", sRentPg.LeftCapacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRentPg
 	}
 	if isGEPosAutoExitPunishChange(number) {
 		if sRentPg.LeftCapacity.Cmp(rentLeftSpace) < 0 {
 			log.Warn("sRentPg LeftCapacity less rentLeftSpace", " LeftCapacity", sRentPg.LeftCapacity)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentSRentPg
 		}
 	}
@@ -1504,6 +1573,7 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 		postion++
 		if rootHash, ok := snap.StorageData.verifyParamsStoragePoc(txDataInfo, postion, chain, number); !ok {
 			log.Warn("sRentPg verify fail", " RootHash2", rootHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currentSRentPg
 		} else {
 			sRentPg.LeftRootHash = rootHash
@@ -1519,10 +1589,12 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 
 		if !snap.checkEnoughSRTPg(currentSRentPg, sRentPg, number-1, a.db) {
 			log.Warn("sRent", "checkEnoughSRT fail", sRentPg.BurnSRTAddress)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientSRT)
 			return currentSRentPg
 		}
 		if state.GetBalance(txSender).Cmp(amount) < 0 {
 			log.Warn("sRent", "balance", state.GetBalance(txSender))
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 			return currentSRentPg
 		}
 		state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), amount))
@@ -1537,14 +1609,17 @@ func (a *Alien) processLeasePledge(currentSRentPg []LeasePledgeRecord, txDataInf
 		data = append(data, dataList[1].Bytes()...)
 		a.addCustomerTxLog(tx, receipts, topics, data)
 		currentSRentPg = append(currentSRentPg, sRentPg)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	} else {
 		log.Warn("sRentPg", "checkSRentPg fail", sRentPg.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 	}
 	return currentSRentPg
 }
 func (a *Alien) processLeaseRenewal(currentSRentReNew []LeaseRenewalRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) []LeaseRenewalRecord {
 	if len(txDataInfo) < 6 {
 		log.Warn("sRentReNew", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSRentReNew
 	}
 	sRentReNew := LeaseRenewalRecord{
@@ -1559,6 +1634,7 @@ func (a *Alien) processLeaseRenewal(currentSRentReNew []LeaseRenewalRecord, txDa
 	postion := 3
 	if err := sRentReNew.Address.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("sRentReNew", "Hash", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentReNew
 	}
 	postion++
@@ -1566,22 +1642,26 @@ func (a *Alien) processLeaseRenewal(currentSRentReNew []LeaseRenewalRecord, txDa
 	postion++
 	if duration, err := strconv.ParseUint(txDataInfo[postion], 10, 32); err != nil {
 		log.Warn("sRentReNew", "duration", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentReNew
 	} else {
 		sRentReNew.Duration = new(big.Int).SetUint64(duration)
 	}
 	if sRentReNew.Duration.Cmp(snap.SystemConfig.Deposit[sscEnumMinimumRent]) < 0 {
 		log.Warn("sRentReNew", "Duration to small", sRentReNew.Duration)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRentReNew
 	}
 	if sRentReNew.Duration.Cmp(snap.SystemConfig.Deposit[sscEnumMaximumRent]) > 0 {
 		log.Warn("sRentReNew", "Duration to big", sRentReNew.Duration)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRentReNew
 	}
 	if tenant, ok := snap.StorageData.checkSRentReNew(currentSRentReNew, sRentReNew, txSender, number, a.blockPerDay()); ok {
 		sRentReNew.Tenant = tenant
 	} else {
 		log.Warn("sRentReNew", "checkSRentReNew fail", sRentReNew.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSRentReNew
 	}
 	lease := snap.StorageData.StoragePledge[sRentReNew.Address].Lease
@@ -1590,6 +1670,7 @@ func (a *Alien) processLeaseRenewal(currentSRentReNew []LeaseRenewalRecord, txDa
 	sRentReNew.Capacity = l.Capacity
 	if !snap.checkEnoughSRTReNew(currentSRentReNew, sRentReNew, number-1, a.db) {
 		log.Warn("sRentReNew", "checkEnoughSRT fail", sRentReNew.Tenant)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientSRT)
 		return currentSRentReNew
 	}
 	sRentReNew.NewHash = tx.Hash()
@@ -1598,11 +1679,13 @@ func (a *Alien) processLeaseRenewal(currentSRentReNew []LeaseRenewalRecord, txDa
 	topics[1].SetBytes(sRentReNew.Hash.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentSRentReNew = append(currentSRentReNew, sRentReNew)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSRentReNew
 }
 func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64, chain consensus.ChainHeaderReader) []LeaseRenewalPledgeRecord {
 	if len(txDataInfo) < 7 {
 		log.Warn("sRentReNewPg", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSRentReNewPg
 	}
 	sRentPg := LeaseRenewalPledgeRecord{
@@ -1616,6 +1699,7 @@ func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPled
 	postion := 3
 	if err := sRentPg.Address.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("sRentReNewPg", "Hash", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentReNewPg
 	}
 	postion++
@@ -1623,17 +1707,20 @@ func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPled
 	postion++
 	if capacity, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("sRentReNewPg", "Capacity", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRentReNewPg
 	} else {
 		sRentPg.Capacity = capacity.BigInt()
 	}
 	if sRentPg.Capacity.Cmp(common.Big0) <= 0 {
 		log.Warn("sRentReNewPg Capacity less or equal 0", " Capacity", sRentPg.Capacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSRentReNewPg
 	}
 	postion++
 	if rootHash, ok := snap.StorageData.verifyParamsStoragePoc(txDataInfo, postion, chain, number); !ok {
 		log.Warn("sRentReNewPg verify fail", " RootHash", rootHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentSRentReNewPg
 	} else {
 		sRentPg.RootHash = rootHash
@@ -1648,10 +1735,12 @@ func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPled
 		sRentPg.BurnSRTAddress = burnSRTAddress
 		if !snap.checkEnoughSRTReNewPg(currentSRentReNewPg, sRentPg, number-1, a.db) {
 			log.Warn("sRentReNewPg", "checkEnoughSRT fail", sRentPg.BurnSRTAddress)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientSRT)
 			return currentSRentReNewPg
 		}
 		if state.GetBalance(txSender).Cmp(amount) < 0 {
 			log.Warn("sRentReNewPg", "balance", state.GetBalance(txSender))
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 			return currentSRentReNewPg
 		}
 		state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), amount))
@@ -1666,8 +1755,10 @@ func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPled
 		data = append(data, dataList[1].Bytes()...)
 		a.addCustomerTxLog(tx, receipts, topics, data)
 		currentSRentReNewPg = append(currentSRentReNewPg, sRentPg)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	} else {
 		log.Warn("sRentReNewPg", "checkSRentReNewPg fail", sRentPg.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 	}
 	return currentSRentReNewPg
 }
@@ -1675,6 +1766,7 @@ func (a *Alien) processLeaseRenewalPledge(currentSRentReNewPg []LeaseRenewalPled
 func (a *Alien) processLeaseRescind(currentSRescind []LeaseRescindRecord, currentExchangeSRT []ExchangeSRTRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number uint64) ([]LeaseRescindRecord, []ExchangeSRTRecord) {
 	if len(txDataInfo) < 5 {
 		log.Warn("stRescind", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSRescind, currentExchangeSRT
 	}
 	sRescind := LeaseRescindRecord{
@@ -1684,6 +1776,7 @@ func (a *Alien) processLeaseRescind(currentSRescind []LeaseRescindRecord, curren
 	postion := 3
 	if err := sRescind.Address.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("stRescind", "Hash", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSRescind, currentExchangeSRT
 	}
 	postion++
@@ -1695,8 +1788,10 @@ func (a *Alien) processLeaseRescind(currentSRescind []LeaseRescindRecord, curren
 		topics[1].SetBytes(sRescind.Hash.Bytes())
 		a.addCustomerTxLog(tx, receipts, topics, nil)
 		currentSRescind = append(currentSRescind, sRescind)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	} else {
 		log.Warn("stRescind", "checkSRescind fail", sRescind.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 	}
 	return currentSRescind, currentExchangeSRT
 }
@@ -1878,20 +1973,24 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 	//log.Info("storageRecoveryCertificate", "txDataInfo", txDataInfo)
 	if len(txDataInfo) < 6 {
 		log.Warn("storage Recovery Certificate", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storageRecoveryData
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
 	if pledgeAddr != txSender {
 		log.Warn("storage Recovery Certificate  no role", " txSender", txSender)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return storageRecoveryData
 	}
 	storagepledge := snap.StorageData.StoragePledge[pledgeAddr]
 	if storagepledge == nil {
 		log.Warn("storage Recovery Certificate  not find pledge", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storageRecoveryData
 	}
 	if len(txDataInfo[4]) == 0 || txDataInfo[4] == "" {
 		log.Warn("storage Recovery Certificate  not any rent hash", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storageRecoveryData
 	}
 	leaseHashStr := strings.Split(txDataInfo[4], ",")
@@ -1909,6 +2008,7 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 	}
 	if len(delLeaseHash) != len(leaseHashStr) {
 		log.Warn("storage  Recovery Certificate  There are leases that have not expired ", " leaseHash", txDataInfo[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storageRecoveryData
 	}
 	storageCapacity := decimal.Zero // new(big.Int).Add(storagepledge.TotalCapacity,totalReCapacity.BigInt())
@@ -1923,6 +2023,7 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 	verifydatas := strings.Split(validData, ",")
 	if len(verifydatas) < 10 {
 		log.Warn("verifyStoragePoc", "invalide poc string format")
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return storageRecoveryData
 	}
 	rootHash := verifydatas[len(verifydatas)-1]
@@ -1930,16 +2031,19 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 		blockSize, err := decimal.NewFromString(verifydatas[4])
 		if err != nil || blockSize.Cmp(decimal.Zero) <= 0 {
 			log.Warn("applyStorageProof blocksize err ", "blockSize", blockSize, "set storageBlockSize", storageBlockSize)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageRecoveryData
 		}
 		blockNum, err := decimal.NewFromString(verifydatas[5])
 		if err != nil || blockNum.Cmp(decimal.Zero) <= 0 {
 			log.Warn("applyStorageProof blockNum err ", "blockNum", blockNum)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageRecoveryData
 		}
 		storageCapacity = blockSize.Mul(blockNum)
 		if storageCapacity.Cmp(decimal.Zero) <= 0 {
 			log.Warn("applyStorageProof storageCapacity err ", "storageCapacity", storageCapacity)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageRecoveryData
 		}
 		freecapacity := decimal.Zero
@@ -1949,22 +2053,26 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 		totalcapacity := storagepledge.TotalCapacity
 		if storageCapacity.BigInt().Cmp(totalcapacity) > 0 || storageCapacity.Cmp(totalReCapacity.Add(freecapacity)) != 0 {
 			log.Warn("storage  Recovery storageCapacity is error", " storageCapacity", txDataInfo[5])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return storageRecoveryData
 		}
 
 		verifyHeader := chain.GetHeaderByHash(common.HexToHash(verifydatas[2]))
 		if verifyHeader == nil || verifyHeader.Number.String() != verifydatas[0] || strconv.FormatInt(int64(verifyHeader.Nonce.Uint64()), 10) != verifydatas[1] {
 			log.Warn("storageRecoveryCertificate  GetHeaderByHash not find by hash  ", "verifydatas", verifydatas)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageRecoveryData
 		}
 		if verifyType == "v1" {
 			if !verifyStoragePocV1(txDataInfo[5], rootHash, verifyHeader.Nonce.Uint64()) {
 				log.Warn("storageRecoveryCertificate   verify  faild", "roothash", storagepledge.StorageSpaces.RootHash.String())
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageRecoveryData
 			}
 		} else {
 			if !verifyStoragePoc(txDataInfo[5], rootHash, verifyHeader.Nonce.Uint64()) {
 				log.Warn("storageRecoveryCertificate   verify  faild", "roothash", storagepledge.StorageSpaces.RootHash.String())
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageRecoveryData
 			}
 		}
@@ -1973,6 +2081,7 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 		verifyHeader := chain.GetHeaderByHash(common.HexToHash(verifydatas[2]))
 		if verifyHeader == nil || verifyHeader.Number.String() != verifydatas[0] || strconv.FormatInt(int64(verifyHeader.Nonce.Uint64()), 10) != verifydatas[1] {
 			log.Warn("storageRecoveryCertificate  GetHeaderByHash not find by hash  ", "verifydatas", verifydatas)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageRecoveryData
 		}
 		//
@@ -1991,6 +2100,7 @@ func (a *Alien) storageRecoveryCertificate(storageRecoveryData []SPledgeRecovery
 	topics[1].SetBytes(pledgeAddr.Bytes())
 	topics[2].SetBytes([]byte(storageCapacity.String()))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storageRecoveryData
 }
 func (s *Snapshot) updateStorageRecoveryData(storageRecoveryData []SPledgeRecoveryRecord, headerNumber *big.Int, db ethdb.Database) {
@@ -2027,17 +2137,20 @@ func (a *Alien) applyStorageProof(storageProofRecord []StorageProofRecord, txDat
 	//log.Debug("applyStorageProof", "txDataInfo", txDataInfo)
 	if len(txDataInfo) < 7 {
 		log.Warn("Storage Proof", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storageProofRecord
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
 	if pledgeAddr != txSender {
 		log.Warn("Storage Proof txSender no role", " txSender", txSender, "pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return storageProofRecord
 
 	}
 	storagepledge := snap.StorageData.StoragePledge[pledgeAddr]
 	if storagepledge == nil {
 		log.Warn("Storage Proof not find pledge", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return storageProofRecord
 	}
 	var verifyResult []string
@@ -2048,6 +2161,7 @@ func (a *Alien) applyStorageProof(storageProofRecord []StorageProofRecord, txDat
 		var capacity *big.Int
 		if capvalue, err := decimal.NewFromString(txDataInfo[5]); err != nil {
 			log.Warn("Storage Proof capvalue format error", "Capacity", txDataInfo[5])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return storageProofRecord
 		} else {
 			capacity = capvalue.BigInt()
@@ -2068,11 +2182,13 @@ func (a *Alien) applyStorageProof(storageProofRecord []StorageProofRecord, txDat
 			leaseHash = common.HexToHash(txDataInfo[4])
 			if _, ok := storagepledge.Lease[leaseHash]; !ok {
 				log.Warn("Storage Proof not find leaseHash", " leaseHash", leaseHash)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageProofRecord
 			}
 			storageFile := storagepledge.Lease[leaseHash].StorageFile
 			if _, ok := storageFile[rootHash]; !ok {
 				log.Warn("Storage Proof lease not find rootHash", " rootHash", rootHash)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageProofRecord
 			}
 			lease := storagepledge.Lease[leaseHash]
@@ -2081,36 +2197,43 @@ func (a *Alien) applyStorageProof(storageProofRecord []StorageProofRecord, txDat
 			storageFile := storagepledge.StorageSpaces.StorageFile
 			if _, ok := storageFile[rootHash]; !ok {
 				log.Warn("applyStorageProof not find rootHash", " rootHash", rootHash)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageProofRecord
 			}
 			tragetCapacity = storageFile[rootHash].Capacity
 		}
 		if tragetCapacity == nil || tragetCapacity.Cmp(capacity) != 0 {
 			log.Warn("applyStorageProof  capacity not same", " capacity", capacity)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageProofRecord
 		}
 		pocs := strings.Split(validData, ",")
 		if len(pocs) < 10 {
 			log.Warn("verifyStoragePoc", "invalide poc string format")
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageProofRecord
 		}
 		verifyHeader := chain.GetHeaderByHash(common.HexToHash(pocs[2]))
 		if verifyHeader == nil || verifyHeader.Number.String() != pocs[0] || strconv.FormatInt(int64(verifyHeader.Nonce.Uint64()), 10) != pocs[1] {
 			log.Warn("applyStorageProof  GetHeaderByHash not find by hash  ", "poc", pocs)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageProofRecord
 		}
 		if currNumber.Cmp(new(big.Int).Add(proofTimeOut, verifyHeader.Number)) > 0 {
 			log.Warn("applyStorageProof data timeout  ", "TimeOut", proofTimeOut, "currNumber", currNumber, "proof number", verifyHeader.Number)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return storageProofRecord
 		}
 		if verifyType == "v1" {
 			if !verifyStoragePocV1(txDataInfo[6], storagepledge.StorageSpaces.RootHash.String(), verifyHeader.Nonce.Uint64()) {
 				log.Warn("applyStorageProof   verify  faild", "roothash", storagepledge.StorageSpaces.RootHash.String())
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageProofRecord
 			}
 		} else {
 			if !verifyStoragePoc(validData, storagepledge.StorageSpaces.RootHash.String(), verifyHeader.Nonce.Uint64()) {
 				log.Warn("applyStorageProof   verify  faild", "roothash", storagepledge.StorageSpaces.RootHash.String())
+				a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 				return storageProofRecord
 			}
 		}
@@ -2169,6 +2292,7 @@ func (a *Alien) applyStorageProof(storageProofRecord []StorageProofRecord, txDat
 		a.addCustomerTxLog(tx, receipts, topics, nil)
 	}
 
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storageProofRecord
 }
 
@@ -2542,6 +2666,7 @@ func (s *StorageData) checkSRentReNewPg(currentSRentReNewPg []LeaseRenewalPledge
 func (a *Alien) exchangeStoragePrice(storageExchangePriceRecord []StorageExchangePriceRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int) []StorageExchangePriceRecord {
 	if len(txDataInfo) < 5 {
 		log.Warn("exchange   Price  of Storage", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storageExchangePriceRecord
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
@@ -2549,27 +2674,32 @@ func (a *Alien) exchangeStoragePrice(storageExchangePriceRecord []StorageExchang
 		if _, ok := snap.StorageData.StorageEntrust[pledgeAddr]; ok {
 			if snap.StorageData.StorageEntrust[pledgeAddr].Manager != txSender {
 				log.Warn("isStorageManager", "txSender is not manager", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return storageExchangePriceRecord
 			}
 		} else {
 			log.Warn("isStorageManager", "manager is empty", pledgeAddr)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return storageExchangePriceRecord
 		}
 	} else {
 		if pledgeAddr != txSender {
 			if revenue, ok := snap.RevenueStorage[pledgeAddr]; !ok || revenue.RevenueAddress != txSender {
 				log.Warn("exchange   Price  of Storage  [no role]", " txSender", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return storageExchangePriceRecord
 			}
 		}
 	}
 	if _, ok := snap.StorageData.StoragePledge[pledgeAddr]; !ok {
 		log.Warn("exchange  Price not find Pledge", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storageExchangePriceRecord
 	}
 	price, err := decimal.NewFromString(txDataInfo[4])
 	if err != nil {
 		log.Warn("exchange  Price is wrong", " price", txDataInfo[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return storageExchangePriceRecord
 	}
 	basePrice := snap.SystemConfig.Deposit[sscEnumStoragePrice]
@@ -2579,6 +2709,7 @@ func (a *Alien) exchangeStoragePrice(storageExchangePriceRecord []StorageExchang
 	}
 	if price.BigInt().Cmp(minThreshold) < 0 || price.BigInt().Cmp(new(big.Int).Mul(big.NewInt(10), basePrice)) > 0 {
 		log.Warn("exchange  Price not legal", " pledgeAddr", pledgeAddr, "price", price, "basePrice", basePrice)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return storageExchangePriceRecord
 	}
 
@@ -2591,6 +2722,7 @@ func (a *Alien) exchangeStoragePrice(storageExchangePriceRecord []StorageExchang
 	topics[1].SetBytes(pledgeAddr.Bytes())
 	topics[2].SetBytes([]byte(txDataInfo[4]))
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storageExchangePriceRecord
 }
 
@@ -3875,6 +4007,7 @@ func (s *StorageData) dealSPledgeRevert3(pledge *SPledge, revertLockReward []Spa
 func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchangeBwRecord, storageBwPayRecord []StorageBwPayRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int) ([]StorageExchangeBwRecord, []StorageBwPayRecord) {
 	if len(txDataInfo) < 5 {
 		log.Warn("exchange   bw  of Storage", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storageExchangeBwRecord, storageBwPayRecord
 	}
 	pledgeAddr := common.HexToAddress(txDataInfo[3])
@@ -3882,6 +4015,7 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 		if pledgeAddr != txSender {
 			if revenue, ok := snap.RevenueStorage[pledgeAddr]; !ok || revenue.RevenueAddress != txSender {
 				log.Warn("exchange  bw no role  to change  ", " txSender", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 				return storageExchangeBwRecord, storageBwPayRecord
 			}
 		}
@@ -3890,6 +4024,7 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 	storagePg := snap.StorageData.StoragePledge[pledgeAddr]
 	if storagePg == nil || storagePg.PledgeStatus.Cmp(big.NewInt(SPledgeNormal)) != 0 {
 		log.Warn("exchange  bw not find Pledge", " pledgeAddr", pledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storageExchangeBwRecord, storageBwPayRecord
 	}
 	if blocknumber.Uint64() >= PosrIncentiveEffectNumber {
@@ -3899,6 +4034,7 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 		//}
 		if storagePg.Address != txSender {
 			log.Warn("exchange  bw no role  to change  ", " pledgeAddr", pledgeAddr, "Address", storagePg.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return storageExchangeBwRecord, storageBwPayRecord
 		}
 		//if storagePg.Bandwidth.Cmp(bandwidthAdjustThreshold)<=0 {
@@ -3909,17 +4045,20 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 	bandwidth, err := decimal.NewFromString(txDataInfo[4])
 	if err != nil {
 		log.Warn("  bw format error", " bandwidth", txDataInfo[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return storageExchangeBwRecord, storageBwPayRecord
 	}
 
 	if bandwidth.Cmp(decimal.Zero) < 0 {
 		log.Warn("exchange  bandwidth < 0", " pledgeAddr", pledgeAddr, "bandwidth", bandwidth)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return storageExchangeBwRecord, storageBwPayRecord
 	}
 	totalPledgeAmount := big.NewInt(0)
 	if blocknumber.Uint64() >= PosrIncentiveEffectNumber {
 		if bandwidth.Cmp(decimal.NewFromInt(20)) < 0 {
 			log.Warn("exchange  bandwidth < 20", " pledgeAddr", pledgeAddr, "bandwidth", bandwidth)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return storageExchangeBwRecord, storageBwPayRecord
 		}
 		totalStorage := big.NewInt(0)
@@ -3931,6 +4070,7 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 		if payPledgeAmount.Cmp(big.NewInt(0)) > 0 {
 			if state.GetBalance(txSender).Cmp(payPledgeAmount) < 0 {
 				log.Warn("exchange  bandwidth  Insufficient funds", " pledgeAddr", pledgeAddr, "payPledgeAmount", payPledgeAmount, "txSender", txSender, "Balance", state.GetBalance(txSender))
+				a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 				return storageExchangeBwRecord, storageBwPayRecord
 			}
 			state.SubBalance(txSender, payPledgeAmount)
@@ -3957,6 +4097,7 @@ func (a *Alien) changeStorageBandwidth(storageExchangeBwRecord []StorageExchange
 		reData := totalPledgeAmount.Bytes()
 		a.addCustomerTxLog(tx, receipts, topics, reData)
 	}
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storageExchangeBwRecord, storageBwPayRecord
 
 }
@@ -4321,16 +4462,19 @@ func getBandwidthRewardNewRatio(bandwidth *big.Int) decimal.Decimal {
 func (a *Alien) payStorageBWPledge(storageBwPayRecord []StorageBwPayRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int) []StorageBwPayRecord {
 	if len(txDataInfo) < 4 {
 		log.Warn("payStorageBWPledge", "parameter error need 4 act", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return storageBwPayRecord
 	}
 	storageAddress := common.HexToAddress(txDataInfo[3])
 	storageNode := snap.StorageData.StoragePledge[storageAddress]
 	if storageNode == nil {
 		log.Warn("payStorageBWPledge", "storage not exit storageAddress", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return storageBwPayRecord
 	}
 	if storageNode.Address != txSender {
 		log.Warn("payStorageBWPledge", "pledge address no role", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return storageBwPayRecord
 	}
 	totalStorage := big.NewInt(0)
@@ -4345,11 +4489,13 @@ func (a *Alien) payStorageBWPledge(storageBwPayRecord []StorageBwPayRecord, txDa
 	}
 	if payAmount.Cmp(big.NewInt(0)) <= 0 {
 		log.Warn("payStorageBWPledge", "not need pay pledgeAmount", needPledgeAmount, "act pledgeAmount ", storageNode.SpaceDeposit)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return storageBwPayRecord
 	}
 	sendBalance := state.GetBalance(txSender)
 	if sendBalance.Cmp(payAmount) <= 0 {
 		log.Warn("payStorageBWPledge", "balance not enough", txSender, "sendBalance ", sendBalance, "payAmount", payAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return storageBwPayRecord
 	}
 	state.SetBalance(txSender, new(big.Int).Sub(sendBalance, payAmount))
@@ -4371,6 +4517,7 @@ func (a *Alien) payStorageBWPledge(storageBwPayRecord []StorageBwPayRecord, txDa
 		})
 	}
 
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return storageBwPayRecord
 }
 func (s *Snapshot) updateBwPledgePayData(storageBwPayRecord []StorageBwPayRecord, headerNumber *big.Int, db ethdb.Database) {
@@ -4827,6 +4974,7 @@ func (s *Snapshot) initStorageManager() {
 func (a *Alien) modifyStorageManager(currentManager []ModifySManagerRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, db *state.StateDB, snap *Snapshot, number *big.Int) []ModifySManagerRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("modifyStorageManager", "parameter error need 4 act", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentManager
 	}
 	postion := 3
@@ -4834,26 +4982,31 @@ func (a *Alien) modifyStorageManager(currentManager []ModifySManagerRecord, txDa
 	storageNode := snap.StorageData.StoragePledge[storageAddress]
 	if storageNode == nil {
 		log.Warn("modifyStorageManager", "storage not exit storageAddress", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentManager
 	}
 	storageNum := new(big.Int).Set(storageNode.Number)
 	if isGEInitStorageManagerNumber(storageNum.Uint64()) {
 		log.Warn("modifyStorageManager", "storage can not change manager", storageAddress, "storageNum", storageNum)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentManager
 	}
 	if storageAddress != txSender {
 		log.Warn("modifyStorageManager", "pledge address no role", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentManager
 	}
 	storageNodePaddr := storageNode.Address
 	storageEntrust := snap.StorageData.StorageEntrust[storageAddress]
 	if storageEntrust == nil {
 		log.Warn("modifyStorageManager", "storage not exit storageEntrust", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentManager
 	}
 	curManager := storageEntrust.Manager
 	if curManager != storageNodePaddr {
 		log.Warn("modifyStorageManager", "pledge address has change manager already", storageAddress)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentManager
 	}
 	postion++
@@ -4869,6 +5022,7 @@ func (a *Alien) modifyStorageManager(currentManager []ModifySManagerRecord, txDa
 	topics[1].SetBytes(storageAddress.Bytes())
 	topics[2].SetBytes(manager.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentManager
 }
 
@@ -4886,6 +5040,7 @@ func (s *Snapshot) updateStorageManager(modifySManagerRecord []ModifySManagerRec
 func (a *Alien) completeStoragePledge(currentCSPledge []CompleteSPledgeRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int) []CompleteSPledgeRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("completeStoragePledge", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentCSPledge
 	}
 	completeSPledge := CompleteSPledgeRecord{
@@ -4896,29 +5051,35 @@ func (a *Alien) completeStoragePledge(currentCSPledge []CompleteSPledgeRecord, t
 	postion := 3
 	if err := completeSPledge.Pledge.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("completeSPledge", "Pledge", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCSPledge
 	}
 	if _, ok := snap.StorageData.StorageEntrust[completeSPledge.Pledge]; ok {
 		if snap.StorageData.StorageEntrust[completeSPledge.Pledge].Manager != txSender {
 			log.Warn("completeSPledge", "txSender is not manager", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentCSPledge
 		}
 	} else {
 		log.Warn("completeSPledge", "manager is empty", completeSPledge.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCSPledge
 	}
 	postion++
 	if amount, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("completeSPledge", "amount", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentCSPledge
 	} else {
 		if amount.Cmp(decimal.Zero) < 0 {
 			log.Warn("completeSPledge", "amount small than 0", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentCSPledge
 		}
 		amountBig := amount.BigInt()
 		if amountBig.Cmp(common.Big0) < 0 {
 			log.Warn("completeSPledge", "amountBig small than 0", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentCSPledge
 		}
 
@@ -4926,6 +5087,7 @@ func (a *Alien) completeStoragePledge(currentCSPledge []CompleteSPledgeRecord, t
 		modValue := new(big.Int).Mod(completeSPledge.Amount, utgOneValue)
 		if modValue.Cmp(common.Big0) != 0 {
 			log.Warn("completeSPledge", "amount must rounding ", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentCSPledge
 		}
 	}
@@ -4939,15 +5101,18 @@ func (a *Alien) completeStoragePledge(currentCSPledge []CompleteSPledgeRecord, t
 		}
 		if addAmount.Cmp(spaceDeposit) > 0 {
 			log.Warn("completeSPledge", "pledgeAmount is too big", completeSPledge.Amount)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentCSPledge
 		}
 	} else {
 		log.Warn("completeSPledge", "StoragePledge is empty", completeSPledge.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentCSPledge
 	}
 
 	if state.GetBalance(txSender).Cmp(completeSPledge.Amount) < 0 {
 		log.Warn("completeSPledge", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentCSPledge
 	}
 	state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), completeSPledge.Amount))
@@ -4958,6 +5123,7 @@ func (a *Alien) completeStoragePledge(currentCSPledge []CompleteSPledgeRecord, t
 	topics[2].SetBytes(completeSPledge.Amount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentCSPledge = append(currentCSPledge, completeSPledge)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentCSPledge
 }
 
@@ -4989,6 +5155,7 @@ func (s *Snapshot) updateCompleteSPledge(completeSPledgeRecord []CompleteSPledge
 func (a *Alien) storageSetRewardRatio(currentRatio []SPRewardRatioRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int) []SPRewardRatioRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("storageSetRewardRatio", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentRatio
 	}
 	sPRewardRatio := SPRewardRatioRecord{
@@ -4998,29 +5165,35 @@ func (a *Alien) storageSetRewardRatio(currentRatio []SPRewardRatioRecord, txData
 	postion := 3
 	if err := sPRewardRatio.Pledge.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageSetRewardRatio", "Pledge", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentRatio
 	}
 	if _, ok := snap.StorageData.StorageEntrust[sPRewardRatio.Pledge]; ok {
 		if snap.StorageData.StorageEntrust[sPRewardRatio.Pledge].Manager != txSender {
 			log.Warn("storageSetRewardRatio", "txSender is not manager", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentRatio
 		}
 	} else {
 		log.Warn("storageSetRewardRatio", "manager is empty", sPRewardRatio.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentRatio
 	}
 	postion++
 	if rate, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("storageSetRewardRatio", "rate", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentRatio
 	} else {
 		rateBig := rate.BigInt()
 		if rateBig.Cmp(common.Big0) < 0 {
 			log.Warn("storageSetRewardRatio", "rate small than 0", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentRatio
 		}
 		if rateBig.Cmp(sPDistributionDefaultRate) > 0 {
 			log.Warn("storageSetRewardRatio", "rate is too big", rateBig)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentRatio
 		}
 		sPRewardRatio.Rate = rateBig
@@ -5028,10 +5201,12 @@ func (a *Alien) storageSetRewardRatio(currentRatio []SPRewardRatioRecord, txData
 	if sp, ok := snap.StorageData.StoragePledge[sPRewardRatio.Pledge]; ok {
 		if sp.PledgeStatus.Cmp(big.NewInt(SPledgeNormal)) != 0 && sp.PledgeStatus.Cmp(big.NewInt(SPledgeInactive)) != 0 {
 			log.Warn("storageSetRewardRatio", "pledgeStatus is not normal or inactive", sPRewardRatio.Pledge)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentRatio
 		}
 	} else {
 		log.Warn("storageSetRewardRatio", "StoragePledge is empty", sPRewardRatio.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentRatio
 	}
 
@@ -5042,6 +5217,7 @@ func (a *Alien) storageSetRewardRatio(currentRatio []SPRewardRatioRecord, txData
 	topics[2].SetBytes(sPRewardRatio.Rate.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentRatio = append(currentRatio, sPRewardRatio)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentRatio
 }
 
@@ -5059,6 +5235,7 @@ func (s *Snapshot) updateSPRewardRatio(sPRewardRatioRecord []SPRewardRatioRecord
 func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int) []SPPoolRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("storageSetStoragePools", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSPPool
 	}
 	sPPool := SPPoolRecord{
@@ -5068,15 +5245,18 @@ func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo
 	postion := 3
 	if err := sPPool.Pledge.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageSetStoragePools", "Pledge", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSPPool
 	}
 	if _, ok := snap.StorageData.StorageEntrust[sPPool.Pledge]; ok {
 		if snap.StorageData.StorageEntrust[sPPool.Pledge].Manager != txSender {
 			log.Warn("storageSetStoragePools", "txSender is not manager", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentSPPool
 		}
 	} else {
 		log.Warn("storageSetStoragePools", "manager is empty", sPPool.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPPool
 	}
 	postion++
@@ -5085,6 +5265,7 @@ func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo
 
 	} else {
 		log.Warn("storageSetStoragePools", "StoragePledge is empty", sPPool.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPPool
 	}
 
@@ -5094,29 +5275,35 @@ func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo
 			spheight := se.Spheight
 			if number.Uint64()-spheight.Uint64() <= sPPoollockDay*snap.getBlockPreDay() {
 				log.Warn("storageSetStoragePools", "sPPoollockDay not pass", sPPool.Pledge)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentSPPool
 			}
 			if se.Sphash == sPPool.Hash {
 				log.Warn("storageSetStoragePools", "address is in target pool", sPPool.Pledge)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentSPPool
 			}
 		}
 	} else {
 		log.Warn("storageSetStoragePools", "StoragePledge is empty", sPPool.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPPool
 	}
 	if sp, ok := snap.SpData.PoolPledge[sPPool.Hash]; ok {
 		if sp.Status != spStatusActive {
 			log.Warn("storageSetStoragePools", "pool is not active", sPPool.Hash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSPPool
 		}
 	} else {
 		log.Warn("storageSetStoragePools", "pool is empty", sPPool.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPPool
 	}
 	if _, ok := snap.StorageData.StoragePledge[sPPool.Pledge]; ok {
 		if snap.StorageData.StoragePledge[sPPool.Pledge].PledgeStatus.Cmp(big.NewInt(SPledgeNormal)) != 0 {
 			log.Warn("storageSetStoragePools", "pledgeStatus is not normal", sPPool.Pledge)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSPPool
 		}
 		sCapacity := new(big.Int).Set(snap.StorageData.StoragePledge[sPPool.Pledge].TotalCapacity)
@@ -5131,10 +5318,12 @@ func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo
 		}
 		if addCapacity.Cmp(poolTotalCapacity) > 0 {
 			log.Warn("storageSetStoragePools", "capacity oversize", sPPool.Pledge)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentSPPool
 		}
 	} else {
 		log.Warn("storageSetStoragePools", "StoragePledge is empty", sPPool.Pledge)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPPool
 	}
 	topics := make([]common.Hash, 3)
@@ -5144,6 +5333,7 @@ func (a *Alien) storageSetStoragePools(currentSPPool []SPPoolRecord, txDataInfo
 	topics[2].SetBytes(sPPool.Hash.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentSPPool = append(currentSPPool, sPPool)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSPPool
 }
 
@@ -5176,43 +5366,51 @@ func (s *Snapshot) updateSPPool(sPPoolRecord []SPPoolRecord, number *big.Int, db
 func (a *Alien) storageMigration(currentMigration []SPMigrationRecord, currentLockReward []LockRewardRecord, currentExchangeSRT []ExchangeSRTRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int, chain consensus.ChainHeaderReader) ([]SPMigrationRecord, []LockRewardRecord, []ExchangeSRTRecord) {
 	if len(txDataInfo) <= 8 {
 		log.Warn("storageMigration", "parameter error len=", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	postion := 3
 	peledgeAddr := common.HexToAddress(txDataInfo[postion])
 	if _, ok := snap.StorageData.StoragePledge[peledgeAddr]; !ok {
 		log.Warn("storageMigration", " peledgeAddr is not exist", peledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	if _, ok := snap.StorageData.StorageEntrust[peledgeAddr]; !ok {
 		log.Warn("storageMigration", " StorageEntrust is not exist", peledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	for _, item := range currentMigration {
 		if item.Pledge == peledgeAddr {
 			log.Warn("storageMigration", " peledgeAddr is in exit", peledgeAddr)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentMigration, currentLockReward, currentExchangeSRT
 		}
 	}
 	manager := snap.StorageData.StorageEntrust[peledgeAddr].Manager
 	if txSender != manager {
 		log.Warn("storageMigration", " txSender is not manager", manager)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	postion++
 	storageCapacity, err := decimal.NewFromString(txDataInfo[postion])
 	if err != nil {
 		log.Warn("storageMigration", "storageCapacity", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	totalCapacity := snap.StorageData.StoragePledge[peledgeAddr].TotalCapacity
 	if totalCapacity.Cmp(storageCapacity.BigInt()) != 0 {
 		log.Warn("storageMigration", "storageCapacity not equal", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	maxPledgeCapacity := maxPledgeStorageCapacityV2
 	if storageCapacity.Cmp(minPledgeStorageCapacity) < 0 || storageCapacity.Cmp(maxPledgeCapacity) > 0 {
 		log.Warn("storageMigration", "storageCapacity", storageCapacity, "minPledgeStorageCapacity", minPledgeStorageCapacity, "maxPledgeStorageCapacity", maxPledgeStorageCapacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	postion++
@@ -5221,6 +5419,7 @@ func (a *Alien) storageMigration(currentMigration []SPMigrationRecord, currentLo
 	pkNonce, err := decimal.NewFromString(txDataInfo[postion])
 	if err != nil {
 		log.Warn("storageMigration", "pkNonce", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	postion++
@@ -5235,30 +5434,36 @@ func (a *Alien) storageMigration(currentMigration []SPMigrationRecord, currentLo
 	verifyDataArr := strings.Split(verifyData, ",")
 	if len(verifyDataArr) < 10 {
 		log.Warn("storageMigration verifyData format error", "verifyData", verifyData, "verifyDataArr", verifyDataArr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	pkHeader := chain.GetHeaderByHash(common.HexToHash(pkBlockHash))
 	if pkHeader == nil {
 		log.Warn("storageMigration", "pkBlockHash is not exist", pkBlockHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	if verifyDataArr[4] != storageBlockSize {
 		log.Warn("storageMigration storageBlockSize error", "storageBlockSize", storageBlockSize, "verifyDataArr[4]", verifyDataArr[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	if pkHeader.Number.String() != startPkNumber || pkHeader.Nonce.Uint64() != pkNonce.BigInt().Uint64() {
 		log.Warn("storageMigration  packege param compare error", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash, " chain", pkHeader.Number)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	rootHash := verifyDataArr[len(verifyDataArr)-1]
 	if verifyType == "v1" {
 		if !verifyPocStringV1(startPkNumber, txDataInfo[6], pkBlockHash, txDataInfo[8], rootHash, txDataInfo[3]) {
 			log.Warn("storageMigration  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currentMigration, currentLockReward, currentExchangeSRT
 		}
 	} else {
 		if !verifyPocString(startPkNumber, txDataInfo[6], pkBlockHash, verifyData, rootHash, txDataInfo[3]) {
 			log.Warn("storageMigration  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currentMigration, currentLockReward, currentExchangeSRT
 		}
 	}
@@ -5266,17 +5471,20 @@ func (a *Alien) storageMigration(currentMigration []SPMigrationRecord, currentLo
 	storageSize, err := decimal.NewFromString(verifyDataArr[4])
 	if err != nil || storageSize.Cmp(decimal.Zero) <= 0 {
 		log.Warn("storageMigration storageSize format error", "storageSize", verifyDataArr[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 
 	blocknum, err := decimal.NewFromString(verifyDataArr[5])
 	if err != nil || blocknum.Cmp(decimal.Zero) <= 0 {
 		log.Warn("storageMigration blocknum format error", "blocknum", verifyDataArr[5])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 	actblocknum := storageCapacity.Div(storageSize)
 	if actblocknum.Cmp(blocknum) != 0 {
 		log.Warn("storageMigration storageCapacity not same in verify", "actblocknum", actblocknum, "blocknum", blocknum.Mul(storageSize))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currentMigration, currentLockReward, currentExchangeSRT
 	}
 
@@ -5338,6 +5546,7 @@ func (a *Alien) storageMigration(currentMigration []SPMigrationRecord, currentLo
 	topics[1].SetBytes(peledgeAddr.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentMigration = append(currentMigration, migration)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentMigration, currentLockReward, currentExchangeSRT
 }
 
@@ -5395,16 +5604,19 @@ func (s *Snapshot) updateSPMigration(migrationRecord []SPMigrationRecord, number
 func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, blocknumber *big.Int, chain consensus.ChainHeaderReader) []SPledge2Record {
 	if len(txDataInfo) < 13 {
 		log.Warn("declareStoragePledge2", "parameter error len=", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currStoragePledge2
 	}
 	peledgeAddr := common.HexToAddress(txDataInfo[3])
 	if _, ok := snap.StorageData.StoragePledge[peledgeAddr]; ok {
 		log.Warn("Storage Pledge2 repeat", " peledgeAddr", peledgeAddr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currStoragePledge2
 	}
 	var bigPrice *big.Int
 	if price, err := decimal.NewFromString(txDataInfo[4]); err != nil {
 		log.Warn("Storage Pledge2 price wrong", "price", txDataInfo[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currStoragePledge2
 	} else {
 		bigPrice = price.BigInt()
@@ -5415,27 +5627,31 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	minPrice = (basePrice.Mul(decimal.NewFromFloat(0.1))).BigInt()
 	if bigPrice.Cmp(minPrice) < 0 || bigPrice.Cmp(maxPrice) > 0 {
 		log.Warn("price is set too high 2", " price", bigPrice)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	storageCapacity, err := decimal.NewFromString(txDataInfo[5])
 	if err != nil {
 		log.Warn("Storage Pledge2 storageCapacity format error", "storageCapacity", txDataInfo[5])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	maxPledgeCapacity := maxPledgeStorageCapacity
 	maxPledgeCapacity = maxPledgeStorageCapacityV2
 	minStorageCapacity := minPledgeStorageCapacity
-	if blocknumber.Uint64() >= 101900 {
+	if isGEStorageMinCapacityEffect(blocknumber.Uint64()) {
 		minStorageCapacity = decimal.NewFromInt(1)
 	}
 	if storageCapacity.Cmp(minStorageCapacity) < 0 || storageCapacity.Cmp(maxPledgeCapacity) > 0 {
 		log.Warn("Storage Pledge2 storageCapacity error", "storageCapacity", storageCapacity, "minPledgeStorageCapacity", minStorageCapacity, "maxPledgeStorageCapacity", maxPledgeStorageCapacity)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	startPkNumber := txDataInfo[6]
 	pkNonce, err := decimal.NewFromString(txDataInfo[7])
 	if err != nil {
 		log.Warn("Storage Pledge2 package nonce error", "pkNonce", txDataInfo[7])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	pkBlockHash := txDataInfo[8]
@@ -5448,20 +5664,24 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	verifyDataArr := strings.Split(verifyData, ",")
 	if len(verifyDataArr) < 10 {
 		log.Warn("Storage Pledge2 verifyData format error", "verifyData", verifyData, "verifyDataArr", verifyDataArr)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 
 	pkHeader := chain.GetHeaderByHash(common.HexToHash(pkBlockHash))
 	if pkHeader == nil {
 		log.Warn("Storage Pledge2", "pkBlockHash is not exist", pkBlockHash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currStoragePledge2
 	}
 	if verifyDataArr[4] != storageBlockSize {
 		log.Warn("Storage Pledge2 storageBlockSize error", "storageBlockSize", storageBlockSize, "verifyDataArr[4]", verifyDataArr[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 	if pkHeader.Number.String() != startPkNumber || pkHeader.Nonce.Uint64() != pkNonce.BigInt().Uint64() {
 		log.Warn("Storage Pledge2  packege param compare error", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash, " chain", pkHeader.Number)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 
@@ -5469,11 +5689,13 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	if verifyType == "v1" {
 		if !verifyPocStringV1(startPkNumber, txDataInfo[7], pkBlockHash, txDataInfo[9], rootHash, txDataInfo[3]) {
 			log.Warn("Storage Pledge2  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge2
 		}
 	} else {
 		if !verifyPocString(startPkNumber, txDataInfo[7], pkBlockHash, verifyData, rootHash, txDataInfo[3]) {
 			log.Warn("Storage Pledge2  verifyPoc Faild", "startPkNumber", startPkNumber, "pkNonce", pkNonce, "pkBlockHash", pkBlockHash)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 			return currStoragePledge2
 		}
 	}
@@ -5481,17 +5703,20 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	storageSize, err := decimal.NewFromString(verifyDataArr[4])
 	if err != nil || storageSize.Cmp(decimal.Zero) <= 0 {
 		log.Warn("Storage Pledge2 storageSize format error", "storageSize", verifyDataArr[4])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 
 	blocknum, err := decimal.NewFromString(verifyDataArr[5])
 	if err != nil || blocknum.Cmp(decimal.Zero) <= 0 {
 		log.Warn("Storage Pledge2 blocknum format error", "blocknum", verifyDataArr[5])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 	actblocknum := storageCapacity.Div(storageSize)
 	if actblocknum.Cmp(blocknum) != 0 {
 		log.Warn("Storage Pledge2 storageCapacity not same in verify", "actblocknum", actblocknum, "blocknum", blocknum.Mul(storageSize))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonProofFailed)
 		return currStoragePledge2
 	}
 
@@ -5499,11 +5724,13 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 
 	if err != nil || bandwidth.BigInt().Cmp(big.NewInt(0)) <= 0 {
 		log.Warn("Storage Pledge2  bandwidth error", "bandwidth", bandwidth)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 
 	if err := a.checkPledgeMaxStorageSpace2(currStoragePledge2, peledgeAddr, snap, blocknumber, storageCapacity.BigInt()); err != nil {
 		log.Warn("Storage Pledge2", "checkRevenueStorageBind", err.Error())
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	totalStorage := big.NewInt(0)
@@ -5514,6 +5741,7 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	pledgeRateDec, err := decimal.NewFromString(txDataInfo[11])
 	if err != nil || pledgeRateDec.BigInt().Cmp(MinimumThresholdForPledgeAmount) < 0 || pledgeRateDec.BigInt().Cmp(big.NewInt(100)) > 0 {
 		log.Warn("Storage Pledge2  pledgeRate error", "pledgeRate", txDataInfo[11])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	pledgeRate := pledgeRateDec.BigInt()
@@ -5530,16 +5758,19 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 	entrustRate, err := decimal.NewFromString(txDataInfo[12])
 	if err != nil || entrustRate.BigInt().Cmp(big.NewInt(0)) < 0 {
 		log.Warn("Storage Pledge2  entrustRate error", "entrustRate", entrustRate)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 	entrustRateBig := entrustRate.BigInt()
 	if entrustRateBig.Cmp(sPDistributionDefaultRate) > 0 {
 		log.Warn("Storage Pledge2  entrustRate error", "entrustRate is too big", entrustRateBig)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currStoragePledge2
 	}
 
 	if state.GetBalance(txSender).Cmp(pledgeAmount) < 0 {
 		log.Warn("Claimed sotrage2", "balance", state.GetBalance(txSender), "need", pledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currStoragePledge2
 	}
 	state.SetBalance(txSender, new(big.Int).Sub(state.GetBalance(txSender), pledgeAmount))
@@ -5566,6 +5797,7 @@ func (a *Alien) declareStoragePledge2(currStoragePledge2 []SPledge2Record, txDat
 		Hash:            tx.Hash(),
 	}
 	currStoragePledge2 = append(currStoragePledge2, storageRecord)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currStoragePledge2
 }
 
@@ -5671,6 +5903,7 @@ func (s *Snapshot) updateStorageData2(pledgeRecord []SPledge2Record, db ethdb.Da
 func (a *Alien) storageSPEntrust(currentSPEntrust []SPEntrustRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int, chain consensus.ChainHeaderReader) []SPEntrustRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("storageSPEntrust", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSPEntrust
 	}
 	sPEntrust := SPEntrustRecord{
@@ -5682,44 +5915,53 @@ func (a *Alien) storageSPEntrust(currentSPEntrust []SPEntrustRecord, txDataInfo
 	postion := 3
 	if err := sPEntrust.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageSPEntrust", "miner address", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSPEntrust
 	}
 	if _, ok := snap.StorageData.StoragePledge[sPEntrust.Target]; !ok {
 		log.Warn("storageSPEntrust", "StoragePledge is not exist", sPEntrust.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPEntrust
 	}
 	if _, ok := snap.StorageData.StorageEntrust[sPEntrust.Target]; !ok {
 		log.Warn("storageSPEntrust", "StorageEntrust is not exist", sPEntrust.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSPEntrust
 	}
 
 	if _, ok := snap.StorageData.StoragePledge[sPEntrust.Address]; ok {
 		log.Warn("storageSPEntrust", "txSender is Storage address", sPEntrust.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSPEntrust
 	}
 	postion++
 	if amount, err := decimal.NewFromString(txDataInfo[postion]); err != nil {
 		log.Warn("storageSPEntrust", "amount", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSPEntrust
 	} else {
 		if amount.Cmp(decimal.Zero) < 0 {
 			log.Warn("storageSPEntrust", "amount small than 0", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 			return currentSPEntrust
 		}
 		sPEntrust.Amount = amount.BigInt()
 	}
 	if sPEntrust.Amount.Cmp(utgOneValue) < 0 {
 		log.Warn("storageSPEntrust", "amountBig small than 1 utg", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSPEntrust
 	}
 	modValue := new(big.Int).Mod(sPEntrust.Amount, utgOneValue)
 	if modValue.Cmp(common.Big0) != 0 {
 		log.Warn("storageSPEntrust", "amount must rounding", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSPEntrust
 	}
 	storagePledge := snap.StorageData.StoragePledge[sPEntrust.Target]
 	if storagePledge.PledgeStatus.Cmp(big.NewInt(SPledgeInactive)) != 0 {
 		log.Warn("storageSPEntrust", "pledgeStatus is not inactive", sPEntrust.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSPEntrust
 	}
 	spaceDeposit := new(big.Int).Set(storagePledge.SpaceDeposit)
@@ -5733,16 +5975,19 @@ func (a *Alien) storageSPEntrust(currentSPEntrust []SPEntrustRecord, txDataInfo
 	}
 	if pledgeAmount.Cmp(spaceDeposit) > 0 {
 		log.Warn("storageSPEntrust", "pledgeAmount bigger than spaceDeposit", pledgeAmount)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSPEntrust
 	}
 	targetMiner := snap.findStorageTargetMiner(txSender)
 	nilAddr := common.Address{}
 	if targetMiner != nilAddr && targetMiner != sPEntrust.Target {
 		log.Warn("storageSPEntrust", "one address can only pledge one miner ", targetMiner)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSPEntrust
 	}
 	if state.GetBalance(txSender).Cmp(sPEntrust.Amount) < 0 {
 		log.Warn("storageSPEntrust", "balance", state.GetBalance(txSender))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInsufficientBalance)
 		return currentSPEntrust
 	}
 	state.SubBalance(txSender, sPEntrust.Amount)
@@ -5754,6 +5999,7 @@ func (a *Alien) storageSPEntrust(currentSPEntrust []SPEntrustRecord, txDataInfo
 	data.SetBytes(sPEntrust.Amount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, data.Bytes())
 	currentSPEntrust = append(currentSPEntrust, sPEntrust)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSPEntrust
 }
 
@@ -5806,6 +6052,7 @@ func (s *Snapshot) updateSPEntrust(entrustRecord []SPEntrustRecord, number *big.
 func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int, chain consensus.ChainHeaderReader) []SETransferRecord {
 	if len(txDataInfo) <= 5 {
 		log.Warn("storageEntrustedPledgeTransfer", "parameter error", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSETransfer
 	}
 	sETransfer := SETransferRecord{
@@ -5818,25 +6065,30 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 	}
 	if isInCurrentSETransfer(currentSETransfer, sETransfer.Address) {
 		log.Warn("storageEntrustedPledgeTransfer", "Address is in currentSETransfer", sETransfer.Address)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSETransfer
 	}
 	postion := 3
 	if err := sETransfer.Original.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageEntrustedPledgeTransfer", "Target error", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSETransfer
 	}
 	if se, ok := snap.StorageData.StorageEntrust[sETransfer.Original]; ok {
 		if se.Manager == txSender {
 			log.Warn("storageEntrustedPledgeTransfer", "manager address no role", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentSETransfer
 		}
 		stp := snap.StorageData.StoragePledge[sETransfer.Original]
 		if stp == nil {
 			log.Warn("storageEntrustedPledgeTransfer", "storagePledge is not exist", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentSETransfer
 		}
 		if stp.PledgeStatus.Cmp(big.NewInt(SPledgeNormal)) != 0 && stp.PledgeStatus.Cmp(big.NewInt(SPledgeInactive)) != 0 {
 			log.Warn("storageEntrustedPledgeTransfer", "stp Status  is exiting or exited ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 		transAmount := big.NewInt(0)
@@ -5846,6 +6098,7 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 				pledgeBLock := new(big.Int).Sub(new(big.Int).SetUint64(snap.Number), detail.Height)
 				if pledgeBLock.Cmp(pledgeMinBLock) < 0 {
 					log.Warn("storageEntrustedPledgeTransfer", "Entrust hash illegality", txSender)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 					return currentSETransfer
 				}
 				transAmount = new(big.Int).Add(transAmount, detail.Amount)
@@ -5853,12 +6106,14 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 		}
 		if transAmount.Cmp(big.NewInt(0)) <= 0 {
 			log.Warn("storageEntrustedPledgeTransfer", "TxSender does not have a transferable deposit ", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 		sETransfer.PledgeAmount = transAmount
 
 	} else {
 		log.Warn("storageEntrustedPledgeTransfer", "se not exist ", sETransfer.Original)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSETransfer
 	}
 
@@ -5868,33 +6123,40 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 	if TargetTypePos == sETransfer.TargetType {
 		if err := sETransfer.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("storageEntrustedPledgeTransfer", "PoS target Address error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentSETransfer
 		}
 		if _, ok := snap.PosPledge[sETransfer.Target]; !ok {
 			log.Warn("storageEntrustedPledgeTransfer", "PoS node not exit ", sETransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentSETransfer
 		}
 		if _, ok := snap.PosPledge[sETransfer.Address]; ok {
 			log.Warn("storageEntrustedPledgeTransfer", "txSender is miner address", sETransfer.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 		targetMiner := snap.findPosTargetMiner(txSender)
 		nilAddr := common.Address{}
 		if targetMiner != nilAddr && targetMiner != sETransfer.Target {
 			log.Warn("storageEntrustedPledgeTransfer", "one address can only pledge one pos miner ", targetMiner)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 	} else if TargetTypeSp == sETransfer.TargetType {
 		if err := sETransfer.TargetHash.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("storageEntrustedPledgeTransfer", "Sp target Hash error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentSETransfer
 		}
 		if sp, ok := snap.SpData.PoolPledge[sETransfer.TargetHash]; !ok {
 			log.Warn("storageEntrustedPledgeTransfer", "Sp target not exit ", sETransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentSETransfer
 		} else {
 			if sp.Status != spStatusActive {
 				log.Warn("storageEntrustedPledgeTransfer", "SP Status  is need active ", txSender)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentSETransfer
 			}
 		}
@@ -5902,15 +6164,18 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 		nilAddr := common.Hash{}
 		if targetPool != nilAddr && targetPool != sETransfer.TargetHash {
 			log.Warn("storageEntrustedPledgeTransfer", "one address can only pledge one pool ", targetPool)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 	} else if TargetTypeSn == sETransfer.TargetType {
 		if _, ok := snap.StorageData.StoragePledge[sETransfer.Address]; ok {
 			log.Warn("storageEntrustedPledgeTransfer", "txSender is Storage address", sETransfer.Address)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSETransfer
 		}
 		if err := sETransfer.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 			log.Warn("storageEntrustedPledgeTransfer", "SN target Address error", txDataInfo[postion])
+			a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 			return currentSETransfer
 		}
 		currBlockTranAmount := big.NewInt(0)
@@ -5923,11 +6188,13 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 			if snItem, ok1 := snap.StorageData.StoragePledge[sETransfer.Target]; ok1 {
 				if snItem.PledgeStatus.Cmp(big.NewInt(SPledgeInactive)) != 0 {
 					log.Warn("storageEntrustedPledgeTransfer", "Sn is not inactive", sETransfer.Target)
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentSETransfer
 				}
 				estimateAmountV1 := new(big.Int).Add(currBlockTranAmount, snEtPledge.PledgeAmount)
 				if estimateAmountV1.Cmp(snItem.SpaceDeposit) >= 0 {
 					log.Warn("storageEntrustedPledgeTransfer", "Sn entrusted pledge is full", txDataInfo[postion])
+					a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 					return currentSETransfer
 				}
 				estimateAmountV2 := new(big.Int).Add(estimateAmountV1, sETransfer.PledgeAmount)
@@ -5949,10 +6216,12 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 			}
 		} else {
 			log.Warn("storageEntrustedPledgeTransfer", "SN node not exit", sETransfer.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentSETransfer
 		}
 	} else {
 		log.Warn("storageEntrustedPledgeTransfer", "TargetType is illegal", sETransfer.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidValue)
 		return currentSETransfer
 	}
 
@@ -5963,6 +6232,7 @@ func (a *Alien) storageEntrustedPledgeTransfer(currentSETransfer []SETransferRec
 	topics[1].SetBytes(sETransfer.LockAmount.Bytes())
 	topics[2].SetBytes(sETransfer.PledgeAmount.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSETransfer
 }
 
@@ -6059,6 +6329,7 @@ func (s *Snapshot) updateSETransfer(entrustRecord []SETransferRecord, number *bi
 func (a *Alien) storageEntrustedPledgeExit(currentSEExit []SEExitRecord, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int, chain consensus.ChainHeaderReader) []SEExitRecord {
 	if len(txDataInfo) <= 4 {
 		log.Warn("storageEntrustedPledgeExit", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentSEExit
 	}
 	sEExit := SEExitRecord{
@@ -6070,6 +6341,7 @@ func (a *Alien) storageEntrustedPledgeExit(currentSEExit []SEExitRecord, txDataI
 	postion := 3
 	if err := sEExit.Target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageEntrustedPledgeExit", "miner address", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentSEExit
 	}
 	postion++
@@ -6077,27 +6349,32 @@ func (a *Alien) storageEntrustedPledgeExit(currentSEExit []SEExitRecord, txDataI
 
 	if se, ok := snap.StorageData.StorageEntrust[sEExit.Target]; !ok {
 		log.Warn("storageEntrustedPledgeExit", "StorageEntrust is not exist", sEExit.Target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSEExit
 	} else {
 		if se.Manager == txSender {
 			log.Warn("storageEntrustedPledgeExit", "txSender is Manager", sEExit.Target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSEExit
 		}
 	}
 
 	if _, ok := snap.StorageData.StorageEntrust[sEExit.Target].Detail[sEExit.Hash]; !ok {
 		log.Warn("storageEntrustedPledgeExit", "Hash is not exist", sEExit.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentSEExit
 	} else {
 		pledgeDetail := snap.StorageData.StorageEntrust[sEExit.Target].Detail[sEExit.Hash]
 		if pledgeDetail.Address != txSender {
 			log.Warn("storageEntrustedPledgeExit", "txSender is not right", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentSEExit
 		}
 		pledgeMinBLock := a.getEntrustPledgeMinBLock(stpEntrustMinDay)
 		pledgeBLock := new(big.Int).Sub(number, pledgeDetail.Height)
 		if pledgeBLock.Cmp(pledgeMinBLock) < 0 {
 			log.Warn("storageEntrustedPledgeExit", "Entrust hash not pass time", pledgeDetail.Height)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 			return currentSEExit
 		}
 		sEExit.Address = pledgeDetail.Address
@@ -6106,6 +6383,7 @@ func (a *Alien) storageEntrustedPledgeExit(currentSEExit []SEExitRecord, txDataI
 
 	if isInCurrentSEExit(currentSEExit, sEExit.Hash) {
 		log.Warn("storageEntrustedPledgeExit", "Hash is in currentSEExit", sEExit.Hash)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentSEExit
 	}
 
@@ -6118,6 +6396,7 @@ func (a *Alien) storageEntrustedPledgeExit(currentSEExit []SEExitRecord, txDataI
 	data.SetBytes(sEExit.Hash.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, data.Bytes())
 	currentSEExit = append(currentSEExit, sEExit)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentSEExit
 }
 
@@ -6561,26 +6840,31 @@ func (s *StorageData) calDealLeaseStatus2(number uint64, snap *Snapshot, db ethd
 func (a *Alien) storageExitPool(currentExitPool []common.Address, txDataInfo []string, txSender common.Address, tx *types.Transaction, receipts []*types.Receipt, state *state.StateDB, snap *Snapshot, number *big.Int) []common.Address {
 	if len(txDataInfo) <= 3 {
 		log.Warn("storageExitPool", "parameter number", len(txDataInfo))
+		a.addCustomTxResult(tx, receipts, CustomTxReasonFieldCount)
 		return currentExitPool
 	}
 	postion := 3
 	var target common.Address
 	if err := target.UnmarshalText1([]byte(txDataInfo[postion])); err != nil {
 		log.Warn("storageExitPool", "Pledge", txDataInfo[postion])
+		a.addCustomTxResult(tx, receipts, CustomTxReasonInvalidField)
 		return currentExitPool
 	}
 	nilHash := common.Hash{}
 	if _, ok := snap.StorageData.StorageEntrust[target]; ok {
 		if snap.StorageData.StorageEntrust[target].Manager != txSender {
 			log.Warn("storageExitPool", "txSender is not manager", txSender)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonUnauthorized)
 			return currentExitPool
 		}
 		if snap.StorageData.StorageEntrust[target].Sphash == nilHash {
 			log.Warn("storageExitPool", "Sphash is nilHash", target)
+			a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 			return currentExitPool
 		}
 	} else {
 		log.Warn("storageExitPool", "manager is empty", target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentExitPool
 	}
 
@@ -6589,15 +6873,18 @@ func (a *Alien) storageExitPool(currentExitPool []common.Address, txDataInfo []s
 			spheight := snap.StorageData.StorageEntrust[target].Spheight
 			if number.Uint64()-spheight.Uint64() <= sPPoollockDay*snap.getBlockPreDay() {
 				log.Warn("storageExitPool", "sPPoollockDay not pass", target)
+				a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 				return currentExitPool
 			}
 		}
 	} else {
 		log.Warn("storageExitPool", "StoragePledge is empty", target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonNotFound)
 		return currentExitPool
 	}
 	if isInCurrentExitPool(currentExitPool, target) {
 		log.Warn("storageExitPool", "target is in currentExitPool", target)
+		a.addCustomTxResult(tx, receipts, CustomTxReasonStateCheck)
 		return currentExitPool
 	}
 	topics := make([]common.Hash, 2)
@@ -6606,6 +6893,7 @@ func (a *Alien) storageExitPool(currentExitPool []common.Address, txDataInfo []s
 	topics[1].SetBytes(target.Bytes())
 	a.addCustomerTxLog(tx, receipts, topics, nil)
 	currentExitPool = append(currentExitPool, target)
+	a.addCustomTxResult(tx, receipts, CustomTxReasonNone)
 	return currentExitPool
 }
 
@@ -238,7 +238,7 @@ func Test_PledgeRewardAcal(t *testing.T) {
 		rewards := make([]SpaceRewardRecord, 0)
 		capSuccAddrs := make(map[common.Address]*big.Int)
 		//(ratios map[common.Address]*StorageRatio, revenueStorage map[common.Address]*RevenueParameter, number uint64, period uint64, sussSPAddrs []common.Address,capSuccAddrs map[common.Address]*big.Int, db ethdb.Database) ([]SpaceRewardRecord, *big.Int, *big.Int) {
-		rewards, harvest, _ = snap.StorageData.calcStoragePledgeReward(storageRatios, snap.RevenueStorage, tt.blocknumber.Uint64(), snap.Period, sussSPAddrs, capSuccAddrs, nil)
+		rewards, harvest, _ = snap.StorageData.calcStoragePledgeReward(storageRatios, snap.RevenueStorage, tt.blocknumber.Uint64(), snap.Period, sussSPAddrs, capSuccAddrs, nil, snap)
 		totalReward := big.NewInt(0)
 		totalSPaceIndex := decimal.NewFromFloat(0)
 		for _, reward := range rewards {
@@ -56,6 +56,24 @@ func verifyPocString(block, nonce, blockhash, pocstr, roothash string, deviceAdd
 	}
 }
 
+// VerifyPocString checks the proof of capacity of a storage pledge declared
+// with the given block, in either version.
+func VerifyPocString(block, nonce, blockhash, pocstr, roothash, deviceAddr string) bool {
+	if strings.HasPrefix(pocstr, "v1") {
+		return verifyPocStringV1(block, nonce, blockhash, pocstr, roothash, deviceAddr)
+	}
+	return verifyPocString(block, nonce, blockhash, pocstr, roothash, deviceAddr)
+}
+
+// VerifyStoragePoc checks a proof of capacity answering the challenge of a block
+// with the given nonce, in either version.
+func VerifyStoragePoc(pocstr, roothash string, nonce uint64) bool {
+	if strings.HasPrefix(pocstr, "v1") {
+		return verifyStoragePocV1(pocstr, roothash, nonce)
+	}
+	return verifyStoragePoc(pocstr, roothash, nonce)
+}
+
 func verifyStoragePoc(pocstr, roothash string, nonce uint64) bool {
 	poc := strings.Split(pocstr, ",")
 	if len(poc) < 10 {
@@ -150,6 +168,10 @@ func verifyBn(blockpos, b0, bn, bn1 string) bool {
 }
 
 func verifySamplePos(n uint64, block, nonce, blockhash, fileblocknumber string) bool {
+	if pos, ok := new(big.Int).SetString(fileblocknumber, 10); !ok || pos.Sign() == 0 {
+		log.Warn("verifySamplePos", "invalide file block number", fileblocknumber)
+		return false
+	}
 	if n != getSamplePos(block, nonce, blockhash, fileblocknumber) {
 		log.Warn("verifySamplePos", "verify simble number failed")
 		return false
@@ -197,6 +219,9 @@ func Acc(b1, b2, n string) string {
 	if err != nil {
 		return ""
 	}
+	if len(block1) < 20 || len(block2) < 20 {
+		return ""
+	}
 
 	var N uint64
 	if n != "" {
@@ -0,0 +1,135 @@
+package alien
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/storageproof"
+)
+
+func TestVerifyGeneratedPoc(t *testing.T) {
+	device := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	plot := storageproof.Challenge{Number: 1200, Nonce: 42, Hash: common.HexToHash("0x1234")}
+	seed := storageproof.Seed(plot, device)
+	block, nonce, blockhash := "1200", "42", plot.Hash.Hex()
+
+	var v1Proofs int
+	for _, blocks := range []uint64{2, 3, 64, 100, 1001} {
+		src := storageproof.NewSparse(seed, blocks)
+		proof, err := storageproof.Prove(src, seed, plot)
+		if err != nil {
+			t.Fatalf("%d blocks: failed to prove: %v", blocks, err)
+		}
+		root := proof.Root.String()
+		if !verifyPocString(block, nonce, blockhash, proof.V0, root, device.Hex()) {
+			t.Errorf("%d blocks: declaration v0 proof rejected: %s", blocks, proof.V0)
+		}
+		if proof.V1 != "" && !verifyPocStringV1(block, nonce, blockhash, proof.V1, root, device.Hex()) {
+			t.Errorf("%d blocks: declaration v1 proof rejected: %s", blocks, proof.V1)
+		}
+		// Later proofs answer the challenge of other blocks against the same root
+		for i := uint64(0); i < 20; i++ {
+			c := storageproof.Challenge{Number: 1300 + i, Nonce: i * 7919, Hash: common.BigToHash(new(big.Int).SetUint64(1000 + i))}
+			proof, err := storageproof.Prove(src, seed, c)
+			if err != nil {
+				t.Fatalf("%d blocks: failed to prove: %v", blocks, err)
+			}
+			if !verifyStoragePoc(proof.V0, root, c.Nonce) {
+				t.Errorf("%d blocks: v0 proof rejected: %s", blocks, proof.V0)
+			}
+			if proof.V1 != "" {
+				v1Proofs++
+				if !verifyStoragePocV1(proof.V1, root, c.Nonce) {
+					t.Errorf("%d blocks: v1 proof rejected: %s", blocks, proof.V1)
+				}
+			}
+		}
+	}
+	if v1Proofs == 0 {
+		t.Fatalf("no v1 proof generated")
+	}
+	// A tampered path is rejected
+	proof, _ := storageproof.Prove(storageproof.NewSparse(seed, 64), seed, plot)
+	fields := strings.Split(proof.V1, ",")
+	fields[len(fields)-2] = strings.Repeat("0", 40)
+	if verifyStoragePocV1(strings.Join(fields, ","), proof.Root.String(), plot.Nonce) {
+		t.Errorf("tampered v1 proof accepted")
+	}
+}
+
+// mutatePocField changes a single field of a proof string.
+func mutatePocField(field string) string {
+	if n, err := strconv.ParseUint(field, 10, 64); err == nil {
+		return strconv.FormatUint(n+1, 10)
+	}
+	if i := strings.IndexByte(field, '-'); i > 0 {
+		return field[:i] + "-1" + field[i+1:]
+	}
+	if strings.HasSuffix(field, "0") {
+		return field[:len(field)-1] + "1"
+	}
+	return field[:len(field)-1] + "0"
+}
+
+func TestPocFieldMutations(t *testing.T) {
+	device := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	plot := storageproof.Challenge{Number: 1200, Nonce: 42, Hash: common.HexToHash("0x1234")}
+	seed := storageproof.Seed(plot, device)
+	block, nonce, blockhash := "1200", "42", plot.Hash.Hex()
+
+	// The challenge fields only pick the sampled block, so the files are large
+	// enough for the mutated ones not to pick the same block again.
+	for _, blocks := range []uint64{64, 1001} {
+		src := storageproof.NewSparse(seed, blocks)
+		for i := uint64(0); i < 8; i++ {
+			c := storageproof.Challenge{Number: 1200 + i, Nonce: 42 + i, Hash: common.BigToHash(new(big.Int).SetUint64(i))}
+			if i == 0 {
+				c = plot
+			}
+			proof, err := storageproof.Prove(src, seed, c)
+			if err != nil {
+				t.Fatalf("%d blocks: failed to prove: %v", blocks, err)
+			}
+			root := proof.Root.String()
+			for _, pocstr := range []string{proof.V0, proof.V1} {
+				if pocstr == "" {
+					continue
+				}
+				fields := strings.Split(pocstr, ",")
+				v1 := fields[0] == "v1"
+				n, _ := strconv.ParseUint(fields[3], 10, 64)
+				if v1 {
+					n, _ = strconv.ParseUint(fields[4], 10, 64)
+				}
+				for j := range fields {
+					// The block size is only checked by the callers, the block count
+					// of v1 proofs only read if the sample wraps to it and the even
+					// block sibling of v0 proofs is repeated in the path it is checked in.
+					switch {
+					case !v1 && j == 4, v1 && (j == 5 || j == 6), !v1 && j == 9 && n&1 == 0:
+						continue
+					}
+					mutated := append([]string{}, fields...)
+					mutated[j] = mutatePocField(fields[j])
+					mutatedStr := strings.Join(mutated, ",")
+
+					declared := verifyPocString(block, nonce, blockhash, mutatedStr, root, device.Hex())
+					stored := verifyStoragePoc(mutatedStr, root, c.Nonce)
+					if v1 {
+						declared = verifyPocStringV1(block, nonce, blockhash, mutatedStr, root, device.Hex())
+						stored = verifyStoragePocV1(mutatedStr, root, c.Nonce)
+					}
+					if c == plot && declared {
+						t.Errorf("%d blocks: declaration proof with field %d mutated accepted: %s", blocks, j, mutatedStr)
+					}
+					if stored {
+						t.Errorf("%d blocks: storage proof with field %d mutated accepted: %s", blocks, j, mutatedStr)
+					}
+				}
+			}
+		}
+	}
+}
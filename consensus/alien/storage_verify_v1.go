@@ -137,6 +137,10 @@ func verifyPocV1(pocstr []string, h1, roothash string, start, r uint64) bool {
 		round int
 		hashpos int
 	)
+	if len(pocstr) == 0 {
+		log.Warn("verifyPocV1", "invalide poc data, len:", len(pocstr))
+		return false
+	}
 
 	r = r / 2
 	hashpos = int(r & 1)
@@ -176,6 +180,10 @@ func verifyPocV1(pocstr []string, h1, roothash string, start, r uint64) bool {
 }
 
 func verifySamplePosV1(n uint64, block, nonce, blockhash, fileblocknumber string, start, end uint64) bool {
+	if end-start+1 == 0 {
+		log.Warn("verifySamplePosV1", "invalide block range", start, "end", end)
+		return false
+	}
 	calPos := getSamplePosV1(block, nonce, blockhash, fileblocknumber, start, end)
 	calPos += start
 	if n != calPos {
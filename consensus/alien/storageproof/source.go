@@ -0,0 +1,116 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package storageproof
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Plot writes the given number of blocks of a capacity file plotted from seed.
+func Plot(w io.Writer, seed Block, blocks uint64) error {
+	bw := bufio.NewWriter(w)
+	prev := seed
+	for i := uint64(0); i < blocks; i++ {
+		prev = NextBlock(seed, prev, i)
+		if _, err := bw.Write(prev[:]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// File is a capacity file plotted on disk.
+type File struct {
+	f      *os.File
+	blocks uint64
+}
+
+// OpenFile opens a capacity file written by Plot. Trailing bytes short of a
+// whole block are ignored.
+func OpenFile(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &File{f: f, blocks: uint64(info.Size()) / BlockSize}, nil
+}
+
+// Blocks implements Source.
+func (f *File) Blocks() uint64 {
+	return f.blocks
+}
+
+// Block implements Source.
+func (f *File) Block(i uint64) (Block, error) {
+	var b Block
+	if i >= f.blocks {
+		return b, fmt.Errorf("block %d beyond file end %d", i, f.blocks)
+	}
+	if _, err := f.f.ReadAt(b[:], int64(i)*BlockSize); err != nil {
+		return b, err
+	}
+	return b, nil
+}
+
+// Close closes the underlying file.
+func (f *File) Close() error {
+	return f.f.Close()
+}
+
+// Sparse is a capacity file of which no block is stored, each one being plotted
+// again from the seed when read. Reading the blocks in order plots each of them
+// once.
+type Sparse struct {
+	seed   Block
+	blocks uint64
+
+	next uint64 // Index of the block following last
+	last Block  // Last block plotted, the seed before the first one
+}
+
+// NewSparse creates a capacity file of the given number of blocks plotted from
+// seed, without storing it.
+func NewSparse(seed Block, blocks uint64) *Sparse {
+	return &Sparse{seed: seed, blocks: blocks, last: seed}
+}
+
+// Blocks implements Source.
+func (s *Sparse) Blocks() uint64 {
+	return s.blocks
+}
+
+// Block implements Source.
+func (s *Sparse) Block(i uint64) (Block, error) {
+	if i >= s.blocks {
+		return Block{}, fmt.Errorf("block %d beyond file end %d", i, s.blocks)
+	}
+	if i < s.next {
+		s.next, s.last = 0, s.seed
+	}
+	for ; s.next <= i; s.next++ {
+		s.last = NextBlock(s.seed, s.last, s.next)
+	}
+	return s.last, nil
+}
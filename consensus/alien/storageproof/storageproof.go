@@ -0,0 +1,328 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package storageproof builds the proof of capacity strings checked by the alien
+// engine for storage pledges, storage proofs and lease pledges.
+//
+// A capacity file is a chain of 20 byte blocks plotted from a seed derived from
+// the block a storage pledge is declared at. Block i is the SHA1 of the seed,
+// block i-1 and i for even i, and the wrapping sum of them for odd i, with the
+// seed standing in for block -1. The root hash of a file is the root of a tree
+// over its blocks whose levels alternate between the same two functions, the
+// last node of a level holding an odd number of them being paired with itself.
+//
+// A proof answers the challenge of a block by opening the block its hash picks.
+// Version 0 proofs carry both nodes of every level of the path, version 1 proofs
+// only the siblings, for a range of the file.
+package storageproof
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+// BlockSize is the size in bytes of a block of a capacity file.
+const BlockSize = 20
+
+// RangeAlign is the number of blocks version 1 ranges are aligned to. Above
+// this many blocks the verifier walks the path relative to the range start.
+const RangeAlign = 1 << 20
+
+var (
+	// ErrTooFewBlocks is returned if a proof is requested over less than two blocks.
+	ErrTooFewBlocks = errors.New("at least two blocks needed")
+
+	// ErrUnalignedRange is returned if a version 1 range does not start at a
+	// multiple of RangeAlign.
+	ErrUnalignedRange = errors.New("range start not aligned")
+
+	// ErrSampleOutOfRange is returned if the challenge picks a block outside of
+	// the proven range, which version 1 proofs cannot answer.
+	ErrSampleOutOfRange = errors.New("sampled block out of range")
+)
+
+// Block is a block of a capacity file, or a node of its tree.
+type Block [BlockSize]byte
+
+// String returns the block in the hex form used by the proof strings.
+func (b Block) String() string {
+	return hex.EncodeToString(b[:])
+}
+
+// Challenge is the block a proof answers, or the one a capacity file is plotted
+// from.
+type Challenge struct {
+	Number uint64
+	Nonce  uint64
+	Hash   common.Hash
+}
+
+func (c Challenge) fields() []string {
+	return []string{strconv.FormatUint(c.Number, 10), strconv.FormatUint(c.Nonce, 10), c.Hash.Hex()}
+}
+
+// Seed returns the seed a capacity file of the given device is plotted from, for
+// a pledge declared with the plot challenge.
+func Seed(plot Challenge, device common.Address) Block {
+	return Block(sha1.Sum([]byte(strings.Join(plot.fields(), "") + device.Hex())))
+}
+
+// hashBlocks is the SHA1 of both blocks, followed by pos in little endian if set.
+func hashBlocks(b1, b2 Block, pos *uint64) Block {
+	data := append(append(make([]byte, 0, 2*BlockSize+8), b1[:]...), b2[:]...)
+	if pos != nil {
+		var enc [8]byte
+		binary.LittleEndian.PutUint64(enc[:], *pos)
+		data = append(data, enc[:]...)
+	}
+	return Block(sha1.Sum(data))
+}
+
+// accBlocks is the wrapping sum of both blocks, as two little endian words
+// incremented by n and four bytes.
+func accBlocks(b1, b2 Block, n uint64) Block {
+	var acc Block
+	for i := 0; i < 16; i += 8 {
+		sum := binary.LittleEndian.Uint64(b1[i:]) + binary.LittleEndian.Uint64(b2[i:]) + n
+		binary.LittleEndian.PutUint64(acc[i:], sum)
+	}
+	for i := 16; i < BlockSize; i++ {
+		acc[i] = b1[i] + b2[i]
+	}
+	return acc
+}
+
+// NextBlock returns block i of a capacity file plotted from seed, given block
+// i-1, or the seed itself for the first block.
+func NextBlock(seed, prev Block, i uint64) Block {
+	if i&1 == 0 {
+		return hashBlocks(seed, prev, &i)
+	}
+	return accBlocks(seed, prev, i)
+}
+
+// node returns the parent of two nodes of the given tree level.
+func node(level int, left, right Block) Block {
+	if level&1 == 0 {
+		return hashBlocks(left, right, nil)
+	}
+	return accBlocks(left, right, 0)
+}
+
+// Source gives access to the blocks of a capacity file.
+type Source interface {
+	// Blocks returns the number of blocks of the file.
+	Blocks() uint64
+
+	// Block returns block i of the file. Blocks are mostly read in order.
+	Block(i uint64) (Block, error)
+}
+
+// tree is the path of a block in the tree over a range of a capacity file.
+type tree struct {
+	root  Block
+	pairs [][2]Block // Both nodes of every level of the path, from the blocks up
+}
+
+// buildTree reads the blocks in [start, end] and computes the root of their tree
+// along with the path of the block at index target of the range.
+func buildTree(src Source, start, end, target uint64) (*tree, error) {
+	count := end - start + 1
+	depth := 0
+	for width := count; width > 1; width = (width + 1) / 2 {
+		depth++
+	}
+	var (
+		pending = make([]*Block, depth+1)
+		pairs   = make([][2]Block, depth)
+		index   = make([]uint64, depth+1) // Number of nodes seen at each level
+	)
+	var push func(level int, b Block)
+	push = func(level int, b Block) {
+		idx := index[level]
+		index[level]++
+		if level == depth || idx&1 == 0 {
+			pending[level] = &b
+			return
+		}
+		left := *pending[level]
+		pending[level] = nil
+		if idx>>1 == target>>(uint(level)+1) {
+			pairs[level] = [2]Block{left, b}
+		}
+		push(level+1, node(level, left, b))
+	}
+	for i := start; i <= end; i++ {
+		b, err := src.Block(i)
+		if err != nil {
+			return nil, err
+		}
+		push(0, b)
+	}
+	// Pair the last node of the levels holding an odd number of them with itself
+	for level := 0; level < depth; level++ {
+		if pending[level] != nil {
+			push(level, *pending[level])
+		}
+	}
+	return &tree{root: *pending[depth], pairs: pairs}, nil
+}
+
+// Root computes the root hash of the blocks in [start, end] of a capacity file.
+func Root(src Source, start, end uint64) (Block, error) {
+	if end >= src.Blocks() || end <= start {
+		return Block{}, fmt.Errorf("%w in [%d, %d] of %d", ErrTooFewBlocks, start, end, src.Blocks())
+	}
+	t, err := buildTree(src, start, end, 0)
+	if err != nil {
+		return Block{}, err
+	}
+	return t.root, nil
+}
+
+// sample returns the number the challenge hash picks modulo m.
+func sample(c Challenge, m uint64) uint64 {
+	h := sha1.Sum([]byte(strings.Join(c.fields(), "")))
+	return new(big.Int).Mod(new(big.Int).SetBytes(h[:]), new(big.Int).SetUint64(m)).Uint64()
+}
+
+// opening is the block picked by a challenge with its neighbours.
+type opening struct {
+	n          uint64
+	prev, self Block
+}
+
+func open(src Source, seed Block, n uint64) (*opening, error) {
+	o := &opening{n: n, prev: seed}
+	if n > 0 {
+		prev, err := src.Block(n - 1)
+		if err != nil {
+			return nil, err
+		}
+		o.prev = prev
+	}
+	self, err := src.Block(n)
+	if err != nil {
+		return nil, err
+	}
+	o.self = self
+	return o, nil
+}
+
+// Proof is a proof of capacity answering a challenge.
+type Proof struct {
+	Root Block  // Root hash of the proven blocks
+	V0   string // Version 0 proof over the whole file
+	V1   string // Version 1 proof over the whole file, empty if the challenge picked no block of it
+}
+
+// Prove answers the challenge with the proofs of both versions over a whole
+// capacity file plotted from seed. The challenge is the plot one for the proof
+// of a storage pledge declaration.
+func Prove(src Source, seed Block, c Challenge) (*Proof, error) {
+	v0, root, err := ProveV0(src, seed, c)
+	if err != nil {
+		return nil, err
+	}
+	proof := &Proof{Root: root, V0: v0}
+	v1, _, err := ProveV1(src, seed, c, 0, src.Blocks()-1)
+	switch {
+	case err == nil:
+		proof.V1 = v1
+	case !errors.Is(err, ErrSampleOutOfRange):
+		return nil, err
+	}
+	return proof, nil
+}
+
+// ProveV0 answers the challenge with a version 0 proof over a whole capacity
+// file plotted from seed, returning it along with the root hash of the file.
+func ProveV0(src Source, seed Block, c Challenge) (string, Block, error) {
+	blocks := src.Blocks()
+	if blocks < 2 {
+		return "", Block{}, ErrTooFewBlocks
+	}
+	o, err := open(src, seed, sample(c, blocks))
+	if err != nil {
+		return "", Block{}, err
+	}
+	t, err := buildTree(src, 0, blocks-1, o.n)
+	if err != nil {
+		return "", Block{}, err
+	}
+	fields := append(c.fields(), strconv.FormatUint(o.n, 10), strconv.Itoa(BlockSize), strconv.FormatUint(blocks, 10),
+		seed.String(), o.prev.String(), o.self.String())
+	if o.n&1 == 0 {
+		fields = append(fields, t.pairs[0][1].String())
+	}
+	for _, pair := range t.pairs {
+		fields = append(fields, pair[0].String(), pair[1].String())
+	}
+	fields = append(fields, t.root.String())
+	return strings.Join(fields, ","), t.root, nil
+}
+
+// ProveV1 answers the challenge with a version 1 proof over the blocks in
+// [start, end] of a capacity file plotted from seed, returning it along with
+// the root hash of the range.
+func ProveV1(src Source, seed Block, c Challenge, start, end uint64) (string, Block, error) {
+	blocks := src.Blocks()
+	if end >= blocks || end <= start {
+		return "", Block{}, fmt.Errorf("%w in [%d, %d] of %d", ErrTooFewBlocks, start, end, blocks)
+	}
+	if start%RangeAlign != 0 {
+		return "", Block{}, fmt.Errorf("%w: %d", ErrUnalignedRange, start)
+	}
+	n := sample(c, end-start+1)
+	if n == 0 {
+		n = blocks
+	}
+	n += start
+	if n > end {
+		return "", Block{}, fmt.Errorf("%w: %d not in [%d, %d]", ErrSampleOutOfRange, n, start, end)
+	}
+	o, err := open(src, seed, n)
+	if err != nil {
+		return "", Block{}, err
+	}
+	t, err := buildTree(src, start, end, n-start)
+	if err != nil {
+		return "", Block{}, err
+	}
+	fields := append([]string{"v1"}, c.fields()...)
+	fields = append(fields, strconv.FormatUint(n, 10), strconv.Itoa(BlockSize), strconv.FormatUint(blocks, 10),
+		fmt.Sprintf("%d-%d", start, end), seed.String(), o.prev.String(), o.self.String())
+	if n&1 == 0 {
+		fields = append(fields, t.pairs[0][1].String())
+	}
+	for level := 1; level < len(t.pairs); level++ {
+		sibling := t.pairs[level][0]
+		if (n-start)>>uint(level)&1 == 0 {
+			sibling = t.pairs[level][1]
+		}
+		fields = append(fields, sibling.String())
+	}
+	fields = append(fields, t.root.String())
+	return strings.Join(fields, ","), t.root, nil
+}
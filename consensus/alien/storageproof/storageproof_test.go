@@ -0,0 +1,81 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package storageproof
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+)
+
+func TestPlottedAndSparseFiles(t *testing.T) {
+	seed := Seed(Challenge{Number: 10, Nonce: 7, Hash: common.HexToHash("0x01")}, common.HexToAddress("0x02"))
+	path := filepath.Join(t.TempDir(), "capacity")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Plot(out, seed, 37); err != nil {
+		t.Fatalf("failed to plot: %v", err)
+	}
+	out.Close()
+
+	file, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open capacity file: %v", err)
+	}
+	defer file.Close()
+	sparse := NewSparse(seed, 37)
+	if file.Blocks() != sparse.Blocks() {
+		t.Fatalf("block count mismatch: have %d, want %d", file.Blocks(), sparse.Blocks())
+	}
+	for _, i := range []uint64{0, 1, 36, 5, 6} {
+		have, err := file.Block(i)
+		if err != nil {
+			t.Fatalf("failed to read block %d: %v", i, err)
+		}
+		if want, _ := sparse.Block(i); have != want {
+			t.Errorf("block %d mismatch: have %v, want %v", i, have, want)
+		}
+	}
+	if _, err := sparse.Block(37); err == nil {
+		t.Errorf("block beyond the file end read")
+	}
+	fileRoot, _ := Root(file, 0, 36)
+	sparseRoot, _ := Root(sparse, 0, 36)
+	if fileRoot != sparseRoot {
+		t.Errorf("root mismatch: have %v, want %v", fileRoot, sparseRoot)
+	}
+
+	c := Challenge{Number: 20, Nonce: 3, Hash: common.HexToHash("0x03")}
+	proof, err := Prove(file, seed, c)
+	if err != nil {
+		t.Fatalf("failed to prove: %v", err)
+	}
+	if proof.Root != fileRoot {
+		t.Errorf("proof root mismatch: have %v, want %v", proof.Root, fileRoot)
+	}
+	if _, _, err := ProveV1(sparse, seed, c, 1, 36); !errors.Is(err, ErrUnalignedRange) {
+		t.Errorf("unaligned range: have %v, want %v", err, ErrUnalignedRange)
+	}
+	if _, _, err := ProveV0(NewSparse(seed, 1), seed, c); err != ErrTooFewBlocks {
+		t.Errorf("single block file: have %v, want %v", err, ErrTooFewBlocks)
+	}
+}
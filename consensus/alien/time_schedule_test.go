@@ -0,0 +1,145 @@
+package alien
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+// resetSchedule drops the tables applied to the process by the engines and
+// inspectors of a test, restoring the mainnet ones.
+func resetSchedule() {
+	scheduleLock.Lock()
+	defer scheduleLock.Unlock()
+
+	scheduleForks, scheduleTimes = nil, nil
+	setForkSchedule(nil)
+	setTimeSchedule(nil)
+}
+
+// Tests that the first config applies its tables to the process, and that
+// configs running on other tables are rejected afterwards.
+func TestApplySchedule(t *testing.T) {
+	t.Cleanup(resetSchedule)
+
+	config := &params.AlienConfig{Forks: params.GenesisAlienForks(), Times: &params.AlienTimes{Scale: 60}}
+	if err := applySchedule(config); err != nil {
+		t.Fatalf("failed to apply schedule: %v", err)
+	}
+	if signFixBlockNumber != 0 || secondsPerDay != 1440 {
+		t.Fatalf("tables not applied: sign fix at %d, day %d", signFixBlockNumber, secondsPerDay)
+	}
+	// The same tables, spelled out in full, are accepted
+	same := &params.AlienConfig{Forks: params.GenesisAlienForks().WithDefaults(), Times: config.Times.WithDefaults()}
+	if err := applySchedule(same); err != nil {
+		t.Errorf("same tables rejected: %v", err)
+	}
+	for _, other := range []*params.AlienConfig{
+		{Times: config.Times},
+		{Forks: config.Forks},
+		{Forks: config.Forks, Times: &params.AlienTimes{Scale: 30}},
+	} {
+		if err := applySchedule(other); err != errScheduleConflict {
+			t.Errorf("other tables: have %v, want %v", err, errScheduleConflict)
+		}
+	}
+	if signFixBlockNumber != 0 || secondsPerDay != 1440 {
+		t.Errorf("tables overwritten: sign fix at %d, day %d", signFixBlockNumber, secondsPerDay)
+	}
+}
+
+func TestTimeSchedule(t *testing.T) {
+	defer setTimeSchedule(nil)
+
+	if secondsPerDay != 24*60*60 || utgLockRewardInterval != 30 {
+		t.Fatalf("default schedule not mainnet: day %d, lock round %d days", secondsPerDay, utgLockRewardInterval)
+	}
+	setTimeSchedule(&params.AlienTimes{Day: 600, StorageVerification: 60, LockRewardDays: 2})
+	if secondsPerDay != 600 || storageVerificationCheck != 60 || utgLockRewardInterval != 2 {
+		t.Errorf("configured spans not applied: %d %d %d", secondsPerDay, storageVerificationCheck, utgLockRewardInterval)
+	}
+	if payFlowRewardInterval != params.MainnetAlienTimes.PayFlowReward {
+		t.Errorf("unset span not mainnet: have %d, want %d", payFlowRewardInterval, params.MainnetAlienTimes.PayFlowReward)
+	}
+
+	// Scaled time shortens the days and the offsets into them alike
+	setTimeSchedule(&params.AlienTimes{Scale: 60})
+	if secondsPerDay != 1440 || storageVerificationCheck != 60 || utgLockRewardInterval != 30 {
+		t.Fatalf("spans not scaled: day %d, verification %d, lock round %d days", secondsPerDay, storageVerificationCheck, utgLockRewardInterval)
+	}
+	for day := uint64(1); day < 4; day++ {
+		number := day*1440/2 + 60/2
+		if !isStorageVerificationCheck(number, 2) || isStorageVerificationCheck(number+1, 2) {
+			t.Errorf("storage verification of day %d not at block %d", day, number)
+		}
+	}
+	// Snapshots missing lock parameters fill them in on the scaled spans
+	snap := newCodecTestSnapshot()
+	snap.Period = 2
+	snap.StorageData = NewStorageSnap()
+	snap.SystemConfig.LockParameters = make(map[uint32]*LockParameter)
+	cpy := snap.copy()
+	for who, want := range map[uint32]LockParameter{
+		sscEnumCndLock: {LockPeriod: 180 * 1440 / 2},
+		sscEnumFlwLock: {LockPeriod: 180 * 1440 / 2},
+		sscEnumRwdLock: {LockPeriod: 30 * 1440 / 2, RlsPeriod: 180 * 1440 / 2, Interval: 1440 / 2},
+	} {
+		if have := cpy.SystemConfig.LockParameters[who]; have == nil || *have != want {
+			t.Errorf("lock parameters %d: have %+v, want %+v", who, have, want)
+		}
+	}
+	setForkSchedule(params.GenesisAlienForks())
+	defer setForkSchedule(nil)
+	if !isPaySignerRewards(30*1440, 1) || isPaySignerRewards(1440, 1) {
+		t.Errorf("signer rewards not paid once per lock round")
+	}
+}
+
+// Tests that a chain running in scaled time releases the locked rewards of its
+// signer within a few lock rounds, on the block of the scaled day the lock trie
+// releases the signer on.
+func TestScaledTimeRewardRelease(t *testing.T) {
+	start := uint64(time.Now().Add(-240 * time.Hour).Unix())
+	clock := &simClock{now: time.Unix(int64(start), 0)}
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	config := &params.AlienConfig{
+		Period:           1,
+		Epoch:            defaultEpochLength,
+		MaxSignerCount:   1,
+		MinVoterBalance:  big.NewInt(0),
+		GenesisTimestamp: start + 1,
+		SelfVoteSigners:  []common.UnprefixedAddress{common.UnprefixedAddress(signer)},
+		Forks:            params.GenesisAlienForks(),
+		Times:            &params.AlienTimes{Scale: 2880},
+	}
+	chain := newSimChain(t, clock, 1337, config, common.Hash{}, core.GenesisAlloc{signer: {Balance: simBalance}}, nil)
+	chain.keys[signer] = key
+
+	// Days last 30 blocks, so a lock round takes 900
+	round := utgLockRewardInterval * secondsPerDay / config.Period
+	if round != 900 {
+		t.Fatalf("lock round of %d blocks, want 900", round)
+	}
+	day := newLockTrieDay(config.Period)
+	if day.blocks != 30 || day.payStart+day.payEnd >= day.blocks {
+		t.Fatalf("lock trie day of %d blocks releasing from %d to %d, want 30", day.blocks, day.payStart, day.blocks-day.payEnd)
+	}
+	release := day.accountReleaseNumber(generateKey(signer, sscEnumSignerReward))
+	for number := uint64(1); number <= 4*round; number++ {
+		block := chain.mine()
+		clock.advance(block.Time())
+		if chain.balance(signer).Cmp(simBalance) > 0 {
+			if day.releaseNumber(number) != release {
+				t.Fatalf("rewards released at block %d, out of the release block %d of the day", number, release)
+			}
+			return
+		}
+	}
+	t.Fatalf("rewards not released within %d blocks", 4*round)
+}
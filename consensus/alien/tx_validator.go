@@ -0,0 +1,163 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package alien
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/customtx"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+)
+
+var (
+	// errInvalidCustomTx is returned if a custom transaction payload cannot be decoded.
+	errInvalidCustomTx = errors.New("invalid custom transaction")
+
+	// errNotManager is returned if a system config transaction is not sent by
+	// the manager address of its category.
+	errNotManager = errors.New("sender is not the manager address")
+
+	// errInsufficientSRT is returned if a lease request exceeds the SRT balance of the tenant.
+	errInsufficientSRT = errors.New("insufficient SRT for lease request")
+)
+
+// TxValidator rejects alien custom transactions at transaction pool admission
+// time which processCustomTx would drop after the sender already paid gas. It
+// implements core.TxValidator.
+type TxValidator struct {
+	alien *Alien
+	chain consensus.ChainHeaderReader
+
+	lock   sync.RWMutex
+	snap   *Snapshot // Snapshot at the head of the pool, nil if not available
+	number uint64    // Number of the head of the pool
+}
+
+// TxValidator returns a transaction pool admission check validating custom
+// transactions against the head of the given chain.
+func (a *Alien) TxValidator(chain consensus.ChainHeaderReader) *TxValidator {
+	return &TxValidator{alien: a, chain: chain}
+}
+
+// Reset caches the snapshot at the new head of the transaction pool, which the
+// checks of the transactions admitted until the next head run against.
+func (v *TxValidator) Reset(head *types.Header) {
+	var snap *Snapshot
+	if v.chain != nil && head != nil {
+		var err error
+		if snap, err = v.alien.snapshot(v.chain, head.Number.Uint64(), head.Hash(), nil, nil, defaultLoopCntRecalculateSigners); err != nil {
+			log.Debug("Custom tx validation skipped", "number", head.Number, "err", err)
+		}
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.snap = snap
+	if head != nil {
+		v.number = head.Number.Uint64()
+	}
+}
+
+// ValidateTx checks the payload of a custom transaction, only rejecting what the
+// engine would reject on execution. Transactions which are not custom
+// transactions, or whose category the engine does not know, pass.
+func (v *TxValidator) ValidateTx(tx *types.Transaction, from common.Address) error {
+	if !customtx.IsCustomTx(tx.Data()) {
+		return nil
+	}
+	payload, err := customtx.Parse(tx.Data())
+	if err != nil {
+		if errors.Is(err, customtx.ErrNotCustomTx) || errors.Is(err, customtx.ErrUnknownCategory) {
+			return nil
+		}
+		// The engine runs the ufo payloads it cannot process as plain transactions
+		if customtx.Split(tx.Data())[0] == customtx.PrefixUFO {
+			return nil
+		}
+		return fmt.Errorf("%w: %v", errInvalidCustomTx, err)
+	}
+	if payload.Header().Prefix == customtx.PrefixSSC {
+		return v.validateManager(payload, from)
+	}
+	if rent, ok := payload.(*customtx.RentRequest); ok {
+		return v.validateRentRequest(rent, from)
+	}
+	return nil
+}
+
+// headSnapshot returns the snapshot cached at the head of the pool, or nil if
+// it is not available.
+func (v *TxValidator) headSnapshot() (*Snapshot, uint64) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.snap, v.number
+}
+
+// validateManager checks the sender of a system config transaction the same way
+// the process*Config functions do.
+func (v *TxValidator) validateManager(payload customtx.Payload, from common.Address) error {
+	category := payload.Header().Category
+	if category == customtx.CategoryManager {
+		if from != managerAddressManager {
+			return fmt.Errorf("%w: %s requires %s", errNotManager, category, managerAddressManager.Hex())
+		}
+		return nil
+	}
+	var who uint32
+	switch category {
+	case customtx.CategoryExchRate:
+		who = sscEnumExchRate
+	case customtx.CategoryWdthPnsh:
+		who = sscEnumWdthPnsh
+	default:
+		who = sscEnumSystem
+	}
+	snap, _ := v.headSnapshot()
+	if snap == nil {
+		return nil
+	}
+	if manager := snap.SystemConfig.ManagerAddress[who]; from != manager {
+		return fmt.Errorf("%w: %s requires %s", errNotManager, category, manager.Hex())
+	}
+	return nil
+}
+
+// validateRentRequest checks that the tenant holds enough SRT to pay for the lease.
+func (v *TxValidator) validateRentRequest(rent *customtx.RentRequest, from common.Address) error {
+	snap, number := v.headSnapshot()
+	if snap == nil || snap.SRT == nil || number+1 <= StorageEffectBlockNumber {
+		return nil
+	}
+	request := LeaseRequestRecord{
+		Tenant:   from,
+		Address:  rent.Pledge,
+		Capacity: rent.Capacity,
+		Duration: new(big.Int).SetUint64(rent.Duration),
+		Price:    rent.Price,
+	}
+	if !snap.checkEnoughSRT(nil, request, number, v.alien.db) {
+		return fmt.Errorf("%w: tenant %s", errInsufficientSRT, from.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package alien
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/customtx"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+	"github.com/UltronGlow/UltronGlow-Origin/params"
+)
+
+func TestTxValidator_ValidateTx(t *testing.T) {
+	sender := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	tests := []struct {
+		data string
+		from common.Address
+		err  error
+	}{
+		{"", sender, nil},
+		{"hello world", sender, nil},
+		{"UTG:1:Unknown:0x00", sender, nil},
+		{"UTG:1:CandReq:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", sender, nil},
+		{"UTG:1:CandReq", sender, errInvalidCustomTx},
+		{"UTG:1:CandReq:0x7f2a5e7e", sender, errInvalidCustomTx},
+		{"UTG:1:Exch:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", sender, errInvalidCustomTx},
+		{"SSC:1:Manager:1:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", sender, errNotManager},
+		{"SSC:1:Manager:1:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", managerAddressManager, nil},
+		{"SSC:1:Manager:x:0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9", managerAddressManager, errInvalidCustomTx},
+		{"ufo:1:sc:setcb", sender, nil},
+		{"ufo:1:event:declare:hash:x:decision:no", sender, nil},
+	}
+	// Without a chain there is no snapshot to check the senders against
+	validator := (&Alien{}).TxValidator(nil)
+	validator.Reset(&types.Header{Number: big.NewInt(1)})
+	for i, tt := range tests {
+		tx := types.NewTransaction(0, sender, big.NewInt(0), 100000, big.NewInt(1), []byte(tt.data))
+		err := validator.ValidateTx(tx, tt.from)
+		if tt.err == nil && err != nil {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if tt.err != nil && !errors.Is(err, tt.err) {
+			t.Errorf("test %d: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+// Tests the checks needing the head snapshot against the snapshot of a chain,
+// cached when the validator is reset to the head.
+func TestTxValidatorSnapshot(t *testing.T) {
+	chain, key := newTxValidatorTestChain(t)
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	tenant := common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+	exch := types.NewTransaction(0, tenant, big.NewInt(0), 100000, big.NewInt(1), []byte("SSC:1:ExchRate:1000"))
+	rent := types.NewTransaction(0, tenant, big.NewInt(0), 100000, big.NewInt(1), []byte(fmt.Sprintf("UTG:1:stRent:%s:%d:30:1000", signer.Hex(), gbTob)))
+
+	// Until reset to a head, the validator has no snapshot to check against
+	validator := chain.engine.TxValidator(chain.chain)
+	if err := validator.ValidateTx(exch, tenant); err != nil {
+		t.Fatalf("sender checked without a snapshot: %v", err)
+	}
+	validator.Reset(chain.chain.CurrentHeader())
+	if snap, number := validator.headSnapshot(); snap == nil || snap.SRT == nil || number != 3 {
+		t.Fatalf("head snapshot not cached at 3: have %d", number)
+	}
+	if err := validator.ValidateTx(exch, tenant); !errors.Is(err, errNotManager) {
+		t.Errorf("exchange rate by other sender: have %v, want %v", err, errNotManager)
+	}
+	if err := validator.ValidateTx(exch, managerAddressExchRate); err != nil {
+		t.Errorf("exchange rate by manager rejected: %v", err)
+	}
+	if err := validator.ValidateTx(rent, tenant); !errors.Is(err, errInsufficientSRT) {
+		t.Errorf("lease request without SRT: have %v, want %v", err, errInsufficientSRT)
+	}
+	snap := validator.snap.copy()
+	snap.SRT.Add(tenant, big.NewInt(30*1000))
+	validator.snap = snap
+	if err := validator.ValidateTx(rent, tenant); err != nil {
+		t.Errorf("lease request paid in SRT rejected: %v", err)
+	}
+}
+
+// newTxValidatorTestChain creates a chain of three blocks sealed by a single
+// signer with all the forks active, and returns it with the key of the signer.
+func newTxValidatorTestChain(t *testing.T) (*simChain, *ecdsa.PrivateKey) {
+	start := uint64(time.Now().Add(-time.Hour).Unix())
+	clock := &simClock{now: time.Unix(int64(start), 0)}
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+	config := &params.AlienConfig{
+		Period:           1,
+		Epoch:            defaultEpochLength,
+		MaxSignerCount:   1,
+		MinVoterBalance:  big.NewInt(0),
+		GenesisTimestamp: start + 1,
+		SelfVoteSigners:  []common.UnprefixedAddress{common.UnprefixedAddress(signer)},
+		Forks:            params.GenesisAlienForks(),
+	}
+	chain := newSimChain(t, clock, 1337, config, common.Hash{}, core.GenesisAlloc{signer: {Balance: simBalance}}, nil)
+	chain.keys[signer] = key
+	for i := 0; i < 3; i++ {
+		clock.advance(chain.mine().Time())
+	}
+	return chain, key
+}
+
+// customTxOutcome runs a custom transaction sent by key through processCustomTx
+// on top of the head of the chain, and returns the outcome the engine records.
+func customTxOutcome(t *testing.T, chain *simChain, key *ecdsa.PrivateKey, data string) (uint64, CustomTxReason, bool) {
+	head := chain.chain.CurrentHeader()
+	statedb, err := chain.chain.StateAt(head.Root)
+	if err != nil {
+		t.Fatalf("failed to load head state: %v", err)
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := types.SignTx(types.NewTransaction(0, sender, big.NewInt(0), 1000000, big.NewInt(1), []byte(data)), types.NewEIP155Signer(chain.config.ChainID), key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number, big.NewInt(1)),
+		Time:       head.Time + 1,
+		Coinbase:   sender,
+	}
+	receipt := &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful, BlockNumber: header.Number}
+	if _, _, err := chain.engine.processCustomTx(HeaderExtra{}, chain.chain, header, statedb, types.Transactions{tx}, []*types.Receipt{receipt}); err != nil {
+		t.Fatalf("failed to process %q: %v", data, err)
+	}
+	return findCustomTxResult(receipt)
+}
+
+// customTxCorpus returns valid payloads of most categories along with their
+// variants missing the last field, carrying an extra field and with each field
+// replaced by garbage or left empty.
+func customTxCorpus() []string {
+	var (
+		addr1 = common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+		addr2 = common.HexToAddress("0x3a1c7b6e9d2f4e5a8b0c1d2e3f405162738495a6")
+		addr3 = common.HexToAddress("0x5b38da6a701c568545dcfcb03fcb875f56beddc4")
+		hash  = common.HexToHash("0x3210000000000000000000000000000000000000000000000000000000000000")
+	)
+	payloads := []customtx.Payload{
+		&customtx.Vote{},
+		&customtx.Confirm{BlockNumber: big.NewInt(1)},
+		&customtx.Declare{ProposalHash: hash},
+		&customtx.SCConfirm{SCHash: hash, Number: big.NewInt(10), Time: big.NewInt(1600000000), LoopInfo: "a", ChargingInfo: "b"},
+		&customtx.SCSetCoinbase{SCHash: hash},
+		&customtx.SCDelCoinbase{SCHash: hash},
+		&customtx.Exch{Target: addr1, Amount: big.NewInt(1e18)},
+		&customtx.MultiSign{Threshold: 2, Signers: []common.Address{addr1, addr2, addr3}},
+		&customtx.Bind{Device: addr1, RevenueType: 1, Contract: addr2, Revenue: addr2},
+		&customtx.Unbind{Device: addr1, RevenueType: 1},
+		&customtx.Rebind{Device: addr1, RevenueType: 0, Revenue: addr2},
+		&customtx.FlwReq{Target: addr1, ISPQosID: 3, Bandwidth: 100},
+		&customtx.CandEntrustExit{Target: addr1, Hash: hash},
+		&customtx.CandPoSTransfer{Original: addr1, Transfer: customtx.TransferTarget{TargetType: customtx.TargetTypeSN, Address: addr2}},
+		&customtx.StorageProof{Pledge: addr1, Capacity: "1024", Proof: "x,y"},
+		&customtx.SPApply{PledgeAmount: big.NewInt(5000), Fee: 10, EntrustRate: 50, Revenue: addr2},
+		&customtx.SPRevenueBind{PoolHash: hash, Bind: true, Revenue: addr1},
+		&customtx.Deposit{Amount: big.NewInt(300), Who: 2},
+		&customtx.LockConfig{Kind: customtx.CategoryRwdLock, LockPeriod: 30, RlsPeriod: 180, Interval: 1},
+		&customtx.WdthPnsh{Target: addr1, Punish: 0x20},
+		&customtx.Manager{Who: 3, Address: addr2},
+	}
+	var corpus []string
+	for _, p := range payloads {
+		enc := string(p.Encode())
+		fields := strings.Split(enc, customtx.Separator)
+		corpus = append(corpus, enc, strings.Join(fields[:len(fields)-1], customtx.Separator), enc+customtx.Separator+"extra")
+		for i := 3; i < len(fields); i++ {
+			for _, garbage := range []string{"x", ""} {
+				mutated := append([]string{}, fields...)
+				mutated[i] = garbage
+				corpus = append(corpus, strings.Join(mutated, customtx.Separator))
+			}
+		}
+	}
+	for _, h := range customtx.Registered() {
+		corpus = append(corpus, h.String())
+	}
+	return corpus
+}
+
+// Tests that the validator only refuses the custom transactions the engine
+// rejects on execution, so that no transaction valid on chain is kept out of
+// the pool.
+func TestTxValidatorMatchesEngine(t *testing.T) {
+	chain, key := newTxValidatorTestChain(t)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	validator := chain.engine.TxValidator(chain.chain)
+	validator.Reset(chain.chain.CurrentHeader())
+	for _, data := range customTxCorpus() {
+		tx := types.NewTransaction(0, sender, big.NewInt(0), 1000000, big.NewInt(1), []byte(data))
+		err := validator.ValidateTx(tx, sender)
+		if err == nil {
+			continue
+		}
+		if status, reason, found := customTxOutcome(t, chain, key, data); !found || status != CustomTxRejected {
+			t.Errorf("%q refused by the validator (%v) but processed by the engine: found %v, status %d, reason %s", data, err, found, status, reason)
+		}
+	}
+}
+
+// Tests that customtx.Parse accepts the payloads the engine processes and
+// rejects those the engine refuses as malformed or ignores. The engine checks
+// the sender and the state of some categories before their fields, and range
+// checks some fields against the chain, so its other refusals do not tell
+// whether the payload is well formed.
+func TestCustomTxParseMatchesEngine(t *testing.T) {
+	chain, key := newTxValidatorTestChain(t)
+
+	for _, data := range customTxCorpus() {
+		_, err := customtx.Parse([]byte(data))
+		status, reason, found := customTxOutcome(t, chain, key, data)
+		switch {
+		case !found:
+			if err == nil {
+				t.Errorf("%q ignored by the engine but parsed", data)
+			}
+		case status == CustomTxAccepted:
+			if err != nil {
+				t.Errorf("%q processed by the engine but not parsed: %v", data, err)
+			}
+		case reason == CustomTxReasonFieldCount || reason == CustomTxReasonInvalidField:
+			if err == nil {
+				t.Errorf("%q refused by the engine (%s) but parsed", data, reason)
+			}
+		}
+	}
+}
@@ -62,331 +62,225 @@ const (
 	see_s      = "SEExit"
 	post_s     = "POSTransfer"
 	SpBind_s   ="SpBind"
+	dsp_s      = "DoubleSignPunish"
 )
 
-func verifyHeaderExtern(currentExtra *HeaderExtra, verifyExtra *HeaderExtra) error {
-
-	//ExchangeNFC               []ExchangeNFCRecord
-	err := verifyExchangeNFC(currentExtra.ExchangeNFC, verifyExtra.ExchangeNFC)
-	if err != nil {
-		return err
-	}
-	//LockReward                []LockRewardRecord
-	err = verifyLockReward(currentExtra.LockReward, verifyExtra.LockReward)
-	if err != nil {
-		return err
-	}
-
-	//DeviceBind                []DeviceBindRecord
-	err = verifyDeviceBind(currentExtra.DeviceBind, verifyExtra.DeviceBind)
-	if err != nil {
-		return err
-	}
-
-	//CandidatePledge           []CandidatePledgeRecord
-	err = verifyCandidatePledge(currentExtra.CandidatePledge, verifyExtra.CandidatePledge)
-	if err != nil {
-		return err
-	}
-	//CandidatePunish           []CandidatePunishRecord
-	err = verifyCandidatePunish(currentExtra.CandidatePunish, verifyExtra.CandidatePunish)
-	if err != nil {
-		return err
-	}
-	//MinerStake                []MinerStakeRecord
-	err = verifyMinerStake(currentExtra.MinerStake, verifyExtra.MinerStake)
-	if err != nil {
-		return err
-	}
-
-	//CandidateExit             []common.Address
-	err = verifyExit(currentExtra.CandidateExit, verifyExtra.CandidateExit, "CandidateExit")
-	if err != nil {
-		return err
-	}
-
-	//ClaimedBandwidth          []ClaimedBandwidthRecord
-	err = verifyClaimedBandwidth(currentExtra.ClaimedBandwidth, verifyExtra.ClaimedBandwidth)
-	if err != nil {
-		return err
-	}
-
-	//FlowMinerExit             []common.Address
-	err = verifyExit(currentExtra.FlowMinerExit, verifyExtra.FlowMinerExit, "FlowMinerExit")
-	if err != nil {
-		return err
-	}
-
-	//BandwidthPunish           []BandwidthPunishRecord
-	err = verifyBandwidthPunish(currentExtra.BandwidthPunish, verifyExtra.BandwidthPunish)
-	if err != nil {
-		return err
-	}
-
-	//ConfigExchRate            uint32
-	err = verifyUint32Config(currentExtra.ConfigExchRate, verifyExtra.ConfigExchRate, "ConfigExchRate")
-	if err != nil {
-		return err
-	}
-	//ConfigOffLine             uint32
-	err = verifyUint32Config(currentExtra.ConfigOffLine, verifyExtra.ConfigOffLine, "ConfigOffLine")
-	if err != nil {
-		return err
-	}
-
-	//ConfigDeposit             []ConfigDepositRecord
-	err = verifyConfigDeposit(currentExtra.ConfigDeposit, verifyExtra.ConfigDeposit)
-	if err != nil {
-		return err
-	}
-
-	//ConfigISPQOS              []ISPQOSRecord
-	err = verifyConfigISPQOS(currentExtra.ConfigISPQOS, verifyExtra.ConfigISPQOS)
-	if err != nil {
-		return err
-	}
-
-	//LockParameters            []LockParameterRecord
-	err = verifyLockParameters(currentExtra.LockParameters, verifyExtra.LockParameters)
-	if err != nil {
-		return err
-	}
-
-	//ManagerAddress            []ManagerAddressRecord
-	err = verifyManagerAddress(currentExtra.ManagerAddress, verifyExtra.ManagerAddress)
-	if err != nil {
-		return err
-	}
-	//FlowHarvest               *big.Int
-	err = verifyBigInt(currentExtra.FlowHarvest, verifyExtra.FlowHarvest, "FlowHarvest")
-	if err != nil {
-		return err
-	}
-	//GrantProfit               []consensus.GrantProfitRecord
-	err = verifyGrantProfit(currentExtra.GrantProfit, verifyExtra.GrantProfit)
-	if err != nil {
-		return err
-	}
-
-	//FlowReport                []MinerFlowReportRecord
-	err = verifyFlowReport(currentExtra.FlowReport, verifyExtra.FlowReport)
-	if err != nil {
-		return err
-	}
-	//StoragePledge
-	err = verifyStoragePledge(currentExtra.StoragePledge, verifyExtra.StoragePledge)
-	if err != nil {
-		return err
-	}
-	//StoragePledgeExit
-	err = verifyStoragePledgeExit(currentExtra.StoragePledgeExit, verifyExtra.StoragePledgeExit)
-	if err != nil {
-		return err
-	}
-
-	//LeaseRequest
-	err = verifyLeaseRequest(currentExtra.LeaseRequest, verifyExtra.LeaseRequest)
-	if err != nil {
-		return err
-	}
-	//	esrt_s="ExchangeSRT"
-	err = verifyExchangeSRT(currentExtra.ExchangeSRT, verifyExtra.ExchangeSRT)
-	if err != nil {
-		return err
-	}
-	//esrpg_s="LeasePledge"
-	err = verifyLeasePledge(currentExtra.LeasePledge, verifyExtra.LeasePledge)
-	if err != nil {
-		return err
-	}
-	//esrrn_s="LeaseRenewal"
-	err = verifyLeaseRenewal(currentExtra.LeaseRenewal, verifyExtra.LeaseRenewal)
-	if err != nil {
-		return err
-	}
-	//esrrnpg_s="LeaseRenewalPledge"
-	err = verifyLeaseRenewalPledge(currentExtra.LeaseRenewalPledge, verifyExtra.LeaseRenewalPledge)
-	if err != nil {
-		return err
-	}
-	//esrc_s="LeaseRescind"
-	err = verifyLeaseRescind(currentExtra.LeaseRescind, verifyExtra.LeaseRescind)
-	if err != nil {
-		return err
-	}
-	//esrd_s="StorageRecoveryData"
-	err = verifyStorageRecoveryData(currentExtra.StorageRecoveryData, verifyExtra.StorageRecoveryData)
-	if err != nil {
-		return err
-	}
-	//espr_s="StorageProofRecord"
-	err = verifyStorageProofRecord(currentExtra.StorageProofRecord, verifyExtra.StorageProofRecord)
-	if err != nil {
-		return err
-	}
-	//esep_s="StorageExchangePrice"
-	err = verifyStorageExchangePrice(currentExtra.StorageExchangePrice, verifyExtra.StorageExchangePrice)
-	if err != nil {
-		return err
-	}
-	if currentExtra.StorageDataRoot != verifyExtra.StorageDataRoot {
-		return errors.New("Compare StorageDataRoot, current is " + currentExtra.StorageDataRoot.String() + ". but verify is " + verifyExtra.StorageDataRoot.String())
-	}
-	//esr_s="ExtraStateRoot"
-	if currentExtra.ExtraStateRoot != verifyExtra.ExtraStateRoot {
-		return errors.New("Compare ExtraStateRoot, current is " + currentExtra.ExtraStateRoot.String() + ". but verify is " + verifyExtra.ExtraStateRoot.String())
-	}
-	//elar_s="LockAccountsRoot"
-	if currentExtra.LockAccountsRoot != verifyExtra.LockAccountsRoot {
-		return errors.New("Compare LockAccountsRoot, current is " + currentExtra.LockAccountsRoot.String() + ". but verify is " + verifyExtra.LockAccountsRoot.String())
-	}
-	//SRTDataRoot
-	if currentExtra.SRTDataRoot != verifyExtra.SRTDataRoot {
-		return errors.New("Compare SRTDataRoot, current is " + currentExtra.SRTDataRoot.String() + ". but verify is " + verifyExtra.SRTDataRoot.String())
-	}
-	//esbp_s    = "StorageBwPay"
-	err = verifyStorageBwPay(currentExtra.StorageBwPay, verifyExtra.StorageBwPay)
-	if err != nil {
-		return err
-	}
-	if currentExtra.GrantProfitHash != verifyExtra.GrantProfitHash {
-		return errors.New("Compare GrantProfitHash, current is " + currentExtra.GrantProfitHash.String() + ". but verify is " + verifyExtra.GrantProfitHash.String())
-	}
-	//epn_s    = "CandidatePledgeNew"
-	err = verifyCandidatePledgeNew(currentExtra.CandidatePledgeNew, verifyExtra.CandidatePledgeNew)
-	if err != nil {
-		return err
-	}
-	//epent_s   = "CandidatePledgeEntrust"
-	err = verifyCandidatePledgeEntrust(currentExtra.CandidatePledgeEntrust, verifyExtra.CandidatePledgeEntrust)
-	if err != nil {
-		return err
-	}
-	//epente_s   = "CandidatePEntrustExit"
-	err = verifyCandidatePEntrustExit(currentExtra.CandidatePEntrustExit, verifyExtra.CandidatePEntrustExit)
-	if err != nil {
-		return err
-	}
-	//eae_s     = "CandidateAutoExit"
-	err = verifyCandidateAutoExit(currentExtra.CandidateAutoExit, verifyExtra.CandidateAutoExit)
-	if err != nil {
-		return err
-	}
-	//ecr_s     = "CandidateChangeRate"
-	err = verifyCandidateChangeRate(currentExtra.CandidateChangeRate, verifyExtra.CandidateChangeRate)
-	if err != nil {
-		return err
-	}
-
-	//CurLeaseSpace               *big.Int
-	err = verifyBigInt(currentExtra.CurLeaseSpace, verifyExtra.CurLeaseSpace, "CurLeaseSpace")
-	if err != nil {
-		return err
-	}
-
-	//scp_s     = "SpCreateParamter"
-	err = verifySpCreateParamter(currentExtra.SpCreateParamter, verifyExtra.SpCreateParamter)
-	if err != nil {
-		return err
-	}
-	//msm_s     = "ModifySManager"
-	err = verifyModifySManager(currentExtra.ModifySManager, verifyExtra.ModifySManager)
-	if err != nil {
-		return err
-	}
-
-	//	sapp_s     = "SpAdjustPgParamter"
-	err = verifySpAdjustPgParamter(currentExtra.SpAdjustPgParamter, verifyExtra.SpAdjustPgParamter)
-	if err != nil {
-		return err
-	}
-	//	srsp_s     = "SpRemoveSnParamter"
-	err = verifySpRemoveSnParamter(currentExtra.SpRemoveSnParamter, verifyExtra.SpRemoveSnParamter)
-	if err != nil {
-		return err
-	}
-	//	csp_s     = "CompleteSPledge"
-	err = verifyCompleteSPledge(currentExtra.CompleteSPledge, verifyExtra.CompleteSPledge)
-	if err != nil {
-		return err
-	}
-	//sprr_s     = "SPRewardRatio"
-	err = verifySPRewardRatio(currentExtra.SPRewardRatio, verifyExtra.SPRewardRatio)
-	if err != nil {
-		return err
-	}
-	//spp_s     = "SPPool"
-	err = verifySPPool(currentExtra.SPPool, verifyExtra.SPPool)
-	if err != nil {
-		return err
-	}
-	//spm_s     = "SPMigration"
-	err = verifySPMigration(currentExtra.SPMigration, verifyExtra.SPMigration)
-	if err != nil {
-		return err
-	}
-	//sp2_s     = "StoragePledge2"
-	err = verifySPledge2(currentExtra.StoragePledge2, verifyExtra.StoragePledge2)
-	if err != nil {
-		return err
-	}
-	//spe_s     = "SPEntrust"
-	err = verifySPEntrust(currentExtra.SPEntrust, verifyExtra.SPEntrust)
-	if err != nil {
-		return err
-	}
-	// spetpp_s SpEttPledgeParamter
-	err = verifySpEttPledge(currentExtra.SpEttPledgeParamter, verifyExtra.SpEttPledgeParamter)
-	if err != nil {
-		return err
-	}
-	// spexitp_s  = "SpExitParameter"
-	err = verifySpExit(currentExtra.SpExitParameter, verifyExtra.SpExitParameter)
-	if err != nil {
-		return err
-	}
-	//spfeep_s   = "SpFeeParameter"
-	err = verifySpFee(currentExtra.SpFeeParameter, verifyExtra.SpFeeParameter)
-	if err != nil {
-		return err
-	}
+// headerExtraCheck compares one field of the header extra computed locally with
+// the same field of the header extra sealed in a block.
+type headerExtraCheck struct {
+	field  string
+	verify func(current *HeaderExtra, verify *HeaderExtra) error
+}
+
+// headerExtraChecks are the header extra fields compared on block import, in
+// the order they are verified.
+var headerExtraChecks = []headerExtraCheck{
+	{"ExchangeNFC", func(current, verify *HeaderExtra) error {
+		return verifyExchangeNFC(current.ExchangeNFC, verify.ExchangeNFC)
+	}},
+	{"LockReward", func(current, verify *HeaderExtra) error {
+		return verifyLockReward(current.LockReward, verify.LockReward)
+	}},
+	{"DeviceBind", func(current, verify *HeaderExtra) error {
+		return verifyDeviceBind(current.DeviceBind, verify.DeviceBind)
+	}},
+	{"CandidatePledge", func(current, verify *HeaderExtra) error {
+		return verifyCandidatePledge(current.CandidatePledge, verify.CandidatePledge)
+	}},
+	{"CandidatePunish", func(current, verify *HeaderExtra) error {
+		return verifyCandidatePunish(current.CandidatePunish, verify.CandidatePunish)
+	}},
+	{"MinerStake", func(current, verify *HeaderExtra) error {
+		return verifyMinerStake(current.MinerStake, verify.MinerStake)
+	}},
+	{"CandidateExit", func(current, verify *HeaderExtra) error {
+		return verifyExit(current.CandidateExit, verify.CandidateExit, "CandidateExit")
+	}},
+	{"ClaimedBandwidth", func(current, verify *HeaderExtra) error {
+		return verifyClaimedBandwidth(current.ClaimedBandwidth, verify.ClaimedBandwidth)
+	}},
+	{"FlowMinerExit", func(current, verify *HeaderExtra) error {
+		return verifyExit(current.FlowMinerExit, verify.FlowMinerExit, "FlowMinerExit")
+	}},
+	{"BandwidthPunish", func(current, verify *HeaderExtra) error {
+		return verifyBandwidthPunish(current.BandwidthPunish, verify.BandwidthPunish)
+	}},
+	{"ConfigExchRate", func(current, verify *HeaderExtra) error {
+		return verifyUint32Config(current.ConfigExchRate, verify.ConfigExchRate, "ConfigExchRate")
+	}},
+	{"ConfigOffLine", func(current, verify *HeaderExtra) error {
+		return verifyUint32Config(current.ConfigOffLine, verify.ConfigOffLine, "ConfigOffLine")
+	}},
+	{"ConfigDeposit", func(current, verify *HeaderExtra) error {
+		return verifyConfigDeposit(current.ConfigDeposit, verify.ConfigDeposit)
+	}},
+	{"ConfigISPQOS", func(current, verify *HeaderExtra) error {
+		return verifyConfigISPQOS(current.ConfigISPQOS, verify.ConfigISPQOS)
+	}},
+	{"LockParameters", func(current, verify *HeaderExtra) error {
+		return verifyLockParameters(current.LockParameters, verify.LockParameters)
+	}},
+	{"ManagerAddress", func(current, verify *HeaderExtra) error {
+		return verifyManagerAddress(current.ManagerAddress, verify.ManagerAddress)
+	}},
+	{"FlowHarvest", func(current, verify *HeaderExtra) error {
+		return verifyBigInt(current.FlowHarvest, verify.FlowHarvest, "FlowHarvest")
+	}},
+	{"GrantProfit", func(current, verify *HeaderExtra) error {
+		return verifyGrantProfit(current.GrantProfit, verify.GrantProfit)
+	}},
+	{"FlowReport", func(current, verify *HeaderExtra) error {
+		return verifyFlowReport(current.FlowReport, verify.FlowReport)
+	}},
+	{"StoragePledge", func(current, verify *HeaderExtra) error {
+		return verifyStoragePledge(current.StoragePledge, verify.StoragePledge)
+	}},
+	{"StoragePledgeExit", func(current, verify *HeaderExtra) error {
+		return verifyStoragePledgeExit(current.StoragePledgeExit, verify.StoragePledgeExit)
+	}},
+	{"LeaseRequest", func(current, verify *HeaderExtra) error {
+		return verifyLeaseRequest(current.LeaseRequest, verify.LeaseRequest)
+	}},
+	{"ExchangeSRT", func(current, verify *HeaderExtra) error {
+		return verifyExchangeSRT(current.ExchangeSRT, verify.ExchangeSRT)
+	}},
+	{"LeasePledge", func(current, verify *HeaderExtra) error {
+		return verifyLeasePledge(current.LeasePledge, verify.LeasePledge)
+	}},
+	{"LeaseRenewal", func(current, verify *HeaderExtra) error {
+		return verifyLeaseRenewal(current.LeaseRenewal, verify.LeaseRenewal)
+	}},
+	{"LeaseRenewalPledge", func(current, verify *HeaderExtra) error {
+		return verifyLeaseRenewalPledge(current.LeaseRenewalPledge, verify.LeaseRenewalPledge)
+	}},
+	{"LeaseRescind", func(current, verify *HeaderExtra) error {
+		return verifyLeaseRescind(current.LeaseRescind, verify.LeaseRescind)
+	}},
+	{"StorageRecoveryData", func(current, verify *HeaderExtra) error {
+		return verifyStorageRecoveryData(current.StorageRecoveryData, verify.StorageRecoveryData)
+	}},
+	{"StorageProofRecord", func(current, verify *HeaderExtra) error {
+		return verifyStorageProofRecord(current.StorageProofRecord, verify.StorageProofRecord)
+	}},
+	{"StorageExchangePrice", func(current, verify *HeaderExtra) error {
+		return verifyStorageExchangePrice(current.StorageExchangePrice, verify.StorageExchangePrice)
+	}},
+	{"StorageDataRoot", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.StorageDataRoot, verify.StorageDataRoot, "StorageDataRoot")
+	}},
+	{"ExtraStateRoot", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.ExtraStateRoot, verify.ExtraStateRoot, "ExtraStateRoot")
+	}},
+	{"LockAccountsRoot", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.LockAccountsRoot, verify.LockAccountsRoot, "LockAccountsRoot")
+	}},
+	{"SRTDataRoot", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.SRTDataRoot, verify.SRTDataRoot, "SRTDataRoot")
+	}},
+	{"StorageBwPay", func(current, verify *HeaderExtra) error {
+		return verifyStorageBwPay(current.StorageBwPay, verify.StorageBwPay)
+	}},
+	{"GrantProfitHash", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.GrantProfitHash, verify.GrantProfitHash, "GrantProfitHash")
+	}},
+	{"CandidatePledgeNew", func(current, verify *HeaderExtra) error {
+		return verifyCandidatePledgeNew(current.CandidatePledgeNew, verify.CandidatePledgeNew)
+	}},
+	{"CandidatePledgeEntrust", func(current, verify *HeaderExtra) error {
+		return verifyCandidatePledgeEntrust(current.CandidatePledgeEntrust, verify.CandidatePledgeEntrust)
+	}},
+	{"CandidatePEntrustExit", func(current, verify *HeaderExtra) error {
+		return verifyCandidatePEntrustExit(current.CandidatePEntrustExit, verify.CandidatePEntrustExit)
+	}},
+	{"CandidateAutoExit", func(current, verify *HeaderExtra) error {
+		return verifyCandidateAutoExit(current.CandidateAutoExit, verify.CandidateAutoExit)
+	}},
+	{"CandidateChangeRate", func(current, verify *HeaderExtra) error {
+		return verifyCandidateChangeRate(current.CandidateChangeRate, verify.CandidateChangeRate)
+	}},
+	{"CurLeaseSpace", func(current, verify *HeaderExtra) error {
+		return verifyBigInt(current.CurLeaseSpace, verify.CurLeaseSpace, "CurLeaseSpace")
+	}},
+	{"SpCreateParamter", func(current, verify *HeaderExtra) error {
+		return verifySpCreateParamter(current.SpCreateParamter, verify.SpCreateParamter)
+	}},
+	{"ModifySManager", func(current, verify *HeaderExtra) error {
+		return verifyModifySManager(current.ModifySManager, verify.ModifySManager)
+	}},
+	{"SpAdjustPgParamter", func(current, verify *HeaderExtra) error {
+		return verifySpAdjustPgParamter(current.SpAdjustPgParamter, verify.SpAdjustPgParamter)
+	}},
+	{"SpRemoveSnParamter", func(current, verify *HeaderExtra) error {
+		return verifySpRemoveSnParamter(current.SpRemoveSnParamter, verify.SpRemoveSnParamter)
+	}},
+	{"CompleteSPledge", func(current, verify *HeaderExtra) error {
+		return verifyCompleteSPledge(current.CompleteSPledge, verify.CompleteSPledge)
+	}},
+	{"SPRewardRatio", func(current, verify *HeaderExtra) error {
+		return verifySPRewardRatio(current.SPRewardRatio, verify.SPRewardRatio)
+	}},
+	{"SPPool", func(current, verify *HeaderExtra) error {
+		return verifySPPool(current.SPPool, verify.SPPool)
+	}},
+	{"SPMigration", func(current, verify *HeaderExtra) error {
+		return verifySPMigration(current.SPMigration, verify.SPMigration)
+	}},
+	{"StoragePledge2", func(current, verify *HeaderExtra) error {
+		return verifySPledge2(current.StoragePledge2, verify.StoragePledge2)
+	}},
+	{"SPEntrust", func(current, verify *HeaderExtra) error {
+		return verifySPEntrust(current.SPEntrust, verify.SPEntrust)
+	}},
+	{"SpEttPledgeParamter", func(current, verify *HeaderExtra) error {
+		return verifySpEttPledge(current.SpEttPledgeParamter, verify.SpEttPledgeParamter)
+	}},
+	{"SpExitParameter", func(current, verify *HeaderExtra) error {
+		return verifySpExit(current.SpExitParameter, verify.SpExitParameter)
+	}},
+	{"SpFeeParameter", func(current, verify *HeaderExtra) error {
+		return verifySpFee(current.SpFeeParameter, verify.SpFeeParameter)
+	}},
+	{"SpEntrustParameter", func(current, verify *HeaderExtra) error {
+		return verifySpEntrust(current.SpEntrustParameter, verify.SpEntrustParameter)
+	}},
+	{"SETransfer", func(current, verify *HeaderExtra) error {
+		return verifySETransfer(current.SETransfer, verify.SETransfer)
+	}},
+	{"SEExit", func(current, verify *HeaderExtra) error {
+		return verifySEExit(current.SEExit, verify.SEExit)
+	}},
+	{"POSTransfer", func(current, verify *HeaderExtra) error {
+		return verifyPOSTransfer(current.POSTransfer, verify.POSTransfer)
+	}},
+	{"SpDataRoot", func(current, verify *HeaderExtra) error {
+		return verifyHash(current.SpDataRoot, verify.SpDataRoot, "SpDataRoot")
+	}},
+	{"SPEPool", func(current, verify *HeaderExtra) error {
+		return verifyExit(current.SPEPool, verify.SPEPool, "SPEPool")
+	}},
+	{"SpBind", func(current, verify *HeaderExtra) error {
+		return verifySpBind(current.SpBind, verify.SpBind)
+	}},
+	{"DoubleSignPunish", func(current, verify *HeaderExtra) error {
+		return verifyDoubleSignPunishRecords(current.DoubleSignPunish, verify.DoubleSignPunish)
+	}},
+}
 
-	//spetrtep_s = "SpEntrustParameter"
-	err = verifySpEntrust(currentExtra.SpEntrustParameter, verifyExtra.SpEntrustParameter)
-	if err != nil {
-		return err
-	}
-	//set_s      = "SETransfer"
-	err = verifySETransfer(currentExtra.SETransfer, verifyExtra.SETransfer)
-	if err != nil {
-		return err
-	}
-	//see_s      = "SEExit"
-	err = verifySEExit(currentExtra.SEExit, verifyExtra.SEExit)
-	if err != nil {
-		return err
-	}
-	//post_s      = "POSTransfer"
-	err = verifyPOSTransfer(currentExtra.POSTransfer, verifyExtra.POSTransfer)
-	if err != nil {
-		return err
+func verifyHeaderExtern(currentExtra *HeaderExtra, verifyExtra *HeaderExtra) error {
+	for _, check := range headerExtraChecks {
+		if err := check.verify(currentExtra, verifyExtra); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if currentExtra.SpDataRoot != verifyExtra.SpDataRoot {
-		return errors.New("Compare SpDataRoot, current is " + currentExtra.SpDataRoot.String() + ". but verify is " + verifyExtra.SpDataRoot.String())
-	}
-	err = verifyExit(currentExtra.SPEPool, verifyExtra.SPEPool, "SPEPool")
-	if err != nil {
-		return err
-	}
-	//SpBind_s   ="SpBind"
-	err = verifySpBind(currentExtra.SpBind, verifyExtra.SpBind)
-	if err != nil {
-		return err
+func verifyHash(current common.Hash, verify common.Hash, name string) error {
+	if current != verify {
+		return errors.New("Compare " + name + ", current is " + current.String() + ". but verify is " + verify.String())
 	}
 	return nil
 }
-
 func verifyUint32Config(current uint32, verify uint32, name string) error {
 	if current != verify {
 		s := strconv.FormatUint(uint64(current), 10)
@@ -2430,4 +2324,19 @@ func compareSpBind(a []SpBindRecord, b []SpBindRecord) error {
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+// verifyDoubleSignPunishRecords compares the punishments in order, as the
+// punishments sealed in a block are taken from the evidence pool in order.
+func verifyDoubleSignPunishRecords(current []DoubleSignPunishRecord, verify []DoubleSignPunishRecord) error {
+	arrLen, err := verifyArrayBasic(dsp_s, current, verify)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < arrLen; i++ {
+		c, v := current[i], verify[i]
+		if c.Target != v.Target || c.Amount.Cmp(v.Amount) != 0 || c.Evidence.Hash() != v.Evidence.Hash() {
+			return errorsMsg4(dsp_s, c)
+		}
+	}
+	return nil
+}
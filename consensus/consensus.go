@@ -168,6 +168,14 @@ type Engine interface {
 	VerifyHeaderExtra(chain ChainHeaderReader, header *types.Header, verifyExtra []byte) error
 }
 
+// Finality is a consensus engine deciding when blocks can no longer be
+// reorganised.
+type Finality interface {
+	// FinalizedNumber retrieves the number of the last finalized block of the
+	// chain ending at head.
+	FinalizedNumber(head *types.Header) (uint64, error)
+}
+
 // PoW is a consensus engine based on proof-of-work.
 type PoW interface {
 	Engine
@@ -2415,6 +2415,12 @@ func (bc *BlockChain) CurrentHeader() *types.Header {
 	return bc.hc.CurrentHeader()
 }
 
+// CurrentFinalizedHeader retrieves the last finalized header of the canonical
+// chain, or nil if the consensus engine has no finality.
+func (bc *BlockChain) CurrentFinalizedHeader() *types.Header {
+	return bc.hc.CurrentFinalizedHeader()
+}
+
 // GetTd retrieves a block's total difficulty in the canonical chain from the
 // database by hash and number, caching it if found.
 func (bc *BlockChain) GetTd(hash common.Hash, number uint64) *big.Int {
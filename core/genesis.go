@@ -427,6 +427,15 @@ func DefaultSCGenesisBlock() *Genesis {
 	}
 }
 
+var (
+	developerAlienFaucetBalance  = new(big.Int).Mul(big.NewInt(1e9), big.NewInt(params.Ether))
+	developerAlienManagerBalance = new(big.Int).Mul(big.NewInt(1e6), big.NewInt(params.Ether))
+)
+
+// developerAlienTimeScale is the factor the alien developer network runs the
+// reward schedules faster than mainnet by, making a day last 24 minutes.
+const developerAlienTimeScale = 60
+
 // DeveloperGenesisBlock returns the 'utg --dev' genesis block.
 func DeveloperGenesisBlock(period uint64, faucet common.Address) *Genesis {
 	// Override the default period to the user requested one
@@ -455,6 +464,50 @@ func DeveloperGenesisBlock(period uint64, faucet common.Address) *Genesis {
 	}
 }
 
+// DeveloperAlienGenesisBlock returns the 'utg --dev --dev.alien' genesis block,
+// sealed by the faucet alone and running every alien rule change, storage and
+// PoS included, from block 0 on short days. The manager addresses are funded
+// to send the system config transactions.
+func DeveloperAlienGenesisBlock(period uint64, faucet common.Address, managers []common.Address) *Genesis {
+	// Alien seals on a fixed schedule, so fall back to one second blocks
+	if period == 0 {
+		period = 1
+	}
+	alien := *params.AllAlienProtocolChanges.Alien
+	alien.Period = period
+	alien.SelfVoteSigners = []common.UnprefixedAddress{common.UnprefixedAddress(faucet)}
+	alien.Forks = params.GenesisAlienForks()
+	alien.Times = &params.AlienTimes{Scale: developerAlienTimeScale}
+
+	// The alien seal doesn't cover the base fee, so London stays off as on mainnet
+	config := *params.AllAlienProtocolChanges
+	config.LondonBlock = nil
+	config.Alien = &alien
+
+	genesis := &Genesis{
+		Config:     &config,
+		ExtraData:  make([]byte, 32+crypto.SignatureLength),
+		GasLimit:   11500000,
+		Difficulty: big.NewInt(1),
+		Alloc: map[common.Address]GenesisAccount{
+			common.BytesToAddress([]byte{1}): {Balance: big.NewInt(1)}, // ECRecover
+			common.BytesToAddress([]byte{2}): {Balance: big.NewInt(1)}, // SHA256
+			common.BytesToAddress([]byte{3}): {Balance: big.NewInt(1)}, // RIPEMD
+			common.BytesToAddress([]byte{4}): {Balance: big.NewInt(1)}, // Identity
+			common.BytesToAddress([]byte{5}): {Balance: big.NewInt(1)}, // ModExp
+			common.BytesToAddress([]byte{6}): {Balance: big.NewInt(1)}, // ECAdd
+			common.BytesToAddress([]byte{7}): {Balance: big.NewInt(1)}, // ECScalarMul
+			common.BytesToAddress([]byte{8}): {Balance: big.NewInt(1)}, // ECPairing
+			common.BytesToAddress([]byte{9}): {Balance: big.NewInt(1)}, // BLAKE2b
+		},
+	}
+	for _, manager := range managers {
+		genesis.Alloc[manager] = GenesisAccount{Balance: new(big.Int).Set(developerAlienManagerBalance)}
+	}
+	genesis.Alloc[faucet] = GenesisAccount{Balance: new(big.Int).Set(developerAlienFaucetBalance)}
+	return genesis
+}
+
 func decodePrealloc(data string) GenesisAlloc {
 	var p []struct{ Addr, Balance *big.Int }
 	if err := rlp.NewStream(strings.NewReader(data), 0).Decode(&p); err != nil {
@@ -534,6 +534,21 @@ func (hc *HeaderChain) CurrentHeader() *types.Header {
 	return hc.currentHeader.Load().(*types.Header)
 }
 
+// CurrentFinalizedHeader retrieves the last finalized header of the canonical
+// chain, or nil if the consensus engine has no finality or it is unknown.
+func (hc *HeaderChain) CurrentFinalizedHeader() *types.Header {
+	engine, ok := hc.engine.(consensus.Finality)
+	if !ok {
+		return nil
+	}
+	number, err := engine.FinalizedNumber(hc.CurrentHeader())
+	if err != nil {
+		log.Debug("Failed to retrieve finalized number", "err", err)
+		return nil
+	}
+	return hc.GetHeaderByNumber(number)
+}
+
 // SetCurrentHeader sets the in-memory head header marker of the canonical chan
 // as the given header.
 func (hc *HeaderChain) SetCurrentHeader(head *types.Header) {
@@ -56,6 +56,28 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consen
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
 func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, verifySeals bool) (types.Receipts, []*types.Log, uint64, error) {
+	header, receipts, allLogs, usedGas, err := p.process(block, statedb, cfg)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if verifySeals {
+		err := p.engine.VerifyHeaderExtra(p.bc, header, block.Extra())
+		if err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	return receipts, allLogs, usedGas, nil
+}
+
+// FinalizeHeader processes the block like Process and returns its header as
+// finalized by the consensus engine, without checking it against the sealed one.
+func (p *StateProcessor) FinalizeHeader(block *types.Block, statedb *state.StateDB, cfg vm.Config) (*types.Header, error) {
+	header, _, _, _, err := p.process(block, statedb, cfg)
+	return header, err
+}
+
+// process runs the transactions of the block and finalizes a copy of its header.
+func (p *StateProcessor) process(block *types.Block, statedb *state.StateDB, cfg vm.Config) (*types.Header, types.Receipts, []*types.Log, uint64, error) {
 	var (
 		receipts types.Receipts
 		usedGas  = new(uint64)
@@ -64,13 +86,6 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		gp       = new(GasPool).AddGas(block.GasLimit())
 		txIndex  = 0
 	)
-
-	var headerExtra []byte
-	if verifySeals {
-		headerExtra = make([]byte, len(header.Extra))
-		copy(headerExtra, header.Extra)
-
-	}
 	// Mutate the block and state according to any hard-fork specs
 	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
 		misc.ApplyDAOHardFork(statedb)
@@ -81,12 +96,12 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	for i, tx := range block.Transactions() {
 		msg, err := tx.AsMessage(types.MakeSigner(p.config, header.Number), header.BaseFee)
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
 		receipt, err := applyTransaction(msg, p.config, p.bc, nil, gp, statedb, header, tx, usedGas, vmenv)
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		receipts = append(receipts, receipt)
 		//allLogs = append(allLogs, receipt.Logs...)
@@ -96,18 +111,12 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
 	err := p.engine.Finalize(p.bc, header, statedb, block.Transactions(), block.Uncles(), receipts, payProfit, vmenv.GasReward)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, nil, 0, err
 	}
 	for _, receipt := range receipts {
 		allLogs = append(allLogs, receipt.Logs...)
 	}
-	if verifySeals {
-		err := p.engine.VerifyHeaderExtra(p.bc, header, headerExtra)
-		if err != nil {
-			return nil, nil, 0, err
-		}
-	}
-	return receipts, allLogs, *usedGas, nil
+	return header, receipts, allLogs, *usedGas, nil
 }
 
 func applyTransaction(msg types.Message, config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, evm *vm.EVM) (*types.Receipt, error) {
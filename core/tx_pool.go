@@ -156,6 +156,8 @@ type TxPoolConfig struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	CustomTxCheck bool // Whether custom transactions the alien engine would reject are refused on admission
 }
 
 // DefaultTxPoolConfig contains the default configurations for the transaction
@@ -238,6 +240,7 @@ type TxPool struct {
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
+	validator     TxValidator    // Optional consensus engine admission check
 
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
@@ -258,6 +261,17 @@ type TxPool struct {
 	wg              sync.WaitGroup // tracks loop, scheduleReorgLoop
 }
 
+// TxValidator is an optional, consensus engine specific admission check. It is
+// run on every transaction that passed the standard validation, allowing the
+// engine to reject transactions it would otherwise drop silently on execution.
+// Reset is called whenever the pool moves to a new head, before the dropped
+// transactions are reinjected and without holding the pool lock, so the check
+// can prepare the state it needs once per head instead of once per transaction.
+type TxValidator interface {
+	ValidateTx(tx *types.Transaction, from common.Address) error
+	Reset(head *types.Header)
+}
+
 type txpoolResetRequest struct {
 	oldHead, newHead *types.Header
 }
@@ -424,6 +438,18 @@ func (pool *TxPool) GasPrice() *big.Int {
 	return new(big.Int).Set(pool.gasPrice)
 }
 
+// SetValidator installs an additional admission check for new transactions.
+// Transactions already in the pool are not revalidated.
+func (pool *TxPool) SetValidator(validator TxValidator) {
+	if validator != nil {
+		validator.Reset(pool.chain.CurrentBlock().Header())
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.validator = validator
+}
+
 // SetGasPrice updates the minimum price required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *TxPool) SetGasPrice(price *big.Int) {
@@ -614,6 +640,12 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if tx.Gas() < intrGas {
 		return ErrIntrinsicGas
 	}
+	// Let the consensus engine reject transactions it would not process
+	if pool.validator != nil {
+		if err := pool.validator.ValidateTx(tx, from); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1110,6 +1142,21 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 		// the flatten operation can be avoided.
 		promoteAddrs = dirtyAccounts.flatten()
 	}
+	if reset != nil {
+		// Prepare the admission check of the new head before locking the pool, as
+		// it may take a while
+		pool.mu.RLock()
+		validator := pool.validator
+		pool.mu.RUnlock()
+
+		if validator != nil {
+			head := reset.newHead
+			if head == nil {
+				head = pool.chain.CurrentBlock().Header()
+			}
+			validator.Reset(head)
+		}
+	}
 	pool.mu.Lock()
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
@@ -311,6 +311,108 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// testTxValidator rejects every transaction from a single sender, counting the
+// heads it is reset to.
+type testTxValidator struct {
+	banned common.Address
+	resets int
+}
+
+var errTestBannedSender = errors.New("banned sender")
+
+func (v *testTxValidator) ValidateTx(tx *types.Transaction, from common.Address) error {
+	if from == v.banned {
+		return errTestBannedSender
+	}
+	return nil
+}
+
+func (v *testTxValidator) Reset(head *types.Header) {
+	v.resets++
+}
+
+// Tests that an installed validator is consulted on admission and its error is
+// returned to the caller.
+func TestTransactionValidator(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	tx := transaction(0, 100000, key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, big.NewInt(1000000))
+
+	pool.SetValidator(&testTxValidator{banned: from})
+	if err := pool.AddLocal(tx); !errors.Is(err, errTestBannedSender) {
+		t.Fatalf("expected %v, got %v", errTestBannedSender, err)
+	}
+	pool.SetValidator(&testTxValidator{})
+	if err := pool.AddLocal(tx); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	pool.SetValidator(nil)
+	if err := pool.AddLocal(pricedTransaction(1, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+// Tests that an installed validator is reset to the head of the pool when it is
+// installed and whenever the pool moves to a new head.
+func TestTransactionValidatorReset(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupTxPool()
+	defer pool.Stop()
+
+	validator := new(testTxValidator)
+	pool.SetValidator(validator)
+	if validator.resets != 1 {
+		t.Fatalf("validator reset %d times on install, want 1", validator.resets)
+	}
+	<-pool.requestReset(nil, nil)
+	if validator.resets != 2 {
+		t.Fatalf("validator reset %d times on new head, want 2", validator.resets)
+	}
+}
+
+// lockingTxValidator reads the pool on reset, which deadlocks if the pool is
+// locked meanwhile.
+type lockingTxValidator struct {
+	testTxValidator
+	pool *TxPool
+}
+
+func (v *lockingTxValidator) Reset(head *types.Header) {
+	v.pool.Stats()
+	v.testTxValidator.Reset(head)
+}
+
+// Tests that the validator is reset without holding the pool lock, so that a
+// slow reset does not stall the pool.
+func TestTransactionValidatorResetUnlocked(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupTxPool()
+	defer pool.Stop()
+
+	validator := &lockingTxValidator{pool: pool}
+	done := make(chan struct{})
+	go func() {
+		pool.SetValidator(validator)
+		<-pool.requestReset(nil, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("validator reset while holding the pool lock")
+	}
+	if validator.resets != 2 {
+		t.Fatalf("validator reset %d times, want 2", validator.resets)
+	}
+}
+
 func TestTransactionQueue(t *testing.T) {
 	t.Parallel()
 
@@ -31,10 +31,12 @@ import (
 
 	"github.com/UltronGlow/UltronGlow-Origin/common"
 	"github.com/UltronGlow/UltronGlow-Origin/common/hexutil"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
 	"github.com/UltronGlow/UltronGlow-Origin/core"
 	"github.com/UltronGlow/UltronGlow-Origin/core/rawdb"
 	"github.com/UltronGlow/UltronGlow-Origin/core/state"
 	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/core/vm"
 	"github.com/UltronGlow/UltronGlow-Origin/internal/ethapi"
 	"github.com/UltronGlow/UltronGlow-Origin/rlp"
 	"github.com/UltronGlow/UltronGlow-Origin/rpc"
@@ -411,6 +413,46 @@ func (api *PublicDebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, sta
 	return stateDb.IteratorDump(opts), nil
 }
 
+// alienDiffReexec is the number of blocks AlienDiffHeaderExtra is willing to
+// reexecute to produce the missing state of the parent block.
+const alienDiffReexec = uint64(128)
+
+// AlienDiffHeaderExtra reexecutes a block of an alien chain on top of the state
+// of its parent, finalizes it locally and lists every header extra field which
+// differs from the one sealed in the block.
+func (api *PrivateDebugAPI) AlienDiffHeaderExtra(blockNr rpc.BlockNumber) (*alien.HeaderExtraDiff, error) {
+	engine, ok := api.eth.engine.(*alien.Alien)
+	if !ok {
+		return nil, errors.New("not an alien chain")
+	}
+	var block *types.Block
+	if blockNr == rpc.LatestBlockNumber || blockNr == rpc.PendingBlockNumber {
+		block = api.eth.blockchain.CurrentBlock()
+	} else {
+		block = api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not sealed")
+	}
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := api.eth.stateAtBlock(parent, alienDiffReexec, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	processor := core.NewStateProcessor(api.eth.blockchain.Config(), api.eth.blockchain, engine)
+	header, err := processor.FinalizeHeader(block, statedb, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return engine.DiffHeaderExtra(header, block.Header())
+}
+
 // StorageRangeResult is the result of a debug_storageRangeAt API call.
 type StorageRangeResult struct {
 	Storage storageMap   `json:"storage"`
@@ -44,6 +44,7 @@ import (
 	"github.com/UltronGlow/UltronGlow-Origin/eth/filters"
 	"github.com/UltronGlow/UltronGlow-Origin/eth/gasprice"
 	"github.com/UltronGlow/UltronGlow-Origin/eth/protocols/eth"
+	"github.com/UltronGlow/UltronGlow-Origin/eth/protocols/evidence"
 	"github.com/UltronGlow/UltronGlow-Origin/eth/protocols/snap"
 	"github.com/UltronGlow/UltronGlow-Origin/ethdb"
 	"github.com/UltronGlow/UltronGlow-Origin/event"
@@ -71,6 +72,7 @@ type Ethereum struct {
 	txPool             *core.TxPool
 	blockchain         *core.BlockChain
 	handler            *handler
+	evidence           *evidence.Handler // Double sign evidence gossip, alien engine only
 	ethDialCandidates  enode.Iterator
 	snapDialCandidates enode.Iterator
 
@@ -214,6 +216,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 	eth.txPool = core.NewTxPool(config.TxPool, chainConfig, eth.blockchain)
+	if alienEngine, ok := eth.engine.(*alien.Alien); ok {
+		if config.TxPool.CustomTxCheck {
+			eth.txPool.SetValidator(alienEngine.TxValidator(eth.blockchain))
+		}
+		eth.evidence = evidence.NewHandler(alienEngine, eth.blockchain)
+	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieCleanLimit + cacheConfig.TrieDirtyLimit + cacheConfig.SnapshotLimit
@@ -556,6 +564,9 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
+	if s.evidence != nil {
+		protos = append(protos, s.evidence.MakeProtocols()...)
+	}
 	return protos
 }
 
@@ -577,6 +588,9 @@ func (s *Ethereum) Start() error {
 	}
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
+	if s.evidence != nil {
+		s.evidence.Start()
+	}
 	return nil
 }
 
@@ -587,6 +601,9 @@ func (s *Ethereum) Stop() error {
 	s.ethDialCandidates.Close()
 	s.snapDialCandidates.Close()
 	s.handler.Stop()
+	if s.evidence != nil {
+		s.evidence.Stop()
+	}
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
@@ -128,6 +128,22 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		}
 		return f.blockLogs(ctx, header)
 	}
+	// Resolve the finalized tags of the range
+	if isFinalizedTag(f.begin) || isFinalizedTag(f.end) {
+		finalized, err := f.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if finalized == nil {
+			return nil, errors.New("finalized block not found")
+		}
+		if isFinalizedTag(f.begin) {
+			f.begin = finalized.Number.Int64()
+		}
+		if isFinalizedTag(f.end) {
+			f.end = finalized.Number.Int64()
+		}
+	}
 	// Figure out the limits of the filter range
 	header, _ := f.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if header == nil {
@@ -163,6 +179,11 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	return logs, err
 }
 
+// isFinalizedTag reports whether number is one of the finalized block tags.
+func isFinalizedTag(number int64) bool {
+	return number == rpc.FinalizedBlockNumber.Int64() || number == rpc.SafeBlockNumber.Int64()
+}
+
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
@@ -52,6 +52,8 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// FinalizedBlocksSubscription queries headers for blocks that are finalized
+	FinalizedBlocksSubscription
 	// LastSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -83,9 +85,10 @@ type subscription struct {
 // EventSystem creates subscriptions, processes events and broadcasts them to the
 // subscription which match the subscription criteria.
 type EventSystem struct {
-	backend   Backend
-	lightMode bool
-	lastHead  *types.Header
+	backend       Backend
+	lightMode     bool
+	lastHead      *types.Header
+	lastFinalized *types.Header
 
 	// Subscriptions
 	txsSub         event.Subscription // Subscription for new transaction event
@@ -290,6 +293,22 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscripti
 	return es.subscribe(sub)
 }
 
+// SubscribeNewFinalizedHeads creates a subscription that writes the header of
+// every block finalized on the chain, in order.
+func (es *EventSystem) SubscribeNewFinalizedHeads(headers chan *types.Header) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       FinalizedBlocksSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan []common.Hash),
+		headers:   headers,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
 // SubscribePendingTxs creates a subscription that writes transaction hashes for
 // transactions that enter the transaction pool.
 func (es *EventSystem) SubscribePendingTxs(hashes chan []common.Hash) *Subscription {
@@ -364,6 +383,45 @@ func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent)
 			}
 		})
 	}
+	es.handleFinalizedHeads(filters)
+}
+
+// handleFinalizedHeads sends the headers finalized since the last chain event
+// to the finalized heads subscriptions. The first finalized header seen is sent
+// alone, every later one is preceded by the headers finalized along with it.
+func (es *EventSystem) handleFinalizedHeads(filters filterIndex) {
+	if len(filters[FinalizedBlocksSubscription]) == 0 {
+		es.lastFinalized = nil
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	finalized, _ := es.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+	if finalized == nil {
+		return
+	}
+	last := es.lastFinalized
+	es.lastFinalized = finalized
+
+	from := finalized.Number.Uint64()
+	if last != nil {
+		if last.Number.Cmp(finalized.Number) >= 0 {
+			return
+		}
+		from = last.Number.Uint64() + 1
+	}
+	for number := from; number <= finalized.Number.Uint64(); number++ {
+		header := finalized
+		if number < finalized.Number.Uint64() {
+			if header, _ = es.backend.HeaderByNumber(ctx, rpc.BlockNumber(number)); header == nil {
+				continue
+			}
+		}
+		for _, f := range filters[FinalizedBlocksSubscription] {
+			f.headers <- header
+		}
+	}
 }
 
 func (es *EventSystem) lightFilterNewHead(newHeader *types.Header, callBack func(*types.Header, bool)) {
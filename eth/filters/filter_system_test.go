@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"reflect"
 	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -52,6 +53,7 @@ type testBackend struct {
 	rmLogsFeed      event.Feed
 	pendingLogsFeed event.Feed
 	chainFeed       event.Feed
+	finalized       uint64 // Finalized block number, accessed atomically
 }
 
 func (b *testBackend) ChainDb() ethdb.Database {
@@ -70,6 +72,9 @@ func (b *testBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumbe
 			return nil, nil
 		}
 		num = *number
+	} else if blockNr == rpc.FinalizedBlockNumber || blockNr == rpc.SafeBlockNumber {
+		num = atomic.LoadUint64(&b.finalized)
+		hash = rawdb.ReadCanonicalHash(b.db, num)
 	} else {
 		num = uint64(blockNr)
 		hash = rawdb.ReadCanonicalHash(b.db, num)
@@ -213,6 +218,55 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub1.Err()
 }
 
+// TestFinalizedHeadsSubscription tests if a finalized heads subscription returns
+// every finalized header once and in order, however far each chain event moves
+// the finalized block.
+func TestFinalizedHeadsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		backend  = &testBackend{db: db}
+		api      = NewPublicFilterAPI(backend, false, deadline)
+		genesis  = (&core.Genesis{BaseFee: big.NewInt(params.InitialBaseFee)}).MustCommit(db)
+		chain, _ = core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 10, func(i int, gen *core.BlockGen) {})
+	)
+	for _, blk := range chain {
+		rawdb.WriteHeader(db, blk.Header())
+		rawdb.WriteCanonicalHash(db, blk.Hash(), blk.NumberU64())
+	}
+	headers := make(chan *types.Header)
+	sub := api.events.SubscribeNewFinalizedHeads(headers)
+	defer sub.Unsubscribe()
+
+	// The first finalized block is sent alone
+	atomic.StoreUint64(&backend.finalized, 2)
+	backend.chainFeed.Send(core.ChainEvent{Hash: chain[0].Hash(), Block: chain[0]})
+	select {
+	case header := <-headers:
+		if header.Hash() != chain[1].Hash() {
+			t.Fatalf("first finalized header mismatch: have %d, want 2", header.Number)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("first finalized header not sent")
+	}
+	// Later ones are sent along with the blocks they skip
+	for i, finalized := range []uint64{2, 5, 5, 9} {
+		atomic.StoreUint64(&backend.finalized, finalized)
+		backend.chainFeed.Send(core.ChainEvent{Hash: chain[i+1].Hash(), Block: chain[i+1]})
+	}
+	for want := uint64(3); want <= 9; want++ {
+		select {
+		case header := <-headers:
+			if header.Hash() != chain[want-1].Hash() {
+				t.Fatalf("finalized header mismatch: have %d, want %d", header.Number, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("finalized header %d not sent", want)
+		}
+	}
+}
+
 // TestPendingTxFilter tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
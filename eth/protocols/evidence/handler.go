@@ -0,0 +1,245 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/core"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/event"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+	"github.com/UltronGlow/UltronGlow-Origin/p2p"
+)
+
+const (
+	// evidenceChanSize is the size of channel listening to new evidences.
+	evidenceChanSize = 16
+
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+)
+
+// Backend is the evidence pool the protocol gossips the evidences of.
+type Backend interface {
+	// SubmitDoubleSignEvidence verifies an evidence received from a remote peer
+	// and adds it to the pool.
+	SubmitDoubleSignEvidence(ev *alien.DoubleSignEvidence) error
+
+	// PendingDoubleSignEvidence retrieves the evidences of the pool, to be sent
+	// to newly connected peers.
+	PendingDoubleSignEvidence() []*alien.DoubleSignEvidence
+
+	// SubscribeDoubleSignEvidence subscribes to the evidences added to the pool,
+	// found locally or received from a remote peer.
+	SubscribeDoubleSignEvidence(ch chan<- *alien.DoubleSignEvidence) event.Subscription
+
+	// PruneDoubleSignEvidence drops the evidences punished on chain up to head
+	// or no longer punishable after it.
+	PruneDoubleSignEvidence(chain consensus.ChainHeaderReader, head *types.Header) error
+}
+
+// Chain is the local chain the evidences are pruned against.
+type Chain interface {
+	consensus.ChainHeaderReader
+
+	// SubscribeChainHeadEvent subscribes to new blocks added to the chain.
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// Handler gossips the evidences of the backend to the connected peers.
+type Handler struct {
+	backend Backend
+	chain   Chain
+
+	peers map[string]*Peer
+	lock  sync.RWMutex
+
+	evidenceCh  chan *alien.DoubleSignEvidence
+	evidenceSub event.Subscription
+	headCh      chan core.ChainHeadEvent
+	headSub     event.Subscription
+	wg          sync.WaitGroup
+}
+
+// NewHandler creates a handler gossiping the evidences of backend, pruned on
+// every new head of chain.
+func NewHandler(backend Backend, chain Chain) *Handler {
+	return &Handler{
+		backend: backend,
+		chain:   chain,
+		peers:   make(map[string]*Peer),
+	}
+}
+
+// Start starts broadcasting the evidences added to the pool.
+func (h *Handler) Start() {
+	h.evidenceCh = make(chan *alien.DoubleSignEvidence, evidenceChanSize)
+	h.evidenceSub = h.backend.SubscribeDoubleSignEvidence(h.evidenceCh)
+
+	h.headCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+	h.headSub = h.chain.SubscribeChainHeadEvent(h.headCh)
+
+	h.wg.Add(2)
+	go h.broadcastLoop()
+	go h.pruneLoop()
+}
+
+// Stop stops the broadcast loop.
+func (h *Handler) Stop() {
+	if h.evidenceSub == nil {
+		return
+	}
+	h.evidenceSub.Unsubscribe()
+	h.headSub.Unsubscribe()
+	h.wg.Wait()
+}
+
+// pruneLoop drops the evidences of the pool which can no longer be sealed on
+// every new head, so they are not gossiped forever.
+func (h *Handler) pruneLoop() {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case ev := <-h.headCh:
+			if err := h.backend.PruneDoubleSignEvidence(h.chain, ev.Block.Header()); err != nil {
+				log.Debug("Failed to prune double sign evidences", "number", ev.Block.Number(), "err", err)
+			}
+
+		case <-h.headSub.Err():
+			return
+		}
+	}
+}
+
+// broadcastLoop sends every new evidence to the peers not knowing it yet.
+func (h *Handler) broadcastLoop() {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case ev := <-h.evidenceCh:
+			hash := ev.Hash()
+			h.lock.RLock()
+			for _, peer := range h.peers {
+				if peer.KnownEvidence(hash) {
+					continue
+				}
+				if err := peer.SendEvidences([]*alien.DoubleSignEvidence{ev}); err != nil {
+					peer.Log().Debug("Failed to send evidence", "err", err)
+				}
+			}
+			h.lock.RUnlock()
+
+		case <-h.evidenceSub.Err():
+			return
+		}
+	}
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `evidence`.
+func (h *Handler) MakeProtocols() []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		version := version // Closure
+
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return h.runPeer(newPeer(version, p, rw))
+			},
+		}
+	}
+	return protocols
+}
+
+// runPeer registers a peer, sends it the pending evidences and handles its
+// messages until it disconnects.
+func (h *Handler) runPeer(peer *Peer) error {
+	h.lock.Lock()
+	h.peers[peer.id] = peer
+	h.lock.Unlock()
+
+	defer func() {
+		h.lock.Lock()
+		delete(h.peers, peer.id)
+		h.lock.Unlock()
+	}()
+	if evs := h.backend.PendingDoubleSignEvidence(); len(evs) > 0 {
+		if err := peer.SendEvidences(evs); err != nil {
+			return err
+		}
+	}
+	for {
+		if err := h.handleMessage(peer); err != nil {
+			peer.Log().Debug("Message handling failed in `evidence`", "err", err)
+			return err
+		}
+	}
+}
+
+// handleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `evidence` protocol. The remote connection is torn down
+// upon returning any error.
+func (h *Handler) handleMessage(peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	switch msg.Code {
+	case EvidenceMsg:
+		var evs EvidencePacket
+		if err := msg.Decode(&evs); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		if !peer.allowEvidences(len(evs)) {
+			return fmt.Errorf("%w: %d evidences", errFlooding, len(evs))
+		}
+		for i, ev := range evs {
+			if ev == nil || ev.First == nil || ev.Second == nil || ev.First.Number == nil {
+				return fmt.Errorf("%w: evidence %d incomplete", errDecode, i)
+			}
+			peer.markEvidence(ev.Hash())
+			err := h.backend.SubmitDoubleSignEvidence(ev)
+			switch {
+			case err == nil, errors.Is(err, alien.ErrKnownDoubleSignEvidence):
+			case errors.Is(err, alien.ErrInvalidDoubleSignEvidence):
+				// Forged or malformed, never sent by an honest peer
+				return fmt.Errorf("evidence %d: %w", i, err)
+			default:
+				log.Debug("Rejected double sign evidence", "peer", peer.id[:8], "number", ev.Number(), "err", err)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/consensus"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/core/types"
+	"github.com/UltronGlow/UltronGlow-Origin/event"
+	"github.com/UltronGlow/UltronGlow-Origin/p2p"
+	"github.com/UltronGlow/UltronGlow-Origin/p2p/enode"
+)
+
+// testBackend is an evidence pool accepting or rejecting every evidence.
+type testBackend struct {
+	err       error
+	submitted int
+}
+
+func (b *testBackend) SubmitDoubleSignEvidence(ev *alien.DoubleSignEvidence) error {
+	b.submitted++
+	return b.err
+}
+
+func (b *testBackend) PendingDoubleSignEvidence() []*alien.DoubleSignEvidence { return nil }
+
+func (b *testBackend) SubscribeDoubleSignEvidence(ch chan<- *alien.DoubleSignEvidence) event.Subscription {
+	return nil
+}
+
+func (b *testBackend) PruneDoubleSignEvidence(chain consensus.ChainHeaderReader, head *types.Header) error {
+	return nil
+}
+
+func testEvidences(n int) EvidencePacket {
+	evs := make(EvidencePacket, n)
+	for i := range evs {
+		evs[i] = &alien.DoubleSignEvidence{
+			First:  &types.Header{Number: big.NewInt(int64(i)), Extra: []byte{0x01}},
+			Second: &types.Header{Number: big.NewInt(int64(i)), Extra: []byte{0x02}},
+		}
+	}
+	return evs
+}
+
+// newTestSender connects a remote peer to the handler and returns a function
+// delivering a batch of evidences from it and returning the handling error.
+func newTestSender(h *Handler) func(EvidencePacket) error {
+	app, net := p2p.MsgPipe()
+	peer := newPeer(evidence1, p2p.NewPeer(enode.ID{}, "peer", nil), net)
+	return func(evs EvidencePacket) error {
+		go p2p.Send(app, EvidenceMsg, evs)
+		return h.handleMessage(peer)
+	}
+}
+
+// Tests that a peer sending a forged evidence is disconnected, while one sending
+// an evidence the pool merely can not use yet is kept.
+func TestHandleInvalidEvidence(t *testing.T) {
+	tests := []struct {
+		err  error
+		drop bool
+	}{
+		{nil, false},
+		{alien.ErrKnownDoubleSignEvidence, false},
+		{errors.New("unknown double signer"), false},
+		{fmt.Errorf("%w: same header", alien.ErrInvalidDoubleSignEvidence), true},
+	}
+	for i, tt := range tests {
+		h := NewHandler(&testBackend{err: tt.err}, nil)
+		send := newTestSender(h)
+		err := send(testEvidences(1))
+		if drop := err != nil; drop != tt.drop {
+			t.Errorf("test %d: drop mismatch: have %v, want %v", i, err, tt.drop)
+		}
+		if tt.drop && !errors.Is(err, alien.ErrInvalidDoubleSignEvidence) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, alien.ErrInvalidDoubleSignEvidence)
+		}
+	}
+}
+
+// Tests that a peer flooding the handler with evidences is disconnected before
+// they reach the pool.
+func TestHandleEvidenceFlooding(t *testing.T) {
+	backend := new(testBackend)
+	h := NewHandler(backend, nil)
+	send := newTestSender(h)
+
+	if err := send(testEvidences(evidenceBurst)); err != nil {
+		t.Fatalf("burst rejected: %v", err)
+	}
+	if err := send(testEvidences(evidenceBurst)); !errors.Is(err, errFlooding) {
+		t.Fatalf("flood error mismatch: have %v, want %v", err, errFlooding)
+	}
+	if backend.submitted != evidenceBurst {
+		t.Errorf("submitted evidences mismatch: have %d, want %d", backend.submitted, evidenceBurst)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package evidence
+
+import (
+	"time"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/log"
+	"github.com/UltronGlow/UltronGlow-Origin/p2p"
+	mapset "github.com/deckarep/golang-set"
+	"golang.org/x/time/rate"
+)
+
+// maxKnownEvidences is the maximum evidence hashes to keep in the known list
+// before starting to randomly evict them.
+const maxKnownEvidences = 1024
+
+const (
+	// evidenceRate is the number of evidences per second a peer may send once
+	// its burst is used up. Honest peers hardly ever find a double signer.
+	evidenceRate = 1
+
+	// evidenceBurst is the number of evidences a peer may send at once, enough
+	// for a full pool on connection.
+	evidenceBurst = 256
+)
+
+// Peer is a collection of relevant information we have about an `evidence` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for evidence
+	version   uint              // Protocol version negotiated
+
+	knownEvidences mapset.Set    // Set of evidence hashes known to be known by this peer
+	limiter        *rate.Limiter // Rate limit of the evidences received from the peer
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// newPeer create a wrapper for a network connection and negotiated  protocol
+// version.
+func newPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	return &Peer{
+		id:             id,
+		Peer:           p,
+		rw:             rw,
+		version:        version,
+		knownEvidences: mapset.NewSet(),
+		limiter:        rate.NewLimiter(evidenceRate, evidenceBurst),
+		logger:         log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negoatiated `evidence` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logget with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// KnownEvidence returns whether peer is known to already have an evidence.
+func (p *Peer) KnownEvidence(hash common.Hash) bool {
+	return p.knownEvidences.Contains(hash)
+}
+
+// markEvidence marks an evidence as known for the peer, ensuring that it will
+// never be propagated to this particular peer.
+func (p *Peer) markEvidence(hash common.Hash) {
+	for p.knownEvidences.Cardinality() >= maxKnownEvidences {
+		p.knownEvidences.Pop()
+	}
+	p.knownEvidences.Add(hash)
+}
+
+// allowEvidences reports whether the peer is allowed to send n more evidences
+// without exceeding its rate limit.
+func (p *Peer) allowEvidences(n int) bool {
+	return p.limiter.AllowN(time.Now(), n)
+}
+
+// SendEvidences sends a batch of evidences to the peer and marks them known.
+func (p *Peer) SendEvidences(evs []*alien.DoubleSignEvidence) error {
+	for _, ev := range evs {
+		p.markEvidence(ev.Hash())
+	}
+	return p2p.Send(p.rw, EvidenceMsg, EvidencePacket(evs))
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package evidence implements the `evidence` protocol gossiping the double sign
+// evidences of the alien engine, so that they reach the next in-turn signer.
+package evidence
+
+import (
+	"errors"
+
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	evidence1 = 1
+)
+
+// ProtocolName is the official short name of the `evidence` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "evidence"
+
+// ProtocolVersions are the supported versions of the `evidence` protocol (first
+// is primary).
+var ProtocolVersions = []uint{evidence1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{evidence1: 1}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	EvidenceMsg = 0x00
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+	errFlooding       = errors.New("too many evidences")
+)
+
+// EvidencePacket is the network packet for propagating double sign evidences.
+type EvidencePacket []*alien.DoubleSignEvidence
@@ -292,6 +292,12 @@ func toBlockNumArg(number *big.Int) string {
 	if number.Cmp(pending) == 0 {
 		return "pending"
 	}
+	if number.Int64() == rpc.FinalizedBlockNumber.Int64() {
+		return "finalized"
+	}
+	if number.Int64() == rpc.SafeBlockNumber.Int64() {
+		return "safe"
+	}
 	return hexutil.EncodeBig(number)
 }
 
@@ -334,6 +340,12 @@ func (ec *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header)
 	return ec.c.EthSubscribe(ctx, ch, "newHeads")
 }
 
+// SubscribeNewFinalizedHead subscribes to notifications about the blocks
+// confirmed by the signers of the chain.
+func (ec *Client) SubscribeNewFinalizedHead(ctx context.Context, ch chan<- *types.Header) (utg.Subscription, error) {
+	return ec.c.EthSubscribe(ctx, ch, "newFinalizedHeads")
+}
+
 // State Access
 
 // NetworkID returns the network ID (also known as the chain ID) for this chain.
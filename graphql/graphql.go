@@ -1081,6 +1081,32 @@ func (r *Resolver) Block(ctx context.Context, args struct {
 	return block, nil
 }
 
+func (r *Resolver) FinalizedBlock(ctx context.Context) (*Block, error) {
+	return r.taggedBlock(ctx, rpc.FinalizedBlockNumber)
+}
+
+func (r *Resolver) SafeBlock(ctx context.Context) (*Block, error) {
+	return r.taggedBlock(ctx, rpc.SafeBlockNumber)
+}
+
+// taggedBlock resolves a block tag once, pinning the returned block to the hash
+// it currently resolves to.
+func (r *Resolver) taggedBlock(ctx context.Context, number rpc.BlockNumber) (*Block, error) {
+	header, err := r.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	} else if header == nil {
+		return nil, nil
+	}
+	numberOrHash := rpc.BlockNumberOrHashWithHash(header.Hash(), false)
+	return &Block{
+		backend:      r.backend,
+		numberOrHash: &numberOrHash,
+		hash:         header.Hash(),
+		header:       header,
+	}, nil
+}
+
 func (r *Resolver) Blocks(ctx context.Context, args struct {
 	From *Long
 	To   *Long
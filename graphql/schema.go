@@ -327,6 +327,12 @@ const schema string = `
         # Blocks returns all the blocks between two numbers, inclusive. If
         # to is not supplied, it defaults to the most recent known block.
         blocks(from: Long, to: Long): [Block!]!
+        # FinalizedBlock returns the last block confirmed by the signers of
+        # the chain, which can no longer be reorganised.
+        finalizedBlock: Block
+        # SafeBlock returns the last block safe from reorganisations, which is
+        # the finalized block on alien chains.
+        safeBlock: Block
         # Pending returns the current pending state.
         pending: Pending!
         # Transaction returns a transaction specified by its hash.
@@ -428,6 +428,12 @@ func (lc *LightChain) CurrentHeader() *types.Header {
 	return lc.hc.CurrentHeader()
 }
 
+// CurrentFinalizedHeader retrieves the last finalized header of the canonical
+// chain, or nil if the consensus engine has no finality.
+func (lc *LightChain) CurrentFinalizedHeader() *types.Header {
+	return lc.hc.CurrentFinalizedHeader()
+}
+
 // GetTd retrieves a block's total difficulty in the canonical chain from the
 // database by hash and number, caching it if found.
 func (lc *LightChain) GetTd(hash common.Hash, number uint64) *big.Int {
@@ -0,0 +1,203 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// AlienForks is the activation schedule of the alien consensus rule changes,
+// each activating at the given block and staying active from then on. Rule
+// changes left nil activate at their mainnet height, or never if they are not
+// scheduled on mainnet.
+type AlienForks struct {
+	SignFixBlock                  *big.Int `json:"signFixBlock,omitempty"`                  // Signer number fix
+	GrantProfitOneTimeBlock       *big.Int `json:"grantProfitOneTimeBlock,omitempty"`       // One time grant profit payment
+	LockSimplifyBlock             *big.Int `json:"lockSimplifyBlock,omitempty"`             // Simplified reward locking
+	LockMergeBlock                *big.Int `json:"lockMergeBlock,omitempty"`                // Merge of the lock data
+	TallyRevenueBlock             *big.Int `json:"tallyRevenueBlock,omitempty"`             // Revenue address in tally
+	SignerQueueFixBlock           *big.Int `json:"signerQueueFixBlock,omitempty"`           // Signer queue fix
+	SignerElectNewBlock           *big.Int `json:"signerElectNewBlock,omitempty"`           // New signer election
+	MinerUpdateStateFixBlock      *big.Int `json:"minerUpdateStateFixBlock,omitempty"`      // Miner state update fix
+	TallyPunishdProcessBlock      *big.Int `json:"tallyPunishdProcessBlock,omitempty"`      // Tally punishment processing
+	TallyPunishdFixBlock          *big.Int `json:"tallyPunishdFixBlock,omitempty"`          // Tally punishment fix
+	StorageBlock                  *big.Int `json:"storageBlock,omitempty"`                  // Storage pledges and leases
+	SPledgeRevertFixBlock         *big.Int `json:"sPledgeRevertFixBlock,omitempty"`         // Storage pledge revert fix
+	AdjustSPRBlock                *big.Int `json:"adjustSPRBlock,omitempty"`                // Storage pledge reward adjustment
+	StorageVerifyNewBlock         *big.Int `json:"storageVerifyNewBlock,omitempty"`         // New storage verification
+	StoragePledgeTmpVerifyBlock   *big.Int `json:"storagePledgeTmpVerifyBlock,omitempty"`   // First storage verification pause
+	StorageChBwBlock              *big.Int `json:"storageChBwBlock,omitempty"`              // Storage bandwidth change
+	StoragePledgeTmpVerifyV2Block *big.Int `json:"storagePledgeTmpVerifyV2Block,omitempty"` // Second storage verification pause
+	PledgeRevertLockBlock         *big.Int `json:"pledgeRevertLockBlock,omitempty"`         // SRT and pledge revert locking
+	StoragePledgeOptBlock         *big.Int `json:"storagePledgeOptBlock,omitempty"`         // Storage pledge optimisation
+	FixLeaseCapacityBlock         *big.Int `json:"fixLeaseCapacityBlock,omitempty"`         // Lease capacity fix
+	PosrIncentiveBlock            *big.Int `json:"posrIncentiveBlock,omitempty"`            // PoSR incentive
+	PosrExitNewRuleBlock          *big.Int `json:"posrExitNewRuleBlock,omitempty"`          // New PoSR exit rule
+	PosrNewCalBlock               *big.Int `json:"posrNewCalBlock,omitempty"`               // New PoSR calculation
+	PosNewBlock                   *big.Int `json:"posNewBlock,omitempty"`                   // New PoS pledging
+	PosLastPunishFixBlock         *big.Int `json:"posLastPunishFixBlock,omitempty"`         // PoS last punishment fix
+	PosAutoExitPunishChangeBlock  *big.Int `json:"posAutoExitPunishChangeBlock,omitempty"`  // PoS auto exit punishment change
+	StorageMinCapacityBlock       *big.Int `json:"storageMinCapacityBlock,omitempty"`       // Storage pledges down to a capacity of one
+	GrantBlock                    *big.Int `json:"grantBlock,omitempty"`                    // New grant profit
+	PoCrsAccCalBlock              *big.Int `json:"poCrsAccCalBlock,omitempty"`              // Accumulated PoCR calculation
+	StorageManagerBlock           *big.Int `json:"storageManagerBlock,omitempty"`           // Storage pools
+	CustomTxResultBlock           *big.Int `json:"customTxResultBlock,omitempty"`           // Custom tx outcome logs
+	HeaderExtraVersionBlock       *big.Int `json:"headerExtraVersionBlock,omitempty"`       // Version tagged header extra
+	DoubleSignPunishBlock         *big.Int `json:"doubleSignPunishBlock,omitempty"`         // Double sign evidence and slashing
+	LockTrieBlock                 *big.Int `json:"lockTrieBlock,omitempty"`                 // Reward and storage locks kept in the lock trie
+}
+
+// MainnetAlienForks is the activation schedule of the alien rule changes on the
+// main network. Rule changes not yet scheduled there are nil.
+var MainnetAlienForks = &AlienForks{
+	SignFixBlock:                  big.NewInt(21),
+	GrantProfitOneTimeBlock:       big.NewInt(30),
+	LockSimplifyBlock:             big.NewInt(38),
+	LockMergeBlock:                big.NewInt(44),
+	TallyRevenueBlock:             big.NewInt(48),
+	SignerQueueFixBlock:           big.NewInt(53),
+	SignerElectNewBlock:           big.NewInt(66),
+	MinerUpdateStateFixBlock:      big.NewInt(75),
+	TallyPunishdProcessBlock:      big.NewInt(84),
+	TallyPunishdFixBlock:          big.NewInt(92),
+	StorageBlock:                  big.NewInt(101),
+	SPledgeRevertFixBlock:         big.NewInt(122),
+	AdjustSPRBlock:                big.NewInt(132),
+	StorageVerifyNewBlock:         big.NewInt(147),
+	StoragePledgeTmpVerifyBlock:   big.NewInt(154),
+	StorageChBwBlock:              big.NewInt(184),
+	StoragePledgeTmpVerifyV2Block: big.NewInt(214),
+	PledgeRevertLockBlock:         big.NewInt(254),
+	StoragePledgeOptBlock:         big.NewInt(289),
+	FixLeaseCapacityBlock:         big.NewInt(300),
+	PosrIncentiveBlock:            big.NewInt(321),
+	PosrExitNewRuleBlock:          big.NewInt(332),
+	PosrNewCalBlock:               big.NewInt(342),
+	PosNewBlock:                   big.NewInt(352),
+	PosLastPunishFixBlock:         big.NewInt(362),
+	PosAutoExitPunishChangeBlock:  big.NewInt(372),
+	StorageMinCapacityBlock:       big.NewInt(101900),
+	GrantBlock:                    big.NewInt(1501830),
+	PoCrsAccCalBlock:              big.NewInt(1502010),
+	StorageManagerBlock:           big.NewInt(1502190),
+}
+
+// GenesisAlienForks returns a fork table activating every alien rule change at
+// genesis, as developer networks run.
+func GenesisAlienForks() *AlienForks {
+	forks := new(AlienForks)
+	for _, fork := range forks.forks() {
+		*fork.block = new(big.Int)
+	}
+	return forks
+}
+
+type alienFork struct {
+	name  string
+	block **big.Int
+}
+
+// forks returns the activation heights of the table in schedule order.
+func (f *AlienForks) forks() []alienFork {
+	return []alienFork{
+		{"signFixBlock", &f.SignFixBlock},
+		{"grantProfitOneTimeBlock", &f.GrantProfitOneTimeBlock},
+		{"lockSimplifyBlock", &f.LockSimplifyBlock},
+		{"lockMergeBlock", &f.LockMergeBlock},
+		{"tallyRevenueBlock", &f.TallyRevenueBlock},
+		{"signerQueueFixBlock", &f.SignerQueueFixBlock},
+		{"signerElectNewBlock", &f.SignerElectNewBlock},
+		{"minerUpdateStateFixBlock", &f.MinerUpdateStateFixBlock},
+		{"tallyPunishdProcessBlock", &f.TallyPunishdProcessBlock},
+		{"tallyPunishdFixBlock", &f.TallyPunishdFixBlock},
+		{"storageBlock", &f.StorageBlock},
+		{"sPledgeRevertFixBlock", &f.SPledgeRevertFixBlock},
+		{"adjustSPRBlock", &f.AdjustSPRBlock},
+		{"storageVerifyNewBlock", &f.StorageVerifyNewBlock},
+		{"storagePledgeTmpVerifyBlock", &f.StoragePledgeTmpVerifyBlock},
+		{"storageChBwBlock", &f.StorageChBwBlock},
+		{"storagePledgeTmpVerifyV2Block", &f.StoragePledgeTmpVerifyV2Block},
+		{"pledgeRevertLockBlock", &f.PledgeRevertLockBlock},
+		{"storagePledgeOptBlock", &f.StoragePledgeOptBlock},
+		{"fixLeaseCapacityBlock", &f.FixLeaseCapacityBlock},
+		{"posrIncentiveBlock", &f.PosrIncentiveBlock},
+		{"posrExitNewRuleBlock", &f.PosrExitNewRuleBlock},
+		{"posrNewCalBlock", &f.PosrNewCalBlock},
+		{"posNewBlock", &f.PosNewBlock},
+		{"posLastPunishFixBlock", &f.PosLastPunishFixBlock},
+		{"posAutoExitPunishChangeBlock", &f.PosAutoExitPunishChangeBlock},
+		{"storageMinCapacityBlock", &f.StorageMinCapacityBlock},
+		{"grantBlock", &f.GrantBlock},
+		{"poCrsAccCalBlock", &f.PoCrsAccCalBlock},
+		{"storageManagerBlock", &f.StorageManagerBlock},
+		{"customTxResultBlock", &f.CustomTxResultBlock},
+		{"headerExtraVersionBlock", &f.HeaderExtraVersionBlock},
+		{"doubleSignPunishBlock", &f.DoubleSignPunishBlock},
+		{"lockTrieBlock", &f.LockTrieBlock},
+	}
+}
+
+// WithDefaults returns a copy of the fork table with the rule changes left
+// unset filled in from the mainnet schedule. A nil table yields the mainnet
+// schedule.
+func (f *AlienForks) WithDefaults() *AlienForks {
+	schedule := new(AlienForks)
+	if f != nil {
+		*schedule = *f
+	}
+	mainnet := MainnetAlienForks.forks()
+	for i, fork := range schedule.forks() {
+		if *fork.block == nil && *mainnet[i].block != nil {
+			*fork.block = new(big.Int).Set(*mainnet[i].block)
+		}
+	}
+	return schedule
+}
+
+// Equal reports whether two fork tables, completed from the mainnet schedule,
+// activate every rule change at the same height.
+func (f *AlienForks) Equal(other *AlienForks) bool {
+	have, want := f.WithDefaults().forks(), other.WithDefaults().forks()
+	for i := range have {
+		if !configNumEqual(*have[i].block, *want[i].block) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckOrder checks that the rule changes of the fork table, completed from the
+// mainnet schedule, activate in schedule order, as later rules build on the
+// state introduced by earlier ones.
+func (f *AlienForks) CheckOrder() error {
+	var last alienFork
+	for _, cur := range f.WithDefaults().forks() {
+		if *cur.block == nil {
+			continue
+		}
+		if (*cur.block).Sign() < 0 {
+			return fmt.Errorf("invalid alien fork: %v enabled at %v", cur.name, *cur.block)
+		}
+		if last.name != "" && (*last.block).Cmp(*cur.block) > 0 {
+			return fmt.Errorf("unsupported alien fork ordering: %v enabled at %v, but %v enabled at %v",
+				last.name, *last.block, cur.name, *cur.block)
+		}
+		last = cur
+	}
+	return nil
+}
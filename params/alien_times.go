@@ -0,0 +1,153 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import "fmt"
+
+// AlienTimes is the time table of the alien reward schedules. Spans are given
+// in seconds and turned into blocks with the block period, the pay and check
+// offsets counting from the start of the day. Spans left zero take their
+// mainnet length.
+//
+// A scale above one runs the chain in scaled time, shortening every span by
+// that factor while keeping the counts of days, so a network can go through
+// the lock, release and pay cycles of the rewards in minutes.
+type AlienTimes struct {
+	Day                       uint64 `json:"day,omitempty"`                       // Length of a day
+	LockRewardDays            uint64 `json:"lockRewardDays,omitempty"`            // Days between lock and release rounds of the rewards
+	AccumulateFlowReward      uint64 `json:"accumulateFlowReward,omitempty"`      // Offset of the flow reward accumulation
+	AccumulateRewardLock      uint64 `json:"accumulateRewardLock,omitempty"`      // Offset of the reward locking
+	PaySignerReward           uint64 `json:"paySignerReward,omitempty"`           // Offset of the signer reward payment
+	PayFlowReward             uint64 `json:"payFlowReward,omitempty"`             // Offset of the flow reward payment
+	PayBandwidthReward        uint64 `json:"payBandwidthReward,omitempty"`        // Offset of the bandwidth reward payment
+	PayPosPledgeRedeem        uint64 `json:"payPosPledgeRedeem,omitempty"`        // Offset of the PoS pledge redemption
+	PayPosExit                uint64 `json:"payPosExit,omitempty"`                // Offset of the PoS exit payment
+	CheckPosAutoExit          uint64 `json:"checkPosAutoExit,omitempty"`          // Offset of the PoS auto exit check
+	StorageVerification       uint64 `json:"storageVerification,omitempty"`       // Offset of the storage verification
+	PaySpReward               uint64 `json:"paySpReward,omitempty"`               // Offset of the storage pool reward payment
+	PaySpEntrustReward        uint64 `json:"paySpEntrustReward,omitempty"`        // Offset of the storage pool entrust reward payment
+	PaySpExit                 uint64 `json:"paySpExit,omitempty"`                 // Offset of the storage pool exit payment
+	PaySpEntrustExit          uint64 `json:"paySpEntrustExit,omitempty"`          // Offset of the storage pool entrust exit payment
+	PaySTPEntrust             uint64 `json:"paySTPEntrust,omitempty"`             // Offset of the storage entrust payment
+	PaySTPEntrustExit         uint64 `json:"paySTPEntrustExit,omitempty"`         // Offset of the storage entrust exit payment
+	SignerPledgeLock          uint64 `json:"signerPledgeLock,omitempty"`          // Lock period of the signer pledges
+	FlowPledgeLock            uint64 `json:"flowPledgeLock,omitempty"`            // Lock period of the flow pledges
+	RewardLock                uint64 `json:"rewardLock,omitempty"`                // Lock period of the rewards
+	RewardLockRelease         uint64 `json:"rewardLockRelease,omitempty"`         // Release period of the locked rewards
+	RewardLockReleaseInterval uint64 `json:"rewardLockReleaseInterval,omitempty"` // Interval between releases of the locked rewards
+	Scale                     uint64 `json:"scale,omitempty"`                     // Factor the spans are shortened by (0 = real time)
+}
+
+// MainnetAlienTimes is the time table of the alien reward schedules on the
+// main network.
+var MainnetAlienTimes = &AlienTimes{
+	Day:                       24 * 60 * 60,
+	LockRewardDays:            30,
+	AccumulateFlowReward:      2 * 60 * 60,
+	AccumulateRewardLock:      2*60*60 + 10*10,
+	PaySignerReward:           0,
+	PayFlowReward:             2*60*60 + 30*60,
+	PayBandwidthReward:        1*60*60 + 30*60,
+	PayPosPledgeRedeem:        1*60*60 + 40*60,
+	PayPosExit:                1*60*60 + 50*60,
+	CheckPosAutoExit:          1*60*60 + 60*60,
+	StorageVerification:       1 * 60 * 60,
+	PaySpReward:               2*60*60 + 10*50,
+	PaySpEntrustReward:        2*60*60 + 10*60,
+	PaySpExit:                 2*60*60 + 10*80,
+	PaySpEntrustExit:          2*60*60 + 10*90,
+	PaySTPEntrust:             2*60*60 + 10*100,
+	PaySTPEntrustExit:         2*60*60 + 10*70,
+	SignerPledgeLock:          180 * 24 * 60 * 60,
+	FlowPledgeLock:            180 * 24 * 60 * 60,
+	RewardLock:                30 * 24 * 60 * 60,
+	RewardLockRelease:         180 * 24 * 60 * 60,
+	RewardLockReleaseInterval: 24 * 60 * 60,
+}
+
+// spans returns the spans of the time table in table order.
+func (t *AlienTimes) spans() []alienSpan {
+	return []alienSpan{
+		{"day", &t.Day, false},
+		{"accumulateFlowReward", &t.AccumulateFlowReward, true},
+		{"accumulateRewardLock", &t.AccumulateRewardLock, true},
+		{"paySignerReward", &t.PaySignerReward, true},
+		{"payFlowReward", &t.PayFlowReward, true},
+		{"payBandwidthReward", &t.PayBandwidthReward, true},
+		{"payPosPledgeRedeem", &t.PayPosPledgeRedeem, true},
+		{"payPosExit", &t.PayPosExit, true},
+		{"checkPosAutoExit", &t.CheckPosAutoExit, true},
+		{"storageVerification", &t.StorageVerification, true},
+		{"paySpReward", &t.PaySpReward, true},
+		{"paySpEntrustReward", &t.PaySpEntrustReward, true},
+		{"paySpExit", &t.PaySpExit, true},
+		{"paySpEntrustExit", &t.PaySpEntrustExit, true},
+		{"paySTPEntrust", &t.PaySTPEntrust, true},
+		{"paySTPEntrustExit", &t.PaySTPEntrustExit, true},
+		{"signerPledgeLock", &t.SignerPledgeLock, false},
+		{"flowPledgeLock", &t.FlowPledgeLock, false},
+		{"rewardLock", &t.RewardLock, false},
+		{"rewardLockRelease", &t.RewardLockRelease, false},
+		{"rewardLockReleaseInterval", &t.RewardLockReleaseInterval, false},
+	}
+}
+
+type alienSpan struct {
+	name    string
+	seconds *uint64
+	offset  bool // Whether the span is an offset into the day
+}
+
+// WithDefaults returns a copy of the time table with the spans left unset
+// filled in from the mainnet table and shortened by the scale. A nil table
+// yields the mainnet table.
+func (t *AlienTimes) WithDefaults() *AlienTimes {
+	times := new(AlienTimes)
+	if t != nil {
+		*times = *t
+	}
+	if times.LockRewardDays == 0 {
+		times.LockRewardDays = MainnetAlienTimes.LockRewardDays
+	}
+	mainnet := MainnetAlienTimes.spans()
+	for i, span := range times.spans() {
+		if *span.seconds == 0 {
+			*span.seconds = *mainnet[i].seconds
+		}
+		if times.Scale > 1 {
+			*span.seconds /= times.Scale
+		}
+	}
+	times.Scale = 0
+	return times
+}
+
+// Check checks that the time table, completed from the mainnet table, leaves
+// every pay and check offset within the day, as the offsets falling beyond it
+// are never reached.
+func (t *AlienTimes) Check() error {
+	times := t.WithDefaults()
+	if times.Day == 0 {
+		return fmt.Errorf("invalid alien times: day shortened to zero")
+	}
+	for _, span := range times.spans() {
+		if span.offset && *span.seconds >= times.Day {
+			return fmt.Errorf("invalid alien times: %v at %d is beyond the day of %d", span.name, *span.seconds, times.Day)
+		}
+	}
+	return nil
+}
@@ -347,6 +347,8 @@ type AlienConfig struct {
 	TrantorBlock  *big.Int          `json:"trantorBlock,omitempty"`  // Trantor switch block (nil = no fork)
 	TerminusBlock *big.Int          `json:"terminusBlock,omitempty"` // Terminus switch block (nil = no fork)
 	LightConfig   *AlienLightConfig `json:"lightConfig,omitempty"`
+	Forks         *AlienForks       `json:"forks,omitempty"` // Activation heights of the rule changes (nil = mainnet schedule)
+	Times         *AlienTimes       `json:"times,omitempty"` // Time table of the reward schedules (nil = mainnet lengths)
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -538,6 +540,12 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	if c.Alien != nil {
+		if err := c.Alien.Forks.CheckOrder(); err != nil {
+			return err
+		}
+		return c.Alien.Times.Check()
+	}
 	return nil
 }
 
@@ -17,6 +17,7 @@
 package params
 
 import (
+	"encoding/json"
 	"math/big"
 	"reflect"
 	"testing"
@@ -96,3 +97,77 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestAlienForks(t *testing.T) {
+	var forks *AlienForks
+	if schedule := forks.WithDefaults(); !reflect.DeepEqual(schedule, MainnetAlienForks) {
+		t.Errorf("nil schedule: have %v, want mainnet", schedule)
+	}
+	var config AlienConfig
+	if err := json.Unmarshal([]byte(`{"forks":{"signFixBlock":0,"signerQueueFixBlock":50}}`), &config); err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	schedule := config.Forks.WithDefaults()
+	if schedule.SignFixBlock.Sign() != 0 || schedule.SignerQueueFixBlock.Uint64() != 50 {
+		t.Errorf("configured heights not kept: %v %v", schedule.SignFixBlock, schedule.SignerQueueFixBlock)
+	}
+	if schedule.StorageManagerBlock.Cmp(MainnetAlienForks.StorageManagerBlock) != 0 {
+		t.Errorf("unset height not defaulted: have %v, want %v", schedule.StorageManagerBlock, MainnetAlienForks.StorageManagerBlock)
+	}
+	if schedule.HeaderExtraVersionBlock != nil {
+		t.Errorf("fork not scheduled on mainnet activated: %v", schedule.HeaderExtraVersionBlock)
+	}
+	if config.Forks.StorageManagerBlock != nil {
+		t.Errorf("defaults written into the configured table")
+	}
+	if err := config.Forks.CheckOrder(); err != nil {
+		t.Errorf("valid schedule rejected: %v", err)
+	}
+	config.Forks.SignerQueueFixBlock = big.NewInt(1000)
+	if err := config.Forks.CheckOrder(); err == nil {
+		t.Errorf("out of order schedule accepted")
+	}
+	config.Forks.SignerQueueFixBlock = big.NewInt(-1)
+	if err := config.Forks.CheckOrder(); err == nil {
+		t.Errorf("negative height accepted")
+	}
+}
+
+func TestGenesisAlienForks(t *testing.T) {
+	forks := GenesisAlienForks()
+	for _, fork := range forks.WithDefaults().forks() {
+		if *fork.block == nil || (*fork.block).Sign() != 0 {
+			t.Errorf("%s not active at genesis: %v", fork.name, *fork.block)
+		}
+	}
+	if err := forks.CheckOrder(); err != nil {
+		t.Errorf("genesis schedule rejected: %v", err)
+	}
+}
+
+func TestAlienTimes(t *testing.T) {
+	var times *AlienTimes
+	if table := times.WithDefaults(); !reflect.DeepEqual(table, MainnetAlienTimes) {
+		t.Errorf("nil table not mainnet: %+v", table)
+	}
+	times = &AlienTimes{Day: 3600, PayFlowReward: 600, Scale: 60}
+	table := times.WithDefaults()
+	if table.Day != 60 || table.PayFlowReward != 10 || table.LockRewardDays != MainnetAlienTimes.LockRewardDays {
+		t.Errorf("configured spans not scaled: %+v", table)
+	}
+	if table.StorageVerification != MainnetAlienTimes.StorageVerification/60 || table.Scale != 0 {
+		t.Errorf("unset span not scaled from mainnet: %+v", table)
+	}
+	if times.StorageVerification != 0 || times.Day != 3600 {
+		t.Errorf("defaults written into the configured table")
+	}
+	if err := (&AlienTimes{Scale: 60}).Check(); err != nil {
+		t.Errorf("valid table rejected: %v", err)
+	}
+	if err := times.Check(); err == nil {
+		t.Errorf("offsets beyond the day accepted")
+	}
+	if err := (&AlienTimes{Scale: 1 << 20}).Check(); err == nil {
+		t.Errorf("zero day accepted")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package storagepoc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/UltronGlow/UltronGlow-Origin/common"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien"
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/storageproof"
+	"github.com/UltronGlow/UltronGlow-Origin/crypto"
+)
+
+// device is the storage device all fuzzed proofs are checked for.
+var device = common.HexToAddress("0x7f2a5e7e3b5d0b6a6ef1d2a2b6b0f0b6f8e1c4a9")
+
+// Fuzz feeds the input as a proof string to the verifiers. The declaration
+// block, nonce and hash are taken from the proof itself, and the root hash from
+// its last field, so that mutated valid proofs get past the first checks.
+func Fuzz(input []byte) int {
+	pocstr := string(input)
+	fields := strings.Split(pocstr, ",")
+	if fields[0] == "v1" {
+		fields = fields[1:]
+	}
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	roothash := fields[len(fields)-1]
+	nonce, _ := strconv.ParseUint(fields[1], 10, 64)
+
+	ok := alien.VerifyPocString(fields[0], fields[1], fields[2], pocstr, roothash, device.Hex())
+	if alien.VerifyStoragePoc(pocstr, roothash, nonce) {
+		ok = true
+	}
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// FuzzProver plots a capacity file of a size and for challenges derived from
+// the input, and checks that the proofs built for them by the reference prover
+// verify.
+func FuzzProver(input []byte) int {
+	if len(input) < 18 {
+		return 0
+	}
+	var (
+		blocks = 2 + uint64(binary.BigEndian.Uint16(input))%1024
+		plot   = storageproof.Challenge{
+			Number: binary.BigEndian.Uint64(input[2:]),
+			Nonce:  binary.BigEndian.Uint64(input[10:]),
+			Hash:   crypto.Keccak256Hash(input),
+		}
+		seed = storageproof.Seed(plot, device)
+		src  = storageproof.NewSparse(seed, blocks)
+	)
+	proof, err := storageproof.Prove(src, seed, plot)
+	if err != nil {
+		panic(err)
+	}
+	root := proof.Root.String()
+	block, nonce := strconv.FormatUint(plot.Number, 10), strconv.FormatUint(plot.Nonce, 10)
+	for _, pocstr := range []string{proof.V0, proof.V1} {
+		if pocstr == "" {
+			continue
+		}
+		if !alien.VerifyPocString(block, nonce, plot.Hash.Hex(), pocstr, root, device.Hex()) {
+			panic(fmt.Sprintf("valid declaration proof rejected: %s", pocstr))
+		}
+		if !alien.VerifyStoragePoc(pocstr, root, plot.Nonce) {
+			panic(fmt.Sprintf("valid storage proof rejected: %s", pocstr))
+		}
+	}
+	return 1
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 The utg Authors
+// This file is part of the utg library.
+//
+// The utg library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The utg library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the utg library. If not, see <http://www.gnu.org/licenses/>.
+
+package storagepoc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/UltronGlow/UltronGlow-Origin/consensus/alien/storageproof"
+)
+
+// seedProofs returns valid proofs of both versions to seed the fuzzers with.
+func seedProofs(t *testing.T) []string {
+	var proofs []string
+	for _, blocks := range []uint64{2, 7, 64} {
+		plot := storageproof.Challenge{Number: 1200 + blocks, Nonce: 42}
+		seed := storageproof.Seed(plot, device)
+		proof, err := storageproof.Prove(storageproof.NewSparse(seed, blocks), seed, plot)
+		if err != nil {
+			t.Fatalf("failed to prove: %v", err)
+		}
+		proofs = append(proofs, proof.V0)
+		if proof.V1 != "" {
+			proofs = append(proofs, proof.V1)
+		}
+	}
+	return proofs
+}
+
+// TestSeeds runs the fuzzers over their seeds, which the valid proofs are part
+// of. Run `go-fuzz` on Fuzz and FuzzProver to fuzz them further.
+func TestSeeds(t *testing.T) {
+	for i, proof := range seedProofs(t) {
+		if Fuzz([]byte(proof)) != 1 {
+			t.Errorf("seed proof %d rejected", i)
+		}
+	}
+	Fuzz([]byte("v1,1,2,3,4,5,6,7-5,8,9,10,11"))
+	FuzzProver([]byte("0123456789abcdefghijklmnop"))
+}
+
+// TestCrashers replays proofs which used to crash the verifiers.
+func TestCrashers(t *testing.T) {
+	plot := storageproof.Challenge{Number: 1264, Nonce: 42}
+	seed := storageproof.Seed(plot, device)
+	proof, err := storageproof.Prove(storageproof.NewSparse(seed, 64), seed, plot)
+	if err != nil {
+		t.Fatalf("failed to prove: %v", err)
+	}
+	v0 := strings.Split(proof.V0, ",")
+	v1 := strings.Split(proof.V1, ",")
+	replace := func(fields []string, i int, val string) []byte {
+		mutated := append([]string{}, fields...)
+		mutated[i] = val
+		return []byte(strings.Join(mutated, ","))
+	}
+	crashers := [][]byte{
+		replace(v0, 5, "0"),                      // Zero file blocks
+		replace(v0, 5, "x"),                      // Malformed file blocks
+		replace(v0, 6, "00"),                     // Short block
+		replace(v1, 7, "0-18446744073709551615"), // Range of 2^64 blocks
+		[]byte("v1,0,0,0,0,20,1,0-1,00,00,00,00"),
+	}
+	for i, input := range crashers {
+		if Fuzz(input) != 0 {
+			t.Errorf("crasher %d verified", i)
+		}
+	}
+}